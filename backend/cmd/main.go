@@ -1,34 +1,325 @@
 package main
 
 import (
+	attendancecontrollers "backend/internal/attendance/controllers"
+	attendancemodels "backend/internal/attendance/models"
+	attendancerepository "backend/internal/attendance/repository"
+	attendanceroutes "backend/internal/attendance/routes"
+	attendanceservices "backend/internal/attendance/services"
+	"backend/internal/audit"
+	"backend/internal/auth"
+	"backend/internal/config"
+	coursecontrollers "backend/internal/course/controllers"
+	courserepository "backend/internal/course/repository"
+	courseroutes "backend/internal/course/routes"
+	courseservices "backend/internal/course/services"
+	enrollmentcontrollers "backend/internal/enrollment/controllers"
+	enrollmentrepository "backend/internal/enrollment/repository"
+	enrollmentroutes "backend/internal/enrollment/routes"
+	enrollmentservices "backend/internal/enrollment/services"
+	gradecontrollers "backend/internal/grade/controllers"
+	graderepository "backend/internal/grade/repository"
+	graderoutes "backend/internal/grade/routes"
+	gradeservices "backend/internal/grade/services"
+	guardiancontrollers "backend/internal/guardian/controllers"
+	guardianrepository "backend/internal/guardian/repository"
+	guardianroutes "backend/internal/guardian/routes"
+	guardianservices "backend/internal/guardian/services"
+	"backend/internal/openapi"
+	"backend/internal/problem"
 	"backend/internal/student/controllers"
+	"backend/internal/student/graph"
 	"backend/internal/student/models"
 	"backend/internal/student/repository"
 	"backend/internal/student/routes"
+	"backend/internal/student/rpc"
+	"backend/internal/student/rpc/studentpb"
 	"backend/internal/student/services"
+	"context"
 	"log"
+	"net"
+	"os"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"gorm.io/gorm/logger"
 )
 
 func main() {
-	db, err := repository.GetDB()
+	cfg, err := config.Load(os.Args[1:], os.LookupEnv)
+	if err != nil {
+		log.Fatal("Failed to load config: ", err)
+	}
+	log.Printf("effective config:\n%s", cfg)
 
+	store, err := repository.Open(cfg.Database, gormLogLevel(cfg.Log.Level))
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
-	repo, err := repository.NewStudentRepository(db)
+	Service := services.Service(store.Students)
+	if cfg.Pagination.CursorSecret != "" {
+		Service.CursorSecret = []byte(cfg.Pagination.CursorSecret)
+	}
+	if cfg.Retention.PurgeAfter > 0 {
+		go Service.RunPurger(context.Background(), cfg.Retention.PurgeAfter, cfg.Retention.PurgeInterval)
+	}
+	authService, err := newAuthService(cfg.Auth, store)
 	if err != nil {
-		log.Fatal("variable 'repo' couldn't be initialized", err)
+		log.Fatal("Failed to set up authentication: ", err)
 	}
-	Service := services.Service(repo)
+	authenticate := auth.Middleware(authService)
 	Controller := controllers.Controller(Service)
+	Controller.DefaultPageSize = cfg.Pagination.DefaultSize
+	Controller.MaxPageSize = cfg.Pagination.MaxSize
+	courseRepository, err := newCourseRepository(store)
+	if err != nil {
+		log.Fatal("Failed to set up courses: ", err)
+	}
+	enrollmentRepository, err := newEnrollmentRepository(store, courseRepository)
+	if err != nil {
+		log.Fatal("Failed to set up enrollments: ", err)
+	}
+	courseService := courseservices.Service(courseRepository)
+	courseService.Waitlists = enrollmentRepository
+	courseController := coursecontrollers.Controller(courseService)
+	courseController.DefaultPageSize = cfg.Pagination.DefaultSize
+	courseController.MaxPageSize = cfg.Pagination.MaxSize
+	enrollmentController := enrollmentcontrollers.Controller(enrollmentservices.Service(enrollmentRepository, store.Students, courseRepository))
+	gradeRepository, err := newGradeRepository(store)
+	if err != nil {
+		log.Fatal("Failed to set up grades: ", err)
+	}
+	gradeService := gradeservices.Service(gradeRepository, store.Students, courseRepository, enrollmentRepository)
+	if err := gradeService.UseScales(cfg.Grading); err != nil {
+		log.Fatal("Failed to set up grades: ", err)
+	}
+	Service.AddGuard(models.StatusGraduated, gradeService.GraduationRequirements)
+	gradeController := gradecontrollers.Controller(gradeService)
+	attendanceRepository, err := newAttendanceRepository(store)
+	if err != nil {
+		log.Fatal("Failed to set up attendance: ", err)
+	}
+	attendanceService := attendanceservices.Service(attendanceRepository, store.Students, courseRepository, enrollmentRepository)
+	attendanceService.Thresholds = attendancemodels.Thresholds{
+		WarningPercent:  cfg.Attendance.WarningPercent,
+		AtRiskPercent:   cfg.Attendance.AtRiskPercent,
+		LatesPerAbsence: cfg.Attendance.LatesPerAbsence,
+	}
+	attendanceController := attendancecontrollers.Controller(attendanceService)
+	guardianRepository, err := newGuardianRepository(store)
+	if err != nil {
+		log.Fatal("Failed to set up guardians: ", err)
+	}
+	guardianController := guardiancontrollers.Controller(guardianservices.Service(guardianRepository, store.Students))
+
+	if cfg.Log.Level != "debug" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+	if cfg.Server.GRPCAddr != "" {
+		studentServer := rpc.Server(Service)
+		studentServer.DefaultPageSize = cfg.Pagination.DefaultSize
+		studentServer.MaxPageSize = cfg.Pagination.MaxSize
+		go serveGRPC(cfg.Server.GRPCAddr, newGRPCServer(authService, studentServer))
+	}
 
-	db.AutoMigrate(&models.Student{}, &models.StudentEntity{})
+	graphServer := graph.Server(Service)
+	graphServer.DefaultPageSize = cfg.Pagination.DefaultSize
+	graphServer.MaxPageSize = cfg.Pagination.MaxSize
 
+	router := newRouter(cfg.Server, apiDocument(), auth.Controller(authService), Controller, courseController, enrollmentController, gradeController, attendanceController, guardianController, graphServer, authenticate)
+	router.Run(cfg.Server.Addr)
+}
+
+// reflectionMethods is the prefix of the gRPC reflection methods, which are
+// public like /openapi.json.
+const reflectionMethods = "/grpc.reflection."
+
+// newGRPCServer serves the gRPC API with the same authentication as the REST
+// API, and reflection so that tools such as grpcurl can discover it.
+func newGRPCServer(authenticator auth.Authenticator, studentServer *rpc.StudentServer) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(authenticator, reflectionMethods)),
+		grpc.ChainStreamInterceptor(auth.StreamServerInterceptor(authenticator, reflectionMethods)),
+	)
+	studentpb.RegisterStudentServiceServer(server, studentServer)
+	reflection.Register(server)
+	return server
+}
+
+func serveGRPC(addr string, server *grpc.Server) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatal("Failed to listen for gRPC: ", err)
+	}
+	log.Printf("gRPC API listening on %s", listener.Addr())
+	if err := server.Serve(listener); err != nil {
+		log.Fatal("gRPC server stopped: ", err)
+	}
+}
+
+// newRouter registers every route. doc must describe them all; it is served at
+// /openapi.json.
+func newRouter(server config.Server, doc *openapi.Document, authController *auth.AuthController, studentController *controllers.StudentController, courseController *coursecontrollers.CourseController, enrollmentController *enrollmentcontrollers.EnrollmentController, gradeController *gradecontrollers.GradeController, attendanceController *attendancecontrollers.AttendanceController, guardianController *guardiancontrollers.GuardianController, graphServer *graph.GraphServer, authenticate gin.HandlerFunc) *gin.Engine {
 	router := gin.Default()
-	router.Use(cors.Default())
-	routes.SetupRoutes(router, Controller)
-	router.Run("localhost:8080")
+	router.Use(cors.New(corsConfig(server)))
+	router.Use(audit.RequestIDMiddleware())
+	router.Use(problem.Middleware())
+	auth.SetupRoutes(router, authController, authenticate)
+	routes.SetupRoutes(router, studentController, authenticate)
+	courseroutes.SetupRoutes(router, courseController, authenticate)
+	enrollmentroutes.SetupRoutes(router, enrollmentController, authenticate)
+	graderoutes.SetupRoutes(router, gradeController, authenticate)
+	attendanceroutes.SetupRoutes(router, attendanceController, authenticate)
+	guardianroutes.SetupRoutes(router, guardianController, authenticate)
+	graph.SetupRoutes(router, graphServer, authenticate)
+	openapi.SetupRoutes(router, doc)
+	return router
+}
+
+func apiDocument() *openapi.Document {
+	doc := openapi.New(openapi.Info{
+		Title:       "Students API",
+		Version:     "1.0.0",
+		Description: "Errors are RFC 7807 problem details, except for the token and API key endpoints' own errors.",
+	})
+	auth.Describe(doc)
+	routes.Describe(doc)
+	courseroutes.Describe(doc)
+	enrollmentroutes.Describe(doc)
+	graderoutes.Describe(doc)
+	attendanceroutes.Describe(doc)
+	guardianroutes.Describe(doc)
+	graph.Describe(doc)
+	return doc
+}
+
+// newCourseRepository stores the courses next to the students, or in memory
+// when the students are.
+func newCourseRepository(store *repository.Store) (courseservices.Repository, error) {
+	if store.DB == nil {
+		return courserepository.NewMemoryRepository(), nil
+	}
+	return courserepository.NewCourseRepository(store.DB)
+}
+
+// newEnrollmentRepository stores the enrollments next to the courses, whose
+// rows it locks to count seats, and the students, whose deletions and purges
+// it follows, or in memory when they are.
+func newEnrollmentRepository(store *repository.Store, courses courseservices.Repository) (enrollmentservices.Repository, error) {
+	if store.DB == nil {
+		return enrollmentrepository.NewMemoryRepository(courses), nil
+	}
+	repo, err := enrollmentrepository.NewEnrollmentRepository(store.DB)
+	if err != nil {
+		return nil, err
+	}
+	store.Attach(repo)
+	return repo, nil
+}
+
+// newGradeRepository stores gradebooks and assessments next to the courses and
+// the students, whose purges it follows, or in memory when they are.
+func newGradeRepository(store *repository.Store) (gradeservices.Repository, error) {
+	if store.DB == nil {
+		return graderepository.NewMemoryRepository(), nil
+	}
+	repo, err := graderepository.NewGradeRepository(store.DB)
+	if err != nil {
+		return nil, err
+	}
+	store.Attach(repo)
+	return repo, nil
+}
+
+// newAttendanceRepository stores attendance records next to the courses and
+// the students, whose purges it follows, or in memory when they are.
+func newAttendanceRepository(store *repository.Store) (attendanceservices.Repository, error) {
+	if store.DB == nil {
+		return attendancerepository.NewMemoryRepository(), nil
+	}
+	repo, err := attendancerepository.NewAttendanceRepository(store.DB)
+	if err != nil {
+		return nil, err
+	}
+	store.Attach(repo)
+	return repo, nil
+}
+
+// newGuardianRepository stores guardians next to the students, whose rows it
+// locks to renumber their priorities and whose purges it follows, or in memory
+// when the students are.
+func newGuardianRepository(store *repository.Store) (guardianservices.Repository, error) {
+	if store.DB == nil {
+		return guardianrepository.NewMemoryRepository(), nil
+	}
+	repo, err := guardianrepository.NewGuardianRepository(store.DB)
+	if err != nil {
+		return nil, err
+	}
+	store.Attach(repo)
+	return repo, nil
+}
+
+// newAuthService stores users and refresh tokens next to the students, in memory
+// when the students are.
+func newAuthService(cfg config.Auth, store *repository.Store) (*auth.AuthService, error) {
+	keys, err := auth.NewKeySet(cfg.Keys, cfg.SigningKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Keys) == 0 {
+		log.Println("no auth.keys configured, tokens are signed with a random key and will not survive a restart")
+	}
+
+	var authRepository auth.Repository = auth.NewMemoryRepository()
+	if store.DB != nil {
+		if authRepository, err = auth.NewRepository(store.DB); err != nil {
+			return nil, err
+		}
+	}
+
+	policy, err := auth.NewPolicy(cfg.Roles)
+	if err != nil {
+		return nil, err
+	}
+
+	authService := auth.Service(authRepository, keys)
+	authService.Policy = policy
+	authService.Issuer = cfg.Issuer
+	authService.AccessTokenTTL = cfg.AccessTokenTTL
+	authService.RefreshTokenTTL = cfg.RefreshTokenTTL
+	if err := authService.SeedUsers(cfg.Users); err != nil {
+		return nil, err
+	}
+	if len(cfg.Users) == 0 {
+		log.Println("no auth.users configured, nobody can log in")
+	}
+	return authService, nil
+}
+
+func corsConfig(server config.Server) cors.Config {
+	corsConfig := cors.DefaultConfig()
+	corsConfig.AddAllowHeaders("Authorization", audit.RequestIDHeader)
+	corsConfig.AddExposeHeaders(audit.RequestIDHeader)
+	if server.AllowAllOrigins() {
+		corsConfig.AllowAllOrigins = true
+	} else {
+		corsConfig.AllowOrigins = server.CORSOrigins
+	}
+	return corsConfig
+}
+
+func gormLogLevel(level string) logger.LogLevel {
+	switch level {
+	case "debug", "info":
+		return logger.Info
+	case "warn":
+		return logger.Warn
+	case "error":
+		return logger.Error
+	default:
+		return logger.Silent
+	}
 }
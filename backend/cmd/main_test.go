@@ -0,0 +1,115 @@
+package main
+
+import (
+	attendancecontrollers "backend/internal/attendance/controllers"
+	"backend/internal/auth"
+	"backend/internal/config"
+	coursecontrollers "backend/internal/course/controllers"
+	enrollmentcontrollers "backend/internal/enrollment/controllers"
+	gradecontrollers "backend/internal/grade/controllers"
+	guardiancontrollers "backend/internal/guardian/controllers"
+	"backend/internal/student/controllers"
+	"backend/internal/student/graph"
+	"backend/internal/student/rpc"
+	"backend/internal/student/rpc/studentpb"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func testRouter() (*gin.Engine, error) {
+	gin.SetMode(gin.TestMode)
+	doc := apiDocument()
+	router := newRouter(config.Server{}, doc, auth.Controller(nil), controllers.Controller(nil), coursecontrollers.Controller(nil), enrollmentcontrollers.Controller(nil), gradecontrollers.Controller(nil), attendancecontrollers.Controller(nil), guardiancontrollers.Controller(nil), graph.Server(nil), func(*gin.Context) {})
+	return router, doc.Check(router.Routes())
+}
+
+// A route added to a SetupRoutes must be added to its package's Describe too.
+func TestEveryRouteIsDocumented(t *testing.T) {
+	_, err := testRouter()
+	assert.NoError(t, err)
+}
+
+func TestOpenAPI(t *testing.T) {
+	router, _ := testRouter()
+
+	t.Run("Document", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var doc struct {
+			OpenAPI string                                `json:"openapi"`
+			Paths   map[string]map[string]json.RawMessage `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+		assert.Equal(t, "3.1.0", doc.OpenAPI)
+		assert.Contains(t, doc.Paths["/students/{id}"], "patch")
+		assert.Contains(t, doc.Paths["/admin/api-keys/{id}/rotate"], "post")
+	})
+
+	t.Run("Swagger UI", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `id="swagger-ui"`)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/swagger-initializer.js", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `url: "/openapi.json"`)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/swagger-ui.css", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestGRPCServer(t *testing.T) {
+	keys, err := auth.NewKeySet(nil, "")
+	require.NoError(t, err)
+	server := newGRPCServer(auth.Service(auth.NewMemoryRepository(), keys), rpc.Server(nil))
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx := context.Background()
+
+	t.Run("Authentication", func(t *testing.T) {
+		_, err := studentpb.NewStudentServiceClient(conn).GetStudent(ctx, &studentpb.GetStudentRequest{Id: "7995c72f-7d04-4136-8b5f-000d6d4aae23"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("Reflection", func(t *testing.T) {
+		stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{}}))
+		response, err := stream.Recv()
+		require.NoError(t, err, "reflection needs no credentials")
+
+		var services []string
+		for _, service := range response.GetListServicesResponse().GetService() {
+			services = append(services, service.Name)
+		}
+		assert.Contains(t, services, "students.v1.StudentService")
+	})
+}
@@ -0,0 +1,32 @@
+// Package apitest provides utilities for testing the REST controllers.
+package apitest
+
+import (
+	"backend/internal/problem"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Request serves a request with body to handler and records the response.
+func Request(handler http.Handler, method, url string, body []byte) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, url, bytes.NewBuffer(body))
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+// AssertProblem checks that w is a problem+json response with the given status
+// and detail.
+func AssertProblem(t *testing.T, w *httptest.ResponseRecorder, status int, detail string) {
+	t.Helper()
+	assert.Equal(t, status, w.Code)
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, detail, body["detail"])
+}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"backend/internal/attendance/models"
+	"backend/internal/problem"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AttendanceService interface {
+	Mark(ctx context.Context, courseID uuid.UUID, request *models.SessionRequest) (models.RecordList, error)
+	GetCourseRecords(ctx context.Context, courseID uuid.UUID, query models.Query) (models.RecordList, error)
+	GetStudentRecords(ctx context.Context, studentID uuid.UUID, query models.Query) (models.RecordList, error)
+	GetCourseSummary(ctx context.Context, courseID uuid.UUID, query models.SummaryQuery) (models.SummaryList, error)
+	GetStudentSummary(ctx context.Context, studentID uuid.UUID, query models.SummaryQuery) (models.SummaryList, error)
+}
+
+var (
+	errInvalidID      = &problem.ValidationError{Detail: "invalid UUID", Fields: []problem.FieldError{{Field: "id", Code: "uuid", Message: "must be a UUID"}}}
+	errInvalidRequest = &problem.ValidationError{Detail: "invalid request"}
+)
+
+type AttendanceController struct {
+	Service AttendanceService
+}
+
+func Controller(service AttendanceService) *AttendanceController {
+	return &AttendanceController{Service: service}
+}
+
+// Mark records the attendance of a session in bulk and answers with the saved
+// records.
+func (c *AttendanceController) Mark(ctx *gin.Context) {
+	courseID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidID)
+		return
+	}
+
+	var request models.SessionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errInvalidRequest)
+		return
+	}
+
+	list, err := c.Service.Mark(ctx.Request.Context(), courseID, &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, list)
+}
+
+// GetCourseRecords lists the attendance at the sessions of the course,
+// optionally between the from and to dates.
+func (c *AttendanceController) GetCourseRecords(ctx *gin.Context) {
+	c.records(ctx, c.Service.GetCourseRecords)
+}
+
+// GetStudentRecords lists the attendance of the student, optionally between
+// the from and to dates.
+func (c *AttendanceController) GetStudentRecords(ctx *gin.Context) {
+	c.records(ctx, c.Service.GetStudentRecords)
+}
+
+// GetCourseSummary sums up the attendance of the roster of the course,
+// optionally only of students at the given risk or above.
+func (c *AttendanceController) GetCourseSummary(ctx *gin.Context) {
+	c.summaries(ctx, c.Service.GetCourseSummary)
+}
+
+// GetStudentSummary sums up the attendance of the student in each course.
+func (c *AttendanceController) GetStudentSummary(ctx *gin.Context) {
+	c.summaries(ctx, c.Service.GetStudentSummary)
+}
+
+func (c *AttendanceController) records(ctx *gin.Context, get func(context.Context, uuid.UUID, models.Query) (models.RecordList, error)) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidID)
+		return
+	}
+	query, err := models.ParseQuery(ctx.Request.URL.Query())
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	list, err := get(ctx.Request.Context(), id, query)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, list)
+}
+
+func (c *AttendanceController) summaries(ctx *gin.Context, get func(context.Context, uuid.UUID, models.SummaryQuery) (models.SummaryList, error)) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidID)
+		return
+	}
+	query, err := models.ParseSummaryQuery(ctx.Request.URL.Query())
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	list, err := get(ctx.Request.Context(), id, query)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, list)
+}
@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"backend/internal/apitest"
+	"backend/internal/attendance/mocks"
+	"backend/internal/attendance/models"
+	"backend/internal/problem"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRouter(controller *AttendanceController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(problem.Middleware())
+	router.PUT("/courses/:id/attendance", controller.Mark)
+	router.GET("/courses/:id/attendance", controller.GetCourseRecords)
+	router.GET("/courses/:id/attendance/summary", controller.GetCourseSummary)
+	router.GET("/students/:id/attendance", controller.GetStudentRecords)
+	router.GET("/students/:id/attendance/summary", controller.GetStudentSummary)
+	return router
+}
+
+var (
+	courseID  = uuid.MustParse("0b6f4b4e-53f4-4f3c-9a36-8d2b8c7c1f10")
+	studentID = uuid.MustParse("7995c72f-7d04-4136-8b5f-000d6d4aae23")
+)
+
+func TestMark(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAttendanceService(ctrl)
+	router := newRouter(Controller(mockService))
+	url := "/courses/" + courseID.String() + "/attendance"
+	body := []byte(`{"date": "2026-09-28", "session": 2, "marks": [{"studentId": "` + studentID.String() + `", "status": "late"}]}`)
+
+	t.Run("Success", func(t *testing.T) {
+		request := &models.SessionRequest{Date: "2026-09-28", Session: 2, Marks: []models.Mark{{StudentID: studentID.String(), Status: models.StatusLate}}}
+		list := models.RecordList{Records: []models.Record{{ID: uuid.NewString(), StudentID: studentID.String(), CourseID: courseID.String(), Date: "2026-09-28", Session: 2, Status: models.StatusLate}}}
+		mockService.EXPECT().Mark(gomock.Any(), courseID, request).Return(list, nil)
+
+		w := apitest.Request(router, http.MethodPut, url, body)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var actual models.RecordList
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+		assert.Equal(t, list, actual)
+	})
+
+	t.Run("Not Enrolled", func(t *testing.T) {
+		err := &problem.ValidationError{Detail: "invalid attendance", Fields: []problem.FieldError{{Field: "marks[0].studentId", Code: "enrolled", Message: "is not enrolled in the course"}}}
+		mockService.EXPECT().Mark(gomock.Any(), courseID, gomock.Any()).Return(models.RecordList{}, err)
+
+		w := apitest.Request(router, http.MethodPut, url, body)
+		apitest.AssertProblem(t, w, http.StatusBadRequest, "invalid attendance")
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		w := apitest.Request(router, http.MethodPut, url, []byte(`{"marks": {}}`))
+		apitest.AssertProblem(t, w, http.StatusBadRequest, "invalid request")
+	})
+}
+
+func TestGetCourseRecords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAttendanceService(ctrl)
+	router := newRouter(Controller(mockService))
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().GetCourseRecords(gomock.Any(), courseID, models.Query{From: "2026-09-01", To: "2026-09-30"}).Return(models.RecordList{Records: []models.Record{}}, nil)
+
+		w := apitest.Request(router, http.MethodGet, "/courses/"+courseID.String()+"/attendance?from=2026-09-01&to=2026-09-30", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"records": []}`, w.Body.String())
+	})
+
+	t.Run("Invalid Period", func(t *testing.T) {
+		w := apitest.Request(router, http.MethodGet, "/courses/"+courseID.String()+"/attendance?from=yesterday", nil)
+		apitest.AssertProblem(t, w, http.StatusBadRequest, models.ErrInvalidPeriod.Detail)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		w := apitest.Request(router, http.MethodGet, "/courses/42/attendance", nil)
+		apitest.AssertProblem(t, w, http.StatusBadRequest, "invalid UUID")
+	})
+}
+
+func TestGetCourseSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAttendanceService(ctrl)
+	router := newRouter(Controller(mockService))
+
+	t.Run("Success", func(t *testing.T) {
+		list := models.SummaryList{Summaries: []models.Summary{{StudentID: studentID.String(), CourseID: courseID.String(), Sessions: 10, Present: 7, Absent: 3, Absences: 3, AbsencePercent: 30, Risk: models.RiskAtRisk}}}
+		mockService.EXPECT().GetCourseSummary(gomock.Any(), courseID, models.SummaryQuery{Risk: models.RiskAtRisk}).Return(list, nil)
+
+		w := apitest.Request(router, http.MethodGet, "/courses/"+courseID.String()+"/attendance/summary?risk=atRisk", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"absencePercent":30,"risk":"atRisk"`)
+		var actual models.SummaryList
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+		assert.Equal(t, list, actual)
+	})
+
+	t.Run("Invalid Risk", func(t *testing.T) {
+		w := apitest.Request(router, http.MethodGet, "/courses/"+courseID.String()+"/attendance/summary?risk=high", nil)
+		apitest.AssertProblem(t, w, http.StatusBadRequest, models.ErrInvalidRisk.Detail)
+	})
+}
+
+func TestGetStudentSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAttendanceService(ctrl)
+	router := newRouter(Controller(mockService))
+
+	mockService.EXPECT().GetStudentSummary(gomock.Any(), studentID, models.SummaryQuery{}).Return(models.SummaryList{Summaries: []models.Summary{}}, nil)
+
+	w := apitest.Request(router, http.MethodGet, "/students/"+studentID.String()+"/attendance/summary", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"summaries": []}`, w.Body.String())
+}
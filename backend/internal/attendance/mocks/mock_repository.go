@@ -0,0 +1,213 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/attendance/services/service.go
+
+// Package services is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/internal/attendance/models"
+	models0 "backend/internal/course/models"
+	models1 "backend/internal/enrollment/models"
+	models2 "backend/internal/student/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByCourse mocks base method.
+func (m *MockRepository) GetByCourse(courseID uuid.UUID, query models.Query) ([]models.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCourse", courseID, query)
+	ret0, _ := ret[0].([]models.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCourse indicates an expected call of GetByCourse.
+func (mr *MockRepositoryMockRecorder) GetByCourse(courseID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCourse", reflect.TypeOf((*MockRepository)(nil).GetByCourse), courseID, query)
+}
+
+// GetByStudent mocks base method.
+func (m *MockRepository) GetByStudent(studentID uuid.UUID, query models.Query) ([]models.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStudent", studentID, query)
+	ret0, _ := ret[0].([]models.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByStudent indicates an expected call of GetByStudent.
+func (mr *MockRepositoryMockRecorder) GetByStudent(studentID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudent", reflect.TypeOf((*MockRepository)(nil).GetByStudent), studentID, query)
+}
+
+// Mark mocks base method.
+func (m *MockRepository) Mark(ctx context.Context, records []models.Record) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Mark", ctx, records)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Mark indicates an expected call of Mark.
+func (mr *MockRepositoryMockRecorder) Mark(ctx, records interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Mark", reflect.TypeOf((*MockRepository)(nil).Mark), ctx, records)
+}
+
+// MockStudents is a mock of Students interface.
+type MockStudents struct {
+	ctrl     *gomock.Controller
+	recorder *MockStudentsMockRecorder
+}
+
+// MockStudentsMockRecorder is the mock recorder for MockStudents.
+type MockStudentsMockRecorder struct {
+	mock *MockStudents
+}
+
+// NewMockStudents creates a new mock instance.
+func NewMockStudents(ctrl *gomock.Controller) *MockStudents {
+	mock := &MockStudents{ctrl: ctrl}
+	mock.recorder = &MockStudentsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStudents) EXPECT() *MockStudentsMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockStudents) Get(id uuid.UUID) (*models2.Student, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(*models2.Student)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockStudentsMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStudents)(nil).Get), id)
+}
+
+// MockCourses is a mock of Courses interface.
+type MockCourses struct {
+	ctrl     *gomock.Controller
+	recorder *MockCoursesMockRecorder
+}
+
+// MockCoursesMockRecorder is the mock recorder for MockCourses.
+type MockCoursesMockRecorder struct {
+	mock *MockCourses
+}
+
+// NewMockCourses creates a new mock instance.
+func NewMockCourses(ctrl *gomock.Controller) *MockCourses {
+	mock := &MockCourses{ctrl: ctrl}
+	mock.recorder = &MockCoursesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourses) EXPECT() *MockCoursesMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockCourses) Get(id uuid.UUID) (*models0.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(*models0.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCoursesMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCourses)(nil).Get), id)
+}
+
+// MockEnrollments is a mock of Enrollments interface.
+type MockEnrollments struct {
+	ctrl     *gomock.Controller
+	recorder *MockEnrollmentsMockRecorder
+}
+
+// MockEnrollmentsMockRecorder is the mock recorder for MockEnrollments.
+type MockEnrollmentsMockRecorder struct {
+	mock *MockEnrollments
+}
+
+// NewMockEnrollments creates a new mock instance.
+func NewMockEnrollments(ctrl *gomock.Controller) *MockEnrollments {
+	mock := &MockEnrollments{ctrl: ctrl}
+	mock.recorder = &MockEnrollmentsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEnrollments) EXPECT() *MockEnrollmentsMockRecorder {
+	return m.recorder
+}
+
+// GetByCourse mocks base method.
+func (m *MockEnrollments) GetByCourse(courseID uuid.UUID, query models1.EnrollmentQuery) ([]models1.Enrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCourse", courseID, query)
+	ret0, _ := ret[0].([]models1.Enrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCourse indicates an expected call of GetByCourse.
+func (mr *MockEnrollmentsMockRecorder) GetByCourse(courseID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCourse", reflect.TypeOf((*MockEnrollments)(nil).GetByCourse), courseID, query)
+}
+
+// GetByStudent mocks base method.
+func (m *MockEnrollments) GetByStudent(studentID uuid.UUID, query models1.EnrollmentQuery) ([]models1.Enrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStudent", studentID, query)
+	ret0, _ := ret[0].([]models1.Enrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByStudent indicates an expected call of GetByStudent.
+func (mr *MockEnrollmentsMockRecorder) GetByStudent(studentID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudent", reflect.TypeOf((*MockEnrollments)(nil).GetByStudent), studentID, query)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/attendance/controllers/controller.go
+
+// Package controllers is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/internal/attendance/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockAttendanceService is a mock of AttendanceService interface.
+type MockAttendanceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttendanceServiceMockRecorder
+}
+
+// MockAttendanceServiceMockRecorder is the mock recorder for MockAttendanceService.
+type MockAttendanceServiceMockRecorder struct {
+	mock *MockAttendanceService
+}
+
+// NewMockAttendanceService creates a new mock instance.
+func NewMockAttendanceService(ctrl *gomock.Controller) *MockAttendanceService {
+	mock := &MockAttendanceService{ctrl: ctrl}
+	mock.recorder = &MockAttendanceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttendanceService) EXPECT() *MockAttendanceServiceMockRecorder {
+	return m.recorder
+}
+
+// GetCourseRecords mocks base method.
+func (m *MockAttendanceService) GetCourseRecords(ctx context.Context, courseID uuid.UUID, query models.Query) (models.RecordList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourseRecords", ctx, courseID, query)
+	ret0, _ := ret[0].(models.RecordList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourseRecords indicates an expected call of GetCourseRecords.
+func (mr *MockAttendanceServiceMockRecorder) GetCourseRecords(ctx, courseID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseRecords", reflect.TypeOf((*MockAttendanceService)(nil).GetCourseRecords), ctx, courseID, query)
+}
+
+// GetCourseSummary mocks base method.
+func (m *MockAttendanceService) GetCourseSummary(ctx context.Context, courseID uuid.UUID, query models.SummaryQuery) (models.SummaryList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourseSummary", ctx, courseID, query)
+	ret0, _ := ret[0].(models.SummaryList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourseSummary indicates an expected call of GetCourseSummary.
+func (mr *MockAttendanceServiceMockRecorder) GetCourseSummary(ctx, courseID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseSummary", reflect.TypeOf((*MockAttendanceService)(nil).GetCourseSummary), ctx, courseID, query)
+}
+
+// GetStudentRecords mocks base method.
+func (m *MockAttendanceService) GetStudentRecords(ctx context.Context, studentID uuid.UUID, query models.Query) (models.RecordList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStudentRecords", ctx, studentID, query)
+	ret0, _ := ret[0].(models.RecordList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStudentRecords indicates an expected call of GetStudentRecords.
+func (mr *MockAttendanceServiceMockRecorder) GetStudentRecords(ctx, studentID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentRecords", reflect.TypeOf((*MockAttendanceService)(nil).GetStudentRecords), ctx, studentID, query)
+}
+
+// GetStudentSummary mocks base method.
+func (m *MockAttendanceService) GetStudentSummary(ctx context.Context, studentID uuid.UUID, query models.SummaryQuery) (models.SummaryList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStudentSummary", ctx, studentID, query)
+	ret0, _ := ret[0].(models.SummaryList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStudentSummary indicates an expected call of GetStudentSummary.
+func (mr *MockAttendanceServiceMockRecorder) GetStudentSummary(ctx, studentID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentSummary", reflect.TypeOf((*MockAttendanceService)(nil).GetStudentSummary), ctx, studentID, query)
+}
+
+// Mark mocks base method.
+func (m *MockAttendanceService) Mark(ctx context.Context, courseID uuid.UUID, request *models.SessionRequest) (models.RecordList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Mark", ctx, courseID, request)
+	ret0, _ := ret[0].(models.RecordList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Mark indicates an expected call of Mark.
+func (mr *MockAttendanceServiceMockRecorder) Mark(ctx, courseID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Mark", reflect.TypeOf((*MockAttendanceService)(nil).Mark), ctx, courseID, request)
+}
@@ -0,0 +1,10 @@
+package models
+
+import "backend/internal/problem"
+
+var (
+	ErrConcurrentMark = &problem.ConflictError{Detail: "the session was marked concurrently, try again"}
+	ErrNoMarks        = &problem.ValidationError{Detail: "invalid attendance", Fields: []problem.FieldError{{Field: "marks", Code: "required", Message: "is required"}}}
+	ErrInvalidPeriod  = &problem.ValidationError{Detail: "invalid query", Fields: []problem.FieldError{{Field: "from", Code: "period", Message: "from and to must be dates, from not after to"}}}
+	ErrInvalidRisk    = &problem.ValidationError{Detail: "invalid query", Fields: []problem.FieldError{{Field: "risk", Code: "risk", Message: "must be one of ok, warning, atRisk"}}}
+)
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status is how a student attended a session.
+type Status string
+
+const (
+	StatusPresent Status = "present"
+	StatusAbsent  Status = "absent"
+	StatusLate    Status = "late"
+	StatusExcused Status = "excused"
+)
+
+// Statuses lists every status, in the order summaries count them.
+var Statuses = []Status{StatusPresent, StatusAbsent, StatusLate, StatusExcused}
+
+// Record is the attendance of a student at one session of a course. Sessions
+// are identified by their date and their number within the day, so a course
+// meeting twice a day has sessions 1 and 2.
+type Record struct {
+	ID        string `json:"id"`
+	StudentID string `json:"studentId"`
+	CourseID  string `json:"courseId"`
+	// Date is the day of the session, as YYYY-MM-DD.
+	Date       string    `json:"date"`
+	Session    int       `json:"session"`
+	Status     Status    `json:"status"`
+	Note       string    `json:"note,omitempty"`
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// RecordEntity is unique per student and session, and indexed for the
+// sessions of a course.
+type RecordEntity struct {
+	ID         uuid.UUID `gorm:"primary_key;type:char(36)"`
+	StudentID  uuid.UUID `gorm:"type:char(36);uniqueIndex:idx_attendance_student_session"`
+	CourseID   uuid.UUID `gorm:"type:char(36);uniqueIndex:idx_attendance_student_session;index:idx_attendance_course_date"`
+	Date       string    `gorm:"size:10;uniqueIndex:idx_attendance_student_session;index:idx_attendance_course_date"`
+	Session    int       `gorm:"uniqueIndex:idx_attendance_student_session"`
+	Status     Status    `gorm:"size:20"`
+	Note       string    `gorm:"size:200"`
+	RecordedAt time.Time
+}
+
+func (RecordEntity) TableName() string {
+	return "attendance_records"
+}
+
+// Mark is the attendance of one student in a SessionRequest.
+type Mark struct {
+	StudentID string `json:"studentId"`
+	Status    Status `json:"status" validate:"required,attendance"`
+	Note      string `json:"note,omitempty" validate:"trim,max=200"`
+}
+
+// SessionRequest marks the attendance of any number of students at one session
+// of a course, usually its whole roster. Marking a student again replaces
+// their record.
+type SessionRequest struct {
+	Date string `json:"date" validate:"trim,required,date"`
+	// Session is the number of the session within the day, 1 when omitted.
+	Session int    `json:"session,omitempty" validate:"min=0,max=24"`
+	Marks   []Mark `json:"marks"`
+}
+
+// RecordList holds attendance records by date, then session.
+type RecordList struct {
+	Records []Record `json:"records"`
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"backend/internal/validation"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func records(statuses ...Status) []Record {
+	records := []Record{}
+	for _, status := range statuses {
+		records = append(records, Record{Status: status})
+	}
+	return records
+}
+
+func repeat(status Status, n int) []Status {
+	statuses := make([]Status, n)
+	for i := range statuses {
+		statuses[i] = status
+	}
+	return statuses
+}
+
+func TestSummarize(t *testing.T) {
+	t.Run("Counts", func(t *testing.T) {
+		summary := Summarize("s", "c", records(StatusPresent, StatusAbsent, StatusLate, StatusExcused, StatusPresent), DefaultThresholds)
+		assert.Equal(t, Summary{StudentID: "s", CourseID: "c", Sessions: 5, Present: 2, Absent: 1, Late: 1, Excused: 1, Absences: 1, AbsencePercent: 25, Risk: RiskAtRisk}, summary)
+	})
+
+	t.Run("Thresholds", func(t *testing.T) {
+		for _, tc := range []struct {
+			name     string
+			statuses []Status
+			percent  float64
+			risk     Risk
+		}{
+			{"None", repeat(StatusPresent, 10), 0, RiskOK},
+			{"Below Warning", append(repeat(StatusPresent, 11), StatusAbsent), 8.33, RiskOK},
+			{"Warning", append(repeat(StatusPresent, 9), StatusAbsent), 10, RiskWarning},
+			{"At Risk", append(repeat(StatusPresent, 8), StatusAbsent, StatusAbsent), 20, RiskAtRisk},
+			{"Lates", append(repeat(StatusPresent, 7), StatusLate, StatusLate, StatusLate), 10, RiskWarning},
+			{"Excused", append(repeat(StatusExcused, 10), StatusPresent), 0, RiskOK},
+			{"Nothing Expected", repeat(StatusExcused, 3), 0, RiskOK},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				summary := Summarize("s", "c", records(tc.statuses...), DefaultThresholds)
+				assert.Equal(t, tc.percent, summary.AbsencePercent)
+				assert.Equal(t, tc.risk, summary.Risk)
+			})
+		}
+	})
+
+	t.Run("Lates Never Count", func(t *testing.T) {
+		summary := Summarize("s", "c", records(repeat(StatusLate, 5)...), Thresholds{WarningPercent: 10, AtRiskPercent: 20})
+		assert.Equal(t, 0, summary.Absences)
+		assert.Equal(t, RiskOK, summary.Risk)
+	})
+}
+
+func TestSortSummaries(t *testing.T) {
+	summaries := []Summary{
+		{StudentID: "b", AbsencePercent: 5},
+		{StudentID: "c", AbsencePercent: 30},
+		{StudentID: "a", AbsencePercent: 5},
+	}
+	SortSummaries(summaries)
+	assert.Equal(t, []Summary{
+		{StudentID: "c", AbsencePercent: 30},
+		{StudentID: "a", AbsencePercent: 5},
+		{StudentID: "b", AbsencePercent: 5},
+	}, summaries)
+}
+
+func TestParseSummaryQuery(t *testing.T) {
+	query, err := ParseSummaryQuery(url.Values{})
+	require.NoError(t, err)
+	assert.Equal(t, SummaryQuery{}, query)
+	assert.True(t, query.Matches(Summary{Risk: RiskOK}))
+
+	query, err = ParseSummaryQuery(url.Values{"from": {"2026-09-01"}, "to": {"2026-09-30"}, "risk": {"warning"}})
+	require.NoError(t, err)
+	assert.Equal(t, SummaryQuery{Query: Query{From: "2026-09-01", To: "2026-09-30"}, Risk: RiskWarning}, query)
+	assert.True(t, query.Matches(Summary{Risk: RiskAtRisk}))
+	assert.True(t, query.Matches(Summary{Risk: RiskWarning}))
+	assert.False(t, query.Matches(Summary{Risk: RiskOK}))
+	assert.True(t, query.Query.Matches(Record{Date: "2026-09-01"}))
+	assert.True(t, query.Query.Matches(Record{Date: "2026-09-30"}))
+	assert.False(t, query.Query.Matches(Record{Date: "2026-10-01"}))
+
+	_, err = ParseSummaryQuery(url.Values{"from": {"2026-09-31"}})
+	assert.Equal(t, ErrInvalidPeriod, err)
+	_, err = ParseSummaryQuery(url.Values{"from": {"2026-10-01"}, "to": {"2026-09-01"}})
+	assert.Equal(t, ErrInvalidPeriod, err)
+	_, err = ParseSummaryQuery(url.Values{"risk": {"failing"}})
+	assert.Equal(t, ErrInvalidRisk, err)
+}
+
+func TestSessionRequest(t *testing.T) {
+	request := &SessionRequest{Date: " 2026-09-28 ", Marks: []Mark{{StudentID: "s", Status: " Late "}}}
+	require.NoError(t, validation.Validate(request))
+	assert.Equal(t, "2026-09-28", request.Date)
+	assert.Equal(t, StatusLate, request.Marks[0].Status)
+
+	err := validation.Validate(&SessionRequest{Date: "28.09.2026", Marks: []Mark{{Status: "asleep"}}})
+	assert.EqualError(t, err, "date: must be a date, such as 2026-09-28, marks[0].status: must be one of present, absent, late, excused")
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"backend/internal/validation"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Query narrows attendance records to a period. Records are always listed by
+// date, then session.
+type Query struct {
+	// From and To bound the dates of the sessions, both included, when set.
+	From string
+	To   string
+}
+
+// ParseQuery reads the from and to query parameters.
+func ParseQuery(values url.Values) (Query, error) {
+	query := Query{From: strings.TrimSpace(values.Get("from")), To: strings.TrimSpace(values.Get("to"))}
+	for _, date := range []string{query.From, query.To} {
+		if _, err := time.Parse(validation.DateLayout, date); date != "" && err != nil {
+			return Query{}, ErrInvalidPeriod
+		}
+	}
+	if query.From != "" && query.To != "" && query.From > query.To {
+		return Query{}, ErrInvalidPeriod
+	}
+	return query, nil
+}
+
+// Matches reports whether record is kept by the query. Dates written as
+// YYYY-MM-DD compare as strings.
+func (q Query) Matches(record Record) bool {
+	return (q.From == "" || record.Date >= q.From) && (q.To == "" || record.Date <= q.To)
+}
+
+// SummaryQuery narrows attendance summaries.
+type SummaryQuery struct {
+	Query
+	// Risk only keeps the summaries at this risk or above when it is set.
+	Risk Risk
+}
+
+// ParseSummaryQuery reads the from, to and risk query parameters.
+func ParseSummaryQuery(values url.Values) (SummaryQuery, error) {
+	query, err := ParseQuery(values)
+	if err != nil {
+		return SummaryQuery{}, err
+	}
+	summaryQuery := SummaryQuery{Query: query}
+	switch risk := Risk(strings.TrimSpace(values.Get("risk"))); risk {
+	case "":
+	case RiskOK, RiskWarning, RiskAtRisk:
+		summaryQuery.Risk = risk
+	default:
+		return SummaryQuery{}, ErrInvalidRisk
+	}
+	return summaryQuery, nil
+}
+
+// Matches reports whether summary is kept by the query.
+func (q SummaryQuery) Matches(summary Summary) bool {
+	return q.Risk == "" || summary.Risk.level() >= q.Risk.level()
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"backend/internal/validation"
+	"reflect"
+	"strings"
+)
+
+func init() {
+	validation.Register("attendance", attendance)
+}
+
+// attendance accepts the statuses a student can be marked with. Case is
+// ignored.
+func attendance(value reflect.Value, _ string) string {
+	if value.Kind() != reflect.String || !value.CanSet() || value.String() == "" {
+		return ""
+	}
+	status := Status(strings.ToLower(strings.TrimSpace(value.String())))
+	for _, known := range Statuses {
+		if status == known {
+			value.SetString(string(status))
+			return ""
+		}
+	}
+	return "must be one of present, absent, late, excused"
+}
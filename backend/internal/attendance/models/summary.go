@@ -0,0 +1,109 @@
+package models
+
+import (
+	"math"
+	"sort"
+)
+
+// Risk tells how close absences bring a student to failing a course.
+type Risk string
+
+const (
+	RiskOK      Risk = "ok"
+	RiskWarning Risk = "warning"
+	RiskAtRisk  Risk = "atRisk"
+)
+
+func (r Risk) level() int {
+	switch r {
+	case RiskWarning:
+		return 1
+	case RiskAtRisk:
+		return 2
+	}
+	return 0
+}
+
+// Thresholds set when absences flag a student, as in config.Attendance.
+type Thresholds struct {
+	WarningPercent  int
+	AtRiskPercent   int
+	LatesPerAbsence int
+}
+
+// DefaultThresholds warn at 10% of the sessions missed, flag students at risk
+// at 20% and count three lates as an absence.
+var DefaultThresholds = Thresholds{WarningPercent: 10, AtRiskPercent: 20, LatesPerAbsence: 3}
+
+// Summary adds up the attendance of a student in a course.
+type Summary struct {
+	StudentID string `json:"studentId"`
+	CourseID  string `json:"courseId"`
+	// Sessions counts the sessions the student was marked at.
+	Sessions int `json:"sessions"`
+	Present  int `json:"present"`
+	Absent   int `json:"absent"`
+	Late     int `json:"late"`
+	Excused  int `json:"excused"`
+	// Absences counts the absences plus the lates that add up to absences.
+	Absences int `json:"absences"`
+	// AbsencePercent is the share of the unexcused sessions the absences make,
+	// rounded to two decimals.
+	AbsencePercent float64 `json:"absencePercent"`
+	Risk           Risk    `json:"risk"`
+}
+
+// SummaryList holds attendance summaries, most absences first.
+type SummaryList struct {
+	Summaries []Summary `json:"summaries"`
+}
+
+// Summarize adds up the records of a student in a course and flags the student
+// by the thresholds. Excused absences count neither as absences nor as sessions
+// the student was expected at.
+func Summarize(studentID, courseID string, records []Record, thresholds Thresholds) Summary {
+	summary := Summary{StudentID: studentID, CourseID: courseID, Risk: RiskOK}
+	for _, record := range records {
+		summary.Sessions++
+		switch record.Status {
+		case StatusPresent:
+			summary.Present++
+		case StatusAbsent:
+			summary.Absent++
+		case StatusLate:
+			summary.Late++
+		case StatusExcused:
+			summary.Excused++
+		}
+	}
+
+	summary.Absences = summary.Absent
+	if thresholds.LatesPerAbsence > 0 {
+		summary.Absences += summary.Late / thresholds.LatesPerAbsence
+	}
+	if expected := summary.Sessions - summary.Excused; expected > 0 {
+		summary.AbsencePercent = math.Round(10000*float64(summary.Absences)/float64(expected)) / 100
+	}
+	switch {
+	case summary.Absences > 0 && summary.AbsencePercent >= float64(thresholds.AtRiskPercent):
+		summary.Risk = RiskAtRisk
+	case summary.Absences > 0 && summary.AbsencePercent >= float64(thresholds.WarningPercent):
+		summary.Risk = RiskWarning
+	}
+	return summary
+}
+
+// SortSummaries orders summaries by absence percentage, highest first, then by
+// student and course.
+func SortSummaries(summaries []Summary) {
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		if a.AbsencePercent != b.AbsencePercent {
+			return a.AbsencePercent > b.AbsencePercent
+		}
+		if a.StudentID != b.StudentID {
+			return a.StudentID < b.StudentID
+		}
+		return a.CourseID < b.CourseID
+	})
+}
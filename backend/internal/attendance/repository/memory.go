@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"backend/internal/attendance/models"
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// sessionKey identifies the record of a student at a session.
+type sessionKey struct {
+	StudentID uuid.UUID
+	CourseID  uuid.UUID
+	Date      string
+	Session   int
+}
+
+// memoryRepository keeps attendance records in process memory. It is safe for
+// concurrent use and meant for local development and tests.
+type memoryRepository struct {
+	mu      sync.RWMutex
+	records map[sessionKey]models.RecordEntity
+}
+
+func NewMemoryRepository() *memoryRepository {
+	return &memoryRepository{records: map[sessionKey]models.RecordEntity{}}
+}
+
+func (r *memoryRepository) Mark(ctx context.Context, records []models.Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range records {
+		entity := ModelToEntity(&records[i])
+		key := sessionKey{StudentID: entity.StudentID, CourseID: entity.CourseID, Date: entity.Date, Session: entity.Session}
+		if existing, ok := r.records[key]; ok {
+			entity.ID = existing.ID
+			records[i].ID = existing.ID.String()
+		}
+		r.records[key] = *entity
+	}
+	return nil
+}
+
+func (r *memoryRepository) GetByCourse(courseID uuid.UUID, query models.Query) ([]models.Record, error) {
+	records := r.find(func(entity *models.RecordEntity) bool { return entity.CourseID == courseID }, query)
+	sortRecords(records, func(record models.Record) string { return record.StudentID })
+	return records, nil
+}
+
+func (r *memoryRepository) GetByStudent(studentID uuid.UUID, query models.Query) ([]models.Record, error) {
+	records := r.find(func(entity *models.RecordEntity) bool { return entity.StudentID == studentID }, query)
+	sortRecords(records, func(record models.Record) string { return record.CourseID })
+	return records, nil
+}
+
+func (r *memoryRepository) find(keep func(entity *models.RecordEntity) bool, query models.Query) []models.Record {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	records := []models.Record{}
+	for _, entity := range r.records {
+		if record := EntityToModel(&entity); keep(&entity) && query.Matches(*record) {
+			records = append(records, *record)
+		}
+	}
+	return records
+}
+
+// sortRecords orders records by date, session and then by, as the database
+// does.
+func sortRecords(records []models.Record, by func(models.Record) string) {
+	sort.Slice(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		if a.Session != b.Session {
+			return a.Session < b.Session
+		}
+		return by(a) < by(b)
+	})
+}
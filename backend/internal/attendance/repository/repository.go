@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"backend/internal/attendance/models"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type attendanceRepository struct {
+	DB *gorm.DB
+}
+
+// NewAttendanceRepository stores attendance records in db, whose schema
+// schema.Migrate keeps up to date.
+func NewAttendanceRepository(db *gorm.DB) (*attendanceRepository, error) {
+	return &attendanceRepository{DB: db}, nil
+}
+
+// Mark saves the records in one transaction. A record replaces the one the
+// student already has for the session, keeping its ID.
+func (r *attendanceRepository) Mark(ctx context.Context, records []models.Record) error {
+	return translateError(r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range records {
+			entity := ModelToEntity(&records[i])
+			var existing models.RecordEntity
+			err := tx.Where("student_id = ? AND course_id = ? AND date = ? AND session = ?", entity.StudentID, entity.CourseID, entity.Date, entity.Session).Take(&existing).Error
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				if err := tx.Create(entity).Error; err != nil {
+					return err
+				}
+			case err != nil:
+				return err
+			default:
+				updates := map[string]interface{}{"status": entity.Status, "note": entity.Note, "recorded_at": entity.RecordedAt}
+				if err := tx.Model(&existing).Updates(updates).Error; err != nil {
+					return err
+				}
+				records[i].ID = existing.ID.String()
+			}
+		}
+		return nil
+	}))
+}
+
+// GetByCourse returns the records of every session of the course in the
+// period, by date, session and student.
+func (r *attendanceRepository) GetByCourse(courseID uuid.UUID, query models.Query) ([]models.Record, error) {
+	return r.find(r.DB.Where("course_id = ?", courseID), query, "student_id")
+}
+
+// GetByStudent returns the records of the student in every course in the
+// period, by date, session and course.
+func (r *attendanceRepository) GetByStudent(studentID uuid.UUID, query models.Query) ([]models.Record, error) {
+	return r.find(r.DB.Where("student_id = ?", studentID), query, "course_id")
+}
+
+func (r *attendanceRepository) find(db *gorm.DB, query models.Query, order string) ([]models.Record, error) {
+	if query.From != "" {
+		db = db.Where("date >= ?", query.From)
+	}
+	if query.To != "" {
+		db = db.Where("date <= ?", query.To)
+	}
+	var entities []models.RecordEntity
+	if err := db.Order("date").Order("session").Order(order).Find(&entities).Error; err != nil {
+		return nil, translateError(err)
+	}
+	records := []models.Record{}
+	for i := range entities {
+		records = append(records, *EntityToModel(&entities[i]))
+	}
+	return records, nil
+}
+
+// DeleteStudent keeps the attendance of a deleted student, who gets it back
+// when restored.
+func (r *attendanceRepository) DeleteStudent(tx *gorm.DB, id uuid.UUID) error {
+	return nil
+}
+
+// PurgeStudents deletes the attendance records of the students. The student
+// repository calls it in the transaction that purges them.
+func (r *attendanceRepository) PurgeStudents(tx *gorm.DB, ids []uuid.UUID) error {
+	return tx.Where("student_id IN ?", ids).Delete(&models.RecordEntity{}).Error
+}
+
+func ModelToEntity(record *models.Record) *models.RecordEntity {
+	return &models.RecordEntity{
+		ID:         uuid.MustParse(record.ID),
+		StudentID:  uuid.MustParse(record.StudentID),
+		CourseID:   uuid.MustParse(record.CourseID),
+		Date:       record.Date,
+		Session:    record.Session,
+		Status:     record.Status,
+		Note:       record.Note,
+		RecordedAt: record.RecordedAt,
+	}
+}
+
+func EntityToModel(entity *models.RecordEntity) *models.Record {
+	return &models.Record{
+		ID:         entity.ID.String(),
+		StudentID:  entity.StudentID.String(),
+		CourseID:   entity.CourseID.String(),
+		Date:       entity.Date,
+		Session:    entity.Session,
+		Status:     entity.Status,
+		Note:       entity.Note,
+		RecordedAt: entity.RecordedAt,
+	}
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"backend/internal/attendance/models"
+	"backend/internal/attendance/services"
+	"backend/internal/database/databasetest"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type fixture struct {
+	repo services.Repository
+	// db stores the students and courses the SQL repository refers to; the
+	// memory repository does not look them up.
+	db *gorm.DB
+}
+
+func newMemoryFixture(t *testing.T) *fixture {
+	return &fixture{repo: NewMemoryRepository()}
+}
+
+func newSQLiteFixture(t *testing.T) *fixture {
+	store := databasetest.Open(t)
+	repo, err := NewAttendanceRepository(store.DB)
+	require.NoError(t, err)
+	return &fixture{repo: repo, db: store.DB}
+}
+
+// TestRepositories runs the same checks against every implementation.
+func TestRepositories(t *testing.T) {
+	databasetest.Run(t, map[string]func(t *testing.T) *fixture{
+		"Memory": newMemoryFixture,
+		"SQLite": newSQLiteFixture,
+	}, map[string]func(t *testing.T, f *fixture){
+		"Mark":  testMark,
+		"Query": testQuery,
+	})
+}
+
+// TestPurgeStudents checks that purging students takes their attendance with
+// them. The memory repositories of students and attendance are unrelated, so
+// only the SQL one is checked.
+func TestPurgeStudents(t *testing.T) {
+	ctx := context.Background()
+	store := databasetest.Open(t)
+	repo, err := NewAttendanceRepository(store.DB)
+	require.NoError(t, err)
+	store.Attach(repo)
+
+	courseID := databasetest.AddCourse(t, store.DB)
+	purged, kept := databasetest.AddStudent(t, store.DB), databasetest.AddStudent(t, store.DB)
+	require.NoError(t, repo.Mark(ctx, []models.Record{
+		newRecord(purged, courseID, "2026-09-28", 1, models.StatusPresent),
+		newRecord(kept, courseID, "2026-09-28", 1, models.StatusPresent),
+	}))
+
+	require.NoError(t, store.Students.Delete(ctx, purged))
+	records, err := repo.GetByStudent(purged, models.Query{})
+	require.NoError(t, err)
+	assert.Len(t, records, 1, "deleted students keep their attendance")
+	_, err = store.Students.Purge(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	records, err = repo.GetByCourse(courseID, models.Query{})
+	require.NoError(t, err)
+	require.Len(t, records, 1, "the records of purged students are deleted")
+	assert.Equal(t, kept.String(), records[0].StudentID)
+}
+
+var recordedAt = time.Date(2026, 9, 28, 9, 0, 0, 0, time.UTC)
+
+func newRecord(studentID, courseID uuid.UUID, date string, session int, status models.Status) models.Record {
+	return models.Record{
+		ID:         uuid.New().String(),
+		StudentID:  studentID.String(),
+		CourseID:   courseID.String(),
+		Date:       date,
+		Session:    session,
+		Status:     status,
+		RecordedAt: recordedAt,
+	}
+}
+
+func testMark(t *testing.T, f *fixture) {
+	ctx, repo := context.Background(), f.repo
+	ali, ayse, courseID := databasetest.AddStudent(t, f.db), databasetest.AddStudent(t, f.db), databasetest.AddCourse(t, f.db)
+
+	first := []models.Record{
+		newRecord(ali, courseID, "2026-09-28", 1, models.StatusPresent),
+		newRecord(ayse, courseID, "2026-09-28", 1, models.StatusAbsent),
+	}
+	require.NoError(t, repo.Mark(ctx, first))
+
+	// Marking a student again replaces the record and keeps its ID.
+	again := []models.Record{newRecord(ayse, courseID, "2026-09-28", 1, models.StatusExcused)}
+	again[0].Note = "Doctor's note"
+	require.NoError(t, repo.Mark(ctx, again))
+	assert.Equal(t, first[1].ID, again[0].ID)
+
+	records, err := repo.GetByCourse(courseID, models.Query{})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	byStudent := map[string]models.Record{}
+	for _, record := range records {
+		byStudent[record.StudentID] = record
+	}
+	assert.Equal(t, first[0], byStudent[ali.String()])
+	assert.Equal(t, again[0], byStudent[ayse.String()])
+
+	// A second session the same day is a record of its own.
+	require.NoError(t, repo.Mark(ctx, []models.Record{newRecord(ali, courseID, "2026-09-28", 2, models.StatusLate)}))
+	records, err = repo.GetByStudent(ali, models.Query{})
+	require.NoError(t, err)
+	assert.Len(t, records, 2)
+}
+
+func testQuery(t *testing.T, f *fixture) {
+	ctx, repo := context.Background(), f.repo
+	studentID, math, physics := databasetest.AddStudent(t, f.db), databasetest.AddCourse(t, f.db), databasetest.AddCourse(t, f.db)
+	other := databasetest.AddStudent(t, f.db)
+	require.NoError(t, repo.Mark(ctx, []models.Record{
+		newRecord(studentID, math, "2026-10-05", 1, models.StatusPresent),
+		newRecord(studentID, physics, "2026-09-28", 2, models.StatusLate),
+		newRecord(studentID, math, "2026-09-28", 1, models.StatusAbsent),
+		newRecord(other, math, "2026-09-28", 1, models.StatusPresent),
+	}))
+	sessions := func(records []models.Record, err error) []string {
+		require.NoError(t, err)
+		sessions := []string{}
+		for _, record := range records {
+			sessions = append(sessions, record.Date+"/"+string(record.Status))
+		}
+		return sessions
+	}
+
+	assert.Equal(t, []string{"2026-09-28/absent", "2026-09-28/late", "2026-10-05/present"}, sessions(repo.GetByStudent(studentID, models.Query{})))
+	assert.Equal(t, []string{"2026-09-28/absent", "2026-09-28/late"}, sessions(repo.GetByStudent(studentID, models.Query{To: "2026-09-30"})))
+	assert.Equal(t, []string{"2026-10-05/present"}, sessions(repo.GetByCourse(math, models.Query{From: "2026-10-01"})))
+	assert.Len(t, sessions(repo.GetByCourse(math, models.Query{From: "2026-09-28", To: "2026-09-28"})), 2)
+	assert.Equal(t, []string{}, sessions(repo.GetByCourse(uuid.New(), models.Query{})))
+}
@@ -0,0 +1,84 @@
+package routes
+
+import (
+	"backend/internal/attendance/models"
+	"backend/internal/auth"
+	"backend/internal/openapi"
+	"net/http"
+)
+
+var problemDescriptions = map[int]string{
+	http.StatusBadRequest:          "The request is invalid; errors lists the invalid fields",
+	http.StatusNotFound:            "No such student or course",
+	http.StatusConflict:            "The session was marked concurrently, try again",
+	http.StatusServiceUnavailable:  "The database is unavailable, try again later",
+	http.StatusInternalServerError: "Unexpected error",
+}
+
+// operation secures op with permission and documents the problems it may
+// answer with, besides the 503 and 500 every operation may.
+func operation(doc *openapi.Document, op *openapi.Operation, permission auth.Permission, problems ...int) *openapi.Operation {
+	op.Tags = []string{"attendance"}
+	problems = append(problems, http.StatusServiceUnavailable, http.StatusInternalServerError)
+	for _, status := range problems {
+		op.Respond(status, doc.Problem(problemDescriptions[status]))
+	}
+	return auth.Secure(doc, op, permission)
+}
+
+// Describe documents the routes SetupRoutes registers.
+func Describe(doc *openapi.Document) {
+	doc.AddTag("attendance", "Attendance at the sessions of courses, and the students absences put at risk")
+	doc.Enum(models.StatusPresent, models.StatusAbsent, models.StatusLate, models.StatusExcused)
+	doc.Enum(models.RiskOK, models.RiskWarning, models.RiskAtRisk)
+
+	uuidSchema := &openapi.Schema{Type: "string", Format: "uuid"}
+	dateSchema := &openapi.Schema{Type: "string", Format: "date"}
+	courseID := openapi.Path("id", "The course ID", uuidSchema)
+	studentID := openapi.Path("id", "The student ID", uuidSchema)
+	from := openapi.Query("from", "Only sessions on or after this date", dateSchema)
+	to := openapi.Query("to", "Only sessions on or before this date", dateSchema)
+	risk := openapi.Query("risk", "Only students at this risk or above", doc.Schema(models.RiskOK))
+	summaryDescription := "Only students with a seat are summed up. Excused absences do not count, and lates add up to absences as configured. " +
+		"Students whose absences reach the configured percentages of their sessions get a warning or are at risk of failing."
+
+	doc.Add(http.MethodPut, "/courses/:id/attendance", operation(doc, &openapi.Operation{
+		Summary: "Mark the attendance of a session",
+		Description: "Marks any number of students, usually the whole roster, at one session of the course in a single request. " +
+			"Every student must have a seat in the course. Marking a student again replaces their record.",
+		OperationID: "markAttendance",
+		Parameters:  []*openapi.Parameter{courseID},
+		RequestBody: doc.Body(models.SessionRequest{}, ""),
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.RecordList{}, "The saved records")},
+	}, auth.PermAttendanceWrite, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict))
+
+	doc.Add(http.MethodGet, "/courses/:id/attendance", operation(doc, &openapi.Operation{
+		Summary:     "List the attendance of a course",
+		OperationID: "listCourseAttendance",
+		Parameters:  []*openapi.Parameter{courseID, from, to},
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.RecordList{}, "The records, by date, session and student")},
+	}, auth.PermAttendanceRead, http.StatusBadRequest, http.StatusNotFound))
+
+	doc.Add(http.MethodGet, "/courses/:id/attendance/summary", operation(doc, &openapi.Operation{
+		Summary:     "Sum up the attendance of a course",
+		Description: summaryDescription,
+		OperationID: "getCourseAttendanceSummary",
+		Parameters:  []*openapi.Parameter{courseID, from, to, risk},
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.SummaryList{}, "A summary per student, most absences first")},
+	}, auth.PermAttendanceRead, http.StatusBadRequest, http.StatusNotFound))
+
+	doc.Add(http.MethodGet, "/students/:id/attendance", operation(doc, &openapi.Operation{
+		Summary:     "List the attendance of a student",
+		OperationID: "listStudentAttendance",
+		Parameters:  []*openapi.Parameter{studentID, from, to},
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.RecordList{}, "The records, by date, session and course")},
+	}, auth.PermAttendanceRead, http.StatusBadRequest, http.StatusNotFound))
+
+	doc.Add(http.MethodGet, "/students/:id/attendance/summary", operation(doc, &openapi.Operation{
+		Summary:     "Sum up the attendance of a student",
+		Description: summaryDescription,
+		OperationID: "getStudentAttendanceSummary",
+		Parameters:  []*openapi.Parameter{studentID, from, to, risk},
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.SummaryList{}, "A summary per course, most absences first")},
+	}, auth.PermAttendanceRead, http.StatusBadRequest, http.StatusNotFound))
+}
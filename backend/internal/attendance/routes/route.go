@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"backend/internal/attendance/controllers"
+	"backend/internal/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupRoutes registers the attendance routes behind authenticate, each
+// requiring the permission it needs.
+func SetupRoutes(router *gin.Engine, attendanceController *controllers.AttendanceController, authenticate gin.HandlerFunc) {
+	attendance := router.Group("", authenticate)
+	attendance.PUT("/courses/:id/attendance", auth.Require(auth.PermAttendanceWrite), attendanceController.Mark)
+	attendance.GET("/courses/:id/attendance", auth.Require(auth.PermAttendanceRead), attendanceController.GetCourseRecords)
+	attendance.GET("/courses/:id/attendance/summary", auth.Require(auth.PermAttendanceRead), attendanceController.GetCourseSummary)
+	attendance.GET("/students/:id/attendance", auth.Require(auth.PermAttendanceRead), attendanceController.GetStudentRecords)
+	attendance.GET("/students/:id/attendance/summary", auth.Require(auth.PermAttendanceRead), attendanceController.GetStudentSummary)
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"backend/internal/attendance/controllers"
+	"backend/internal/attendance/mocks"
+	"backend/internal/auth"
+	"backend/internal/auth/authtest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func TestSetupRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Every route must be rejected before it reaches the service.
+	controller := controllers.Controller(mocks.NewMockAttendanceService(ctrl))
+	router := gin.New()
+	SetupRoutes(router, controller, authtest.AuthenticateAs(auth.PermStudentsRead, auth.PermCoursesRead, auth.PermEnrollmentsRead, auth.PermGradesRead))
+
+	authtest.AssertForbidden(t, router)
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"backend/internal/attendance/models"
+	"backend/internal/auth"
+	coursemodels "backend/internal/course/models"
+	enrollmentmodels "backend/internal/enrollment/models"
+	"backend/internal/problem"
+	studentmodels "backend/internal/student/models"
+	"backend/internal/validation"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Mark(ctx context.Context, records []models.Record) error
+	GetByCourse(courseID uuid.UUID, query models.Query) ([]models.Record, error)
+	GetByStudent(studentID uuid.UUID, query models.Query) ([]models.Record, error)
+}
+
+// Students looks up students, telling the attendance of unknown or deleted
+// students apart.
+type Students interface {
+	Get(id uuid.UUID) (*studentmodels.Student, error)
+}
+
+// Courses looks up courses, telling the sessions of unknown courses apart.
+type Courses interface {
+	Get(id uuid.UUID) (*coursemodels.Course, error)
+}
+
+// Enrollments lists rosters; only students with a seat in a course are marked
+// at its sessions.
+type Enrollments interface {
+	GetByStudent(studentID uuid.UUID, query enrollmentmodels.EnrollmentQuery) ([]enrollmentmodels.Enrollment, error)
+	GetByCourse(courseID uuid.UUID, query enrollmentmodels.EnrollmentQuery) ([]enrollmentmodels.Enrollment, error)
+}
+
+var enrolled = enrollmentmodels.EnrollmentQuery{Status: enrollmentmodels.StatusEnrolled}
+
+type AttendanceService struct {
+	repository  Repository
+	students    Students
+	courses     Courses
+	enrollments Enrollments
+	// Thresholds flag students in summaries.
+	Thresholds models.Thresholds
+}
+
+func Service(repository Repository, students Students, courses Courses, enrollments Enrollments) *AttendanceService {
+	return &AttendanceService{
+		repository:  repository,
+		students:    students,
+		courses:     courses,
+		enrollments: enrollments,
+		Thresholds:  models.DefaultThresholds,
+	}
+}
+
+// Mark records the attendance of students at a session of the course, all or
+// none of them. Every student must have a seat in the course and be marked at
+// most once; the fields of every offending mark are reported together.
+func (s *AttendanceService) Mark(ctx context.Context, courseID uuid.UUID, request *models.SessionRequest) (models.RecordList, error) {
+	if err := auth.Authorize(ctx, auth.PermAttendanceWrite); err != nil {
+		return models.RecordList{}, err
+	}
+	if err := validation.Validate(request); err != nil {
+		return models.RecordList{}, err
+	}
+	if len(request.Marks) == 0 {
+		return models.RecordList{}, models.ErrNoMarks
+	}
+	if request.Session == 0 {
+		request.Session = 1
+	}
+	if _, err := s.courses.Get(courseID); err != nil {
+		return models.RecordList{}, err
+	}
+	roster, err := s.enrollments.GetByCourse(courseID, enrolled)
+	if err != nil {
+		return models.RecordList{}, err
+	}
+	seated := map[uuid.UUID]bool{}
+	for _, enrollment := range roster {
+		seated[uuid.MustParse(enrollment.StudentID)] = true
+	}
+
+	recordedAt := time.Now().UTC()
+	records := []models.Record{}
+	marked := map[uuid.UUID]bool{}
+	var failures []problem.FieldError
+	for i, mark := range request.Marks {
+		field := fmt.Sprintf("marks[%d].studentId", i)
+		studentID, err := uuid.Parse(mark.StudentID)
+		switch {
+		case err != nil:
+			failures = append(failures, problem.FieldError{Field: field, Code: "uuid", Message: "must be a UUID"})
+			continue
+		case marked[studentID]:
+			failures = append(failures, problem.FieldError{Field: field, Code: "unique", Message: "is marked more than once"})
+			continue
+		case !seated[studentID]:
+			failures = append(failures, problem.FieldError{Field: field, Code: "enrolled", Message: "is not enrolled in the course"})
+			continue
+		}
+		marked[studentID] = true
+		records = append(records, models.Record{
+			ID:         uuid.New().String(),
+			StudentID:  studentID.String(),
+			CourseID:   courseID.String(),
+			Date:       request.Date,
+			Session:    request.Session,
+			Status:     mark.Status,
+			Note:       mark.Note,
+			RecordedAt: recordedAt,
+		})
+	}
+	if len(failures) > 0 {
+		return models.RecordList{}, &problem.ValidationError{Detail: "invalid attendance", Fields: failures}
+	}
+
+	if err := s.repository.Mark(ctx, records); err != nil {
+		return models.RecordList{}, err
+	}
+	return models.RecordList{Records: records}, nil
+}
+
+// GetCourseRecords lists the attendance at the sessions of the course.
+func (s *AttendanceService) GetCourseRecords(ctx context.Context, courseID uuid.UUID, query models.Query) (models.RecordList, error) {
+	if err := auth.Authorize(ctx, auth.PermAttendanceRead); err != nil {
+		return models.RecordList{}, err
+	}
+	if _, err := s.courses.Get(courseID); err != nil {
+		return models.RecordList{}, err
+	}
+	records, err := s.repository.GetByCourse(courseID, query)
+	if err != nil {
+		return models.RecordList{}, err
+	}
+	return models.RecordList{Records: records}, nil
+}
+
+// GetStudentRecords lists the attendance of the student in every course.
+func (s *AttendanceService) GetStudentRecords(ctx context.Context, studentID uuid.UUID, query models.Query) (models.RecordList, error) {
+	if err := auth.Authorize(ctx, auth.PermAttendanceRead); err != nil {
+		return models.RecordList{}, err
+	}
+	if _, err := s.students.Get(studentID); err != nil {
+		return models.RecordList{}, err
+	}
+	records, err := s.repository.GetByStudent(studentID, query)
+	if err != nil {
+		return models.RecordList{}, err
+	}
+	return models.RecordList{Records: records}, nil
+}
+
+// GetCourseSummary sums up the attendance of every student with a seat in the
+// course, most absences first.
+func (s *AttendanceService) GetCourseSummary(ctx context.Context, courseID uuid.UUID, query models.SummaryQuery) (models.SummaryList, error) {
+	if err := auth.Authorize(ctx, auth.PermAttendanceRead); err != nil {
+		return models.SummaryList{}, err
+	}
+	if _, err := s.courses.Get(courseID); err != nil {
+		return models.SummaryList{}, err
+	}
+	roster, err := s.enrollments.GetByCourse(courseID, enrolled)
+	if err != nil {
+		return models.SummaryList{}, err
+	}
+	records, err := s.repository.GetByCourse(courseID, query.Query)
+	if err != nil {
+		return models.SummaryList{}, err
+	}
+	byStudent := map[string][]models.Record{}
+	for _, record := range records {
+		byStudent[record.StudentID] = append(byStudent[record.StudentID], record)
+	}
+
+	summaries := []models.Summary{}
+	for _, enrollment := range roster {
+		summary := models.Summarize(enrollment.StudentID, courseID.String(), byStudent[enrollment.StudentID], s.Thresholds)
+		if query.Matches(summary) {
+			summaries = append(summaries, summary)
+		}
+	}
+	models.SortSummaries(summaries)
+	return models.SummaryList{Summaries: summaries}, nil
+}
+
+// GetStudentSummary sums up the attendance of the student in every course they
+// have a seat in, most absences first.
+func (s *AttendanceService) GetStudentSummary(ctx context.Context, studentID uuid.UUID, query models.SummaryQuery) (models.SummaryList, error) {
+	if err := auth.Authorize(ctx, auth.PermAttendanceRead); err != nil {
+		return models.SummaryList{}, err
+	}
+	if _, err := s.students.Get(studentID); err != nil {
+		return models.SummaryList{}, err
+	}
+	enrollments, err := s.enrollments.GetByStudent(studentID, enrolled)
+	if err != nil {
+		return models.SummaryList{}, err
+	}
+	records, err := s.repository.GetByStudent(studentID, query.Query)
+	if err != nil {
+		return models.SummaryList{}, err
+	}
+	byCourse := map[string][]models.Record{}
+	for _, record := range records {
+		byCourse[record.CourseID] = append(byCourse[record.CourseID], record)
+	}
+
+	summaries := []models.Summary{}
+	for _, enrollment := range enrollments {
+		summary := models.Summarize(studentID.String(), enrollment.CourseID, byCourse[enrollment.CourseID], s.Thresholds)
+		if query.Matches(summary) {
+			summaries = append(summaries, summary)
+		}
+	}
+	models.SortSummaries(summaries)
+	return models.SummaryList{Summaries: summaries}, nil
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"backend/internal/attendance/mocks"
+	"backend/internal/attendance/models"
+	"backend/internal/auth"
+	"backend/internal/auth/authtest"
+	coursemodels "backend/internal/course/models"
+	enrollmentmodels "backend/internal/enrollment/models"
+	"backend/internal/problem"
+	studentmodels "backend/internal/student/models"
+	"context"
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixture struct {
+	repo        *mocks.MockRepository
+	students    *mocks.MockStudents
+	courses     *mocks.MockCourses
+	enrollments *mocks.MockEnrollments
+	service     *AttendanceService
+}
+
+func newFixture(t *testing.T) *fixture {
+	ctrl := gomock.NewController(t)
+	f := &fixture{
+		repo:        mocks.NewMockRepository(ctrl),
+		students:    mocks.NewMockStudents(ctrl),
+		courses:     mocks.NewMockCourses(ctrl),
+		enrollments: mocks.NewMockEnrollments(ctrl),
+	}
+	f.service = Service(f.repo, f.students, f.courses, f.enrollments)
+	return f
+}
+
+// roster expects the course to be looked up and to seat the students.
+func (f *fixture) roster(courseID uuid.UUID, studentIDs ...uuid.UUID) {
+	f.courses.EXPECT().Get(courseID).Return(&coursemodels.Course{ID: courseID.String()}, nil)
+	enrollments := []enrollmentmodels.Enrollment{}
+	for _, studentID := range studentIDs {
+		enrollments = append(enrollments, enrollmentmodels.Enrollment{StudentID: studentID.String(), CourseID: courseID.String(), Status: enrollmentmodels.StatusEnrolled})
+	}
+	f.enrollments.EXPECT().GetByCourse(courseID, enrolled).Return(enrollments, nil)
+}
+
+func TestMark(t *testing.T) {
+	courseID, ali, ayse := uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		f := newFixture(t)
+		f.roster(courseID, ali, ayse)
+		f.repo.EXPECT().Mark(gomock.Any(), gomock.Len(2)).Return(nil)
+
+		list, err := f.service.Mark(authtest.AdminContext, courseID, &models.SessionRequest{Date: "2026-09-28", Marks: []models.Mark{
+			{StudentID: ali.String(), Status: "present"},
+			{StudentID: ayse.String(), Status: "Excused", Note: " Doctor's note "},
+		}})
+		require.NoError(t, err)
+		require.Len(t, list.Records, 2)
+		record := list.Records[1]
+		assert.NotEmpty(t, record.ID)
+		assert.Equal(t, ayse.String(), record.StudentID)
+		assert.Equal(t, courseID.String(), record.CourseID)
+		assert.Equal(t, 1, record.Session, "the session defaults to the first of the day")
+		assert.Equal(t, models.StatusExcused, record.Status)
+		assert.Equal(t, "Doctor's note", record.Note)
+		assert.False(t, record.RecordedAt.IsZero())
+	})
+
+	t.Run("Invalid Marks", func(t *testing.T) {
+		f := newFixture(t)
+		f.roster(courseID, ali)
+
+		_, err := f.service.Mark(authtest.AdminContext, courseID, &models.SessionRequest{Date: "2026-09-28", Session: 2, Marks: []models.Mark{
+			{StudentID: ali.String(), Status: "late"},
+			{StudentID: "42", Status: "late"},
+			{StudentID: ali.String(), Status: "absent"},
+			{StudentID: ayse.String(), Status: "absent"},
+		}})
+		var validationErr *problem.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []problem.FieldError{
+			{Field: "marks[1].studentId", Code: "uuid", Message: "must be a UUID"},
+			{Field: "marks[2].studentId", Code: "unique", Message: "is marked more than once"},
+			{Field: "marks[3].studentId", Code: "enrolled", Message: "is not enrolled in the course"},
+		}, validationErr.Fields)
+	})
+
+	t.Run("No Marks", func(t *testing.T) {
+		f := newFixture(t)
+		_, err := f.service.Mark(authtest.AdminContext, courseID, &models.SessionRequest{Date: "2026-09-28"})
+		assert.Equal(t, models.ErrNoMarks, err)
+	})
+
+	t.Run("Unknown Course", func(t *testing.T) {
+		f := newFixture(t)
+		f.courses.EXPECT().Get(courseID).Return(nil, coursemodels.ErrCourseNotFound)
+
+		_, err := f.service.Mark(authtest.AdminContext, courseID, &models.SessionRequest{Date: "2026-09-28", Marks: []models.Mark{{StudentID: ali.String(), Status: "present"}}})
+		assert.ErrorIs(t, err, coursemodels.ErrCourseNotFound)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		f := newFixture(t)
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Username: "auditor", Permissions: []auth.Permission{auth.PermAttendanceRead}})
+
+		_, err := f.service.Mark(ctx, courseID, &models.SessionRequest{})
+		assert.Equal(t, &auth.ForbiddenError{Permission: auth.PermAttendanceWrite}, err)
+	})
+}
+
+func TestGetCourseSummary(t *testing.T) {
+	courseID, ali, ayse, can := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	record := func(studentID uuid.UUID, status models.Status) models.Record {
+		return models.Record{StudentID: studentID.String(), CourseID: courseID.String(), Status: status}
+	}
+	records := []models.Record{
+		record(ali, models.StatusPresent), record(ali, models.StatusAbsent), record(ali, models.StatusAbsent), record(ali, models.StatusPresent),
+		record(ayse, models.StatusPresent), record(ayse, models.StatusPresent), record(ayse, models.StatusLate), record(ayse, models.StatusPresent),
+		// Students who dropped the course are left out.
+		record(uuid.New(), models.StatusAbsent),
+	}
+	query := models.Query{From: "2026-09-01"}
+
+	t.Run("Everyone", func(t *testing.T) {
+		f := newFixture(t)
+		f.roster(courseID, ayse, ali, can)
+		f.repo.EXPECT().GetByCourse(courseID, query).Return(records, nil)
+
+		list, err := f.service.GetCourseSummary(authtest.AdminContext, courseID, models.SummaryQuery{Query: query})
+		require.NoError(t, err)
+		require.Len(t, list.Summaries, 3)
+		assert.Equal(t, ali.String(), list.Summaries[0].StudentID)
+		assert.Equal(t, 50.0, list.Summaries[0].AbsencePercent)
+		assert.Equal(t, models.RiskAtRisk, list.Summaries[0].Risk)
+		sessions := map[string]int{}
+		for _, summary := range list.Summaries {
+			sessions[summary.StudentID] = summary.Sessions
+		}
+		assert.Equal(t, map[string]int{ali.String(): 4, ayse.String(): 4, can.String(): 0}, sessions)
+	})
+
+	t.Run("At Risk", func(t *testing.T) {
+		f := newFixture(t)
+		f.service.Thresholds = models.Thresholds{WarningPercent: 50, AtRiskPercent: 75, LatesPerAbsence: 1}
+		f.roster(courseID, ayse, ali, can)
+		f.repo.EXPECT().GetByCourse(courseID, query).Return(records, nil)
+
+		list, err := f.service.GetCourseSummary(authtest.AdminContext, courseID, models.SummaryQuery{Query: query, Risk: models.RiskWarning})
+		require.NoError(t, err)
+		require.Len(t, list.Summaries, 1)
+		assert.Equal(t, ali.String(), list.Summaries[0].StudentID)
+		assert.Equal(t, models.RiskWarning, list.Summaries[0].Risk)
+	})
+}
+
+func TestGetStudentSummary(t *testing.T) {
+	studentID, math, physics := uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		f := newFixture(t)
+		f.students.EXPECT().Get(studentID).Return(&studentmodels.Student{ID: studentID.String()}, nil)
+		f.enrollments.EXPECT().GetByStudent(studentID, enrolled).Return([]enrollmentmodels.Enrollment{
+			{StudentID: studentID.String(), CourseID: math.String()},
+			{StudentID: studentID.String(), CourseID: physics.String()},
+		}, nil)
+		f.repo.EXPECT().GetByStudent(studentID, models.Query{}).Return([]models.Record{
+			{StudentID: studentID.String(), CourseID: physics.String(), Status: models.StatusAbsent},
+			{StudentID: studentID.String(), CourseID: math.String(), Status: models.StatusPresent},
+		}, nil)
+
+		list, err := f.service.GetStudentSummary(authtest.AdminContext, studentID, models.SummaryQuery{})
+		require.NoError(t, err)
+		require.Len(t, list.Summaries, 2)
+		assert.Equal(t, physics.String(), list.Summaries[0].CourseID)
+		assert.Equal(t, 100.0, list.Summaries[0].AbsencePercent)
+		assert.Equal(t, math.String(), list.Summaries[1].CourseID)
+		assert.Equal(t, models.RiskOK, list.Summaries[1].Risk)
+	})
+
+	t.Run("Unknown Student", func(t *testing.T) {
+		f := newFixture(t)
+		f.students.EXPECT().Get(studentID).Return(nil, studentmodels.ErrStudentNotFound)
+
+		_, err := f.service.GetStudentSummary(authtest.AdminContext, studentID, models.SummaryQuery{})
+		assert.ErrorIs(t, err, studentmodels.ErrStudentNotFound)
+	})
+}
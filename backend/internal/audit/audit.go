@@ -0,0 +1,171 @@
+// Package audit records who changed what and when. Entries are written by the
+// repositories in the same transaction as the change they describe.
+package audit
+
+import (
+	"backend/internal/problem"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type Action string
+
+const (
+	ActionCreate  Action = "create"
+	ActionUpdate  Action = "update"
+	ActionDelete  Action = "delete"
+	ActionRestore Action = "restore"
+	ActionPurge   Action = "purge"
+)
+
+// SystemActor is the actor of changes made by the server itself, such as purges.
+const SystemActor = "system"
+
+// AnonymousActor is used when a request carries no identity.
+const AnonymousActor = "anonymous"
+
+var ErrInvalidFilter = &problem.ValidationError{Detail: "invalid audit filter"}
+
+// Entry is one recorded mutation. Before is empty for creations and After is
+// empty for deletions.
+type Entry struct {
+	ID         uint64    `gorm:"primaryKey" json:"id"`
+	EntityType string    `gorm:"size:32;index:idx_audit_entity" json:"entityType"`
+	EntityID   string    `gorm:"size:36;index:idx_audit_entity" json:"entityId"`
+	Action     Action    `gorm:"size:16;index" json:"action"`
+	Actor      string    `gorm:"size:255;index" json:"actor"`
+	RequestID  string    `gorm:"size:64" json:"requestId,omitempty"`
+	At         time.Time `gorm:"index" json:"at"`
+	Before     Snapshot  `gorm:"type:text" json:"before"`
+	After      Snapshot  `gorm:"type:text" json:"after"`
+}
+
+func (Entry) TableName() string {
+	return "audit_entries"
+}
+
+// Snapshot is the JSON encoding of an entity. It is stored as text and rendered
+// as nested JSON rather than as a string.
+type Snapshot []byte
+
+func (s Snapshot) Value() (driver.Value, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	return string(s), nil
+}
+
+func (s *Snapshot) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*s = nil
+	case string:
+		*s = Snapshot(v)
+	case []byte:
+		*s = append(Snapshot(nil), v...)
+	default:
+		return fmt.Errorf("audit: cannot scan %T into a snapshot", value)
+	}
+	return nil
+}
+
+func (s Snapshot) MarshalJSON() ([]byte, error) {
+	if len(s) == 0 {
+		return []byte("null"), nil
+	}
+	return s, nil
+}
+
+func (s *Snapshot) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*s = nil
+		return nil
+	}
+	*s = append(Snapshot(nil), data...)
+	return nil
+}
+
+// NewEntry builds an entry for the actor and request ID carried by ctx. A nil
+// before or after leaves that snapshot empty.
+func NewEntry(ctx context.Context, entityType string, entityID string, action Action, before interface{}, after interface{}) (*Entry, error) {
+	entry := &Entry{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Actor:      Actor(ctx),
+		RequestID:  RequestID(ctx),
+		At:         time.Now().UTC(),
+	}
+	var err error
+	if entry.Before, err = snapshot(before); err != nil {
+		return nil, err
+	}
+	if entry.After, err = snapshot(after); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func snapshot(value interface{}) (Snapshot, error) {
+	if value == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if string(data) == "null" {
+		return nil, nil
+	}
+	return data, nil
+}
+
+// Filter selects entries; empty fields match everything.
+type Filter struct {
+	EntityType string
+	EntityID   string
+	Actor      string
+	Action     Action
+	From       *time.Time
+	To         *time.Time
+}
+
+// Matches is the in-memory equivalent of the SQL filter.
+func (f Filter) Matches(entry *Entry) bool {
+	return (f.EntityType == "" || entry.EntityType == f.EntityType) &&
+		(f.EntityID == "" || entry.EntityID == f.EntityID) &&
+		(f.Actor == "" || entry.Actor == f.Actor) &&
+		(f.Action == "" || entry.Action == f.Action) &&
+		(f.From == nil || !entry.At.Before(*f.From)) &&
+		(f.To == nil || entry.At.Before(*f.To))
+}
+
+// ParseFilter reads entityType, entityId, actor, action and the RFC 3339 from
+// (inclusive) and to (exclusive) query parameters.
+func ParseFilter(values url.Values) (Filter, error) {
+	filter := Filter{
+		EntityType: values.Get("entityType"),
+		EntityID:   values.Get("entityId"),
+		Actor:      values.Get("actor"),
+		Action:     Action(values.Get("action")),
+	}
+	switch filter.Action {
+	case "", ActionCreate, ActionUpdate, ActionDelete, ActionRestore, ActionPurge:
+	default:
+		return Filter{}, fmt.Errorf("%w: unknown action %q", ErrInvalidFilter, filter.Action)
+	}
+	for name, dst := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if value := values.Get(name); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return Filter{}, fmt.Errorf("%w: %s must be an RFC 3339 time", ErrInvalidFilter, name)
+			}
+			*dst = &t
+		}
+	}
+	return filter, nil
+}
@@ -0,0 +1,111 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEntry(t *testing.T) {
+	t.Run("Context", func(t *testing.T) {
+		ctx := WithRequestID(WithActor(context.Background(), "registrar"), "req-1")
+		entry, err := NewEntry(ctx, "student", "42", ActionUpdate, map[string]string{"name": "hasan"}, map[string]string{"name": "ali"})
+		require.NoError(t, err)
+		assert.Equal(t, "registrar", entry.Actor)
+		assert.Equal(t, "req-1", entry.RequestID)
+		assert.JSONEq(t, `{"name":"hasan"}`, string(entry.Before))
+		assert.JSONEq(t, `{"name":"ali"}`, string(entry.After))
+		assert.WithinDuration(t, time.Now(), entry.At, time.Minute)
+	})
+
+	t.Run("Anonymous", func(t *testing.T) {
+		entry, err := NewEntry(context.Background(), "student", "42", ActionCreate, nil, map[string]string{})
+		require.NoError(t, err)
+		assert.Equal(t, AnonymousActor, entry.Actor)
+		assert.Empty(t, entry.RequestID)
+		assert.Nil(t, entry.Before)
+	})
+}
+
+func TestSnapshot(t *testing.T) {
+	data, err := json.Marshal(Entry{After: Snapshot(`{"name":"hasan"}`)})
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"before":null`)
+	assert.Contains(t, string(data), `"after":{"name":"hasan"}`)
+
+	var snapshot Snapshot
+	require.NoError(t, snapshot.Scan([]byte(`{"a":1}`)))
+	assert.Equal(t, Snapshot(`{"a":1}`), snapshot)
+	require.NoError(t, snapshot.Scan(nil))
+	assert.Nil(t, snapshot)
+	assert.Error(t, snapshot.Scan(42))
+
+	value, err := Snapshot(nil).Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestParseFilter(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		values, _ := url.ParseQuery("entityType=student&entityId=42&actor=registrar&action=purge&from=2026-01-01T00:00:00Z&to=2026-02-01T00:00:00%2B03:00")
+		filter, err := ParseFilter(values)
+		require.NoError(t, err)
+		assert.Equal(t, "student", filter.EntityType)
+		assert.Equal(t, "42", filter.EntityID)
+		assert.Equal(t, "registrar", filter.Actor)
+		assert.Equal(t, ActionPurge, filter.Action)
+		assert.True(t, filter.From.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+		assert.True(t, filter.To.Equal(time.Date(2026, 1, 31, 21, 0, 0, 0, time.UTC)))
+
+		assert.True(t, filter.Matches(&Entry{EntityType: "student", EntityID: "42", Actor: "registrar", Action: ActionPurge, At: *filter.From}))
+		assert.False(t, filter.Matches(&Entry{EntityType: "student", EntityID: "42", Actor: "registrar", Action: ActionPurge, At: *filter.To}), "to is exclusive")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, query := range []string{"action=rename", "from=yesterday", "to=2026-01-01"} {
+			values, _ := url.ParseQuery(query)
+			_, err := ParseFilter(values)
+			assert.ErrorIs(t, err, ErrInvalidFilter, query)
+		}
+	})
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	router := gin.New()
+	router.Use(RequestIDMiddleware())
+	router.GET("/", func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, RequestID(ctx.Request.Context()))
+	})
+
+	t.Run("Generated", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+		assert.NotEmpty(t, w.Body.String())
+		assert.Equal(t, w.Body.String(), w.Header().Get(RequestIDHeader))
+	})
+
+	t.Run("Propagated", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(RequestIDHeader, "req-1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, "req-1", w.Body.String())
+	})
+
+	t.Run("TooLong", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(RequestIDHeader, strings.Repeat("x", 65))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Len(t, w.Body.String(), 36)
+	})
+}
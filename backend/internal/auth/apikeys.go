@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyPrefix starts every API key so leaked keys are easy to recognise.
+const APIKeyPrefix = "sk_"
+
+// lastUsedResolution limits how often authenticating with a key writes its
+// LastUsedAt.
+const lastUsedResolution = time.Minute
+
+// CreateAPIKey issues a key with the requested scopes. The principal on ctx may
+// only grant scopes it holds itself.
+func (s *AuthService) CreateAPIKey(ctx context.Context, request APIKeyRequest) (*CreatedAPIKey, error) {
+	if err := Authorize(ctx, PermAPIKeysManage); err != nil {
+		return nil, err
+	}
+	if err := s.validateAPIKeyRequest(ctx, request); err != nil {
+		return nil, err
+	}
+
+	creator := ""
+	if principal, ok := PrincipalFrom(ctx); ok {
+		creator = principal.Username
+	}
+	return s.addAPIKey(&APIKey{
+		ID:        uuid.New(),
+		Name:      request.Name,
+		Scopes:    request.Scopes,
+		CreatedBy: creator,
+		ExpiresAt: request.ExpiresAt,
+	})
+}
+
+// ListAPIKeys returns every key without its secret, newest first.
+func (s *AuthService) ListAPIKeys(ctx context.Context) ([]APIKey, error) {
+	if err := Authorize(ctx, PermAPIKeysManage); err != nil {
+		return nil, err
+	}
+	keys, err := s.repository.GetAPIKeys()
+	if err != nil {
+		return nil, err
+	}
+	if keys == nil {
+		keys = []APIKey{}
+	}
+	return keys, nil
+}
+
+func (s *AuthService) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	if err := Authorize(ctx, PermAPIKeysManage); err != nil {
+		return err
+	}
+	return s.repository.RevokeAPIKey(id, time.Now())
+}
+
+// RotateAPIKey replaces a key with a new one of the same name, scopes and
+// expiry and revokes the old key. As when creating a key, the principal on ctx
+// must hold every scope of the key, since it receives the new secret.
+func (s *AuthService) RotateAPIKey(ctx context.Context, id uuid.UUID) (*CreatedAPIKey, error) {
+	if err := Authorize(ctx, PermAPIKeysManage); err != nil {
+		return nil, err
+	}
+	old, err := s.repository.GetAPIKey(id)
+	if err != nil {
+		return nil, err
+	}
+	if old.RevokedAt != nil || !time.Now().Before(old.ExpiresAt) {
+		return nil, ErrAPIKeyNotFound
+	}
+	if err := authorizeScopes(ctx, old.Scopes); err != nil {
+		return nil, err
+	}
+
+	creator := old.CreatedBy
+	if principal, ok := PrincipalFrom(ctx); ok {
+		creator = principal.Username
+	}
+	replacement := &APIKey{
+		ID:        uuid.New(),
+		Name:      old.Name,
+		Scopes:    old.Scopes,
+		CreatedBy: creator,
+		ExpiresAt: old.ExpiresAt,
+	}
+	key, err := generateAPIKey(replacement)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repository.RotateAPIKey(old.ID, replacement, time.Now()); err != nil {
+		return nil, err
+	}
+	return &CreatedAPIKey{APIKey: *replacement, Key: key}, nil
+}
+
+// AuthenticateAPIKey verifies an API key and returns a principal holding its
+// scopes.
+func (s *AuthService) AuthenticateAPIKey(key string) (*Principal, error) {
+	if !strings.HasPrefix(key, APIKeyPrefix) {
+		return nil, ErrInvalidToken
+	}
+	apiKey, err := s.repository.GetAPIKeyByHash(hashToken(key))
+	if errors.Is(err, ErrAPIKeyNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if apiKey.RevokedAt != nil || !now.Before(apiKey.ExpiresAt) {
+		return nil, ErrInvalidToken
+	}
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= lastUsedResolution {
+		if err := s.repository.TouchAPIKey(apiKey.ID, now); err != nil {
+			return nil, err
+		}
+	}
+	return &Principal{
+		Subject:     apiKey.ID.String(),
+		Username:    "apikey:" + apiKey.Name,
+		Permissions: apiKey.Scopes,
+	}, nil
+}
+
+func (s *AuthService) validateAPIKeyRequest(ctx context.Context, request APIKeyRequest) error {
+	if strings.TrimSpace(request.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidAPIKey)
+	}
+	if len(request.Scopes) == 0 {
+		return fmt.Errorf("%w: at least one scope is required", ErrInvalidAPIKey)
+	}
+	if request.ExpiresAt.IsZero() {
+		return fmt.Errorf("%w: expiresAt is required", ErrInvalidAPIKey)
+	}
+	if !time.Now().Before(request.ExpiresAt) {
+		return fmt.Errorf("%w: expiresAt must be in the future", ErrInvalidAPIKey)
+	}
+
+	for _, scope := range request.Scopes {
+		if !knownPermission(scope) {
+			return fmt.Errorf("%w: unknown scope %q", ErrInvalidAPIKey, scope)
+		}
+	}
+	return authorizeScopes(ctx, request.Scopes)
+}
+
+// authorizeScopes checks that the principal on ctx holds every scope, so that
+// no one hands out a key more powerful than themselves.
+func authorizeScopes(ctx context.Context, scopes []Permission) error {
+	principal, _ := PrincipalFrom(ctx)
+	for _, scope := range scopes {
+		if !principal.Can(scope) {
+			return &ForbiddenError{Permission: scope}
+		}
+	}
+	return nil
+}
+
+func (s *AuthService) addAPIKey(apiKey *APIKey) (*CreatedAPIKey, error) {
+	key, err := generateAPIKey(apiKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repository.AddAPIKey(apiKey); err != nil {
+		return nil, err
+	}
+	return &CreatedAPIKey{APIKey: *apiKey, Key: key}, nil
+}
+
+// generateAPIKey returns a new secret key and sets the hash and prefix of
+// apiKey from it.
+func generateAPIKey(apiKey *APIKey) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	key := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	apiKey.Hash = hashToken(key)
+	apiKey.Prefix = key[:len(APIKeyPrefix)+8]
+	return key, nil
+}
@@ -0,0 +1,362 @@
+package auth
+
+import (
+	"backend/internal/config"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var testSecret = []byte("a-test-secret-of-at-least-32-bytes")
+
+func newTestService(t *testing.T, keys *KeySet) *AuthService {
+	hash, err := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	service := Service(NewMemoryRepository(), keys)
+	require.NoError(t, service.SeedUsers([]config.User{
+		{Username: "registrar", PasswordHash: string(hash), Roles: []string{"registrar"}},
+		{Username: "admin", PasswordHash: string(hash), Roles: []string{"admin"}},
+	}))
+	return service
+}
+
+func writeRSAKey(t *testing.T) (string, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "key.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path, key
+}
+
+func TestLogin(t *testing.T) {
+	service := newTestService(t, NewKeySetFrom(NewHMACKey("k1", testSecret)))
+
+	t.Run("Success", func(t *testing.T) {
+		tokens, err := service.Login(Credentials{Username: "registrar", Password: "s3cret"})
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer", tokens.TokenType)
+		assert.Equal(t, 900, tokens.ExpiresIn)
+
+		principal, err := service.Authenticate(tokens.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, "registrar", principal.Username)
+		assert.Equal(t, []string{"registrar"}, principal.Roles)
+		assert.NotEmpty(t, principal.Subject)
+	})
+
+	t.Run("WrongPassword", func(t *testing.T) {
+		_, err := service.Login(Credentials{Username: "registrar", Password: "guess"})
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("UnknownUser", func(t *testing.T) {
+		_, err := service.Login(Credentials{Username: "nobody", Password: "s3cret"})
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+}
+
+func TestRefresh(t *testing.T) {
+	service := newTestService(t, NewKeySetFrom(NewHMACKey("k1", testSecret)))
+	tokens, err := service.Login(Credentials{Username: "registrar", Password: "s3cret"})
+	require.NoError(t, err)
+
+	t.Run("Rotates", func(t *testing.T) {
+		refreshed, err := service.Refresh(tokens.RefreshToken)
+		require.NoError(t, err)
+		assert.NotEqual(t, tokens.RefreshToken, refreshed.RefreshToken)
+
+		_, err = service.Authenticate(refreshed.AccessToken)
+		assert.NoError(t, err)
+
+		// The first token was used, so presenting it again revokes the whole family.
+		_, err = service.Refresh(tokens.RefreshToken)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+		_, err = service.Refresh(refreshed.RefreshToken)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Logout", func(t *testing.T) {
+		tokens, err := service.Login(Credentials{Username: "registrar", Password: "s3cret"})
+		require.NoError(t, err)
+
+		require.NoError(t, service.Logout(tokens.RefreshToken))
+		_, err = service.Refresh(tokens.RefreshToken)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+		assert.ErrorIs(t, service.Logout(tokens.RefreshToken), ErrInvalidToken)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		service.RefreshTokenTTL = -time.Second
+		defer func() { service.RefreshTokenTTL = time.Hour }()
+		tokens, err := service.Login(Credentials{Username: "registrar", Password: "s3cret"})
+		require.NoError(t, err)
+
+		_, err = service.Refresh(tokens.RefreshToken)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := service.Refresh("not-a-token")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestAuthenticate(t *testing.T) {
+	path, private := writeRSAKey(t)
+	keys, err := NewKeySet([]config.SigningKey{
+		{ID: "old", Algorithm: "HS256", Secret: string(testSecret)},
+		{ID: "new", Algorithm: "RS256", PrivateKeyFile: path},
+	}, "old")
+	require.NoError(t, err)
+	service := newTestService(t, keys)
+
+	oldTokens, err := service.Login(Credentials{Username: "registrar", Password: "s3cret"})
+	require.NoError(t, err)
+
+	t.Run("KeyRotation", func(t *testing.T) {
+		keys.signing = keys.keys["new"]
+		newTokens, err := service.Login(Credentials{Username: "registrar", Password: "s3cret"})
+		require.NoError(t, err)
+
+		token, _, err := jwt.NewParser().ParseUnverified(newTokens.AccessToken, &claims{})
+		require.NoError(t, err)
+		assert.Equal(t, "RS256", token.Method.Alg())
+		assert.Equal(t, "new", token.Header["kid"])
+
+		_, err = service.Authenticate(newTokens.AccessToken)
+		assert.NoError(t, err)
+		_, err = service.Authenticate(oldTokens.AccessToken)
+		assert.NoError(t, err, "tokens signed before the rotation stay valid")
+
+		delete(keys.keys, "old")
+		_, err = service.Authenticate(oldTokens.AccessToken)
+		assert.ErrorIs(t, err, ErrInvalidToken, "removing a key invalidates its tokens")
+	})
+
+	t.Run("AlgorithmConfusion", func(t *testing.T) {
+		// An HS256 token "signed" with the public key must not verify against the RSA key.
+		forged := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    service.Issuer,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}})
+		forged.Header["kid"] = "new"
+		signed, err := forged.SignedString(x509.MarshalPKCS1PublicKey(&private.PublicKey))
+		require.NoError(t, err)
+
+		_, err = service.Authenticate(signed)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		service.AccessTokenTTL = -time.Second
+		defer func() { service.AccessTokenTTL = 15 * time.Minute }()
+		tokens, err := service.Login(Credentials{Username: "registrar", Password: "s3cret"})
+		require.NoError(t, err)
+
+		_, err = service.Authenticate(tokens.AccessToken)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("WrongIssuer", func(t *testing.T) {
+		other := Service(NewMemoryRepository(), keys)
+		other.Issuer = "someone-else"
+		token, err := keys.sign(claims{RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    other.Issuer,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}})
+		require.NoError(t, err)
+
+		_, err = service.Authenticate(token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestJWKS(t *testing.T) {
+	path, private := writeRSAKey(t)
+	keys, err := NewKeySet([]config.SigningKey{
+		{ID: "hmac", Algorithm: "HS256", Secret: string(testSecret)},
+		{ID: "rsa", Algorithm: "RS256", PrivateKeyFile: path},
+	}, "hmac")
+	require.NoError(t, err)
+
+	jwks := keys.JWKS()
+	require.Len(t, jwks.Keys, 1, "HMAC secrets are not published")
+	assert.Equal(t, "rsa", jwks.Keys[0].KeyID)
+	assert.Equal(t, "RS256", jwks.Keys[0].Algorithm)
+	assert.Equal(t, "AQAB", jwks.Keys[0].E)
+	assert.NotEmpty(t, private.N)
+
+	_, err = NewKeySet([]config.SigningKey{{ID: "rsa", Algorithm: "RS256", PrivateKeyFile: filepath.Join(t.TempDir(), "missing.pem")}}, "rsa")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	generated, err := NewKeySet(nil, "")
+	require.NoError(t, err)
+	assert.Empty(t, generated.JWKS().Keys)
+}
+
+func TestAPIKeys(t *testing.T) {
+	service := newTestService(t, NewKeySetFrom(NewHMACKey("k1", testSecret)))
+	admin := WithPrincipal(context.Background(), &Principal{Username: "admin", Permissions: []Permission{PermAll}})
+	expiresAt := time.Now().Add(time.Hour)
+
+	t.Run("CreateAndAuthenticate", func(t *testing.T) {
+		created, err := service.CreateAPIKey(admin, APIKeyRequest{Name: "importer", Scopes: []Permission{PermStudentsRead, PermStudentsWrite}, ExpiresAt: expiresAt})
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(created.Key, APIKeyPrefix))
+		assert.True(t, strings.HasPrefix(created.Key, created.Prefix))
+		assert.Equal(t, "admin", created.CreatedBy)
+		assert.NotContains(t, created.Hash, created.Key)
+
+		principal, err := service.AuthenticateAPIKey(created.Key)
+		require.NoError(t, err)
+		assert.Equal(t, created.ID.String(), principal.Subject)
+		assert.Equal(t, "apikey:importer", principal.Username)
+		assert.True(t, principal.Can(PermStudentsWrite))
+		assert.False(t, principal.Can(PermStudentsDelete))
+
+		keys, err := service.ListAPIKeys(admin)
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		require.NotNil(t, keys[0].LastUsedAt, "authenticating records the last use")
+
+		_, err = service.AuthenticateAPIKey(created.Key + "x")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+		_, err = service.AuthenticateAPIKey("not a key")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		for name, request := range map[string]APIKeyRequest{
+			"NoName":       {Scopes: []Permission{PermStudentsRead}, ExpiresAt: expiresAt},
+			"NoScopes":     {Name: "key", ExpiresAt: expiresAt},
+			"UnknownScope": {Name: "key", Scopes: []Permission{"students:teach"}, ExpiresAt: expiresAt},
+			"NoExpiry":     {Name: "key", Scopes: []Permission{PermStudentsRead}},
+			"Expired":      {Name: "key", Scopes: []Permission{PermStudentsRead}, ExpiresAt: time.Now().Add(-time.Minute)},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := service.CreateAPIKey(admin, request)
+				assert.ErrorIs(t, err, ErrInvalidAPIKey)
+			})
+		}
+	})
+
+	t.Run("ScopesLimitedToCreator", func(t *testing.T) {
+		manager := WithPrincipal(context.Background(), &Principal{Username: "manager", Permissions: []Permission{PermAPIKeysManage, PermStudentsRead}})
+		_, err := service.CreateAPIKey(manager, APIKeyRequest{Name: "key", Scopes: []Permission{PermStudentsRead}, ExpiresAt: expiresAt})
+		assert.NoError(t, err)
+
+		_, err = service.CreateAPIKey(manager, APIKeyRequest{Name: "key", Scopes: []Permission{PermStudentsDelete}, ExpiresAt: expiresAt})
+		var forbidden *ForbiddenError
+		require.ErrorAs(t, err, &forbidden)
+		assert.Equal(t, PermStudentsDelete, forbidden.Permission)
+
+		teacher := WithPrincipal(context.Background(), &Principal{Permissions: []Permission{PermStudentsRead}})
+		_, err = service.ListAPIKeys(teacher)
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		created, err := service.CreateAPIKey(admin, APIKeyRequest{Name: "revoked", Scopes: []Permission{PermStudentsRead}, ExpiresAt: expiresAt})
+		require.NoError(t, err)
+
+		require.NoError(t, service.RevokeAPIKey(admin, created.ID))
+		_, err = service.AuthenticateAPIKey(created.Key)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+		assert.ErrorIs(t, service.RevokeAPIKey(admin, created.ID), ErrAPIKeyNotFound)
+	})
+
+	t.Run("Rotate", func(t *testing.T) {
+		created, err := service.CreateAPIKey(admin, APIKeyRequest{Name: "rotated", Scopes: []Permission{PermStudentsRead}, ExpiresAt: expiresAt})
+		require.NoError(t, err)
+
+		rotated, err := service.RotateAPIKey(admin, created.ID)
+		require.NoError(t, err)
+		assert.NotEqual(t, created.ID, rotated.ID)
+		assert.NotEqual(t, created.Key, rotated.Key)
+		assert.Equal(t, created.Name, rotated.Name)
+		assert.Equal(t, created.Scopes, rotated.Scopes)
+		assert.True(t, created.ExpiresAt.Equal(rotated.ExpiresAt))
+
+		_, err = service.AuthenticateAPIKey(created.Key)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+		_, err = service.AuthenticateAPIKey(rotated.Key)
+		assert.NoError(t, err)
+
+		_, err = service.RotateAPIKey(admin, created.ID)
+		assert.ErrorIs(t, err, ErrAPIKeyNotFound, "a revoked key cannot be rotated")
+	})
+
+	t.Run("RotateScopesLimitedToRotator", func(t *testing.T) {
+		created, err := service.CreateAPIKey(admin, APIKeyRequest{Name: "powerful", Scopes: []Permission{PermAll}, ExpiresAt: expiresAt})
+		require.NoError(t, err)
+		manager := WithPrincipal(context.Background(), &Principal{Username: "manager", Permissions: []Permission{PermAPIKeysManage, PermStudentsRead}})
+
+		_, err = service.RotateAPIKey(manager, created.ID)
+		var forbidden *ForbiddenError
+		require.ErrorAs(t, err, &forbidden)
+		assert.Equal(t, PermAll, forbidden.Permission)
+		_, err = service.AuthenticateAPIKey(created.Key)
+		assert.NoError(t, err, "a refused rotation leaves the key alone")
+	})
+
+	t.Run("RotateConcurrently", func(t *testing.T) {
+		created, err := service.CreateAPIKey(admin, APIKeyRequest{Name: "contended", Scopes: []Permission{PermStudentsRead}, ExpiresAt: expiresAt})
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		results := make(chan error, 5)
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := service.RotateAPIKey(admin, created.ID)
+				results <- err
+			}()
+		}
+		wg.Wait()
+		close(results)
+		rotated := 0
+		for err := range results {
+			if err == nil {
+				rotated++
+			} else {
+				assert.ErrorIs(t, err, ErrAPIKeyNotFound)
+			}
+		}
+		assert.Equal(t, 1, rotated)
+
+		keys, err := service.ListAPIKeys(admin)
+		require.NoError(t, err)
+		live := 0
+		for _, key := range keys {
+			if key.Name == "contended" && key.RevokedAt == nil {
+				live++
+			}
+		}
+		assert.Equal(t, 1, live, "a key is replaced by a single live key")
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		key := APIKeyPrefix + "expired"
+		require.NoError(t, service.repository.AddAPIKey(&APIKey{ID: uuid.New(), Name: "expired", Hash: hashToken(key), Scopes: []Permission{PermStudentsRead}, ExpiresAt: time.Now().Add(-time.Second)}))
+
+		_, err := service.AuthenticateAPIKey(key)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
@@ -0,0 +1,47 @@
+// Package authtest provides utilities for testing the routes that the auth
+// package guards.
+package authtest
+
+import (
+	"backend/internal/auth"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// pathParam matches the parameters of a gin route path, such as :id.
+var pathParam = regexp.MustCompile(`:[A-Za-z_]+`)
+
+// AdminContext carries a principal allowed to do everything, for the tests of
+// the services.
+var AdminContext = auth.WithPrincipal(context.Background(), &auth.Principal{Username: "admin", Permissions: []auth.Permission{auth.PermAll}})
+
+// AuthenticateAs authenticates every request as a principal with the given
+// permissions.
+func AuthenticateAs(permissions ...auth.Permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		principal := &auth.Principal{Username: "test", Permissions: permissions}
+		ctx.Request = ctx.Request.WithContext(auth.WithPrincipal(ctx.Request.Context(), principal))
+	}
+}
+
+// AssertForbidden requests every route of router, with a UUID for each path
+// parameter, and asserts that each is rejected as forbidden. Set up with
+// mocks that expect no calls, it checks that no route reaches the service
+// without its permission.
+func AssertForbidden(t *testing.T, router *gin.Engine) {
+	t.Helper()
+	for _, route := range router.Routes() {
+		t.Run(route.Method+" "+route.Path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			path := pathParam.ReplaceAllString(route.Path, "7995c72f-7d04-4136-8b5f-000d6d4aae23")
+			router.ServeHTTP(w, httptest.NewRequest(route.Method, path, nil))
+			assert.Equal(t, http.StatusForbidden, w.Code)
+		})
+	}
+}
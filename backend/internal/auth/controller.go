@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AuthController struct {
+	Service *AuthService
+}
+
+func Controller(service *AuthService) *AuthController {
+	return &AuthController{Service: service}
+}
+
+func (c *AuthController) Login(ctx *gin.Context) {
+	var credentials Credentials
+	if err := ctx.ShouldBindJSON(&credentials); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	tokens, err := c.Service.Login(credentials)
+	if errors.Is(err, ErrInvalidCredentials) {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to log in"})
+		return
+	}
+	ctx.JSON(http.StatusOK, tokens)
+}
+
+func (c *AuthController) Refresh(ctx *gin.Context) {
+	var request RefreshRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil || request.RefreshToken == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	tokens, err := c.Service.Refresh(request.RefreshToken)
+	if errors.Is(err, ErrInvalidToken) {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to refresh token"})
+		return
+	}
+	ctx.JSON(http.StatusOK, tokens)
+}
+
+// Logout revokes the given refresh token.
+func (c *AuthController) Logout(ctx *gin.Context) {
+	var request RefreshRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil || request.RefreshToken == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	err := c.Service.Logout(request.RefreshToken)
+	if errors.Is(err, ErrInvalidToken) {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke token"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Refresh token revoked"})
+}
+
+// Me returns the principal of the request.
+func (c *AuthController) Me(ctx *gin.Context) {
+	principal, ok := PrincipalFrom(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+	ctx.JSON(http.StatusOK, principal)
+}
+
+func (c *AuthController) JWKS(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.Service.JWKS())
+}
+
+// CreateAPIKey answers 201 with the new key, which is only shown this once.
+func (c *AuthController) CreateAPIKey(ctx *gin.Context) {
+	var request APIKeyRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	key, err := c.Service.CreateAPIKey(ctx.Request.Context(), request)
+	if AbortWithError(ctx, err) {
+		return
+	}
+	if errors.Is(err, ErrInvalidAPIKey) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create api key"})
+		return
+	}
+	ctx.JSON(http.StatusCreated, key)
+}
+
+func (c *AuthController) ListAPIKeys(ctx *gin.Context) {
+	keys, err := c.Service.ListAPIKeys(ctx.Request.Context())
+	if AbortWithError(ctx, err) {
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve api keys"})
+		return
+	}
+	ctx.JSON(http.StatusOK, keys)
+}
+
+func (c *AuthController) RevokeAPIKey(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid api key id"})
+		return
+	}
+
+	err = c.Service.RevokeAPIKey(ctx.Request.Context(), id)
+	if AbortWithError(ctx, err) {
+		return
+	}
+	if errors.Is(err, ErrAPIKeyNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke api key"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "API key revoked"})
+}
+
+// RotateAPIKey revokes a key and answers 201 with its replacement.
+func (c *AuthController) RotateAPIKey(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid api key id"})
+		return
+	}
+
+	key, err := c.Service.RotateAPIKey(ctx.Request.Context(), id)
+	if AbortWithError(ctx, err) {
+		return
+	}
+	if errors.Is(err, ErrAPIKeyNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to rotate api key"})
+		return
+	}
+	ctx.JSON(http.StatusCreated, key)
+}
+
+// SetupRoutes registers the token endpoints, which are public, and /auth/me and
+// the API key endpoints, which are behind authenticate.
+func SetupRoutes(router *gin.Engine, authController *AuthController, authenticate gin.HandlerFunc) {
+	router.POST("/auth/login", authController.Login)
+	router.POST("/auth/refresh", authController.Refresh)
+	router.POST("/auth/logout", authController.Logout)
+	router.GET("/auth/me", authenticate, authController.Me)
+	router.GET("/.well-known/jwks.json", authController.JWKS)
+
+	apiKeys := router.Group("/admin/api-keys", authenticate, Require(PermAPIKeysManage))
+	apiKeys.POST("", authController.CreateAPIKey)
+	apiKeys.GET("", authController.ListAPIKeys)
+	apiKeys.DELETE("/:id", authController.RevokeAPIKey)
+	apiKeys.POST("/:id/rotate", authController.RotateAPIKey)
+}
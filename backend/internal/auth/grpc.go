@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"backend/internal/problem"
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor is the gRPC counterpart of Middleware: calls need an
+// "authorization" metadata entry holding "Bearer <token>" or "ApiKey <key>".
+// Methods whose full name starts with one of public, such as
+// "/grpc.reflection.", are served without credentials.
+func UnaryServerInterceptor(authenticator Authenticator, public ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if isPublic(info.FullMethod, public) {
+			return handler(ctx, req)
+		}
+		ctx, err := authenticateRPC(ctx, authenticator)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming calls.
+func StreamServerInterceptor(authenticator Authenticator, public ...string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isPublic(info.FullMethod, public) {
+			return handler(srv, stream)
+		}
+		ctx, err := authenticateRPC(stream.Context(), authenticator)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticatedStream carries the principal on its context.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func isPublic(fullMethod string, public []string) bool {
+	for _, prefix := range public {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func authenticateRPC(ctx context.Context, authenticator Authenticator) (context.Context, error) {
+	var authorization string
+	if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+		authorization = values[0]
+	}
+	_, credentials, authenticate := parseAuthorization(authenticator, authorization)
+	if authenticate == nil || credentials == "" {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	principal, err := authenticate(credentials)
+	if errors.Is(err, ErrInvalidToken) {
+		return nil, status.Error(codes.Unauthenticated, ErrInvalidToken.Error())
+	}
+	if err != nil {
+		return nil, problem.GRPCStatus(err).Err()
+	}
+	return WithPrincipal(ctx, principal), nil
+}
@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"backend/internal/config"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Key signs and verifies tokens with one algorithm. Its ID is the kid header of
+// the tokens it signs.
+type Key struct {
+	ID     string
+	method jwt.SigningMethod
+	sign   interface{}
+	verify interface{}
+}
+
+// KeySet holds the signing key and the keys kept to verify tokens signed before a
+// rotation. To rotate, add a new key, make it the signing key and remove the old
+// one once the tokens it signed have expired.
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+}
+
+// NewKeySet loads the configured keys. Without keys it generates a random HS256
+// key, so tokens do not survive a restart.
+func NewKeySet(keys []config.SigningKey, signingKey string) (*KeySet, error) {
+	set := &KeySet{keys: map[string]*Key{}}
+	if len(keys) == 0 {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		key := NewHMACKey("default", secret)
+		set.keys[key.ID] = key
+		set.signing = key
+		return set, nil
+	}
+
+	for _, cfg := range keys {
+		key, err := loadKey(cfg)
+		if err != nil {
+			return nil, err
+		}
+		set.keys[key.ID] = key
+	}
+	set.signing = set.keys[signingKey]
+	if set.signing == nil {
+		return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidKey, signingKey)
+	}
+	return set, nil
+}
+
+func loadKey(cfg config.SigningKey) (*Key, error) {
+	switch cfg.Algorithm {
+	case "HS256":
+		return NewHMACKey(cfg.ID, []byte(cfg.Secret)), nil
+	case "RS256":
+		data, err := os.ReadFile(cfg.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %v", ErrInvalidKey, cfg.ID, err)
+		}
+		private, err := parseRSAPrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %v", ErrInvalidKey, cfg.ID, err)
+		}
+		return NewRSAKey(cfg.ID, private), nil
+	}
+	return nil, fmt.Errorf("%w %q: unsupported algorithm %q", ErrInvalidKey, cfg.ID, cfg.Algorithm)
+}
+
+// parseRSAPrivateKey accepts PKCS #1 and PKCS #8 PEM blocks.
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	private, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("not an RSA private key")
+	}
+	return private, nil
+}
+
+func NewHMACKey(id string, secret []byte) *Key {
+	return &Key{ID: id, method: jwt.SigningMethodHS256, sign: secret, verify: secret}
+}
+
+func NewRSAKey(id string, private *rsa.PrivateKey) *Key {
+	return &Key{ID: id, method: jwt.SigningMethodRS256, sign: private, verify: &private.PublicKey}
+}
+
+// NewKeySetFrom builds a key set from already loaded keys.
+func NewKeySetFrom(signing *Key, others ...*Key) *KeySet {
+	set := &KeySet{signing: signing, keys: map[string]*Key{signing.ID: signing}}
+	for _, key := range others {
+		set.keys[key.ID] = key
+	}
+	return set
+}
+
+func (s *KeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.signing.method, claims)
+	token.Header["kid"] = s.signing.ID
+	return token.SignedString(s.signing.sign)
+}
+
+// keyFunc picks the verification key by kid and rejects tokens whose algorithm
+// does not match the key, so an RSA public key is never used as an HMAC secret.
+func (s *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("key %q does not use %s", kid, token.Method.Alg())
+	}
+	return key.verify, nil
+}
+
+// JWK is a public key in JSON Web Key format.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS publishes the RS256 public keys. HMAC secrets are never published, so
+// HS256 tokens can only be verified by this server.
+func (s *KeySet) JWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	for _, key := range s.keys {
+		public, ok := key.verify.(*rsa.PublicKey)
+		if !ok {
+			continue
+		}
+		jwks.Keys = append(jwks.Keys, JWK{
+			KeyType:   "RSA",
+			Use:       "sig",
+			Algorithm: key.method.Alg(),
+			KeyID:     key.ID,
+			N:         base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+		})
+	}
+	sort.Slice(jwks.Keys, func(i, j int) bool { return jwks.Keys[i].KeyID < jwks.Keys[j].KeyID })
+	return jwks
+}
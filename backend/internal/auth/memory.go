@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// memoryRepository keeps users and refresh tokens in process memory, for the
+// memory:// database driver and tests.
+type memoryRepository struct {
+	mu     sync.Mutex
+	users  map[uuid.UUID]User
+	tokens map[uuid.UUID]RefreshToken
+	keys   map[uuid.UUID]APIKey
+}
+
+func NewMemoryRepository() *memoryRepository {
+	return &memoryRepository{
+		users:  map[uuid.UUID]User{},
+		tokens: map[uuid.UUID]RefreshToken{},
+		keys:   map[uuid.UUID]APIKey{},
+	}
+}
+
+func (r *memoryRepository) GetUser(id uuid.UUID) (*User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	return &user, nil
+}
+
+func (r *memoryRepository) GetUserByUsername(username string) (*User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, user := range r.users {
+		if user.Username == username {
+			return &user, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+func (r *memoryRepository) SaveUser(user *User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for id, existing := range r.users {
+		if existing.Username == user.Username {
+			existing.PasswordHash = user.PasswordHash
+			existing.Roles = user.Roles
+			existing.UpdatedAt = now
+			r.users[id] = existing
+			return nil
+		}
+	}
+	user.CreatedAt, user.UpdatedAt = now, now
+	r.users[user.ID] = *user
+	return nil
+}
+
+func (r *memoryRepository) AddRefreshToken(token *RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token.CreatedAt = time.Now()
+	r.tokens[token.ID] = *token
+	return nil
+}
+
+func (r *memoryRepository) GetRefreshToken(hash string) (*RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, token := range r.tokens {
+		if token.Hash == hash {
+			return &token, nil
+		}
+	}
+	return nil, ErrInvalidToken
+}
+
+func (r *memoryRepository) RevokeRefreshToken(id uuid.UUID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.tokens[id]
+	if !ok || token.RevokedAt != nil {
+		return ErrInvalidToken
+	}
+	token.RevokedAt = &at
+	r.tokens[id] = token
+	return nil
+}
+
+func (r *memoryRepository) RevokeUserRefreshTokens(userID uuid.UUID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, token := range r.tokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			token.RevokedAt = &at
+			r.tokens[id] = token
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepository) AddAPIKey(key *APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key.CreatedAt = time.Now()
+	r.keys[key.ID] = *key
+	return nil
+}
+
+func (r *memoryRepository) GetAPIKey(id uuid.UUID) (*APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[id]
+	if !ok {
+		return nil, ErrAPIKeyNotFound
+	}
+	return &key, nil
+}
+
+func (r *memoryRepository) GetAPIKeyByHash(hash string) (*APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, key := range r.keys {
+		if key.Hash == hash {
+			return &key, nil
+		}
+	}
+	return nil, ErrAPIKeyNotFound
+}
+
+func (r *memoryRepository) GetAPIKeys() ([]APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var keys []APIKey
+	for _, key := range r.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].CreatedAt.After(keys[j].CreatedAt)
+		}
+		return keys[i].ID.String() < keys[j].ID.String()
+	})
+	return keys, nil
+}
+
+func (r *memoryRepository) RevokeAPIKey(id uuid.UUID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[id]
+	if !ok || key.RevokedAt != nil {
+		return ErrAPIKeyNotFound
+	}
+	key.RevokedAt = &at
+	r.keys[id] = key
+	return nil
+}
+
+func (r *memoryRepository) RotateAPIKey(id uuid.UUID, replacement *APIKey, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[id]
+	if !ok || key.RevokedAt != nil {
+		return ErrAPIKeyNotFound
+	}
+	key.RevokedAt = &at
+	r.keys[id] = key
+	replacement.CreatedAt = time.Now()
+	r.keys[replacement.ID] = *replacement
+	return nil
+}
+
+func (r *memoryRepository) TouchAPIKey(id uuid.UUID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if key, ok := r.keys[id]; ok {
+		key.LastUsedAt = &at
+		r.keys[id] = key
+	}
+	return nil
+}
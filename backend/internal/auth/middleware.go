@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"backend/internal/problem"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Authenticator verifies the credentials of a request.
+type Authenticator interface {
+	Authenticate(accessToken string) (*Principal, error)
+	AuthenticateAPIKey(key string) (*Principal, error)
+}
+
+// challenge lists the accepted schemes in WWW-Authenticate.
+const challenge = `Bearer realm="students", ApiKey realm="students"`
+
+// Middleware rejects requests without a valid "Authorization: Bearer <token>"
+// or "Authorization: ApiKey <key>" header and puts the principal of the others
+// on the request context.
+func Middleware(authenticator Authenticator) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		scheme, credentials, authenticate := parseAuthorization(authenticator, ctx.GetHeader("Authorization"))
+		if authenticate == nil || credentials == "" {
+			unauthorized(ctx, challenge, "authentication required")
+			return
+		}
+
+		principal, err := authenticate(credentials)
+		if errors.Is(err, ErrInvalidToken) {
+			unauthorized(ctx, scheme+` realm="students", error="invalid_token"`, ErrInvalidToken.Error())
+			return
+		}
+		if err != nil {
+			problem.Abort(ctx, err)
+			return
+		}
+
+		ctx.Request = ctx.Request.WithContext(WithPrincipal(ctx.Request.Context(), principal))
+		ctx.Next()
+	}
+}
+
+// parseAuthorization splits an Authorization value into its canonical scheme
+// and credentials, and picks the method of authenticator for the scheme. The
+// method is nil for unknown schemes.
+func parseAuthorization(authenticator Authenticator, authorization string) (string, string, func(string) (*Principal, error)) {
+	scheme, credentials, _ := strings.Cut(authorization, " ")
+	credentials = strings.TrimSpace(credentials)
+	switch {
+	case strings.EqualFold(scheme, "Bearer"):
+		return "Bearer", credentials, authenticator.Authenticate
+	case strings.EqualFold(scheme, "ApiKey"):
+		return "ApiKey", credentials, authenticator.AuthenticateAPIKey
+	}
+	return scheme, credentials, nil
+}
+
+func unauthorized(ctx *gin.Context, challenge string, message string) {
+	unauthorized := problem.New(http.StatusUnauthorized, message)
+	unauthorized.Header = http.Header{"Www-Authenticate": {challenge}}
+	problem.Render(ctx, unauthorized)
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// User is a local account. Passwords are only stored as bcrypt hashes.
+type User struct {
+	ID           uuid.UUID `gorm:"primary_key;type:char(36)"`
+	Username     string    `gorm:"size:255;uniqueIndex"`
+	PasswordHash string    `gorm:"size:255"`
+	Roles        []string  `gorm:"serializer:json;type:text"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// RefreshToken is an issued refresh token. Only the SHA-256 hash of the token
+// is stored, so a leaked table cannot be used to mint access tokens.
+type RefreshToken struct {
+	ID        uuid.UUID  `gorm:"primary_key;type:char(36)"`
+	Hash      string     `gorm:"size:64;uniqueIndex"`
+	UserID    uuid.UUID  `gorm:"type:char(36);index"`
+	ExpiresAt time.Time  `gorm:"index"`
+	RevokedAt *time.Time `gorm:"index"`
+	CreatedAt time.Time
+}
+
+// TokenPair is the response of a login or refresh.
+type TokenPair struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	TokenType    string `json:"tokenType"`
+	ExpiresIn    int    `json:"expiresIn"`
+}
+
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// APIKey lets a machine call the API with the permissions in Scopes. Only the
+// SHA-256 hash of the key is stored; Prefix identifies it in listings.
+type APIKey struct {
+	ID         uuid.UUID    `gorm:"primary_key;type:char(36)" json:"id"`
+	Name       string       `gorm:"size:255" json:"name"`
+	Prefix     string       `gorm:"size:16" json:"prefix"`
+	Hash       string       `gorm:"size:64;uniqueIndex" json:"-"`
+	Scopes     []Permission `gorm:"serializer:json;type:text" json:"scopes"`
+	CreatedBy  string       `gorm:"size:255" json:"createdBy"`
+	CreatedAt  time.Time    `json:"createdAt"`
+	ExpiresAt  time.Time    `json:"expiresAt"`
+	LastUsedAt *time.Time   `json:"lastUsedAt"`
+	RevokedAt  *time.Time   `gorm:"index" json:"revokedAt"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+type APIKeyRequest struct {
+	Name      string       `json:"name"`
+	Scopes    []Permission `json:"scopes"`
+	ExpiresAt time.Time    `json:"expiresAt"`
+}
+
+// CreatedAPIKey is returned once, when a key is created or rotated. The key
+// itself cannot be retrieved afterwards.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// ErrorResponse is the body of the errors the token and API key endpoints
+// answer with themselves.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
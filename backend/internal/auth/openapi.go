@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"backend/internal/openapi"
+	"net/http"
+)
+
+const (
+	bearerScheme = "bearerAuth"
+	apiKeyScheme = "apiKeyAuth"
+)
+
+// Secure documents that op requires an authenticated principal holding
+// permission, or only authentication when permission is empty.
+func Secure(doc *openapi.Document, op *openapi.Operation, permission Permission) *openapi.Operation {
+	op.Security = []openapi.SecurityRequirement{{bearerScheme: {}}, {apiKeyScheme: {}}}
+	op.Respond(http.StatusUnauthorized, doc.Problem("Missing or invalid credentials"))
+	if permission != "" {
+		if op.Description != "" {
+			op.Description += "\n\n"
+		}
+		op.Description += "Requires the `" + string(permission) + "` permission."
+		op.Respond(http.StatusForbidden, doc.Problem("The principal lacks the "+string(permission)+" permission"))
+	}
+	return op
+}
+
+// Describe documents the routes SetupRoutes registers and the security schemes
+// the other packages' operations refer to.
+func Describe(doc *openapi.Document) {
+	doc.AddSecurityScheme(bearerScheme, &openapi.SecurityScheme{
+		Type:         "http",
+		Scheme:       "bearer",
+		BearerFormat: "JWT",
+		Description:  "An access token from POST /auth/login or /auth/refresh.",
+	})
+	doc.AddSecurityScheme(apiKeyScheme, &openapi.SecurityScheme{
+		Type:        "apiKey",
+		In:          "header",
+		Name:        "Authorization",
+		Description: "An API key, sent as `Authorization: ApiKey sk_...`.",
+	})
+	doc.AddTag("auth", "Tokens and the authenticated principal")
+	doc.AddTag("api-keys", "API keys for machine clients")
+
+	errorResponse := func(description string) *openapi.Response {
+		return doc.JSON(ErrorResponse{}, description)
+	}
+	idParameter := openapi.Path("id", "The API key ID", &openapi.Schema{Type: "string", Format: "uuid"})
+
+	doc.Add(http.MethodPost, "/auth/login", &openapi.Operation{
+		Tags:        []string{"auth"},
+		Summary:     "Log in with a username and password",
+		OperationID: "login",
+		RequestBody: doc.Body(Credentials{}, ""),
+		Responses: map[string]*openapi.Response{
+			"200": doc.JSON(TokenPair{}, "An access and a refresh token"),
+			"400": errorResponse("The body is not valid JSON"),
+			"401": errorResponse("Wrong username or password"),
+			"500": errorResponse("The login failed"),
+		},
+	})
+	doc.Add(http.MethodPost, "/auth/refresh", &openapi.Operation{
+		Tags:        []string{"auth"},
+		Summary:     "Exchange a refresh token for new tokens",
+		Description: "The refresh token is rotated: the one sent can no longer be used.",
+		OperationID: "refreshToken",
+		RequestBody: doc.Body(RefreshRequest{}, ""),
+		Responses: map[string]*openapi.Response{
+			"200": doc.JSON(TokenPair{}, "A new access and refresh token"),
+			"400": errorResponse("The body has no refresh token"),
+			"401": errorResponse("The refresh token is invalid, expired or revoked"),
+			"500": errorResponse("The refresh failed"),
+		},
+	})
+	doc.Add(http.MethodPost, "/auth/logout", &openapi.Operation{
+		Tags:        []string{"auth"},
+		Summary:     "Revoke a refresh token",
+		OperationID: "logout",
+		RequestBody: doc.Body(RefreshRequest{}, ""),
+		Responses: map[string]*openapi.Response{
+			"200": doc.JSON(openapi.Message{}, "The refresh token was revoked"),
+			"400": errorResponse("The body has no refresh token"),
+			"401": errorResponse("The refresh token is invalid"),
+			"500": errorResponse("The revocation failed"),
+		},
+	})
+	doc.Add(http.MethodGet, "/auth/me", Secure(doc, &openapi.Operation{
+		Tags:        []string{"auth"},
+		Summary:     "The authenticated principal",
+		OperationID: "getPrincipal",
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(Principal{}, "The principal and its permissions")},
+	}, ""))
+	doc.Add(http.MethodGet, "/.well-known/jwks.json", &openapi.Operation{
+		Tags:        []string{"auth"},
+		Summary:     "The public keys access tokens are signed with",
+		OperationID: "getJWKS",
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(JWKS{}, "A JSON Web Key Set")},
+	})
+
+	doc.Add(http.MethodPost, "/admin/api-keys", Secure(doc, &openapi.Operation{
+		Tags:        []string{"api-keys"},
+		Summary:     "Create an API key",
+		Description: "The key is only returned in this response. Its scopes cannot exceed the creator's permissions.",
+		OperationID: "createAPIKey",
+		RequestBody: doc.Body(APIKeyRequest{}, ""),
+		Responses: map[string]*openapi.Response{
+			"201": doc.JSON(CreatedAPIKey{}, "The key and its metadata"),
+			"400": errorResponse("The name, scopes or expiry are invalid"),
+			"500": errorResponse("The key could not be created"),
+		},
+	}, PermAPIKeysManage))
+	doc.Add(http.MethodGet, "/admin/api-keys", Secure(doc, &openapi.Operation{
+		Tags:        []string{"api-keys"},
+		Summary:     "List API keys",
+		OperationID: "listAPIKeys",
+		Responses: map[string]*openapi.Response{
+			"200": doc.JSON([]APIKey{}, "Every key, newest first, including revoked ones"),
+			"500": errorResponse("The keys could not be listed"),
+		},
+	}, PermAPIKeysManage))
+	doc.Add(http.MethodDelete, "/admin/api-keys/:id", Secure(doc, &openapi.Operation{
+		Tags:        []string{"api-keys"},
+		Summary:     "Revoke an API key",
+		OperationID: "revokeAPIKey",
+		Parameters:  []*openapi.Parameter{idParameter},
+		Responses: map[string]*openapi.Response{
+			"200": doc.JSON(openapi.Message{}, "The key was revoked"),
+			"400": errorResponse("The ID is not a UUID"),
+			"404": errorResponse("No such key, or it is already revoked"),
+			"500": errorResponse("The key could not be revoked"),
+		},
+	}, PermAPIKeysManage))
+	doc.Add(http.MethodPost, "/admin/api-keys/:id/rotate", Secure(doc, &openapi.Operation{
+		Tags:        []string{"api-keys"},
+		Summary:     "Replace an API key with a new one",
+		Description: "The new key has the same name, scopes and expiry. The old key is revoked.",
+		OperationID: "rotateAPIKey",
+		Parameters:  []*openapi.Parameter{idParameter},
+		Responses: map[string]*openapi.Response{
+			"201": doc.JSON(CreatedAPIKey{}, "The new key"),
+			"400": errorResponse("The ID is not a UUID"),
+			"404": errorResponse("No such key, or it is revoked or expired"),
+			"500": errorResponse("The key could not be rotated"),
+		},
+	}, PermAPIKeysManage))
+}
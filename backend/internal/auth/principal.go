@@ -0,0 +1,44 @@
+// Package auth issues and verifies the tokens that protect the API and puts the
+// authenticated principal on the request context.
+package auth
+
+import (
+	"backend/internal/audit"
+	"context"
+)
+
+// Principal is the authenticated caller of a request. Its permissions are
+// resolved from its roles when the request is authenticated, so changes to the
+// role definitions apply to tokens that were already issued.
+type Principal struct {
+	Subject     string       `json:"sub"`
+	Username    string       `json:"username"`
+	Roles       []string     `json:"roles"`
+	Permissions []Permission `json:"permissions"`
+}
+
+// SystemPrincipal is used for work the server does on its own, such as purges.
+func SystemPrincipal() *Principal {
+	return &Principal{Username: "system", Permissions: []Permission{PermAll}}
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFrom returns the principal put on ctx by Middleware.
+func PrincipalFrom(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// AuditContext returns ctx with its principal, if any, as the actor of the
+// changes recorded in the audit trail under it.
+func AuditContext(ctx context.Context) context.Context {
+	if principal, ok := PrincipalFrom(ctx); ok {
+		return audit.WithActor(ctx, principal.Username)
+	}
+	return ctx
+}
@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"backend/internal/problem"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Permission is a "resource:action" string. "*" grants everything and
+// "resource:*" every action on a resource.
+type Permission string
+
+const (
+	PermAll              Permission = "*"
+	PermStudentsRead     Permission = "students:read"
+	PermStudentsReadPII  Permission = "students:read:pii"
+	PermStudentsWrite    Permission = "students:write"
+	PermStudentsDelete   Permission = "students:delete"
+	PermCoursesRead      Permission = "courses:read"
+	PermCoursesWrite     Permission = "courses:write"
+	PermEnrollmentsRead  Permission = "enrollments:read"
+	PermEnrollmentsWrite Permission = "enrollments:write"
+	PermGradesRead       Permission = "grades:read"
+	PermGradesWrite      Permission = "grades:write"
+	PermAttendanceRead   Permission = "attendance:read"
+	PermAttendanceWrite  Permission = "attendance:write"
+	PermGuardiansRead    Permission = "guardians:read"
+	PermGuardiansWrite   Permission = "guardians:write"
+	PermAuditRead        Permission = "audit:read"
+	PermAPIKeysManage    Permission = "apikeys:manage"
+)
+
+// Permissions lists every permission a role may be granted.
+var Permissions = []Permission{PermStudentsRead, PermStudentsReadPII, PermStudentsWrite, PermStudentsDelete, PermCoursesRead, PermCoursesWrite, PermEnrollmentsRead, PermEnrollmentsWrite, PermGradesRead, PermGradesWrite, PermAttendanceRead, PermAttendanceWrite, PermGuardiansRead, PermGuardiansWrite, PermAuditRead, PermAPIKeysManage}
+
+var ErrForbidden = errors.New("forbidden")
+
+// ErrUnauthenticated is returned when there is no principal on the context.
+var ErrUnauthenticated error = &unauthenticatedError{}
+
+type unauthenticatedError struct{}
+
+func (e *unauthenticatedError) Error() string {
+	return "authentication required"
+}
+
+func (e *unauthenticatedError) Problem() *problem.Problem {
+	unauthenticated := problem.New(http.StatusUnauthorized, e.Error())
+	unauthenticated.Header = http.Header{"Www-Authenticate": {challenge}}
+	return unauthenticated
+}
+
+// ForbiddenError is returned when the principal lacks a permission. It matches
+// ErrForbidden.
+type ForbiddenError struct {
+	Permission Permission
+}
+
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("missing permission %s", e.Permission)
+}
+
+func (e *ForbiddenError) Is(target error) bool {
+	return target == ErrForbidden
+}
+
+// Problem names the missing permission:
+//
+//	{"status": 403, "reason": "missing_permission", "permission": "students:delete", ...}
+func (e *ForbiddenError) Problem() *problem.Problem {
+	forbidden := problem.New(http.StatusForbidden, e.Error())
+	forbidden.Extensions = map[string]interface{}{"reason": "missing_permission", "permission": e.Permission}
+	return forbidden
+}
+
+// Can reports whether the principal has been granted permission.
+func (p *Principal) Can(permission Permission) bool {
+	for _, granted := range p.Permissions {
+		if granted == PermAll || granted == permission {
+			return true
+		}
+		if resource, ok := strings.CutSuffix(string(granted), ":*"); ok && strings.HasPrefix(string(permission), resource+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// Policy maps role names to the permissions they grant.
+type Policy struct {
+	roles map[string][]Permission
+}
+
+// NewPolicy checks that every role only grants known permissions.
+func NewPolicy(roles map[string][]string) (*Policy, error) {
+	policy := &Policy{roles: map[string][]Permission{}}
+	var errs []error
+	for role, permissions := range roles {
+		for _, permission := range permissions {
+			if !knownPermission(Permission(permission)) {
+				errs = append(errs, fmt.Errorf("role %q grants unknown permission %q", role, permission))
+			}
+			policy.roles[role] = append(policy.roles[role], Permission(permission))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return policy, nil
+}
+
+func knownPermission(permission Permission) bool {
+	for _, known := range Permissions {
+		if permission == PermAll || permission == known {
+			return true
+		}
+		if resource, ok := strings.CutSuffix(string(permission), ":*"); ok && strings.HasPrefix(string(known), resource+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// Permissions returns the permissions granted by roles. Unknown roles grant nothing.
+func (p *Policy) Permissions(roles []string) []Permission {
+	var permissions []Permission
+	for _, role := range roles {
+		permissions = append(permissions, p.roles[role]...)
+	}
+	return permissions
+}
+
+// Authorize checks that the principal on ctx has permission.
+func Authorize(ctx context.Context, permission Permission) error {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	if !principal.Can(permission) {
+		return &ForbiddenError{Permission: permission}
+	}
+	return nil
+}
+
+// Require rejects requests whose principal lacks permission. It must run after
+// Middleware.
+func Require(permission Permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if err := Authorize(ctx.Request.Context(), permission); err != nil {
+			AbortWithError(ctx, err)
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// AbortWithError answers 401 or 403 for the errors returned by Authorize and
+// reports whether err was one of them.
+func AbortWithError(ctx *gin.Context, err error) bool {
+	if !errors.Is(err, ErrForbidden) && !errors.Is(err, ErrUnauthenticated) {
+		return false
+	}
+	problem.Abort(ctx, err)
+	return true
+}
+
+// Redact clears the fields of the struct v points to whose access tag names a
+// permission the principal on ctx lacks, recursing into nested structs and slices:
+//
+//	Email string `json:"email,omitempty" access:"students:read:pii"`
+func Redact(ctx context.Context, v interface{}) {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok {
+		principal = &Principal{}
+	}
+	redact(principal, reflect.ValueOf(v))
+}
+
+func redact(principal *Principal, value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !value.IsNil() {
+			redact(principal, value.Elem())
+		}
+	case reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			redact(principal, value.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if permission, ok := field.Tag.Lookup("access"); ok && !principal.Can(Permission(permission)) {
+				if value.Field(i).CanSet() {
+					value.Field(i).SetZero()
+				}
+				continue
+			}
+			redact(principal, value.Field(i))
+		}
+	}
+}
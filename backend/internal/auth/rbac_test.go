@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCan(t *testing.T) {
+	principal := &Principal{Permissions: []Permission{PermStudentsRead, "audit:*"}}
+	assert.True(t, principal.Can(PermStudentsRead))
+	assert.True(t, principal.Can(PermAuditRead), "resource wildcard")
+	assert.False(t, principal.Can(PermStudentsWrite))
+	assert.False(t, principal.Can(PermStudentsReadPII), "students:read does not imply its sub-permissions")
+
+	assert.True(t, SystemPrincipal().Can(PermStudentsDelete))
+	assert.False(t, (&Principal{}).Can(PermStudentsRead))
+}
+
+func TestPolicy(t *testing.T) {
+	policy, err := NewPolicy(map[string][]string{
+		"teacher": {"students:read"},
+		"auditor": {"students:read", "audit:read"},
+		"admin":   {"*"},
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []Permission{PermStudentsRead, PermStudentsRead, PermAuditRead}, policy.Permissions([]string{"teacher", "auditor", "unknown"}))
+
+	_, err = NewPolicy(map[string][]string{"teacher": {"students:teach", "library:*"}})
+	assert.ErrorContains(t, err, `role "teacher" grants unknown permission "students:teach"`)
+	assert.ErrorContains(t, err, `role "teacher" grants unknown permission "library:*"`)
+}
+
+func TestAuthorize(t *testing.T) {
+	assert.ErrorIs(t, Authorize(context.Background(), PermStudentsRead), ErrUnauthenticated)
+
+	ctx := WithPrincipal(context.Background(), &Principal{Permissions: []Permission{PermStudentsRead}})
+	assert.NoError(t, Authorize(ctx, PermStudentsRead))
+
+	err := Authorize(ctx, PermStudentsDelete)
+	assert.ErrorIs(t, err, ErrForbidden)
+	assert.EqualError(t, err, "missing permission students:delete")
+}
+
+func TestRequire(t *testing.T) {
+	router := gin.Default()
+	router.GET("/students", func(ctx *gin.Context) {
+		principal := &Principal{Username: "teacher", Permissions: []Permission{PermStudentsRead}}
+		ctx.Request = ctx.Request.WithContext(WithPrincipal(ctx.Request.Context(), principal))
+	}, Require(PermStudentsDelete), func(ctx *gin.Context) {
+		t.Error("the handler must not run")
+	})
+
+	w := performRequest(router, "GET", "/students", nil, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Forbidden",
+		"status": 403,
+		"detail": "missing permission students:delete",
+		"instance": "/students",
+		"reason": "missing_permission",
+		"permission": "students:delete"
+	}`, w.Body.String())
+}
+
+func TestRedact(t *testing.T) {
+	type address struct {
+		City   string `json:"city"`
+		Street string `json:"street" access:"students:read:pii"`
+	}
+	type student struct {
+		Name      string    `json:"name"`
+		Email     string    `json:"email" access:"students:read:pii"`
+		Addresses []address `json:"addresses"`
+		Notes     *string   `json:"notes" access:"students:write"`
+	}
+	notes := "notes"
+	newStudent := func() *student {
+		return &student{Name: "hasan", Email: "hasan@example.com", Addresses: []address{{City: "Ankara", Street: "Atatürk Blv."}}, Notes: &notes}
+	}
+
+	teacher := WithPrincipal(context.Background(), &Principal{Permissions: []Permission{PermStudentsRead}})
+	redacted := []*student{newStudent()}
+	Redact(teacher, redacted)
+	assert.Equal(t, &student{Name: "hasan", Addresses: []address{{City: "Ankara"}}}, redacted[0])
+
+	registrar := WithPrincipal(context.Background(), &Principal{Permissions: []Permission{"students:*"}})
+	visible := newStudent()
+	Redact(registrar, visible)
+	assert.Equal(t, newStudent(), visible)
+
+	anonymous := newStudent()
+	Redact(context.Background(), anonymous)
+	assert.Empty(t, anonymous.Email, "without a principal nothing protected is shown")
+}
@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	GetUser(id uuid.UUID) (*User, error)
+	GetUserByUsername(username string) (*User, error)
+	// SaveUser creates the user or updates the one with the same username.
+	SaveUser(user *User) error
+	AddRefreshToken(token *RefreshToken) error
+	GetRefreshToken(hash string) (*RefreshToken, error)
+	// RevokeRefreshToken fails with ErrInvalidToken when the token was already
+	// revoked, so a token can only be exchanged once.
+	RevokeRefreshToken(id uuid.UUID, at time.Time) error
+	RevokeUserRefreshTokens(userID uuid.UUID, at time.Time) error
+	AddAPIKey(key *APIKey) error
+	GetAPIKey(id uuid.UUID) (*APIKey, error)
+	GetAPIKeyByHash(hash string) (*APIKey, error)
+	// GetAPIKeys lists every key, revoked and expired ones included, newest first.
+	GetAPIKeys() ([]APIKey, error)
+	// RevokeAPIKey fails with ErrAPIKeyNotFound when the key does not exist or
+	// was already revoked.
+	RevokeAPIKey(id uuid.UUID, at time.Time) error
+	// RotateAPIKey revokes the key with id and adds replacement at once. Like
+	// RevokeAPIKey it fails with ErrAPIKeyNotFound, and then adds nothing, when
+	// the key does not exist or was already revoked, so that concurrent
+	// rotations leave a single live key.
+	RotateAPIKey(id uuid.UUID, replacement *APIKey, at time.Time) error
+	TouchAPIKey(id uuid.UUID, at time.Time) error
+}
+
+type authRepository struct {
+	DB *gorm.DB
+}
+
+// NewRepository stores users, refresh tokens and API keys in db, whose schema
+// schema.Migrate keeps up to date.
+func NewRepository(db *gorm.DB) (*authRepository, error) {
+	return &authRepository{DB: db}, nil
+}
+
+func (r *authRepository) GetUser(id uuid.UUID) (*User, error) {
+	var user User
+	err := r.DB.Where("id = ?", id).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *authRepository) GetUserByUsername(username string) (*User, error) {
+	var user User
+	err := r.DB.Where("username = ?", username).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *authRepository) SaveUser(user *User) error {
+	return r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "username"}},
+		DoUpdates: clause.AssignmentColumns([]string{"password_hash", "roles", "updated_at"}),
+	}).Create(user).Error
+}
+
+func (r *authRepository) AddRefreshToken(token *RefreshToken) error {
+	return r.DB.Create(token).Error
+}
+
+func (r *authRepository) GetRefreshToken(hash string) (*RefreshToken, error) {
+	var token RefreshToken
+	err := r.DB.Where("hash = ?", hash).First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *authRepository) RevokeRefreshToken(id uuid.UUID, at time.Time) error {
+	result := r.DB.Model(&RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+func (r *authRepository) RevokeUserRefreshTokens(userID uuid.UUID, at time.Time) error {
+	return r.DB.Model(&RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", at).Error
+}
+
+func (r *authRepository) AddAPIKey(key *APIKey) error {
+	return r.DB.Create(key).Error
+}
+
+func (r *authRepository) GetAPIKey(id uuid.UUID) (*APIKey, error) {
+	var key APIKey
+	err := r.DB.Where("id = ?", id).First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *authRepository) GetAPIKeyByHash(hash string) (*APIKey, error) {
+	var key APIKey
+	err := r.DB.Where("hash = ?", hash).First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *authRepository) GetAPIKeys() ([]APIKey, error) {
+	var keys []APIKey
+	err := r.DB.Order("created_at DESC").Order("id").Find(&keys).Error
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *authRepository) RevokeAPIKey(id uuid.UUID, at time.Time) error {
+	return revokeAPIKey(r.DB, id, at)
+}
+
+func revokeAPIKey(db *gorm.DB, id uuid.UUID, at time.Time) error {
+	result := db.Model(&APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (r *authRepository) RotateAPIKey(id uuid.UUID, replacement *APIKey, at time.Time) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := revokeAPIKey(tx, id, at); err != nil {
+			return err
+		}
+		return tx.Create(replacement).Error
+	})
+}
+
+func (r *authRepository) TouchAPIKey(id uuid.UUID, at time.Time) error {
+	return r.DB.Model(&APIKey{}).Where("id = ?", id).Update("last_used_at", at).Error
+}
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"backend/internal/database/schema"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestRepository(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	require.NoError(t, schema.Migrate(db))
+
+	gormRepository, err := NewRepository(db)
+	require.NoError(t, err)
+
+	for name, repo := range map[string]Repository{"Memory": NewMemoryRepository(), "SQLite": gormRepository} {
+		t.Run(name, func(t *testing.T) {
+			user := &User{ID: uuid.New(), Username: "admin", PasswordHash: "hash", Roles: []string{"admin"}}
+			require.NoError(t, repo.SaveUser(user))
+			require.NoError(t, repo.SaveUser(&User{ID: uuid.New(), Username: "admin", PasswordHash: "new hash", Roles: []string{"auditor"}}))
+
+			saved, err := repo.GetUserByUsername("admin")
+			require.NoError(t, err)
+			assert.Equal(t, user.ID, saved.ID, "saving an existing username updates it")
+			assert.Equal(t, "new hash", saved.PasswordHash)
+			assert.Equal(t, []string{"auditor"}, saved.Roles)
+
+			_, err = repo.GetUser(uuid.New())
+			assert.ErrorIs(t, err, ErrUserNotFound)
+			_, err = repo.GetUserByUsername("nobody")
+			assert.ErrorIs(t, err, ErrUserNotFound)
+
+			token := &RefreshToken{ID: uuid.New(), Hash: hashToken(name), UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+			require.NoError(t, repo.AddRefreshToken(token))
+			other := &RefreshToken{ID: uuid.New(), Hash: hashToken(name + "2"), UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+			require.NoError(t, repo.AddRefreshToken(other))
+
+			found, err := repo.GetRefreshToken(token.Hash)
+			require.NoError(t, err)
+			assert.Equal(t, token.ID, found.ID)
+			assert.Nil(t, found.RevokedAt)
+
+			require.NoError(t, repo.RevokeRefreshToken(token.ID, time.Now()))
+			assert.ErrorIs(t, repo.RevokeRefreshToken(token.ID, time.Now()), ErrInvalidToken, "a token is revoked once")
+
+			require.NoError(t, repo.RevokeUserRefreshTokens(user.ID, time.Now()))
+			found, err = repo.GetRefreshToken(other.Hash)
+			require.NoError(t, err)
+			assert.NotNil(t, found.RevokedAt)
+
+			_, err = repo.GetRefreshToken("unknown")
+			assert.ErrorIs(t, err, ErrInvalidToken)
+
+			key := &APIKey{ID: uuid.New(), Name: "importer", Prefix: "sk_abc", Hash: hashToken("key" + name), Scopes: []Permission{PermStudentsRead}, ExpiresAt: time.Now().Add(time.Hour)}
+			require.NoError(t, repo.AddAPIKey(key))
+
+			found2, err := repo.GetAPIKeyByHash(key.Hash)
+			require.NoError(t, err)
+			assert.Equal(t, key.ID, found2.ID)
+			assert.Equal(t, []Permission{PermStudentsRead}, found2.Scopes)
+			assert.Nil(t, found2.LastUsedAt)
+
+			used := time.Now()
+			require.NoError(t, repo.TouchAPIKey(key.ID, used))
+			found2, err = repo.GetAPIKey(key.ID)
+			require.NoError(t, err)
+			require.NotNil(t, found2.LastUsedAt)
+			assert.WithinDuration(t, used, *found2.LastUsedAt, time.Second)
+
+			require.NoError(t, repo.RevokeAPIKey(key.ID, time.Now()))
+			assert.ErrorIs(t, repo.RevokeAPIKey(key.ID, time.Now()), ErrAPIKeyNotFound, "a key is revoked once")
+			assert.ErrorIs(t, repo.RevokeAPIKey(uuid.New(), time.Now()), ErrAPIKeyNotFound)
+
+			rotated := &APIKey{ID: uuid.New(), Name: "rotated", Prefix: "sk_def", Hash: hashToken("rotated" + name), Scopes: []Permission{PermStudentsRead}, ExpiresAt: time.Now().Add(time.Hour)}
+			require.NoError(t, repo.AddAPIKey(rotated))
+			replacement := &APIKey{ID: uuid.New(), Name: "rotated", Prefix: "sk_ghi", Hash: hashToken("replacement" + name), Scopes: []Permission{PermStudentsRead}, ExpiresAt: rotated.ExpiresAt}
+			require.NoError(t, repo.RotateAPIKey(rotated.ID, replacement, time.Now()))
+			found2, err = repo.GetAPIKey(rotated.ID)
+			require.NoError(t, err)
+			assert.NotNil(t, found2.RevokedAt)
+			_, err = repo.GetAPIKey(replacement.ID)
+			require.NoError(t, err)
+
+			again := &APIKey{ID: uuid.New(), Name: "rotated", Prefix: "sk_jkl", Hash: hashToken("again" + name), Scopes: []Permission{PermStudentsRead}, ExpiresAt: rotated.ExpiresAt}
+			assert.ErrorIs(t, repo.RotateAPIKey(rotated.ID, again, time.Now()), ErrAPIKeyNotFound, "a key is rotated once")
+			_, err = repo.GetAPIKey(again.ID)
+			assert.ErrorIs(t, err, ErrAPIKeyNotFound, "a failed rotation adds nothing")
+
+			keys, err := repo.GetAPIKeys()
+			require.NoError(t, err)
+			require.Len(t, keys, 3)
+			revoked := 0
+			for _, key := range keys {
+				if key.RevokedAt != nil {
+					revoked++
+				}
+			}
+			assert.Equal(t, 2, revoked)
+
+			_, err = repo.GetAPIKeyByHash("unknown")
+			assert.ErrorIs(t, err, ErrAPIKeyNotFound)
+			_, err = repo.GetAPIKey(uuid.New())
+			assert.ErrorIs(t, err, ErrAPIKeyNotFound)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package auth
+
+import (
+	"backend/internal/config"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// claims are the claims of an access token. The subject is the user ID.
+type claims struct {
+	jwt.RegisteredClaims
+	Username string   `json:"username"`
+	Roles    []string `json:"roles"`
+}
+
+type AuthService struct {
+	repository      Repository
+	keys            *KeySet
+	Policy          *Policy
+	Issuer          string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	// dummyHash is compared against when the user does not exist, so a login
+	// takes as long for unknown users as for wrong passwords.
+	dummyHash []byte
+}
+
+func Service(repository Repository, keys *KeySet) *AuthService {
+	dummyHash, _ := bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+	defaults := config.Default().Auth
+	policy, _ := NewPolicy(defaults.Roles)
+	return &AuthService{
+		repository:      repository,
+		keys:            keys,
+		Policy:          policy,
+		Issuer:          defaults.Issuer,
+		AccessTokenTTL:  defaults.AccessTokenTTL,
+		RefreshTokenTTL: defaults.RefreshTokenTTL,
+		dummyHash:       dummyHash,
+	}
+}
+
+// SeedUsers creates or updates the configured users.
+func (s *AuthService) SeedUsers(users []config.User) error {
+	for _, user := range users {
+		err := s.repository.SaveUser(&User{
+			ID:           uuid.New(),
+			Username:     user.Username,
+			PasswordHash: user.PasswordHash,
+			Roles:        user.Roles,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Login checks the credentials and issues a new token pair.
+func (s *AuthService) Login(credentials Credentials) (*TokenPair, error) {
+	user, err := s.repository.GetUserByUsername(credentials.Username)
+	if errors.Is(err, ErrUserNotFound) {
+		bcrypt.CompareHashAndPassword(s.dummyHash, []byte(credentials.Password))
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(credentials.Password)) != nil {
+		return nil, ErrInvalidCredentials
+	}
+	return s.issue(user)
+}
+
+// Refresh exchanges a refresh token for a new token pair and revokes it. Using a
+// revoked token again revokes every token of its user, since the token has
+// probably been stolen.
+func (s *AuthService) Refresh(refreshToken string) (*TokenPair, error) {
+	token, err := s.repository.GetRefreshToken(hashToken(refreshToken))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if token.RevokedAt != nil {
+		if err := s.repository.RevokeUserRefreshTokens(token.UserID, now); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidToken
+	}
+	if !now.Before(token.ExpiresAt) {
+		return nil, ErrInvalidToken
+	}
+	if err := s.repository.RevokeRefreshToken(token.ID, now); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repository.GetUser(token.UserID)
+	if errors.Is(err, ErrUserNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.issue(user)
+}
+
+// Logout revokes a refresh token. Access tokens stay valid until they expire,
+// which is why they are short lived.
+func (s *AuthService) Logout(refreshToken string) error {
+	token, err := s.repository.GetRefreshToken(hashToken(refreshToken))
+	if err != nil {
+		return err
+	}
+	return s.repository.RevokeRefreshToken(token.ID, time.Now())
+}
+
+// Authenticate verifies an access token and returns its principal.
+func (s *AuthService) Authenticate(accessToken string) (*Principal, error) {
+	var claims claims
+	_, err := jwt.ParseWithClaims(accessToken, &claims, s.keys.keyFunc,
+		jwt.WithIssuer(s.Issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+	)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	return &Principal{
+		Subject:     claims.Subject,
+		Username:    claims.Username,
+		Roles:       claims.Roles,
+		Permissions: s.Policy.Permissions(claims.Roles),
+	}, nil
+}
+
+// JWKS returns the public keys that verify access tokens.
+func (s *AuthService) JWKS() JWKS {
+	return s.keys.JWKS()
+}
+
+func (s *AuthService) issue(user *User) (*TokenPair, error) {
+	now := time.Now()
+	accessToken, err := s.keys.sign(claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    s.Issuer,
+			Subject:   user.ID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.AccessTokenTTL)),
+		},
+		Username: user.Username,
+		Roles:    user.Roles,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(secret)
+	err = s.repository.AddRefreshToken(&RefreshToken{
+		ID:        uuid.New(),
+		Hash:      hashToken(refreshToken),
+		UserID:    user.ID,
+		ExpiresAt: now.Add(s.RefreshTokenTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(s.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
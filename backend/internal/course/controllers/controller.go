@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"backend/internal/course/models"
+	"backend/internal/problem"
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CourseService interface {
+	GetAll(ctx context.Context, query models.CourseQuery, page int, pageSize int) (models.PaginationResponse, error)
+	Get(ctx context.Context, id uuid.UUID) (*models.Course, error)
+	Add(ctx context.Context, course *models.Course) error
+	Update(ctx context.Context, id uuid.UUID, course *models.Course) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+var (
+	errInvalidID      = &problem.ValidationError{Detail: "invalid UUID", Fields: []problem.FieldError{{Field: "id", Code: "uuid", Message: "must be a UUID"}}}
+	errInvalidRequest = &problem.ValidationError{Detail: "invalid request"}
+)
+
+type CourseController struct {
+	Service         CourseService
+	DefaultPageSize int
+	MaxPageSize     int
+}
+
+func Controller(service CourseService) *CourseController {
+	return &CourseController{Service: service}
+}
+
+// GetAll lists the courses ordered by code, filtered by department, term and q.
+func (c *CourseController) GetAll(ctx *gin.Context) {
+	page, pageSize, err := c.pagination(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	query := models.ParseCourseQuery(ctx.Request.URL.Query())
+	response, err := c.Service.GetAll(ctx.Request.Context(), query, page, pageSize)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *CourseController) Get(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidID)
+		return
+	}
+
+	course, err := c.Service.Get(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, course)
+}
+
+func (c *CourseController) Add(ctx *gin.Context) {
+	var course models.Course
+	if err := ctx.ShouldBindJSON(&course); err != nil {
+		ctx.Error(errInvalidRequest)
+		return
+	}
+	if err := c.Service.Add(ctx.Request.Context(), &course); err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, course)
+}
+
+func (c *CourseController) Update(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidID)
+		return
+	}
+
+	var course models.Course
+	if err := ctx.ShouldBindJSON(&course); err != nil {
+		ctx.Error(errInvalidRequest)
+		return
+	}
+
+	if err := c.Service.Update(ctx.Request.Context(), id, &course); err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, course)
+}
+
+func (c *CourseController) Delete(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidID)
+		return
+	}
+
+	if err := c.Service.Delete(ctx.Request.Context(), id); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Course deleted successfully"})
+}
+
+// pagination reads the page and size query parameters like the student
+// controller, applying the configured default and maximum page size.
+func (c *CourseController) pagination(ctx *gin.Context) (int, int, error) {
+	page := 1
+	pageSize := c.DefaultPageSize
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	var fields []problem.FieldError
+	if pageStr := ctx.Query("page"); pageStr != "" {
+		var err error
+		if page, err = strconv.Atoi(pageStr); err != nil || page < 1 {
+			fields = append(fields, problem.FieldError{Field: "page", Code: "positive", Message: "must be a positive integer"})
+		}
+	}
+	if pageSizeStr := ctx.Query("size"); pageSizeStr != "" {
+		var err error
+		if pageSize, err = strconv.Atoi(pageSizeStr); err != nil || pageSize < 1 {
+			fields = append(fields, problem.FieldError{Field: "size", Code: "positive", Message: "must be a positive integer"})
+		}
+	}
+	if len(fields) > 0 {
+		return 0, 0, &problem.ValidationError{Detail: models.ErrInvalidPage.Detail, Fields: fields}
+	}
+
+	if c.MaxPageSize > 0 && pageSize > c.MaxPageSize {
+		pageSize = c.MaxPageSize
+	}
+	return page, pageSize, nil
+}
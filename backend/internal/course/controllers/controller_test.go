@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"backend/internal/apitest"
+	"backend/internal/course/mocks"
+	"backend/internal/course/models"
+	"backend/internal/problem"
+	studentmodels "backend/internal/student/models"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRouter(controller *CourseController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(problem.Middleware())
+	router.GET("/courses", controller.GetAll)
+	router.GET("/courses/:id", controller.Get)
+	router.POST("/courses", controller.Add)
+	router.PUT("/courses/:id", controller.Update)
+	router.DELETE("/courses/:id", controller.Delete)
+	return router
+}
+
+var course = models.Course{
+	ID:         "7995c72f-7d04-4136-8b5f-000d6d4aae23",
+	Code:       "CENG 242",
+	Title:      "Programming Language Concepts",
+	Credits:    4,
+	Department: "Computer Engineering",
+	Capacity:   60,
+}
+
+func TestGetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCourseService(ctrl)
+	controller := Controller(mockService)
+	controller.MaxPageSize = 50
+	router := newRouter(controller)
+
+	t.Run("Success", func(t *testing.T) {
+		response := models.PaginationResponse{Courses: []models.Course{course}, Page: studentmodels.Page{Number: 2, Size: 50, Elements: 51, Pages: 2}}
+		mockService.EXPECT().GetAll(gomock.Any(), models.CourseQuery{Department: "Computer Engineering", Search: "lang"}, 2, 50).Return(response, nil)
+
+		w := apitest.Request(router, http.MethodGet, "/courses?page=2&size=100&department=Computer+Engineering&q=lang", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var actual models.PaginationResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+		assert.Equal(t, response, actual)
+	})
+
+	t.Run("Invalid Page", func(t *testing.T) {
+		w := apitest.Request(router, http.MethodGet, "/courses?page=0", nil)
+		apitest.AssertProblem(t, w, http.StatusBadRequest, models.ErrInvalidPage.Detail)
+	})
+}
+
+func TestGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCourseService(ctrl)
+	router := newRouter(Controller(mockService))
+	id := uuid.MustParse(course.ID)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().Get(gomock.Any(), id).Return(&course, nil)
+
+		w := apitest.Request(router, http.MethodGet, "/courses/"+course.ID, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var actual models.Course
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+		assert.Equal(t, course, actual)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockService.EXPECT().Get(gomock.Any(), id).Return(nil, models.ErrCourseNotFound)
+
+		w := apitest.Request(router, http.MethodGet, "/courses/"+course.ID, nil)
+		apitest.AssertProblem(t, w, http.StatusNotFound, models.ErrCourseNotFound.Detail)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		w := apitest.Request(router, http.MethodGet, "/courses/42", nil)
+		apitest.AssertProblem(t, w, http.StatusBadRequest, "invalid UUID")
+	})
+}
+
+func TestAdd(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCourseService(ctrl)
+	router := newRouter(Controller(mockService))
+	body, _ := json.Marshal(course)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil)
+
+		w := apitest.Request(router, http.MethodPost, "/courses", body)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		mockService.EXPECT().Add(gomock.Any(), gomock.Any()).Return(models.ErrCourseExists)
+
+		w := apitest.Request(router, http.MethodPost, "/courses", body)
+		apitest.AssertProblem(t, w, http.StatusConflict, models.ErrCourseExists.Detail)
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		w := apitest.Request(router, http.MethodPost, "/courses", []byte("{"))
+		apitest.AssertProblem(t, w, http.StatusBadRequest, "invalid request")
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCourseService(ctrl)
+	router := newRouter(Controller(mockService))
+	body, _ := json.Marshal(course)
+
+	mockService.EXPECT().Update(gomock.Any(), uuid.MustParse(course.ID), gomock.Any()).Return(models.ErrCourseNotFound)
+
+	w := apitest.Request(router, http.MethodPut, "/courses/"+course.ID, body)
+	apitest.AssertProblem(t, w, http.StatusNotFound, models.ErrCourseNotFound.Detail)
+}
+
+func TestDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCourseService(ctrl)
+	router := newRouter(Controller(mockService))
+
+	mockService.EXPECT().Delete(gomock.Any(), uuid.MustParse(course.ID)).Return(nil)
+
+	w := apitest.Request(router, http.MethodDelete, "/courses/"+course.ID, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"message": "Course deleted successfully"}`, w.Body.String())
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/course/controllers/controller.go
+
+// Package controllers is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/internal/course/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockCourseService is a mock of CourseService interface.
+type MockCourseService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCourseServiceMockRecorder
+}
+
+// MockCourseServiceMockRecorder is the mock recorder for MockCourseService.
+type MockCourseServiceMockRecorder struct {
+	mock *MockCourseService
+}
+
+// NewMockCourseService creates a new mock instance.
+func NewMockCourseService(ctrl *gomock.Controller) *MockCourseService {
+	mock := &MockCourseService{ctrl: ctrl}
+	mock.recorder = &MockCourseServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourseService) EXPECT() *MockCourseServiceMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockCourseService) Add(ctx context.Context, course *models.Course) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, course)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockCourseServiceMockRecorder) Add(ctx, course interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockCourseService)(nil).Add), ctx, course)
+}
+
+// Delete mocks base method.
+func (m *MockCourseService) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCourseServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCourseService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockCourseService) Get(ctx context.Context, id uuid.UUID) (*models.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCourseServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCourseService)(nil).Get), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockCourseService) GetAll(ctx context.Context, query models.CourseQuery, page, pageSize int) (models.PaginationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, query, page, pageSize)
+	ret0, _ := ret[0].(models.PaginationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCourseServiceMockRecorder) GetAll(ctx, query, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCourseService)(nil).GetAll), ctx, query, page, pageSize)
+}
+
+// Update mocks base method.
+func (m *MockCourseService) Update(ctx context.Context, id uuid.UUID, course *models.Course) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, course)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCourseServiceMockRecorder) Update(ctx, id, course interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCourseService)(nil).Update), ctx, id, course)
+}
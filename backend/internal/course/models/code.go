@@ -0,0 +1,37 @@
+package models
+
+import (
+	"backend/internal/validation"
+	"reflect"
+	"strings"
+)
+
+func init() {
+	validation.Register("coursecode", courseCode)
+}
+
+// courseCode accepts a department prefix of 2 to 6 ASCII letters and a number of 3
+// or 4 digits, optionally followed by a letter: "CENG 242", "MATH119A". The
+// code is upper cased and the prefix separated from the number by a space, so
+// "ceng242" and "CENG 242" are the same course.
+func courseCode(value reflect.Value, _ string) string {
+	if value.Kind() != reflect.String || !value.CanSet() || value.String() == "" {
+		return ""
+	}
+	const message = "must be 2 to 6 letters and 3 or 4 digits, such as CENG 242"
+	code := strings.ToUpper(strings.ReplaceAll(value.String(), " ", ""))
+
+	letters := strings.IndexFunc(code, func(r rune) bool { return r < 'A' || r > 'Z' })
+	if letters < 2 || letters > 6 {
+		return message
+	}
+	number := code[letters:]
+	if suffix := number[len(number)-1]; suffix >= 'A' && suffix <= 'Z' {
+		number = number[:len(number)-1]
+	}
+	if len(number) < 3 || len(number) > 4 || strings.IndexFunc(number, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return message
+	}
+	value.SetString(code[:letters] + " " + code[letters:])
+	return ""
+}
@@ -0,0 +1,10 @@
+package models
+
+import "backend/internal/problem"
+
+var (
+	ErrCourseNotFound = &problem.NotFoundError{Detail: "course not found"}
+	ErrCourseExists   = &problem.ConflictError{Detail: "a course with this code already exists in this term"}
+	ErrCourseInUse    = &problem.ConflictError{Detail: "the course still has enrollments, gradebook entries or attendance records"}
+	ErrInvalidPage    = &problem.ValidationError{Detail: "page and size must be positive integers"}
+)
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"backend/internal/student/models"
+
+	"github.com/google/uuid"
+)
+
+// Course is validated with the validation package before it is stored. Codes
+// are unique within a term.
+type Course struct {
+	ID         string `json:"id"`
+	Code       string `json:"code" validate:"trim,required,coursecode"`
+	Title      string `json:"title" validate:"trim,required,max=200"`
+	Credits    int    `json:"credits" validate:"min=0,max=30"`
+	Department string `json:"department" validate:"trim,required,max=100"`
+	// Term is the term the course is given in, such as 2026-fall. Term GPAs
+	// group courses by it.
+	Term string `json:"term,omitempty" validate:"trim,term"`
+	// Capacity is the number of students who may enroll.
+	Capacity int `json:"capacity" validate:"min=1,max=10000"`
+}
+
+type CourseEntity struct {
+	ID         uuid.UUID `gorm:"primary_key;type:char(36)"`
+	Code       string    `gorm:"size:20;uniqueIndex:idx_courses_code_term"`
+	Title      string    `gorm:"size:200"`
+	Credits    int
+	Department string `gorm:"size:100;index"`
+	Term       string `gorm:"size:20;uniqueIndex:idx_courses_code_term"`
+	Capacity   int
+}
+
+func (CourseEntity) TableName() string {
+	return "courses"
+}
+
+// PaginationResponse is the course counterpart of the students' envelope.
+type PaginationResponse struct {
+	Courses []Course    `json:"courses"`
+	Page    models.Page `json:"page"`
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"backend/internal/problem"
+	"backend/internal/validation"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCourseCode(t *testing.T) {
+	for _, tc := range []struct {
+		Code       string
+		Normalized string
+		Valid      bool
+	}{
+		{"CENG 242", "CENG 242", true},
+		{"ceng242", "CENG 242", true},
+		{" math  119a ", "MATH 119A", true},
+		{"CS 1010", "CS 1010", true},
+		{"C 101", "", false},
+		{"COMPUTE 101", "", false},
+		{"CENG 24", "", false},
+		{"CENG 24201", "", false},
+		{"CENG", "", false},
+		{"CENG 2x2", "", false},
+		{"ÇENG 242", "", false},
+	} {
+		t.Run(tc.Code, func(t *testing.T) {
+			course := &Course{Code: tc.Code, Title: "Data Structures", Credits: 4, Department: "Computer Engineering", Capacity: 60}
+			err := validation.Validate(course)
+			if tc.Valid {
+				require.NoError(t, err)
+				assert.Equal(t, tc.Normalized, course.Code)
+				return
+			}
+			var invalid *problem.ValidationError
+			require.ErrorAs(t, err, &invalid)
+			assert.Equal(t, "code", invalid.Fields[0].Field)
+			assert.Equal(t, "coursecode", invalid.Fields[0].Code)
+		})
+	}
+}
+
+func TestTerm(t *testing.T) {
+	for _, tc := range []struct {
+		Term       string
+		Normalized string
+		Valid      bool
+	}{
+		{"", "", true},
+		{"2026-fall", "2026-fall", true},
+		{" 2027-Spring ", "2027-spring", true},
+		{"2026-autumn", "", false},
+		{"26-fall", "", false},
+		{"fall-2026", "", false},
+	} {
+		t.Run(tc.Term, func(t *testing.T) {
+			course := &Course{Code: "CENG 242", Title: "Data Structures", Credits: 4, Department: "Computer Engineering", Term: tc.Term, Capacity: 60}
+			err := validation.Validate(course)
+			if tc.Valid {
+				require.NoError(t, err)
+				assert.Equal(t, tc.Normalized, course.Term)
+				return
+			}
+			var invalid *problem.ValidationError
+			require.ErrorAs(t, err, &invalid)
+			assert.Equal(t, "term", invalid.Fields[0].Field)
+		})
+	}
+}
+
+func TestCompareTerms(t *testing.T) {
+	terms := []string{"2027-spring", "2026-fall", "", "2026-summer", "2026-spring"}
+	sort.Slice(terms, func(i, j int) bool { return CompareTerms(terms[i], terms[j]) < 0 })
+	assert.Equal(t, []string{"", "2026-spring", "2026-summer", "2026-fall", "2027-spring"}, terms)
+	assert.Equal(t, 0, CompareTerms("2026-fall", "2026-fall"))
+}
+
+func TestCourseValidation(t *testing.T) {
+	err := validation.Validate(&Course{Code: "CENG 242", Credits: 31})
+	var invalid *problem.ValidationError
+	require.ErrorAs(t, err, &invalid)
+	assert.Equal(t, []problem.FieldError{
+		{Field: "title", Code: "required", Message: "is required"},
+		{Field: "credits", Code: "max", Message: "must be at most 30"},
+		{Field: "department", Code: "required", Message: "is required"},
+		{Field: "capacity", Code: "min", Message: "must be at least 1"},
+	}, invalid.Fields)
+}
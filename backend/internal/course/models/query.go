@@ -0,0 +1,25 @@
+package models
+
+import (
+	"net/url"
+	"strings"
+)
+
+// CourseQuery narrows the course list. Courses are always listed by code, then term.
+type CourseQuery struct {
+	// Department only keeps the courses of this department.
+	Department string
+	// Term only keeps the courses of this term.
+	Term string
+	// Search is matched, case-insensitively, against the code and title.
+	Search string
+}
+
+// ParseCourseQuery reads the department, term and q query parameters.
+func ParseCourseQuery(values url.Values) CourseQuery {
+	return CourseQuery{
+		Department: strings.TrimSpace(values.Get("department")),
+		Term:       strings.ToLower(strings.TrimSpace(values.Get("term"))),
+		Search:     strings.TrimSpace(values.Get("q")),
+	}
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"backend/internal/validation"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	validation.Register("term", term)
+}
+
+// seasons are the parts of an academic year, in calendar order.
+var seasons = []string{"spring", "summer", "fall"}
+
+// term accepts a year and a season, such as "2026-fall". Case is ignored.
+func term(value reflect.Value, _ string) string {
+	if value.Kind() != reflect.String || !value.CanSet() || value.String() == "" {
+		return ""
+	}
+	normalized := strings.ToLower(value.String())
+	if _, _, ok := parseTerm(normalized); !ok {
+		return "must be a year and a season, such as 2026-fall"
+	}
+	value.SetString(normalized)
+	return ""
+}
+
+func parseTerm(term string) (year int, season int, ok bool) {
+	yearPart, seasonPart, found := strings.Cut(term, "-")
+	if !found || len(yearPart) != 4 {
+		return 0, 0, false
+	}
+	year, err := strconv.Atoi(yearPart)
+	if err != nil {
+		return 0, 0, false
+	}
+	for i, name := range seasons {
+		if seasonPart == name {
+			return year, i, true
+		}
+	}
+	return 0, 0, false
+}
+
+// CompareTerms orders terms chronologically, returning -1, 0 or 1 as a comes
+// before, with or after b. Courses without a term come first.
+func CompareTerms(a, b string) int {
+	yearA, seasonA, okA := parseTerm(a)
+	yearB, seasonB, okB := parseTerm(b)
+	switch {
+	case okA != okB:
+		if okB {
+			return -1
+		}
+		return 1
+	case yearA != yearB:
+		if yearA < yearB {
+			return -1
+		}
+		return 1
+	case seasonA != seasonB:
+		if seasonA < seasonB {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
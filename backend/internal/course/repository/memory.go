@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"backend/internal/course/models"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// memoryRepository keeps courses in process memory. It is safe for concurrent
+// use and meant for local development and tests.
+type memoryRepository struct {
+	mu      sync.RWMutex
+	courses map[uuid.UUID]models.CourseEntity
+}
+
+func NewMemoryRepository() *memoryRepository {
+	return &memoryRepository{courses: map[uuid.UUID]models.CourseEntity{}}
+}
+
+func (r *memoryRepository) Get(id uuid.UUID) (*models.Course, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entity, ok := r.courses[id]
+	if !ok {
+		return nil, models.ErrCourseNotFound
+	}
+	return EntityToModel(&entity), nil
+}
+
+func (r *memoryRepository) GetAll(query models.CourseQuery, page int, pageSize int) ([]models.Course, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matching := r.find(query)
+	offset := (page - 1) * pageSize
+	if offset >= len(matching) {
+		return []models.Course{}, nil
+	}
+	end := offset + pageSize
+	if end > len(matching) {
+		end = len(matching)
+	}
+	return matching[offset:end], nil
+}
+
+func (r *memoryRepository) TotalCourseCount(query models.CourseQuery) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return int64(len(r.find(query))), nil
+}
+
+// find is the in-memory equivalent of applyQuery, ordered by code, then term.
+func (r *memoryRepository) find(query models.CourseQuery) []models.Course {
+	search := strings.ToLower(query.Search)
+	courses := []models.Course{}
+	for _, entity := range r.courses {
+		if query.Department != "" && entity.Department != query.Department {
+			continue
+		}
+		if query.Term != "" && entity.Term != query.Term {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(entity.Code), search) &&
+			!strings.Contains(strings.ToLower(entity.Title), search) {
+			continue
+		}
+		courses = append(courses, *EntityToModel(&entity))
+	}
+	sort.Slice(courses, func(i, j int) bool {
+		if courses[i].Code != courses[j].Code {
+			return courses[i].Code < courses[j].Code
+		}
+		return courses[i].Term < courses[j].Term
+	})
+	return courses
+}
+
+// codeTaken reports whether another course than id has code in term, like the
+// unique index of the SQL table.
+func (r *memoryRepository) codeTaken(code string, term string, id uuid.UUID) bool {
+	for otherID, entity := range r.courses {
+		if otherID != id && entity.Code == code && entity.Term == term {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memoryRepository) Add(ctx context.Context, course *models.Course) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entity := ModelToEntity(course)
+	if _, exists := r.courses[entity.ID]; exists || r.codeTaken(entity.Code, entity.Term, entity.ID) {
+		return models.ErrCourseExists
+	}
+	r.courses[entity.ID] = *entity
+	return nil
+}
+
+func (r *memoryRepository) Update(ctx context.Context, course *models.Course) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entity := ModelToEntity(course)
+	if _, exists := r.courses[entity.ID]; !exists {
+		return models.ErrCourseNotFound
+	}
+	if r.codeTaken(entity.Code, entity.Term, entity.ID) {
+		return models.ErrCourseExists
+	}
+	r.courses[entity.ID] = *entity
+	return nil
+}
+
+func (r *memoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.courses[id]; !exists {
+		return models.ErrCourseNotFound
+	}
+	delete(r.courses, id)
+	return nil
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"backend/internal/course/models"
+	"backend/internal/database"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type courseRepository struct {
+	DB *gorm.DB
+}
+
+// NewCourseRepository stores courses in db, whose schema
+// schema.Migrate keeps up to date. db must be opened with TranslateError
+// for duplicate codes to be reported as such.
+func NewCourseRepository(db *gorm.DB) (*courseRepository, error) {
+	return &courseRepository{DB: db}, nil
+}
+
+func applyQuery(db *gorm.DB, query models.CourseQuery) *gorm.DB {
+	if query.Department != "" {
+		db = db.Where("department = ?", query.Department)
+	}
+	if query.Term != "" {
+		db = db.Where("term = ?", query.Term)
+	}
+	if query.Search != "" {
+		pattern := "%" + database.EscapeLike(strings.ToLower(query.Search)) + "%"
+		db = db.Where("(LOWER(code) LIKE ? ESCAPE '!' OR LOWER(title) LIKE ? ESCAPE '!')", pattern, pattern)
+	}
+	return db
+}
+
+func (r *courseRepository) Get(id uuid.UUID) (*models.Course, error) {
+	var entity models.CourseEntity
+	if err := r.DB.Where("id = ?", id).First(&entity).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return EntityToModel(&entity), nil
+}
+
+func (r *courseRepository) GetAll(query models.CourseQuery, page int, pageSize int) ([]models.Course, error) {
+	var entities []models.CourseEntity
+	offset := (page - 1) * pageSize
+	err := applyQuery(r.DB, query).Order("code").Order("term").Offset(offset).Limit(pageSize).Find(&entities).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	courses := []models.Course{}
+	for i := range entities {
+		courses = append(courses, *EntityToModel(&entities[i]))
+	}
+	return courses, nil
+}
+
+func (r *courseRepository) TotalCourseCount(query models.CourseQuery) (int64, error) {
+	var total int64
+	if err := applyQuery(r.DB.Model(&models.CourseEntity{}), query).Count(&total).Error; err != nil {
+		return 0, translateError(err)
+	}
+	return total, nil
+}
+
+func (r *courseRepository) Add(ctx context.Context, course *models.Course) error {
+	return translateError(r.DB.WithContext(ctx).Create(ModelToEntity(course)).Error)
+}
+
+func (r *courseRepository) Update(ctx context.Context, course *models.Course) error {
+	entity := ModelToEntity(course)
+	return translateError(r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.CourseEntity
+		err := tx.Where("id = ?", entity.ID).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ErrCourseNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Save(entity).Error
+	}))
+}
+
+// Delete removes the course. The foreign keys of the enrollments, gradebooks
+// and attendance records refuse to let a course they refer to go.
+func (r *courseRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.DB.WithContext(ctx).Where("id = ?", id).Delete(&models.CourseEntity{})
+	if database.ForeignKeyViolated(result.Error) {
+		return models.ErrCourseInUse
+	}
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrCourseNotFound
+	}
+	return nil
+}
+
+func ModelToEntity(course *models.Course) *models.CourseEntity {
+	return &models.CourseEntity{
+		ID:         uuid.MustParse(course.ID),
+		Code:       course.Code,
+		Title:      course.Title,
+		Credits:    course.Credits,
+		Department: course.Department,
+		Term:       course.Term,
+		Capacity:   course.Capacity,
+	}
+}
+
+func EntityToModel(entity *models.CourseEntity) *models.Course {
+	return &models.Course{
+		ID:         entity.ID.String(),
+		Code:       entity.Code,
+		Title:      entity.Title,
+		Credits:    entity.Credits,
+		Department: entity.Department,
+		Term:       entity.Term,
+		Capacity:   entity.Capacity,
+	}
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"backend/internal/course/models"
+	"backend/internal/course/services"
+	"backend/internal/database/databasetest"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSQLiteRepository(t *testing.T) services.Repository {
+	repo, err := NewCourseRepository(databasetest.Open(t).DB)
+	require.NoError(t, err)
+	return repo
+}
+
+// TestRepositories runs the same checks against every implementation.
+func TestRepositories(t *testing.T) {
+	databasetest.Run(t, map[string]func(t *testing.T) services.Repository{
+		"Memory": func(t *testing.T) services.Repository { return NewMemoryRepository() },
+		"SQLite": newSQLiteRepository,
+	}, map[string]func(t *testing.T, repo services.Repository){
+		"CRUD":          testCRUD,
+		"DuplicateCode": testDuplicateCode,
+		"Query":         testQuery,
+	})
+}
+
+func newCourse(code, title, department string) *models.Course {
+	return &models.Course{ID: uuid.New().String(), Code: code, Title: title, Credits: 4, Department: department, Capacity: 40}
+}
+
+func testCRUD(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	course := newCourse("CENG 242", "Programming Language Concepts", "Computer Engineering")
+	require.NoError(t, repo.Add(ctx, course))
+
+	found, err := repo.Get(uuid.MustParse(course.ID))
+	require.NoError(t, err)
+	assert.Equal(t, course, found)
+
+	course.Capacity = 80
+	require.NoError(t, repo.Update(ctx, course))
+	found, err = repo.Get(uuid.MustParse(course.ID))
+	require.NoError(t, err)
+	assert.Equal(t, 80, found.Capacity)
+
+	require.NoError(t, repo.Delete(ctx, uuid.MustParse(course.ID)))
+	_, err = repo.Get(uuid.MustParse(course.ID))
+	assert.ErrorIs(t, err, models.ErrCourseNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, uuid.MustParse(course.ID)), models.ErrCourseNotFound)
+	assert.ErrorIs(t, repo.Update(ctx, course), models.ErrCourseNotFound)
+}
+
+func testDuplicateCode(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	require.NoError(t, repo.Add(ctx, newCourse("CENG 242", "Programming Language Concepts", "Computer Engineering")))
+	other := newCourse("CENG 213", "Data Structures", "Computer Engineering")
+	require.NoError(t, repo.Add(ctx, other))
+
+	assert.ErrorIs(t, repo.Add(ctx, newCourse("CENG 242", "Another", "Computer Engineering")), models.ErrCourseExists)
+	other.Code = "CENG 242"
+	assert.ErrorIs(t, repo.Update(ctx, other), models.ErrCourseExists)
+
+	// A course may be given again in another term.
+	fall := newCourse("CENG 242", "Programming Language Concepts", "Computer Engineering")
+	fall.Term = "2026-fall"
+	require.NoError(t, repo.Add(ctx, fall))
+	found, err := repo.Get(uuid.MustParse(fall.ID))
+	require.NoError(t, err)
+	assert.Equal(t, "2026-fall", found.Term)
+	courses, err := repo.GetAll(models.CourseQuery{Term: "2026-fall"}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Course{*fall}, courses)
+}
+
+func testQuery(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	for _, course := range []*models.Course{
+		newCourse("MATH 119", "Calculus", "Mathematics"),
+		newCourse("CENG 242", "Programming Language Concepts", "Computer Engineering"),
+		newCourse("CENG 213", "Data Structures", "Computer Engineering"),
+	} {
+		require.NoError(t, repo.Add(ctx, course))
+	}
+	codes := func(query models.CourseQuery, page, pageSize int) []string {
+		courses, err := repo.GetAll(query, page, pageSize)
+		require.NoError(t, err)
+		codes := []string{}
+		for _, course := range courses {
+			codes = append(codes, course.Code)
+		}
+		return codes
+	}
+
+	assert.Equal(t, []string{"CENG 213", "CENG 242", "MATH 119"}, codes(models.CourseQuery{}, 1, 10))
+	assert.Equal(t, []string{"MATH 119"}, codes(models.CourseQuery{}, 2, 2))
+	assert.Equal(t, []string{}, codes(models.CourseQuery{}, 3, 2))
+	assert.Equal(t, []string{"CENG 213", "CENG 242"}, codes(models.CourseQuery{Department: "Computer Engineering"}, 1, 10))
+	assert.Equal(t, []string{"CENG 213"}, codes(models.CourseQuery{Search: "data"}, 1, 10))
+	assert.Equal(t, []string{"CENG 213", "CENG 242"}, codes(models.CourseQuery{Search: "ceng"}, 1, 10))
+
+	total, err := repo.TotalCourseCount(models.CourseQuery{Department: "Computer Engineering"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+}
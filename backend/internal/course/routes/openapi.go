@@ -0,0 +1,80 @@
+package routes
+
+import (
+	"backend/internal/auth"
+	"backend/internal/course/models"
+	"backend/internal/openapi"
+	"net/http"
+)
+
+var problemDescriptions = map[int]string{
+	http.StatusBadRequest:          "The request is invalid; errors lists the invalid fields",
+	http.StatusNotFound:            "No such course",
+	http.StatusConflict:            "Another course has this code in the same term, or the course is still in use",
+	http.StatusServiceUnavailable:  "The database is unavailable, try again later",
+	http.StatusInternalServerError: "Unexpected error",
+}
+
+// operation secures op with permission and documents the problems it may
+// answer with, besides the 503 and 500 every operation may.
+func operation(doc *openapi.Document, op *openapi.Operation, permission auth.Permission, problems ...int) *openapi.Operation {
+	op.Tags = []string{"courses"}
+	problems = append(problems, http.StatusServiceUnavailable, http.StatusInternalServerError)
+	for _, status := range problems {
+		op.Respond(status, doc.Problem(problemDescriptions[status]))
+	}
+	return auth.Secure(doc, op, permission)
+}
+
+// Describe documents the routes SetupRoutes registers.
+func Describe(doc *openapi.Document) {
+	doc.AddTag("courses", "The course catalog")
+
+	one := 1.0
+	id := openapi.Path("id", "The course ID", &openapi.Schema{Type: "string", Format: "uuid"})
+
+	doc.Add(http.MethodGet, "/courses", operation(doc, &openapi.Operation{
+		Summary:     "List courses",
+		Description: "Courses are ordered by code, then term.",
+		OperationID: "listCourses",
+		Parameters: []*openapi.Parameter{
+			openapi.Query("page", "The page number, starting at 1", &openapi.Schema{Type: "integer", Minimum: &one, Default: 1}),
+			openapi.Query("size", "The page size. The server's configured maximum applies", &openapi.Schema{Type: "integer", Minimum: &one}),
+			openapi.Query("department", "Only courses of this department", &openapi.Schema{Type: "string"}),
+			openapi.Query("term", "Only courses of this term, such as 2026-fall", &openapi.Schema{Type: "string"}),
+			openapi.Query("q", "Free-text search over the code and title", &openapi.Schema{Type: "string"}),
+		},
+		Responses: map[string]*openapi.Response{"200": doc.JSON(models.PaginationResponse{}, "A page of courses")},
+	}, auth.PermCoursesRead, http.StatusBadRequest))
+
+	doc.Add(http.MethodGet, "/courses/:id", operation(doc, &openapi.Operation{
+		Summary:     "Get a course",
+		OperationID: "getCourse",
+		Parameters:  []*openapi.Parameter{id},
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.Course{}, "The course")},
+	}, auth.PermCoursesRead, http.StatusBadRequest, http.StatusNotFound))
+
+	doc.Add(http.MethodPost, "/courses", operation(doc, &openapi.Operation{
+		Summary:     "Create a course",
+		Description: "Codes are upper cased with a space after the letters, so ceng242 is stored as CENG 242. The ID is generated.",
+		OperationID: "createCourse",
+		RequestBody: doc.Body(models.Course{}, ""),
+		Responses:   map[string]*openapi.Response{"201": doc.JSON(models.Course{}, "The created course")},
+	}, auth.PermCoursesWrite, http.StatusBadRequest, http.StatusConflict))
+
+	doc.Add(http.MethodPut, "/courses/:id", operation(doc, &openapi.Operation{
+		Summary:     "Replace a course",
+		OperationID: "updateCourse",
+		Parameters:  []*openapi.Parameter{id},
+		RequestBody: doc.Body(models.Course{}, "The id in the body is ignored"),
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.Course{}, "The updated course")},
+	}, auth.PermCoursesWrite, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict))
+
+	doc.Add(http.MethodDelete, "/courses/:id", operation(doc, &openapi.Operation{
+		Summary:     "Delete a course",
+		Description: "A course that still has enrollments, gradebook entries or attendance records cannot be deleted.",
+		OperationID: "deleteCourse",
+		Parameters:  []*openapi.Parameter{id},
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(openapi.Message{}, "The course was deleted")},
+	}, auth.PermCoursesWrite, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict))
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"backend/internal/auth"
+	"backend/internal/course/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupRoutes registers the course routes behind authenticate, each requiring the
+// permission it needs.
+func SetupRoutes(router *gin.Engine, courseController *controllers.CourseController, authenticate gin.HandlerFunc) {
+	courses := router.Group("/courses", authenticate)
+	courses.GET("", auth.Require(auth.PermCoursesRead), courseController.GetAll)
+	courses.GET("/:id", auth.Require(auth.PermCoursesRead), courseController.Get)
+	courses.POST("", auth.Require(auth.PermCoursesWrite), courseController.Add)
+	courses.PUT("/:id", auth.Require(auth.PermCoursesWrite), courseController.Update)
+	courses.DELETE("/:id", auth.Require(auth.PermCoursesWrite), courseController.Delete)
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"backend/internal/auth"
+	"backend/internal/auth/authtest"
+	"backend/internal/course/controllers"
+	"backend/internal/course/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func TestSetupRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Every route must be rejected before it reaches the service.
+	controller := controllers.Controller(mocks.NewMockCourseService(ctrl))
+	router := gin.New()
+	SetupRoutes(router, controller, authtest.AuthenticateAs(auth.PermStudentsRead))
+
+	authtest.AssertForbidden(t, router)
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"backend/internal/auth"
+	"backend/internal/course/models"
+	studentmodels "backend/internal/student/models"
+	"backend/internal/validation"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Get(id uuid.UUID) (*models.Course, error)
+	GetAll(query models.CourseQuery, page int, pageSize int) ([]models.Course, error)
+	TotalCourseCount(query models.CourseQuery) (int64, error)
+	Add(ctx context.Context, course *models.Course) error
+	Update(ctx context.Context, course *models.Course) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// Waitlists gives the free seats of a course to the students waiting for it.
+type Waitlists interface {
+	Promote(ctx context.Context, courseID uuid.UUID) error
+}
+
+type CourseService struct {
+	repository Repository
+	// Waitlists, when set, fills the seats that raising the capacity of a
+	// course frees.
+	Waitlists Waitlists
+}
+
+func Service(repository Repository) *CourseService {
+	return &CourseService{repository: repository}
+}
+
+func (s *CourseService) Get(ctx context.Context, id uuid.UUID) (*models.Course, error) {
+	if err := auth.Authorize(ctx, auth.PermCoursesRead); err != nil {
+		return nil, err
+	}
+	return s.repository.Get(id)
+}
+
+// GetAll returns a page of the courses matching query, ordered by code, then term.
+func (s *CourseService) GetAll(ctx context.Context, query models.CourseQuery, page int, pageSize int) (models.PaginationResponse, error) {
+	if err := auth.Authorize(ctx, auth.PermCoursesRead); err != nil {
+		return models.PaginationResponse{}, err
+	}
+	if page <= 0 || pageSize <= 0 {
+		return models.PaginationResponse{}, models.ErrInvalidPage
+	}
+	courses, err := s.repository.GetAll(query, page, pageSize)
+	if err != nil {
+		return models.PaginationResponse{}, err
+	}
+	total, err := s.repository.TotalCourseCount(query)
+	if err != nil {
+		return models.PaginationResponse{}, err
+	}
+
+	pages := (total + int64(pageSize) - 1) / int64(pageSize)
+	return models.PaginationResponse{
+		Courses: courses,
+		Page: studentmodels.Page{
+			Number:   page,
+			Size:     pageSize,
+			Elements: int(total),
+			Pages:    int(pages),
+		},
+	}, nil
+}
+
+func (s *CourseService) Add(ctx context.Context, course *models.Course) error {
+	if err := auth.Authorize(ctx, auth.PermCoursesWrite); err != nil {
+		return err
+	}
+	if err := validation.Validate(course); err != nil {
+		return err
+	}
+	course.ID = uuid.New().String()
+	return s.repository.Add(ctx, course)
+}
+
+// Update replaces every field of the course with the given id, and gives the
+// seats a raised capacity frees to its waitlist.
+func (s *CourseService) Update(ctx context.Context, id uuid.UUID, course *models.Course) error {
+	if err := auth.Authorize(ctx, auth.PermCoursesWrite); err != nil {
+		return err
+	}
+	if err := validation.Validate(course); err != nil {
+		return err
+	}
+	course.ID = id.String()
+	if err := s.repository.Update(ctx, course); err != nil {
+		return err
+	}
+	if s.Waitlists == nil {
+		return nil
+	}
+	return s.Waitlists.Promote(ctx, id)
+}
+
+func (s *CourseService) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := auth.Authorize(ctx, auth.PermCoursesWrite); err != nil {
+		return err
+	}
+	return s.repository.Delete(ctx, id)
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"backend/internal/auth"
+	"backend/internal/auth/authtest"
+	"backend/internal/course/mocks"
+	"backend/internal/course/models"
+	"backend/internal/problem"
+	studentmodels "backend/internal/student/models"
+	"context"
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := Service(repo)
+	query := models.CourseQuery{Department: "Computer Engineering"}
+
+	t.Run("Success", func(t *testing.T) {
+		courses := []models.Course{{ID: uuid.New().String(), Code: "CENG 242"}}
+		repo.EXPECT().GetAll(query, 2, 10).Return(courses, nil)
+		repo.EXPECT().TotalCourseCount(query).Return(int64(11), nil)
+
+		response, err := service.GetAll(authtest.AdminContext, query, 2, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, models.PaginationResponse{
+			Courses: courses,
+			Page:    studentmodels.Page{Number: 2, Size: 10, Elements: 11, Pages: 2},
+		}, response)
+	})
+
+	t.Run("Invalid Page", func(t *testing.T) {
+		_, err := service.GetAll(authtest.AdminContext, query, 0, 10)
+		assert.ErrorIs(t, err, models.ErrInvalidPage)
+	})
+}
+
+func TestAdd(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := Service(repo)
+
+	t.Run("Success", func(t *testing.T) {
+		course := &models.Course{Code: "ceng242", Title: " Programming  Language Concepts ", Credits: 4, Department: "Computer Engineering", Capacity: 60}
+		repo.EXPECT().Add(gomock.Any(), course).Return(nil)
+
+		assert.NoError(t, service.Add(authtest.AdminContext, course))
+		assert.NotEmpty(t, course.ID)
+		assert.Equal(t, "CENG 242", course.Code)
+		assert.Equal(t, "Programming Language Concepts", course.Title)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		err := service.Add(authtest.AdminContext, &models.Course{Code: "242"})
+		assert.ErrorIs(t, err, problem.ErrValidation)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		course := &models.Course{Code: "CENG 242", Title: "Programming Language Concepts", Credits: 4, Department: "Computer Engineering", Capacity: 60}
+		repo.EXPECT().Add(gomock.Any(), course).Return(models.ErrCourseExists)
+
+		assert.ErrorIs(t, service.Add(authtest.AdminContext, course), problem.ErrConflict)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	waitlists := mocks.NewMockWaitlists(ctrl)
+	service := Service(repo)
+	service.Waitlists = waitlists
+
+	id := uuid.New()
+	course := &models.Course{ID: uuid.New().String(), Code: "CENG 242", Title: "Programming Language Concepts", Credits: 4, Department: "Computer Engineering", Capacity: 60}
+	repo.EXPECT().Update(gomock.Any(), course).Return(nil)
+	waitlists.EXPECT().Promote(gomock.Any(), id).Return(nil)
+
+	assert.NoError(t, service.Update(authtest.AdminContext, id, course))
+	assert.Equal(t, id.String(), course.ID, "the id of the body is ignored")
+
+	// The waitlist is left alone when the course is not updated.
+	repo.EXPECT().Update(gomock.Any(), course).Return(models.ErrCourseNotFound)
+	assert.ErrorIs(t, service.Update(authtest.AdminContext, id, course), models.ErrCourseNotFound)
+}
+
+func TestAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The repository mock fails the test if any of these reach it.
+	service := Service(mocks.NewMockRepository(ctrl))
+	teacher := auth.WithPrincipal(context.Background(), &auth.Principal{Username: "teacher", Permissions: []auth.Permission{auth.PermStudentsRead}})
+
+	_, err := service.Get(teacher, uuid.New())
+	assert.Equal(t, &auth.ForbiddenError{Permission: auth.PermCoursesRead}, err)
+	_, err = service.GetAll(teacher, models.CourseQuery{}, 1, 10)
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	assert.Equal(t, &auth.ForbiddenError{Permission: auth.PermCoursesWrite}, service.Add(teacher, &models.Course{}))
+	assert.ErrorIs(t, service.Update(teacher, uuid.New(), &models.Course{}), auth.ErrForbidden)
+	assert.ErrorIs(t, service.Delete(teacher, uuid.New()), auth.ErrForbidden)
+	assert.ErrorIs(t, service.Delete(context.Background(), uuid.New()), auth.ErrUnauthenticated)
+}
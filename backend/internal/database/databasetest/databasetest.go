@@ -0,0 +1,79 @@
+// Package databasetest provides utilities for testing the SQL repositories
+// against the database the server opens.
+package databasetest
+
+import (
+	"backend/internal/config"
+	coursemodels "backend/internal/course/models"
+	studentmodels "backend/internal/student/models"
+	studentrepository "backend/internal/student/repository"
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Open opens an in-memory SQLite store the way the server does, with foreign
+// keys enforced and the schema migrated, and closes it when the test ends.
+func Open(t *testing.T) *studentrepository.Store {
+	t.Helper()
+	store, err := studentrepository.Open(config.Database{DSN: "sqlite://:memory:"}, logger.Silent)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		sqlDB, _ := store.DB.DB()
+		sqlDB.Close()
+	})
+	return store
+}
+
+// AddStudent stores a student for rows to refer to, and returns its ID. Given
+// no db, for the memory repositories, which look up no students, it only makes
+// up the ID.
+func AddStudent(t *testing.T, db *gorm.DB) uuid.UUID {
+	t.Helper()
+	id := uuid.New()
+	if db == nil {
+		return id
+	}
+	require.NoError(t, db.Create(&studentmodels.StudentEntity{ID: id, Name: "Ada", Surname: "Lovelace", Status: string(studentmodels.StatusEnrolled)}).Error)
+	return id
+}
+
+// AddCourse stores a course for rows to refer to, and returns its ID. Like
+// AddStudent, it only makes up the ID given no db.
+func AddCourse(t *testing.T, db *gorm.DB) uuid.UUID {
+	t.Helper()
+	id := uuid.New()
+	if db == nil {
+		return id
+	}
+	course := &coursemodels.CourseEntity{ID: id, Code: id.String()[:8], Title: "Analytical Engines", Credits: 4, Capacity: 30}
+	require.NoError(t, db.Create(course).Error)
+	return id
+}
+
+// Run runs every check against a new fixture of every kind, in subtests named
+// after both. A check skips the kinds it does not apply to.
+func Run[F any](t *testing.T, fixtures map[string]func(t *testing.T) F, checks map[string]func(t *testing.T, f F)) {
+	for _, kind := range sortedKeys(fixtures) {
+		newFixture := fixtures[kind]
+		t.Run(kind, func(t *testing.T) {
+			for _, name := range sortedKeys(checks) {
+				check := checks[name]
+				t.Run(name, func(t *testing.T) { check(t, newFixture(t)) })
+			}
+		})
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
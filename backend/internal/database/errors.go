@@ -0,0 +1,68 @@
+// Package database holds what the repositories of every subsystem share about
+// talking to the SQL database behind them.
+package database
+
+import (
+	"backend/internal/problem"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/gorm"
+)
+
+// TranslateError maps gorm and database driver errors to the problem kinds the
+// services and controllers understand: a missing record to notFound, a
+// duplicate key to conflict and an unreachable database to an
+// UnavailableError. A nil notFound or conflict leaves those errors as they
+// are. Duplicate keys are only recognised when the DB was opened with
+// TranslateError.
+func TranslateError(err error, notFound error, conflict error) error {
+	switch {
+	case err == nil:
+		return nil
+	case notFound != nil && errors.Is(err, gorm.ErrRecordNotFound):
+		return notFound
+	case conflict != nil && errors.Is(err, gorm.ErrDuplicatedKey):
+		return conflict
+	case Unavailable(err):
+		return &problem.UnavailableError{Err: err}
+	}
+	return err
+}
+
+// ForeignKeyViolated reports whether err means a row could not be deleted or
+// changed because another row refers to it. gorm does not translate this error
+// for MySQL and PostgreSQL, and SQLite reports it without the extended code
+// gorm looks for, so each driver's error is checked here.
+func ForeignKeyViolated(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1451 || mysqlErr.Number == 1452
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "23503"
+	}
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrConstraint && strings.HasPrefix(sqliteErr.Error(), "FOREIGN KEY")
+	}
+	return errors.Is(err, gorm.ErrForeignKeyViolated)
+}
+
+// Unavailable reports whether err means the database could not be reached, as
+// opposed to rejecting the query.
+func Unavailable(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.As(err, &netErr)
+}
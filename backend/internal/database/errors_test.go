@@ -0,0 +1,48 @@
+package database
+
+import (
+	"backend/internal/problem"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestTranslateError(t *testing.T) {
+	notFound := &problem.NotFoundError{Detail: "thing not found"}
+	conflict := &problem.ConflictError{Detail: "thing exists"}
+
+	assert.NoError(t, TranslateError(nil, notFound, conflict))
+	assert.Equal(t, notFound, TranslateError(gorm.ErrRecordNotFound, notFound, conflict))
+	assert.Equal(t, conflict, TranslateError(fmt.Errorf("insert: %w", gorm.ErrDuplicatedKey), notFound, conflict))
+	assert.Equal(t, gorm.ErrRecordNotFound, TranslateError(gorm.ErrRecordNotFound, nil, conflict), "without notFound the error is kept")
+
+	for _, err := range []error{
+		driver.ErrBadConn,
+		&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+	} {
+		translated := TranslateError(err, notFound, conflict)
+		assert.ErrorIs(t, translated, problem.ErrUnavailable)
+		assert.ErrorIs(t, translated, err, "the cause is kept")
+	}
+
+	other := errors.New("syntax error")
+	assert.Equal(t, other, TranslateError(other, notFound, conflict))
+}
+
+func TestForeignKeyViolated(t *testing.T) {
+	assert.True(t, ForeignKeyViolated(fmt.Errorf("delete: %w", gorm.ErrForeignKeyViolated)))
+	assert.True(t, ForeignKeyViolated(&mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row"}))
+	assert.True(t, ForeignKeyViolated(&pgconn.PgError{Code: "23503"}))
+
+	assert.False(t, ForeignKeyViolated(nil))
+	assert.False(t, ForeignKeyViolated(&mysql.MySQLError{Number: 1062}))
+	assert.False(t, ForeignKeyViolated(&pgconn.PgError{Code: "23505"}))
+	assert.False(t, ForeignKeyViolated(gorm.ErrDuplicatedKey))
+}
@@ -0,0 +1,13 @@
+package database
+
+import "strings"
+
+// likeEscaper escapes LIKE wildcards with '!', which, unlike '\', means the same
+// thing in MySQL, PostgreSQL and SQLite string literals.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// EscapeLike escapes the wildcards in s, so that it only matches itself in a
+// LIKE pattern that declares ESCAPE '!'.
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
@@ -0,0 +1,12 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeLike(t *testing.T) {
+	assert.Equal(t, "ceng", EscapeLike("ceng"))
+	assert.Equal(t, "100!% !_done!!", EscapeLike("100% _done!"))
+}
@@ -0,0 +1,395 @@
+// Package schema migrates the SQL database the repositories of every subsystem
+// share.
+package schema
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// migration changes the schema from the previous version to version. Its up
+// function works on frozen copies of the entities rather than on the models,
+// so that it keeps doing the same thing however the models change later.
+type migration struct {
+	version int
+	name    string
+	up      func(tx *gorm.DB) error
+}
+
+// migrations are applied in order, each at most once. Append new ones; never
+// edit or reorder those that have shipped.
+var migrations = []migration{
+	{1, "create students and audit entries", createStudents},
+	{2, "add student profiles", addStudentProfiles},
+	{3, "create student transitions", createTransitions},
+	{4, "create courses", createCourses},
+	{5, "create enrollments", createEnrollments},
+	{6, "create gradebooks", createGradebooks},
+	{7, "create attendance records", createAttendance},
+	{8, "create guardians", createGuardians},
+	{9, "create users and refresh tokens", createUsers},
+	{10, "create API keys", createAPIKeys},
+}
+
+// schemaMigration records an applied migration.
+type schemaMigration struct {
+	Version   int `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrate brings the schema of db up to date, applying every migration that has
+// not been applied yet in its own transaction. MySQL commits schema changes at
+// once, so migrations must be safe to run again after failing halfway.
+func Migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		return err
+	}
+	var applied []int
+	if err := db.Model(&schemaMigration{}).Pluck("version", &applied).Error; err != nil {
+		return err
+	}
+	done := map[int]bool{}
+	for _, version := range applied {
+		done[version] = true
+	}
+
+	for _, m := range migrations {
+		if done[m.version] {
+			continue
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{Version: m.version, Name: m.name, AppliedAt: time.Now().UTC()}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d (%s): %w", m.version, m.name, err)
+		}
+	}
+	return nil
+}
+
+// The entities as migration 1 left them.
+type studentV1 struct {
+	ID        uuid.UUID `gorm:"primary_key;type:char(36)"`
+	Name      string
+	Surname   string
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+type auditEntryV1 struct {
+	ID         uint64    `gorm:"primaryKey"`
+	EntityType string    `gorm:"size:32;index:idx_audit_entity"`
+	EntityID   string    `gorm:"size:36;index:idx_audit_entity"`
+	Action     string    `gorm:"size:16;index"`
+	Actor      string    `gorm:"size:255;index"`
+	RequestID  string    `gorm:"size:64"`
+	At         time.Time `gorm:"index"`
+	Before     string    `gorm:"type:text"`
+	After      string    `gorm:"type:text"`
+}
+
+func (studentV1) TableName() string    { return "students" }
+func (auditEntryV1) TableName() string { return "audit_entries" }
+
+// createStudents creates the tables that used to be auto-migrated, and leaves
+// databases created back then as they are.
+func createStudents(tx *gorm.DB) error {
+	return tx.AutoMigrate(&studentV1{}, &auditEntryV1{})
+}
+
+// The entities as migration 2 left them. Only the columns it adds to students
+// are listed. SQLite cannot add unique columns, so the unique indexes are
+// created once the columns exist.
+type studentV2 struct {
+	ID             uuid.UUID `gorm:"primary_key;type:char(36)"`
+	StudentNumber  *string   `gorm:"size:16"`
+	Status         string    `gorm:"size:16;not null;default:'';index"`
+	EnrollmentDate string    `gorm:"size:10;not null;default:''"`
+	DateOfBirth    string    `gorm:"size:10;not null;default:''"`
+	Email          *string   `gorm:"size:254"`
+	Phone          string    `gorm:"size:16;not null;default:''"`
+}
+
+type studentV2Indexes struct {
+	StudentNumber *string `gorm:"size:16;uniqueIndex:idx_students_student_number"`
+	Email         *string `gorm:"size:254;uniqueIndex:idx_students_email"`
+}
+
+type addressV2 struct {
+	ID         uint      `gorm:"primaryKey"`
+	StudentID  uuid.UUID `gorm:"type:char(36);index"`
+	Position   int
+	Kind       string `gorm:"size:16"`
+	Line1      string `gorm:"size:200"`
+	Line2      string `gorm:"size:200"`
+	City       string `gorm:"size:100"`
+	Region     string `gorm:"size:100"`
+	PostalCode string `gorm:"size:20"`
+	Country    string `gorm:"size:100"`
+}
+
+type sequenceV2 struct {
+	Year  int `gorm:"primaryKey;autoIncrement:false"`
+	Value int
+}
+
+func (studentV2) TableName() string        { return "students" }
+func (studentV2Indexes) TableName() string { return "students" }
+func (addressV2) TableName() string        { return "student_addresses" }
+func (sequenceV2) TableName() string       { return "student_sequences" }
+
+// addStudentProfiles adds the contact details, addresses, status and number of
+// students. Existing students become enrolled and are numbered in the current
+// year, in the order of their IDs.
+func addStudentProfiles(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&studentV2{}, &addressV2{}, &sequenceV2{}); err != nil {
+		return err
+	}
+	if err := tx.Model(&studentV2{}).Where("status = ''").Update("status", "enrolled").Error; err != nil {
+		return err
+	}
+	var ids []uuid.UUID
+	err := tx.Model(&studentV2{}).Where("student_number IS NULL").Order("id").Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+	year := time.Now().Year()
+	for _, id := range ids {
+		number, err := nextStudentNumber(tx, year)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&studentV2{}).Where("id = ?", id).Update("student_number", number).Error; err != nil {
+			return err
+		}
+	}
+	for _, index := range []string{"idx_students_student_number", "idx_students_email"} {
+		if tx.Migrator().HasIndex(&studentV2Indexes{}, index) {
+			continue
+		}
+		if err := tx.Migrator().CreateIndex(&studentV2Indexes{}, index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextStudentNumber takes the next number of year from its sequence, the way
+// the student repository did when migration 2 was written.
+func nextStudentNumber(tx *gorm.DB, year int) (string, error) {
+	sequences := func() *gorm.DB { return tx.Table("student_sequences") }
+	err := sequences().Clauses(clause.OnConflict{DoNothing: true}).
+		Create(map[string]interface{}{"year": year, "value": 0}).Error
+	if err != nil {
+		return "", err
+	}
+	err = sequences().Where("year = ?", year).Update("value", gorm.Expr("value + 1")).Error
+	if err != nil {
+		return "", err
+	}
+	var value int
+	if err := sequences().Where("year = ?", year).Select("value").Scan(&value).Error; err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d-%06d", year, value), nil
+}
+
+// The entity as migration 3 left it.
+type transitionV3 struct {
+	ID        uint64    `gorm:"primaryKey"`
+	StudentID uuid.UUID `gorm:"type:char(36);index"`
+	From      string    `gorm:"column:from_status;size:16"`
+	To        string    `gorm:"column:to_status;size:16"`
+	Reason    string    `gorm:"size:500"`
+	Actor     string    `gorm:"size:255"`
+	At        time.Time
+}
+
+func (transitionV3) TableName() string { return "student_transitions" }
+
+// createTransitions creates the history of student statuses. Existing students
+// start without one.
+func createTransitions(tx *gorm.DB) error {
+	return tx.AutoMigrate(&transitionV3{})
+}
+
+// The entity as migration 4 left it.
+type courseV4 struct {
+	ID         uuid.UUID `gorm:"primary_key;type:char(36)"`
+	Code       string    `gorm:"size:20;uniqueIndex:idx_courses_code_term"`
+	Title      string    `gorm:"size:200"`
+	Credits    int
+	Department string `gorm:"size:100;index"`
+	Term       string `gorm:"size:20;uniqueIndex:idx_courses_code_term"`
+	Capacity   int
+}
+
+func (courseV4) TableName() string { return "courses" }
+
+func createCourses(tx *gorm.DB) error {
+	return tx.AutoMigrate(&courseV4{})
+}
+
+// studentRef is the students table as far as the rows that refer to students
+// need it. Migrations constrain those rows with it rather than with a version
+// of the students, so that the columns of that version are left alone.
+type studentRef struct {
+	ID uuid.UUID `gorm:"primary_key;type:char(36)"`
+}
+
+func (studentRef) TableName() string { return "students" }
+
+// The entity as migration 5 left it. Courses and students cannot be deleted
+// while rows of this or the later migrations refer to them.
+type enrollmentV5 struct {
+	ID          uuid.UUID `gorm:"primary_key;type:char(36)"`
+	StudentID   uuid.UUID `gorm:"type:char(36);uniqueIndex:idx_enrollments_student_course"`
+	CourseID    uuid.UUID `gorm:"type:char(36);uniqueIndex:idx_enrollments_student_course;index:idx_enrollments_course_status"`
+	Status      string    `gorm:"size:20;index:idx_enrollments_course_status"`
+	RequestedAt time.Time
+	EnrolledAt  *time.Time
+	Student     studentRef `gorm:"constraint:OnDelete:RESTRICT"`
+	Course      courseV4   `gorm:"constraint:OnDelete:RESTRICT"`
+}
+
+func (enrollmentV5) TableName() string { return "enrollments" }
+
+func createEnrollments(tx *gorm.DB) error {
+	return tx.AutoMigrate(&enrollmentV5{})
+}
+
+// The entities as migration 6 left them.
+type gradeCategoryV6 struct {
+	ID       uuid.UUID `gorm:"primary_key;type:char(36)"`
+	CourseID uuid.UUID `gorm:"type:char(36);uniqueIndex:idx_grade_categories_course_name"`
+	Name     string    `gorm:"size:50;uniqueIndex:idx_grade_categories_course_name"`
+	Weight   int
+	Course   courseV4 `gorm:"constraint:OnDelete:RESTRICT"`
+}
+
+type assessmentV6 struct {
+	ID         uuid.UUID `gorm:"primary_key;type:char(36)"`
+	StudentID  uuid.UUID `gorm:"type:char(36);index:idx_assessments_student_course"`
+	CourseID   uuid.UUID `gorm:"type:char(36);index:idx_assessments_student_course;index:idx_assessments_course_category"`
+	Category   string    `gorm:"size:50;index:idx_assessments_course_category"`
+	Title      string    `gorm:"size:100"`
+	Score      float64
+	MaxScore   float64
+	RecordedAt time.Time
+	Student    studentRef `gorm:"constraint:OnDelete:RESTRICT"`
+	Course     courseV4   `gorm:"constraint:OnDelete:RESTRICT"`
+}
+
+func (gradeCategoryV6) TableName() string { return "grade_categories" }
+func (assessmentV6) TableName() string    { return "assessments" }
+
+func createGradebooks(tx *gorm.DB) error {
+	return tx.AutoMigrate(&gradeCategoryV6{}, &assessmentV6{})
+}
+
+// The entity as migration 7 left it.
+type attendanceRecordV7 struct {
+	ID         uuid.UUID `gorm:"primary_key;type:char(36)"`
+	StudentID  uuid.UUID `gorm:"type:char(36);uniqueIndex:idx_attendance_student_session"`
+	CourseID   uuid.UUID `gorm:"type:char(36);uniqueIndex:idx_attendance_student_session;index:idx_attendance_course_date"`
+	Date       string    `gorm:"size:10;uniqueIndex:idx_attendance_student_session;index:idx_attendance_course_date"`
+	Session    int       `gorm:"uniqueIndex:idx_attendance_student_session"`
+	Status     string    `gorm:"size:20"`
+	Note       string    `gorm:"size:200"`
+	RecordedAt time.Time
+	Student    studentRef `gorm:"constraint:OnDelete:RESTRICT"`
+	Course     courseV4   `gorm:"constraint:OnDelete:RESTRICT"`
+}
+
+func (attendanceRecordV7) TableName() string { return "attendance_records" }
+
+func createAttendance(tx *gorm.DB) error {
+	return tx.AutoMigrate(&attendanceRecordV7{})
+}
+
+// The entities as migration 8 left them.
+type guardianV8 struct {
+	ID        uuid.UUID `gorm:"primary_key;type:char(36)"`
+	Name      string    `gorm:"size:100"`
+	Surname   string    `gorm:"size:100"`
+	Email     string    `gorm:"size:254"`
+	Phone     string    `gorm:"size:16"`
+	WorkPhone string    `gorm:"size:16"`
+}
+
+type studentGuardianV8 struct {
+	StudentID    uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	GuardianID   uuid.UUID `gorm:"primaryKey;type:char(36);index"`
+	Relationship string    `gorm:"size:20"`
+	Custody      bool
+	Pickup       bool
+	Priority     int
+	Student      studentRef `gorm:"constraint:OnDelete:RESTRICT"`
+	Guardian     guardianV8 `gorm:"constraint:OnDelete:RESTRICT"`
+}
+
+func (guardianV8) TableName() string        { return "guardians" }
+func (studentGuardianV8) TableName() string { return "student_guardians" }
+
+func createGuardians(tx *gorm.DB) error {
+	return tx.AutoMigrate(&guardianV8{}, &studentGuardianV8{})
+}
+
+// The entities as migration 9 left them.
+type userV9 struct {
+	ID           uuid.UUID `gorm:"primary_key;type:char(36)"`
+	Username     string    `gorm:"size:255;uniqueIndex"`
+	PasswordHash string    `gorm:"size:255"`
+	Roles        string    `gorm:"type:text"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type refreshTokenV9 struct {
+	ID        uuid.UUID  `gorm:"primary_key;type:char(36)"`
+	Hash      string     `gorm:"size:64;uniqueIndex"`
+	UserID    uuid.UUID  `gorm:"type:char(36);index"`
+	ExpiresAt time.Time  `gorm:"index"`
+	RevokedAt *time.Time `gorm:"index"`
+	CreatedAt time.Time
+}
+
+func (userV9) TableName() string         { return "users" }
+func (refreshTokenV9) TableName() string { return "refresh_tokens" }
+
+// createUsers creates the tables that used to be auto-migrated by the auth
+// repository, and leaves those it created as they are.
+func createUsers(tx *gorm.DB) error {
+	return tx.AutoMigrate(&userV9{}, &refreshTokenV9{})
+}
+
+// The entity as migration 10 left it.
+type apiKeyV10 struct {
+	ID         uuid.UUID `gorm:"primary_key;type:char(36)"`
+	Name       string    `gorm:"size:255"`
+	Prefix     string    `gorm:"size:16"`
+	Hash       string    `gorm:"size:64;uniqueIndex"`
+	Scopes     string    `gorm:"type:text"`
+	CreatedBy  string    `gorm:"size:255"`
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time `gorm:"index"`
+}
+
+func (apiKeyV10) TableName() string { return "api_keys" }
+
+func createAPIKeys(tx *gorm.DB) error {
+	return tx.AutoMigrate(&apiKeyV10{})
+}
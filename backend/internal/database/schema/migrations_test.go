@@ -0,0 +1,72 @@
+package schema
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestMigrate(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "students.db")), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	})
+
+	// A database the students table was auto-migrated into before migrations
+	// were versioned.
+	require.NoError(t, db.AutoMigrate(&studentV1{}, &auditEntryV1{}))
+	ids := []uuid.UUID{uuid.MustParse("00000000-0000-0000-0000-000000000002"), uuid.MustParse("00000000-0000-0000-0000-000000000001")}
+	for _, id := range ids {
+		require.NoError(t, db.Create(&studentV1{ID: id, Name: "Hasan", Surname: "Hüseyin"}).Error)
+	}
+
+	require.NoError(t, Migrate(db))
+	require.NoError(t, Migrate(db), "migrating again does nothing")
+
+	for _, table := range []string{"courses", "enrollments", "grade_categories", "assessments", "attendance_records", "guardians", "student_guardians", "users", "refresh_tokens", "api_keys"} {
+		assert.True(t, db.Migrator().HasTable(table), table)
+	}
+	assert.True(t, db.Migrator().HasIndex(&courseV4{}, "idx_courses_code_term"))
+	for _, referrer := range []interface{}{&enrollmentV5{}, &gradeCategoryV6{}, &assessmentV6{}, &attendanceRecordV7{}} {
+		assert.True(t, db.Migrator().HasConstraint(referrer, "Course"), "rows refer to existing courses")
+	}
+	for _, referrer := range []interface{}{&enrollmentV5{}, &assessmentV6{}, &attendanceRecordV7{}, &studentGuardianV8{}} {
+		assert.True(t, db.Migrator().HasConstraint(referrer, "Student"), "rows refer to existing students")
+	}
+	assert.True(t, db.Migrator().HasConstraint(&studentGuardianV8{}, "Guardian"), "links refer to existing guardians")
+
+	var applied []schemaMigration
+	require.NoError(t, db.Order("version").Find(&applied).Error)
+	require.Len(t, applied, len(migrations))
+	for i, m := range migrations {
+		assert.Equal(t, m.version, applied[i].Version)
+		assert.Equal(t, m.name, applied[i].Name)
+	}
+
+	year := time.Now().Year()
+	for i, id := range []uuid.UUID{ids[1], ids[0]} {
+		var student studentV2
+		require.NoError(t, db.Select("status", "student_number").First(&student, "id = ?", id).Error)
+		assert.Equal(t, "enrolled", student.Status, "existing students are enrolled")
+		require.NotNil(t, student.StudentNumber)
+		assert.Equal(t, fmt.Sprintf("%d-%06d", year, i+1), *student.StudentNumber, "existing students are numbered in the order of their IDs")
+	}
+
+	var taken studentV2
+	require.NoError(t, db.First(&taken, "id = ?", ids[0]).Error)
+	duplicate := &studentV2{ID: uuid.New(), StudentNumber: taken.StudentNumber}
+	assert.ErrorIs(t, db.Create(duplicate).Error, gorm.ErrDuplicatedKey, "student numbers are unique")
+}
@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"backend/internal/enrollment/models"
+	"backend/internal/problem"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type EnrollmentService interface {
+	Enroll(ctx context.Context, studentID uuid.UUID, courseID uuid.UUID) (*models.Enrollment, error)
+	Drop(ctx context.Context, studentID uuid.UUID, courseID uuid.UUID) error
+	GetByStudent(ctx context.Context, studentID uuid.UUID, query models.EnrollmentQuery) (models.EnrollmentList, error)
+	GetRoster(ctx context.Context, courseID uuid.UUID, query models.EnrollmentQuery) (models.EnrollmentList, error)
+}
+
+var (
+	errInvalidID       = &problem.ValidationError{Detail: "invalid UUID", Fields: []problem.FieldError{{Field: "id", Code: "uuid", Message: "must be a UUID"}}}
+	errInvalidCourseID = &problem.ValidationError{Detail: "invalid UUID", Fields: []problem.FieldError{{Field: "course_id", Code: "uuid", Message: "must be a UUID"}}}
+	// errInvalidRequestCourseID names the field of the body, not the path.
+	errInvalidRequestCourseID = &problem.ValidationError{Detail: "invalid UUID", Fields: []problem.FieldError{{Field: "courseId", Code: "uuid", Message: "must be a UUID"}}}
+	errInvalidRequest         = &problem.ValidationError{Detail: "invalid request"}
+)
+
+type EnrollmentController struct {
+	Service EnrollmentService
+}
+
+func Controller(service EnrollmentService) *EnrollmentController {
+	return &EnrollmentController{Service: service}
+}
+
+// Enroll answers 201 with the enrollment, whose status tells whether the
+// student got a seat or a place on the waitlist.
+func (c *EnrollmentController) Enroll(ctx *gin.Context) {
+	studentID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidID)
+		return
+	}
+
+	var request models.EnrollmentRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errInvalidRequest)
+		return
+	}
+	courseID, err := uuid.Parse(request.CourseID)
+	if err != nil {
+		ctx.Error(errInvalidRequestCourseID)
+		return
+	}
+
+	enrollment, err := c.Service.Enroll(ctx.Request.Context(), studentID, courseID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, enrollment)
+}
+
+func (c *EnrollmentController) Drop(ctx *gin.Context) {
+	studentID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidID)
+		return
+	}
+	courseID, err := uuid.Parse(ctx.Param("course_id"))
+	if err != nil {
+		ctx.Error(errInvalidCourseID)
+		return
+	}
+
+	if err := c.Service.Drop(ctx.Request.Context(), studentID, courseID); err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Enrollment dropped successfully"})
+}
+
+// GetByStudent lists the enrollments of the student, optionally filtered by
+// status.
+func (c *EnrollmentController) GetByStudent(ctx *gin.Context) {
+	c.list(ctx, c.Service.GetByStudent)
+}
+
+// GetRoster lists the enrollments of the course, optionally filtered by
+// status.
+func (c *EnrollmentController) GetRoster(ctx *gin.Context) {
+	c.list(ctx, c.Service.GetRoster)
+}
+
+func (c *EnrollmentController) list(ctx *gin.Context, get func(context.Context, uuid.UUID, models.EnrollmentQuery) (models.EnrollmentList, error)) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidID)
+		return
+	}
+	query, err := models.ParseEnrollmentQuery(ctx.Request.URL.Query())
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	list, err := get(ctx.Request.Context(), id, query)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, list)
+}
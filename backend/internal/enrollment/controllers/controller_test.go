@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"backend/internal/apitest"
+	coursemodels "backend/internal/course/models"
+	"backend/internal/enrollment/mocks"
+	"backend/internal/enrollment/models"
+	"backend/internal/problem"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRouter(controller *EnrollmentController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(problem.Middleware())
+	router.GET("/students/:id/enrollments", controller.GetByStudent)
+	router.POST("/students/:id/enrollments", controller.Enroll)
+	router.DELETE("/students/:id/enrollments/:course_id", controller.Drop)
+	router.GET("/courses/:id/roster", controller.GetRoster)
+	return router
+}
+
+var (
+	studentID  = uuid.MustParse("7995c72f-7d04-4136-8b5f-000d6d4aae23")
+	courseID   = uuid.MustParse("0b6f4b4e-53f4-4f3c-9a36-8d2b8c7c1f10")
+	enrollment = models.Enrollment{
+		ID:          "5d0b6a43-0c1e-4d7e-b0a4-1f1f0e7a6c55",
+		StudentID:   studentID.String(),
+		CourseID:    courseID.String(),
+		Status:      models.StatusWaitlisted,
+		Position:    2,
+		RequestedAt: time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC),
+	}
+)
+
+func TestEnroll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockEnrollmentService(ctrl)
+	router := newRouter(Controller(mockService))
+	url := "/students/" + studentID.String() + "/enrollments"
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().Enroll(gomock.Any(), studentID, courseID).Return(&enrollment, nil)
+
+		w := apitest.Request(router, http.MethodPost, url, []byte(`{"courseId": "`+courseID.String()+`"}`))
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"studentId":"`+studentID.String()+`","courseId":"`+courseID.String()+`"`)
+		assert.Contains(t, w.Body.String(), `"requestedAt":"2026-09-01T09:00:00Z"`)
+		var actual models.Enrollment
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+		assert.Equal(t, enrollment, actual)
+	})
+
+	t.Run("Already Enrolled", func(t *testing.T) {
+		mockService.EXPECT().Enroll(gomock.Any(), studentID, courseID).Return(nil, models.ErrAlreadyEnrolled)
+
+		w := apitest.Request(router, http.MethodPost, url, []byte(`{"courseId": "`+courseID.String()+`"}`))
+		apitest.AssertProblem(t, w, http.StatusConflict, models.ErrAlreadyEnrolled.Detail)
+	})
+
+	t.Run("Unknown Course", func(t *testing.T) {
+		mockService.EXPECT().Enroll(gomock.Any(), studentID, courseID).Return(nil, coursemodels.ErrCourseNotFound)
+
+		w := apitest.Request(router, http.MethodPost, url, []byte(`{"courseId": "`+courseID.String()+`"}`))
+		apitest.AssertProblem(t, w, http.StatusNotFound, coursemodels.ErrCourseNotFound.Detail)
+	})
+
+	t.Run("Invalid Course ID", func(t *testing.T) {
+		w := apitest.Request(router, http.MethodPost, url, []byte(`{"courseId": "CENG 242"}`))
+		apitest.AssertProblem(t, w, http.StatusBadRequest, errInvalidRequestCourseID.Detail)
+		assert.Contains(t, w.Body.String(), `{"field":"courseId","code":"uuid","message":"must be a UUID"}`)
+	})
+
+	t.Run("Invalid Body", func(t *testing.T) {
+		w := apitest.Request(router, http.MethodPost, url, []byte(`{`))
+		apitest.AssertProblem(t, w, http.StatusBadRequest, errInvalidRequest.Detail)
+	})
+
+	t.Run("Invalid Student ID", func(t *testing.T) {
+		w := apitest.Request(router, http.MethodPost, "/students/1/enrollments", []byte(`{"courseId": "`+courseID.String()+`"}`))
+		apitest.AssertProblem(t, w, http.StatusBadRequest, errInvalidID.Detail)
+	})
+}
+
+func TestDrop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockEnrollmentService(ctrl)
+	router := newRouter(Controller(mockService))
+	url := "/students/" + studentID.String() + "/enrollments/" + courseID.String()
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().Drop(gomock.Any(), studentID, courseID).Return(nil)
+
+		w := apitest.Request(router, http.MethodDelete, url, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"message": "Enrollment dropped successfully"}`, w.Body.String())
+	})
+
+	t.Run("Not Enrolled", func(t *testing.T) {
+		mockService.EXPECT().Drop(gomock.Any(), studentID, courseID).Return(models.ErrEnrollmentNotFound)
+
+		w := apitest.Request(router, http.MethodDelete, url, nil)
+		apitest.AssertProblem(t, w, http.StatusNotFound, models.ErrEnrollmentNotFound.Detail)
+	})
+
+	t.Run("Invalid Course ID", func(t *testing.T) {
+		w := apitest.Request(router, http.MethodDelete, "/students/"+studentID.String()+"/enrollments/1", nil)
+		apitest.AssertProblem(t, w, http.StatusBadRequest, errInvalidCourseID.Detail)
+	})
+}
+
+func TestLists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockEnrollmentService(ctrl)
+	router := newRouter(Controller(mockService))
+	list := models.EnrollmentList{Enrollments: []models.Enrollment{enrollment}}
+
+	t.Run("By Student", func(t *testing.T) {
+		mockService.EXPECT().GetByStudent(gomock.Any(), studentID, models.EnrollmentQuery{Status: models.StatusWaitlisted}).Return(list, nil)
+
+		w := apitest.Request(router, http.MethodGet, "/students/"+studentID.String()+"/enrollments?status=waitlisted", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var actual models.EnrollmentList
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+		assert.Equal(t, list, actual)
+	})
+
+	t.Run("Roster", func(t *testing.T) {
+		mockService.EXPECT().GetRoster(gomock.Any(), courseID, models.EnrollmentQuery{}).Return(list, nil)
+
+		w := apitest.Request(router, http.MethodGet, "/courses/"+courseID.String()+"/roster", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var actual models.EnrollmentList
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+		assert.Equal(t, list, actual)
+	})
+
+	t.Run("Invalid Status", func(t *testing.T) {
+		w := apitest.Request(router, http.MethodGet, "/courses/"+courseID.String()+"/roster?status=dropped", nil)
+		apitest.AssertProblem(t, w, http.StatusBadRequest, models.ErrInvalidStatus.Detail)
+	})
+}
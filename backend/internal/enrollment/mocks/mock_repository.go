@@ -0,0 +1,187 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/enrollment/services/service.go
+
+// Package services is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/internal/course/models"
+	models0 "backend/internal/enrollment/models"
+	models1 "backend/internal/student/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Drop mocks base method.
+func (m *MockRepository) Drop(ctx context.Context, studentID, courseID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Drop", ctx, studentID, courseID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Drop indicates an expected call of Drop.
+func (mr *MockRepositoryMockRecorder) Drop(ctx, studentID, courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drop", reflect.TypeOf((*MockRepository)(nil).Drop), ctx, studentID, courseID)
+}
+
+// Enroll mocks base method.
+func (m *MockRepository) Enroll(ctx context.Context, enrollment *models0.Enrollment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enroll", ctx, enrollment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enroll indicates an expected call of Enroll.
+func (mr *MockRepositoryMockRecorder) Enroll(ctx, enrollment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockRepository)(nil).Enroll), ctx, enrollment)
+}
+
+// GetByCourse mocks base method.
+func (m *MockRepository) GetByCourse(courseID uuid.UUID, query models0.EnrollmentQuery) ([]models0.Enrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCourse", courseID, query)
+	ret0, _ := ret[0].([]models0.Enrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCourse indicates an expected call of GetByCourse.
+func (mr *MockRepositoryMockRecorder) GetByCourse(courseID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCourse", reflect.TypeOf((*MockRepository)(nil).GetByCourse), courseID, query)
+}
+
+// GetByStudent mocks base method.
+func (m *MockRepository) GetByStudent(studentID uuid.UUID, query models0.EnrollmentQuery) ([]models0.Enrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStudent", studentID, query)
+	ret0, _ := ret[0].([]models0.Enrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByStudent indicates an expected call of GetByStudent.
+func (mr *MockRepositoryMockRecorder) GetByStudent(studentID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudent", reflect.TypeOf((*MockRepository)(nil).GetByStudent), studentID, query)
+}
+
+// Promote mocks base method.
+func (m *MockRepository) Promote(ctx context.Context, courseID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Promote", ctx, courseID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Promote indicates an expected call of Promote.
+func (mr *MockRepositoryMockRecorder) Promote(ctx, courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Promote", reflect.TypeOf((*MockRepository)(nil).Promote), ctx, courseID)
+}
+
+// MockStudents is a mock of Students interface.
+type MockStudents struct {
+	ctrl     *gomock.Controller
+	recorder *MockStudentsMockRecorder
+}
+
+// MockStudentsMockRecorder is the mock recorder for MockStudents.
+type MockStudentsMockRecorder struct {
+	mock *MockStudents
+}
+
+// NewMockStudents creates a new mock instance.
+func NewMockStudents(ctrl *gomock.Controller) *MockStudents {
+	mock := &MockStudents{ctrl: ctrl}
+	mock.recorder = &MockStudentsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStudents) EXPECT() *MockStudentsMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockStudents) Get(id uuid.UUID) (*models1.Student, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(*models1.Student)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockStudentsMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStudents)(nil).Get), id)
+}
+
+// MockCourses is a mock of Courses interface.
+type MockCourses struct {
+	ctrl     *gomock.Controller
+	recorder *MockCoursesMockRecorder
+}
+
+// MockCoursesMockRecorder is the mock recorder for MockCourses.
+type MockCoursesMockRecorder struct {
+	mock *MockCourses
+}
+
+// NewMockCourses creates a new mock instance.
+func NewMockCourses(ctrl *gomock.Controller) *MockCourses {
+	mock := &MockCourses{ctrl: ctrl}
+	mock.recorder = &MockCoursesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourses) EXPECT() *MockCoursesMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockCourses) Get(id uuid.UUID) (*models.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(*models.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCoursesMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCourses)(nil).Get), id)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/enrollment/controllers/controller.go
+
+// Package controllers is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/internal/enrollment/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockEnrollmentService is a mock of EnrollmentService interface.
+type MockEnrollmentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockEnrollmentServiceMockRecorder
+}
+
+// MockEnrollmentServiceMockRecorder is the mock recorder for MockEnrollmentService.
+type MockEnrollmentServiceMockRecorder struct {
+	mock *MockEnrollmentService
+}
+
+// NewMockEnrollmentService creates a new mock instance.
+func NewMockEnrollmentService(ctrl *gomock.Controller) *MockEnrollmentService {
+	mock := &MockEnrollmentService{ctrl: ctrl}
+	mock.recorder = &MockEnrollmentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEnrollmentService) EXPECT() *MockEnrollmentServiceMockRecorder {
+	return m.recorder
+}
+
+// Drop mocks base method.
+func (m *MockEnrollmentService) Drop(ctx context.Context, studentID, courseID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Drop", ctx, studentID, courseID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Drop indicates an expected call of Drop.
+func (mr *MockEnrollmentServiceMockRecorder) Drop(ctx, studentID, courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drop", reflect.TypeOf((*MockEnrollmentService)(nil).Drop), ctx, studentID, courseID)
+}
+
+// Enroll mocks base method.
+func (m *MockEnrollmentService) Enroll(ctx context.Context, studentID, courseID uuid.UUID) (*models.Enrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enroll", ctx, studentID, courseID)
+	ret0, _ := ret[0].(*models.Enrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enroll indicates an expected call of Enroll.
+func (mr *MockEnrollmentServiceMockRecorder) Enroll(ctx, studentID, courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockEnrollmentService)(nil).Enroll), ctx, studentID, courseID)
+}
+
+// GetByStudent mocks base method.
+func (m *MockEnrollmentService) GetByStudent(ctx context.Context, studentID uuid.UUID, query models.EnrollmentQuery) (models.EnrollmentList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStudent", ctx, studentID, query)
+	ret0, _ := ret[0].(models.EnrollmentList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByStudent indicates an expected call of GetByStudent.
+func (mr *MockEnrollmentServiceMockRecorder) GetByStudent(ctx, studentID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudent", reflect.TypeOf((*MockEnrollmentService)(nil).GetByStudent), ctx, studentID, query)
+}
+
+// GetRoster mocks base method.
+func (m *MockEnrollmentService) GetRoster(ctx context.Context, courseID uuid.UUID, query models.EnrollmentQuery) (models.EnrollmentList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoster", ctx, courseID, query)
+	ret0, _ := ret[0].(models.EnrollmentList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoster indicates an expected call of GetRoster.
+func (mr *MockEnrollmentServiceMockRecorder) GetRoster(ctx, courseID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoster", reflect.TypeOf((*MockEnrollmentService)(nil).GetRoster), ctx, courseID, query)
+}
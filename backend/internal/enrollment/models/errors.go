@@ -0,0 +1,9 @@
+package models
+
+import "backend/internal/problem"
+
+var (
+	ErrEnrollmentNotFound = &problem.NotFoundError{Detail: "the student is neither enrolled in nor waitlisted for this course"}
+	ErrAlreadyEnrolled    = &problem.ConflictError{Detail: "the student is already enrolled in or waitlisted for this course"}
+	ErrInvalidStatus      = &problem.ValidationError{Detail: "invalid status", Fields: []problem.FieldError{{Field: "status", Code: "enum", Message: "must be enrolled or waitlisted"}}}
+)
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status tells whether an enrollment holds a seat in its course.
+type Status string
+
+const (
+	StatusEnrolled   Status = "enrolled"
+	StatusWaitlisted Status = "waitlisted"
+)
+
+// Enrollment places a student in a course, or on the course's waitlist when it
+// is full. Waitlisted students are promoted in the order they asked, as seats
+// free up.
+type Enrollment struct {
+	ID        string `json:"id"`
+	StudentID string `json:"studentId"`
+	CourseID  string `json:"courseId"`
+	Status    Status `json:"status"`
+	// Position is the place of a waitlisted enrollment on the waitlist, starting
+	// at 1.
+	Position    int        `json:"position,omitempty"`
+	RequestedAt time.Time  `json:"requestedAt"`
+	EnrolledAt  *time.Time `json:"enrolledAt,omitempty"`
+}
+
+// EnrollmentRequest is the body of an enrollment.
+type EnrollmentRequest struct {
+	CourseID string `json:"courseId"`
+}
+
+// EnrollmentEntity is unique per student and course, and indexed for the
+// seat count of a course.
+type EnrollmentEntity struct {
+	ID          uuid.UUID `gorm:"primary_key;type:char(36)"`
+	StudentID   uuid.UUID `gorm:"type:char(36);uniqueIndex:idx_enrollments_student_course"`
+	CourseID    uuid.UUID `gorm:"type:char(36);uniqueIndex:idx_enrollments_student_course;index:idx_enrollments_course_status"`
+	Status      Status    `gorm:"size:20;index:idx_enrollments_course_status"`
+	RequestedAt time.Time
+	EnrolledAt  *time.Time
+}
+
+func (EnrollmentEntity) TableName() string {
+	return "enrollments"
+}
+
+// EnrollmentList holds the enrollments of a student, or the roster of a
+// course: its enrolled students, then its waitlist in order.
+type EnrollmentList struct {
+	Enrollments []Enrollment `json:"enrollments"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEnrollmentQuery(t *testing.T) {
+	query, err := ParseEnrollmentQuery(url.Values{})
+	require.NoError(t, err)
+	assert.Equal(t, EnrollmentQuery{}, query)
+	assert.True(t, query.Matches(Enrollment{Status: StatusWaitlisted}))
+
+	query, err = ParseEnrollmentQuery(url.Values{"status": {" waitlisted "}})
+	require.NoError(t, err)
+	assert.Equal(t, EnrollmentQuery{Status: StatusWaitlisted}, query)
+	assert.True(t, query.Matches(Enrollment{Status: StatusWaitlisted}))
+	assert.False(t, query.Matches(Enrollment{Status: StatusEnrolled}))
+
+	_, err = ParseEnrollmentQuery(url.Values{"status": {"dropped"}})
+	assert.Equal(t, ErrInvalidStatus, err)
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"net/url"
+	"strings"
+)
+
+// EnrollmentQuery narrows a list of enrollments.
+type EnrollmentQuery struct {
+	// Status only keeps the enrollments with this status when it is set.
+	Status Status
+}
+
+// ParseEnrollmentQuery reads the status query parameter.
+func ParseEnrollmentQuery(values url.Values) (EnrollmentQuery, error) {
+	var query EnrollmentQuery
+	switch status := Status(strings.TrimSpace(values.Get("status"))); status {
+	case "":
+	case StatusEnrolled, StatusWaitlisted:
+		query.Status = status
+	default:
+		return EnrollmentQuery{}, ErrInvalidStatus
+	}
+	return query, nil
+}
+
+// Matches reports whether enrollment is kept by the query.
+func (q EnrollmentQuery) Matches(enrollment Enrollment) bool {
+	return q.Status == "" || enrollment.Status == q.Status
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	coursemodels "backend/internal/course/models"
+	"backend/internal/enrollment/models"
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Courses looks up the capacity of courses for the memory repository.
+type Courses interface {
+	Get(id uuid.UUID) (*coursemodels.Course, error)
+}
+
+// memoryRepository keeps enrollments in process memory. It is safe for
+// concurrent use and meant for local development and tests; its mutex plays
+// the part of the course row lock.
+type memoryRepository struct {
+	mu          sync.Mutex
+	courses     Courses
+	enrollments map[uuid.UUID]models.EnrollmentEntity
+}
+
+func NewMemoryRepository(courses Courses) *memoryRepository {
+	return &memoryRepository{courses: courses, enrollments: map[uuid.UUID]models.EnrollmentEntity{}}
+}
+
+// find returns the enrollments keep accepts, in the order they were requested.
+func (r *memoryRepository) find(keep func(entity *models.EnrollmentEntity) bool) []models.EnrollmentEntity {
+	entities := []models.EnrollmentEntity{}
+	for _, entity := range r.enrollments {
+		if keep(&entity) {
+			entities = append(entities, entity)
+		}
+	}
+	sort.Slice(entities, func(i, j int) bool {
+		if !entities[i].RequestedAt.Equal(entities[j].RequestedAt) {
+			return entities[i].RequestedAt.Before(entities[j].RequestedAt)
+		}
+		return entities[i].ID.String() < entities[j].ID.String()
+	})
+	return entities
+}
+
+func (r *memoryRepository) ofCourse(courseID uuid.UUID) []models.EnrollmentEntity {
+	return r.find(func(entity *models.EnrollmentEntity) bool { return entity.CourseID == courseID })
+}
+
+// promote is the in-memory equivalent of the SQL promote.
+func (r *memoryRepository) promote(courseID uuid.UUID, capacity int) {
+	enrolled := 0
+	entities := r.ofCourse(courseID)
+	for _, entity := range entities {
+		if entity.Status == models.StatusEnrolled {
+			enrolled++
+		}
+	}
+	now := time.Now().UTC()
+	for _, entity := range entities {
+		if enrolled >= capacity {
+			return
+		}
+		if entity.Status == models.StatusWaitlisted {
+			enrolledAt := now
+			entity.Status, entity.EnrolledAt = models.StatusEnrolled, &enrolledAt
+			r.enrollments[entity.ID] = entity
+			enrolled++
+		}
+	}
+}
+
+func (r *memoryRepository) position(entity *models.EnrollmentEntity) int {
+	for _, enrollment := range roster(r.ofCourse(entity.CourseID), models.EnrollmentQuery{Status: models.StatusWaitlisted}) {
+		if enrollment.ID == entity.ID.String() {
+			return enrollment.Position
+		}
+	}
+	return 0
+}
+
+func (r *memoryRepository) Enroll(ctx context.Context, enrollment *models.Enrollment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entity := ModelToEntity(enrollment)
+	course, err := r.courses.Get(entity.CourseID)
+	if err != nil {
+		return err
+	}
+	for _, existing := range r.ofCourse(entity.CourseID) {
+		if existing.StudentID == entity.StudentID || existing.ID == entity.ID {
+			return models.ErrAlreadyEnrolled
+		}
+	}
+
+	r.promote(entity.CourseID, course.Capacity)
+	enrolled := 0
+	for _, existing := range r.ofCourse(entity.CourseID) {
+		if existing.Status == models.StatusEnrolled {
+			enrolled++
+		}
+	}
+	entity.Status, entity.EnrolledAt = models.StatusWaitlisted, nil
+	if enrolled < course.Capacity {
+		enrolledAt := entity.RequestedAt
+		entity.Status, entity.EnrolledAt = models.StatusEnrolled, &enrolledAt
+	}
+	r.enrollments[entity.ID] = *entity
+
+	*enrollment = *EntityToModel(entity)
+	enrollment.Position = r.position(entity)
+	return nil
+}
+
+func (r *memoryRepository) Promote(ctx context.Context, courseID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	course, err := r.courses.Get(courseID)
+	if err != nil {
+		return err
+	}
+	r.promote(courseID, course.Capacity)
+	return nil
+}
+
+func (r *memoryRepository) Drop(ctx context.Context, studentID uuid.UUID, courseID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	capacity := 0
+	course, err := r.courses.Get(courseID)
+	if err != nil && !errors.Is(err, coursemodels.ErrCourseNotFound) {
+		return err
+	}
+	if course != nil {
+		capacity = course.Capacity
+	}
+	for _, entity := range r.ofCourse(courseID) {
+		if entity.StudentID == studentID {
+			delete(r.enrollments, entity.ID)
+			r.promote(courseID, capacity)
+			return nil
+		}
+	}
+	return models.ErrEnrollmentNotFound
+}
+
+func (r *memoryRepository) GetByStudent(studentID uuid.UUID, query models.EnrollmentQuery) ([]models.Enrollment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	enrollments := []models.Enrollment{}
+	for _, entity := range r.find(func(entity *models.EnrollmentEntity) bool { return entity.StudentID == studentID }) {
+		enrollment := EntityToModel(&entity)
+		if !query.Matches(*enrollment) {
+			continue
+		}
+		enrollment.Position = r.position(&entity)
+		enrollments = append(enrollments, *enrollment)
+	}
+	return enrollments, nil
+}
+
+func (r *memoryRepository) GetByCourse(courseID uuid.UUID, query models.EnrollmentQuery) ([]models.Enrollment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return roster(r.ofCourse(courseID), query), nil
+}
@@ -0,0 +1,273 @@
+package repository
+
+import (
+	coursemodels "backend/internal/course/models"
+	"backend/internal/enrollment/models"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type enrollmentRepository struct {
+	DB *gorm.DB
+}
+
+// NewEnrollmentRepository stores enrollments in db, whose schema
+// schema.Migrate keeps up to date. The courses must be stored in the same
+// database, since enrolling locks them.
+func NewEnrollmentRepository(db *gorm.DB) (*enrollmentRepository, error) {
+	return &enrollmentRepository{DB: db}, nil
+}
+
+// lockCourse returns the capacity of the course, locking its row until tx ends.
+// Every change to the enrollments of a course takes this lock first, so the
+// seats cannot be counted by two transactions at once. SQLite ignores the
+// lock but serializes writers, failing a conflicting transaction rather than
+// interleaving it.
+func lockCourse(tx *gorm.DB, id uuid.UUID) (int, error) {
+	var course coursemodels.CourseEntity
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("capacity").Where("id = ?", id).Take(&course).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, coursemodels.ErrCourseNotFound
+	}
+	return course.Capacity, err
+}
+
+func countEnrolled(tx *gorm.DB, courseID uuid.UUID) (int64, error) {
+	var enrolled int64
+	err := tx.Model(&models.EnrollmentEntity{}).
+		Where("course_id = ? AND status = ?", courseID, models.StatusEnrolled).Count(&enrolled).Error
+	return enrolled, err
+}
+
+// waitlist orders the waitlisted enrollments of a course, first come first
+// served.
+func waitlist(tx *gorm.DB, courseID uuid.UUID) *gorm.DB {
+	return tx.Model(&models.EnrollmentEntity{}).
+		Where("course_id = ? AND status = ?", courseID, models.StatusWaitlisted).
+		Order("requested_at").Order("id")
+}
+
+// promote fills the free seats of the course from the head of its waitlist.
+// Seats free up when a student drops, and when the capacity of the course is
+// raised.
+func promote(tx *gorm.DB, courseID uuid.UUID, capacity int) error {
+	enrolled, err := countEnrolled(tx, courseID)
+	if err != nil || enrolled >= int64(capacity) {
+		return err
+	}
+	var ids []uuid.UUID
+	if err := waitlist(tx, courseID).Limit(capacity-int(enrolled)).Pluck("id", &ids).Error; err != nil || len(ids) == 0 {
+		return err
+	}
+	return tx.Model(&models.EnrollmentEntity{}).Where("id IN ?", ids).
+		Updates(map[string]interface{}{"status": models.StatusEnrolled, "enrolled_at": time.Now().UTC()}).Error
+}
+
+// position numbers a waitlisted enrollment on the waitlist of its course,
+// starting at 1.
+func position(tx *gorm.DB, entity *models.EnrollmentEntity) (int, error) {
+	var ids []uuid.UUID
+	if err := waitlist(tx, entity.CourseID).Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	for i, id := range ids {
+		if id == entity.ID {
+			return i + 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// Enroll gives the student a seat in the course, or puts them on its waitlist
+// when the course is full or others are already waiting. The stored status is
+// written back to enrollment.
+func (r *enrollmentRepository) Enroll(ctx context.Context, enrollment *models.Enrollment) error {
+	entity := ModelToEntity(enrollment)
+	return translateError(r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		capacity, err := lockCourse(tx, entity.CourseID)
+		if err != nil {
+			return err
+		}
+		var existing int64
+		err = tx.Model(&models.EnrollmentEntity{}).
+			Where("student_id = ? AND course_id = ?", entity.StudentID, entity.CourseID).Count(&existing).Error
+		if err != nil {
+			return err
+		}
+		if existing > 0 {
+			return models.ErrAlreadyEnrolled
+		}
+
+		// Seats freed by a raised capacity go to the waitlist before newcomers.
+		if err := promote(tx, entity.CourseID, capacity); err != nil {
+			return err
+		}
+		enrolled, err := countEnrolled(tx, entity.CourseID)
+		if err != nil {
+			return err
+		}
+		entity.Status, entity.EnrolledAt = models.StatusWaitlisted, nil
+		if enrolled < int64(capacity) {
+			enrolledAt := entity.RequestedAt
+			entity.Status, entity.EnrolledAt = models.StatusEnrolled, &enrolledAt
+		}
+		if err := tx.Create(entity).Error; err != nil {
+			return err
+		}
+
+		*enrollment = *EntityToModel(entity)
+		if entity.Status == models.StatusWaitlisted {
+			enrollment.Position, err = position(tx, entity)
+		}
+		return err
+	}))
+}
+
+// Drop removes the student from the course, or from its waitlist, and gives a
+// freed seat to the head of the waitlist.
+func (r *enrollmentRepository) Drop(ctx context.Context, studentID uuid.UUID, courseID uuid.UUID) error {
+	return translateError(r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The enrollments of a deleted course can still be dropped.
+		capacity, err := lockCourse(tx, courseID)
+		if err != nil && !errors.Is(err, coursemodels.ErrCourseNotFound) {
+			return err
+		}
+		result := tx.Where("student_id = ? AND course_id = ?", studentID, courseID).Delete(&models.EnrollmentEntity{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return models.ErrEnrollmentNotFound
+		}
+		return promote(tx, courseID, capacity)
+	}))
+}
+
+// Promote gives the seats freed by raising the capacity of the course to its
+// waitlist.
+func (r *enrollmentRepository) Promote(ctx context.Context, courseID uuid.UUID) error {
+	return translateError(r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		capacity, err := lockCourse(tx, courseID)
+		if err != nil {
+			return err
+		}
+		return promote(tx, courseID, capacity)
+	}))
+}
+
+// DeleteStudent drops the student from every course and waitlist, giving the
+// freed seats to the heads of the waitlists. The student repository calls it
+// in the transaction that deletes the student. The courses are locked in the
+// order of their IDs, so that two deletions cannot wait on each other.
+func (r *enrollmentRepository) DeleteStudent(tx *gorm.DB, id uuid.UUID) error {
+	var courseIDs []uuid.UUID
+	err := tx.Model(&models.EnrollmentEntity{}).Where("student_id = ?", id).Order("course_id").Pluck("course_id", &courseIDs).Error
+	if err != nil {
+		return err
+	}
+	for _, courseID := range courseIDs {
+		capacity, err := lockCourse(tx, courseID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("student_id = ? AND course_id = ?", id, courseID).Delete(&models.EnrollmentEntity{}).Error; err != nil {
+			return err
+		}
+		if err := promote(tx, courseID, capacity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PurgeStudents deletes whatever enrollments of the students are left after
+// DeleteStudent dropped them.
+func (r *enrollmentRepository) PurgeStudents(tx *gorm.DB, ids []uuid.UUID) error {
+	return tx.Where("student_id IN ?", ids).Delete(&models.EnrollmentEntity{}).Error
+}
+
+// GetByStudent lists the enrollments of the student in the order they were
+// requested.
+func (r *enrollmentRepository) GetByStudent(studentID uuid.UUID, query models.EnrollmentQuery) ([]models.Enrollment, error) {
+	db := r.DB.Where("student_id = ?", studentID)
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	var entities []models.EnrollmentEntity
+	if err := db.Order("requested_at").Order("id").Find(&entities).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	enrollments := []models.Enrollment{}
+	for i := range entities {
+		enrollment := EntityToModel(&entities[i])
+		if enrollment.Status == models.StatusWaitlisted {
+			var err error
+			if enrollment.Position, err = position(r.DB, &entities[i]); err != nil {
+				return nil, translateError(err)
+			}
+		}
+		enrollments = append(enrollments, *enrollment)
+	}
+	return enrollments, nil
+}
+
+// GetByCourse returns the roster of the course.
+func (r *enrollmentRepository) GetByCourse(courseID uuid.UUID, query models.EnrollmentQuery) ([]models.Enrollment, error) {
+	var entities []models.EnrollmentEntity
+	err := r.DB.Where("course_id = ?", courseID).Order("requested_at").Order("id").Find(&entities).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return roster(entities, query), nil
+}
+
+// roster lists the enrolled students of a course, then its waitlist numbered
+// from 1. entities must be in the order they were requested.
+func roster(entities []models.EnrollmentEntity, query models.EnrollmentQuery) []models.Enrollment {
+	enrolled, waitlisted := []models.Enrollment{}, []models.Enrollment{}
+	for i := range entities {
+		enrollment := EntityToModel(&entities[i])
+		if enrollment.Status == models.StatusWaitlisted {
+			enrollment.Position = len(waitlisted) + 1
+			waitlisted = append(waitlisted, *enrollment)
+		} else {
+			enrolled = append(enrolled, *enrollment)
+		}
+	}
+
+	enrollments := []models.Enrollment{}
+	for _, enrollment := range append(enrolled, waitlisted...) {
+		if query.Matches(enrollment) {
+			enrollments = append(enrollments, enrollment)
+		}
+	}
+	return enrollments
+}
+
+func ModelToEntity(enrollment *models.Enrollment) *models.EnrollmentEntity {
+	return &models.EnrollmentEntity{
+		ID:          uuid.MustParse(enrollment.ID),
+		StudentID:   uuid.MustParse(enrollment.StudentID),
+		CourseID:    uuid.MustParse(enrollment.CourseID),
+		Status:      enrollment.Status,
+		RequestedAt: enrollment.RequestedAt,
+		EnrolledAt:  enrollment.EnrolledAt,
+	}
+}
+
+func EntityToModel(entity *models.EnrollmentEntity) *models.Enrollment {
+	return &models.Enrollment{
+		ID:          entity.ID.String(),
+		StudentID:   entity.StudentID.String(),
+		CourseID:    entity.CourseID.String(),
+		Status:      entity.Status,
+		RequestedAt: entity.RequestedAt,
+		EnrolledAt:  entity.EnrolledAt,
+	}
+}
@@ -0,0 +1,314 @@
+package repository
+
+import (
+	"backend/internal/config"
+	coursemodels "backend/internal/course/models"
+	courserepository "backend/internal/course/repository"
+	courseservices "backend/internal/course/services"
+	"backend/internal/database/databasetest"
+	"backend/internal/enrollment/models"
+	"backend/internal/enrollment/services"
+	studentrepository "backend/internal/student/repository"
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/logger"
+)
+
+type fixture struct {
+	repo    services.Repository
+	courses courseservices.Repository
+	// store keeps the students the SQL repository refers to; the memory
+	// repository does not look them up.
+	store *studentrepository.Store
+	start time.Time
+}
+
+func newMemoryFixture(t *testing.T) *fixture {
+	courses := courserepository.NewMemoryRepository()
+	return &fixture{repo: NewMemoryRepository(courses), courses: courses}
+}
+
+func newSQLiteFixture(t *testing.T) *fixture {
+	return newStoreFixture(t, databasetest.Open(t))
+}
+
+// newStoreFixture stores the enrollments next to the students of store, and
+// attaches the repository to it.
+func newStoreFixture(t *testing.T, store *studentrepository.Store) *fixture {
+	courses, err := courserepository.NewCourseRepository(store.DB)
+	require.NoError(t, err)
+	repo, err := NewEnrollmentRepository(store.DB)
+	require.NoError(t, err)
+	store.Attach(repo)
+	return &fixture{repo: repo, courses: courses, store: store}
+}
+
+// newTestDBFixture connects to the test database whose DSN, in the form of the
+// database config, is in the environment variable env, and skips the test when
+// it is not set or not reachable. SQLite takes one writer at a time, so only
+// these databases race for the row locks of the course seats.
+func newTestDBFixture(t *testing.T, env string) *fixture {
+	dsn, ok := os.LookupEnv(config.EnvPrefix + env)
+	if !ok {
+		t.Skipf("%s%s is not set", config.EnvPrefix, env)
+	}
+	store, err := studentrepository.Open(config.Database{DSN: dsn}, logger.Silent)
+	if err != nil {
+		t.Skipf("The test database is not available: %v", err)
+	}
+	t.Cleanup(func() {
+		sqlDB, _ := store.DB.DB()
+		sqlDB.Close()
+	})
+	// Courses go last, as the other tables refer to them.
+	for _, table := range []string{"enrollments", "assessments", "grade_categories", "attendance_records", "courses"} {
+		require.NoError(t, store.DB.Exec("DELETE FROM "+table).Error)
+	}
+	return newStoreFixture(t, store)
+}
+
+// TestRepositories runs the same checks against every implementation.
+func TestRepositories(t *testing.T) {
+	databasetest.Run(t, map[string]func(t *testing.T) *fixture{
+		"Memory":   newMemoryFixture,
+		"SQLite":   newSQLiteFixture,
+		"MySQL":    func(t *testing.T) *fixture { return newTestDBFixture(t, "TEST_DB_DSN") },
+		"Postgres": func(t *testing.T) *fixture { return newTestDBFixture(t, "TEST_POSTGRES_DSN") },
+	}, map[string]func(t *testing.T, f *fixture){
+		"Waitlist":       testWaitlist,
+		"RaisedCapacity": testRaisedCapacity,
+		"ByStudent":      testByStudent,
+		"Concurrent":     testConcurrent,
+		"CourseInUse":    testCourseInUse,
+		"DeletedStudent": testDeletedStudent,
+	})
+}
+
+func (f *fixture) addCourse(t *testing.T, code string, capacity int) *coursemodels.Course {
+	course := &coursemodels.Course{ID: uuid.New().String(), Code: code, Title: code, Credits: 4, Department: "Computer Engineering", Capacity: capacity}
+	require.NoError(t, f.courses.Add(context.Background(), course))
+	return course
+}
+
+// enroll requests enrollments a second apart, so that they are ordered.
+func (f *fixture) enroll(t *testing.T, studentID uuid.UUID, course *coursemodels.Course) (*models.Enrollment, error) {
+	if f.start.IsZero() {
+		f.start = time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC)
+	}
+	f.start = f.start.Add(time.Second)
+	enrollment := &models.Enrollment{ID: uuid.New().String(), StudentID: studentID.String(), CourseID: course.ID, RequestedAt: f.start}
+	return enrollment, f.repo.Enroll(context.Background(), enrollment)
+}
+
+// roster renders the roster of course as "student status position" lines.
+func (f *fixture) roster(t *testing.T, course *coursemodels.Course, query models.EnrollmentQuery) []string {
+	enrollments, err := f.repo.GetByCourse(uuid.MustParse(course.ID), query)
+	require.NoError(t, err)
+	return render(enrollments)
+}
+
+func render(enrollments []models.Enrollment) []string {
+	lines := []string{}
+	for _, enrollment := range enrollments {
+		line := enrollment.StudentID[:8] + " " + string(enrollment.Status)
+		if enrollment.Position > 0 {
+			line += " " + strconv.Itoa(enrollment.Position)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// students returns the IDs of n new students, stored where the repository
+// refers to them.
+func (f *fixture) students(t *testing.T, n int) []uuid.UUID {
+	ids := make([]uuid.UUID, n)
+	for i := range ids {
+		ids[i] = uuid.New()
+		if f.store != nil {
+			ids[i] = databasetest.AddStudent(t, f.store.DB)
+		}
+	}
+	return ids
+}
+
+func testWaitlist(t *testing.T, f *fixture) {
+	ctx := context.Background()
+	course := f.addCourse(t, "CENG 242", 2)
+	s := f.students(t, 4)
+	for i, id := range s {
+		enrollment, err := f.enroll(t, id, course)
+		require.NoError(t, err)
+		if i < 2 {
+			assert.Equal(t, models.StatusEnrolled, enrollment.Status)
+			assert.NotNil(t, enrollment.EnrolledAt)
+		} else {
+			assert.Equal(t, models.StatusWaitlisted, enrollment.Status)
+			assert.Equal(t, i-1, enrollment.Position)
+			assert.Nil(t, enrollment.EnrolledAt)
+		}
+	}
+	name := func(i int) string { return s[i].String()[:8] }
+
+	_, err := f.enroll(t, s[0], course)
+	assert.ErrorIs(t, err, models.ErrAlreadyEnrolled)
+	_, err = f.enroll(t, s[0], &coursemodels.Course{ID: uuid.New().String()})
+	assert.ErrorIs(t, err, coursemodels.ErrCourseNotFound)
+
+	assert.Equal(t, []string{name(0) + " enrolled", name(1) + " enrolled", name(2) + " waitlisted 1", name(3) + " waitlisted 2"}, f.roster(t, course, models.EnrollmentQuery{}))
+	assert.Equal(t, []string{name(2) + " waitlisted 1", name(3) + " waitlisted 2"}, f.roster(t, course, models.EnrollmentQuery{Status: models.StatusWaitlisted}))
+
+	// A freed seat goes to the head of the waitlist.
+	require.NoError(t, f.repo.Drop(ctx, s[0], uuid.MustParse(course.ID)))
+	assert.Equal(t, []string{name(1) + " enrolled", name(2) + " enrolled", name(3) + " waitlisted 1"}, f.roster(t, course, models.EnrollmentQuery{}))
+
+	// Leaving the waitlist frees no seat.
+	require.NoError(t, f.repo.Drop(ctx, s[3], uuid.MustParse(course.ID)))
+	assert.Equal(t, []string{name(1) + " enrolled", name(2) + " enrolled"}, f.roster(t, course, models.EnrollmentQuery{}))
+
+	assert.ErrorIs(t, f.repo.Drop(ctx, s[3], uuid.MustParse(course.ID)), models.ErrEnrollmentNotFound)
+}
+
+func testRaisedCapacity(t *testing.T, f *fixture) {
+	ctx := context.Background()
+	course := f.addCourse(t, "CENG 242", 1)
+	s := f.students(t, 4)
+	for _, id := range s[:2] {
+		_, err := f.enroll(t, id, course)
+		require.NoError(t, err)
+	}
+	name := func(i int) string { return s[i].String()[:8] }
+
+	course.Capacity = 2
+	require.NoError(t, f.courses.Update(ctx, course))
+	require.NoError(t, f.repo.Promote(ctx, uuid.MustParse(course.ID)))
+	assert.Equal(t, []string{name(0) + " enrolled", name(1) + " enrolled"}, f.roster(t, course, models.EnrollmentQuery{}), "the waitlisted student takes the new seat")
+	assert.ErrorIs(t, f.repo.Promote(ctx, uuid.New()), coursemodels.ErrCourseNotFound)
+
+	// Seats raised without promoting go to the waitlist before newcomers too.
+	_, err := f.enroll(t, s[2], course)
+	require.NoError(t, err)
+	course.Capacity = 3
+	require.NoError(t, f.courses.Update(ctx, course))
+	enrollment, err := f.enroll(t, s[3], course)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusWaitlisted, enrollment.Status)
+	assert.Equal(t, []string{name(0) + " enrolled", name(1) + " enrolled", name(2) + " enrolled", name(3) + " waitlisted 1"}, f.roster(t, course, models.EnrollmentQuery{}))
+}
+
+func testByStudent(t *testing.T, f *fixture) {
+	full := f.addCourse(t, "CENG 213", 1)
+	open := f.addCourse(t, "CENG 242", 10)
+	s := f.students(t, 2)
+	other, student := s[0], s[1]
+	_, err := f.enroll(t, other, full)
+	require.NoError(t, err)
+	_, err = f.enroll(t, student, full)
+	require.NoError(t, err)
+	_, err = f.enroll(t, student, open)
+	require.NoError(t, err)
+
+	enrollments, err := f.repo.GetByStudent(student, models.EnrollmentQuery{})
+	require.NoError(t, err)
+	require.Len(t, enrollments, 2)
+	assert.Equal(t, full.ID, enrollments[0].CourseID)
+	assert.Equal(t, models.StatusWaitlisted, enrollments[0].Status)
+	assert.Equal(t, 1, enrollments[0].Position)
+	assert.Equal(t, open.ID, enrollments[1].CourseID)
+	assert.Equal(t, models.StatusEnrolled, enrollments[1].Status)
+
+	enrollments, err = f.repo.GetByStudent(student, models.EnrollmentQuery{Status: models.StatusEnrolled})
+	require.NoError(t, err)
+	require.Len(t, enrollments, 1)
+	assert.Equal(t, open.ID, enrollments[0].CourseID)
+
+	enrollments, err = f.repo.GetByStudent(f.students(t, 1)[0], models.EnrollmentQuery{})
+	require.NoError(t, err)
+	assert.Empty(t, enrollments)
+}
+
+// testConcurrent enrolls many students at once into the last seats of a
+// course; the seats must neither be overbooked nor left empty. It only races
+// on MySQL and PostgreSQL, which serve the requests on as many connections.
+func testConcurrent(t *testing.T, f *fixture) {
+	const capacity, requests = 3, 20
+	course := f.addCourse(t, "CENG 242", capacity)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, requests)
+	for _, id := range f.students(t, requests) {
+		wg.Add(1)
+		go func(id uuid.UUID) {
+			defer wg.Done()
+			enrollment := &models.Enrollment{ID: uuid.New().String(), StudentID: id.String(), CourseID: course.ID, RequestedAt: time.Now().UTC()}
+			errs <- f.repo.Enroll(context.Background(), enrollment)
+		}(id)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	enrolled, err := f.repo.GetByCourse(uuid.MustParse(course.ID), models.EnrollmentQuery{Status: models.StatusEnrolled})
+	require.NoError(t, err)
+	assert.Len(t, enrolled, capacity)
+	waitlisted, err := f.repo.GetByCourse(uuid.MustParse(course.ID), models.EnrollmentQuery{Status: models.StatusWaitlisted})
+	require.NoError(t, err)
+	assert.Len(t, waitlisted, requests-capacity)
+}
+
+func testCourseInUse(t *testing.T, f *fixture) {
+	if f.store == nil {
+		t.Skip("the memory repositories of courses and enrollments are unrelated")
+	}
+	ctx := context.Background()
+	course := f.addCourse(t, "CENG101", 1)
+	unused := f.addCourse(t, "CENG102", 1)
+	_, err := f.enroll(t, f.students(t, 1)[0], course)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, f.courses.Delete(ctx, uuid.MustParse(course.ID)), coursemodels.ErrCourseInUse)
+	_, err = f.courses.Get(uuid.MustParse(course.ID))
+	assert.NoError(t, err, "a course with enrollments is kept")
+
+	assert.NoError(t, f.courses.Delete(ctx, uuid.MustParse(unused.ID)))
+}
+
+// testDeletedStudent checks that deleting a student gives their seats to the
+// waitlists, and that purging them takes the enrollments left with them.
+func testDeletedStudent(t *testing.T, f *fixture) {
+	if f.store == nil {
+		t.Skip("the memory repositories of students and enrollments are unrelated")
+	}
+	ctx := context.Background()
+	course := f.addCourse(t, "CENG 242", 1)
+	other := f.addCourse(t, "CENG 213", 10)
+	s := f.students(t, 2)
+	for _, id := range s {
+		_, err := f.enroll(t, id, course)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, f.store.Students.Delete(ctx, s[0]))
+	assert.Equal(t, []string{s[1].String()[:8] + " enrolled"}, f.roster(t, course, models.EnrollmentQuery{}), "the seat goes to the waitlist")
+	enrollments, err := f.repo.GetByStudent(s[0], models.EnrollmentQuery{})
+	require.NoError(t, err)
+	assert.Empty(t, enrollments, "deleted students are dropped")
+
+	// The repository takes enrollments of deleted students, which the service
+	// does not make.
+	_, err = f.enroll(t, s[0], other)
+	require.NoError(t, err)
+	_, err = f.store.Students.Purge(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, f.roster(t, other, models.EnrollmentQuery{}), "purged students leave no enrollments")
+}
@@ -0,0 +1,71 @@
+package routes
+
+import (
+	"backend/internal/auth"
+	"backend/internal/enrollment/models"
+	"backend/internal/openapi"
+	"net/http"
+)
+
+var problemDescriptions = map[int]string{
+	http.StatusBadRequest:          "The request is invalid; errors lists the invalid fields",
+	http.StatusNotFound:            "No such student, course or enrollment",
+	http.StatusConflict:            "The student is already enrolled in or waitlisted for the course",
+	http.StatusServiceUnavailable:  "The database is unavailable, try again later",
+	http.StatusInternalServerError: "Unexpected error",
+}
+
+// operation secures op with permission and documents the problems it may
+// answer with, besides the 503 and 500 every operation may.
+func operation(doc *openapi.Document, op *openapi.Operation, permission auth.Permission, problems ...int) *openapi.Operation {
+	op.Tags = []string{"enrollments"}
+	problems = append(problems, http.StatusServiceUnavailable, http.StatusInternalServerError)
+	for _, status := range problems {
+		op.Respond(status, doc.Problem(problemDescriptions[status]))
+	}
+	return auth.Secure(doc, op, permission)
+}
+
+// Describe documents the routes SetupRoutes registers.
+func Describe(doc *openapi.Document) {
+	doc.AddTag("enrollments", "Students enrolled in courses, and the waitlists of full courses")
+	doc.Enum(models.StatusEnrolled, models.StatusWaitlisted)
+
+	uuidSchema := &openapi.Schema{Type: "string", Format: "uuid"}
+	studentID := openapi.Path("id", "The student ID", uuidSchema)
+	status := openapi.Query("status", "Only enrollments with this status", doc.Schema(models.StatusEnrolled))
+
+	doc.Add(http.MethodGet, "/students/:id/enrollments", operation(doc, &openapi.Operation{
+		Summary:     "List the enrollments of a student",
+		Description: "Enrollments are listed in the order they were requested. Waitlisted ones have their position on the waitlist.",
+		OperationID: "listStudentEnrollments",
+		Parameters:  []*openapi.Parameter{studentID, status},
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.EnrollmentList{}, "The enrollments of the student")},
+	}, auth.PermEnrollmentsRead, http.StatusBadRequest, http.StatusNotFound))
+
+	doc.Add(http.MethodPost, "/students/:id/enrollments", operation(doc, &openapi.Operation{
+		Summary: "Enroll a student in a course",
+		Description: "The student gets a seat when the course has one free, and is put on its waitlist otherwise. " +
+			"Seats are counted under a lock on the course, so concurrent enrollments never overbook it.",
+		OperationID: "enrollStudent",
+		Parameters:  []*openapi.Parameter{studentID},
+		RequestBody: doc.Body(models.EnrollmentRequest{}, ""),
+		Responses:   map[string]*openapi.Response{"201": doc.JSON(models.Enrollment{}, "The enrollment; its status tells whether the student got a seat")},
+	}, auth.PermEnrollmentsWrite, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict))
+
+	doc.Add(http.MethodDelete, "/students/:id/enrollments/:course_id", operation(doc, &openapi.Operation{
+		Summary:     "Drop a course",
+		Description: "Removes the student from the course or its waitlist. A freed seat goes to the first student on the waitlist.",
+		OperationID: "dropEnrollment",
+		Parameters:  []*openapi.Parameter{studentID, openapi.Path("course_id", "The course ID", uuidSchema)},
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(openapi.Message{}, "The enrollment was dropped")},
+	}, auth.PermEnrollmentsWrite, http.StatusBadRequest, http.StatusNotFound))
+
+	doc.Add(http.MethodGet, "/courses/:id/roster", operation(doc, &openapi.Operation{
+		Summary:     "Get the roster of a course",
+		Description: "Lists the enrolled students, then the waitlist in order.",
+		OperationID: "getCourseRoster",
+		Parameters:  []*openapi.Parameter{openapi.Path("id", "The course ID", uuidSchema), status},
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.EnrollmentList{}, "The roster of the course")},
+	}, auth.PermEnrollmentsRead, http.StatusBadRequest, http.StatusNotFound))
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"backend/internal/auth"
+	"backend/internal/enrollment/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupRoutes registers the enrollment routes behind authenticate, each
+// requiring the permission it needs.
+func SetupRoutes(router *gin.Engine, enrollmentController *controllers.EnrollmentController, authenticate gin.HandlerFunc) {
+	enrollments := router.Group("", authenticate)
+	enrollments.GET("/students/:id/enrollments", auth.Require(auth.PermEnrollmentsRead), enrollmentController.GetByStudent)
+	enrollments.POST("/students/:id/enrollments", auth.Require(auth.PermEnrollmentsWrite), enrollmentController.Enroll)
+	enrollments.DELETE("/students/:id/enrollments/:course_id", auth.Require(auth.PermEnrollmentsWrite), enrollmentController.Drop)
+	enrollments.GET("/courses/:id/roster", auth.Require(auth.PermEnrollmentsRead), enrollmentController.GetRoster)
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"backend/internal/auth"
+	coursemodels "backend/internal/course/models"
+	"backend/internal/enrollment/models"
+	studentmodels "backend/internal/student/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Enroll(ctx context.Context, enrollment *models.Enrollment) error
+	Drop(ctx context.Context, studentID uuid.UUID, courseID uuid.UUID) error
+	GetByStudent(studentID uuid.UUID, query models.EnrollmentQuery) ([]models.Enrollment, error)
+	GetByCourse(courseID uuid.UUID, query models.EnrollmentQuery) ([]models.Enrollment, error)
+	// Promote gives the free seats of the course to the head of its waitlist.
+	Promote(ctx context.Context, courseID uuid.UUID) error
+}
+
+// Students looks up students, telling enrollments of unknown or deleted
+// students apart.
+type Students interface {
+	Get(id uuid.UUID) (*studentmodels.Student, error)
+}
+
+// Courses looks up courses, telling the rosters of unknown courses apart.
+type Courses interface {
+	Get(id uuid.UUID) (*coursemodels.Course, error)
+}
+
+type EnrollmentService struct {
+	repository Repository
+	students   Students
+	courses    Courses
+}
+
+func Service(repository Repository, students Students, courses Courses) *EnrollmentService {
+	return &EnrollmentService{repository: repository, students: students, courses: courses}
+}
+
+// Enroll gives the student a seat in the course, or a place on its waitlist
+// when it is full.
+func (s *EnrollmentService) Enroll(ctx context.Context, studentID uuid.UUID, courseID uuid.UUID) (*models.Enrollment, error) {
+	if err := auth.Authorize(ctx, auth.PermEnrollmentsWrite); err != nil {
+		return nil, err
+	}
+	if _, err := s.students.Get(studentID); err != nil {
+		return nil, err
+	}
+	enrollment := &models.Enrollment{
+		ID:          uuid.New().String(),
+		StudentID:   studentID.String(),
+		CourseID:    courseID.String(),
+		RequestedAt: time.Now().UTC(),
+	}
+	if err := s.repository.Enroll(ctx, enrollment); err != nil {
+		return nil, err
+	}
+	return enrollment, nil
+}
+
+// Drop removes the student from the course or its waitlist. A freed seat goes
+// to the first student on the waitlist.
+func (s *EnrollmentService) Drop(ctx context.Context, studentID uuid.UUID, courseID uuid.UUID) error {
+	if err := auth.Authorize(ctx, auth.PermEnrollmentsWrite); err != nil {
+		return err
+	}
+	return s.repository.Drop(ctx, studentID, courseID)
+}
+
+// GetByStudent lists the courses the student enrolled in or waits for.
+func (s *EnrollmentService) GetByStudent(ctx context.Context, studentID uuid.UUID, query models.EnrollmentQuery) (models.EnrollmentList, error) {
+	if err := auth.Authorize(ctx, auth.PermEnrollmentsRead); err != nil {
+		return models.EnrollmentList{}, err
+	}
+	if _, err := s.students.Get(studentID); err != nil {
+		return models.EnrollmentList{}, err
+	}
+	enrollments, err := s.repository.GetByStudent(studentID, query)
+	if err != nil {
+		return models.EnrollmentList{}, err
+	}
+	return models.EnrollmentList{Enrollments: enrollments}, nil
+}
+
+// GetRoster lists the students enrolled in the course, then its waitlist.
+func (s *EnrollmentService) GetRoster(ctx context.Context, courseID uuid.UUID, query models.EnrollmentQuery) (models.EnrollmentList, error) {
+	if err := auth.Authorize(ctx, auth.PermEnrollmentsRead); err != nil {
+		return models.EnrollmentList{}, err
+	}
+	if _, err := s.courses.Get(courseID); err != nil {
+		return models.EnrollmentList{}, err
+	}
+	enrollments, err := s.repository.GetByCourse(courseID, query)
+	if err != nil {
+		return models.EnrollmentList{}, err
+	}
+	return models.EnrollmentList{Enrollments: enrollments}, nil
+}
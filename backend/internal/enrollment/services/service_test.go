@@ -0,0 +1,128 @@
+package services
+
+import (
+	"backend/internal/auth"
+	"backend/internal/auth/authtest"
+	coursemodels "backend/internal/course/models"
+	"backend/internal/enrollment/mocks"
+	"backend/internal/enrollment/models"
+	studentmodels "backend/internal/student/models"
+	"context"
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type fixture struct {
+	repo     *mocks.MockRepository
+	students *mocks.MockStudents
+	courses  *mocks.MockCourses
+	service  *EnrollmentService
+}
+
+func newFixture(t *testing.T) *fixture {
+	ctrl := gomock.NewController(t)
+	f := &fixture{
+		repo:     mocks.NewMockRepository(ctrl),
+		students: mocks.NewMockStudents(ctrl),
+		courses:  mocks.NewMockCourses(ctrl),
+	}
+	f.service = Service(f.repo, f.students, f.courses)
+	return f
+}
+
+func TestEnroll(t *testing.T) {
+	studentID, courseID := uuid.New(), uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		f := newFixture(t)
+		f.students.EXPECT().Get(studentID).Return(&studentmodels.Student{ID: studentID.String()}, nil)
+		f.repo.EXPECT().Enroll(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, enrollment *models.Enrollment) error {
+			assert.Equal(t, studentID.String(), enrollment.StudentID)
+			assert.Equal(t, courseID.String(), enrollment.CourseID)
+			assert.False(t, enrollment.RequestedAt.IsZero())
+			enrollment.Status = models.StatusWaitlisted
+			enrollment.Position = 3
+			return nil
+		})
+
+		enrollment, err := f.service.Enroll(authtest.AdminContext, studentID, courseID)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, enrollment.ID)
+		assert.Equal(t, models.StatusWaitlisted, enrollment.Status)
+		assert.Equal(t, 3, enrollment.Position)
+	})
+
+	t.Run("Unknown Student", func(t *testing.T) {
+		f := newFixture(t)
+		f.students.EXPECT().Get(studentID).Return(nil, studentmodels.ErrStudentNotFound)
+
+		_, err := f.service.Enroll(authtest.AdminContext, studentID, courseID)
+		assert.ErrorIs(t, err, studentmodels.ErrStudentNotFound)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		f := newFixture(t)
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Username: "teacher", Permissions: []auth.Permission{auth.PermEnrollmentsRead}})
+
+		_, err := f.service.Enroll(ctx, studentID, courseID)
+		assert.Equal(t, &auth.ForbiddenError{Permission: auth.PermEnrollmentsWrite}, err)
+	})
+}
+
+func TestDrop(t *testing.T) {
+	f := newFixture(t)
+	studentID, courseID := uuid.New(), uuid.New()
+	f.repo.EXPECT().Drop(gomock.Any(), studentID, courseID).Return(models.ErrEnrollmentNotFound)
+
+	assert.ErrorIs(t, f.service.Drop(authtest.AdminContext, studentID, courseID), models.ErrEnrollmentNotFound)
+}
+
+func TestGetByStudent(t *testing.T) {
+	studentID := uuid.New()
+	query := models.EnrollmentQuery{Status: models.StatusEnrolled}
+
+	t.Run("Success", func(t *testing.T) {
+		f := newFixture(t)
+		enrollments := []models.Enrollment{{ID: uuid.New().String(), StudentID: studentID.String(), Status: models.StatusEnrolled}}
+		f.students.EXPECT().Get(studentID).Return(&studentmodels.Student{ID: studentID.String()}, nil)
+		f.repo.EXPECT().GetByStudent(studentID, query).Return(enrollments, nil)
+
+		list, err := f.service.GetByStudent(authtest.AdminContext, studentID, query)
+		assert.NoError(t, err)
+		assert.Equal(t, models.EnrollmentList{Enrollments: enrollments}, list)
+	})
+
+	t.Run("Unknown Student", func(t *testing.T) {
+		f := newFixture(t)
+		f.students.EXPECT().Get(studentID).Return(nil, studentmodels.ErrStudentNotFound)
+
+		_, err := f.service.GetByStudent(authtest.AdminContext, studentID, query)
+		assert.ErrorIs(t, err, studentmodels.ErrStudentNotFound)
+	})
+}
+
+func TestGetRoster(t *testing.T) {
+	courseID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		f := newFixture(t)
+		enrollments := []models.Enrollment{{ID: uuid.New().String(), CourseID: courseID.String(), Status: models.StatusWaitlisted, Position: 1}}
+		f.courses.EXPECT().Get(courseID).Return(&coursemodels.Course{ID: courseID.String()}, nil)
+		f.repo.EXPECT().GetByCourse(courseID, models.EnrollmentQuery{}).Return(enrollments, nil)
+
+		list, err := f.service.GetRoster(authtest.AdminContext, courseID, models.EnrollmentQuery{})
+		assert.NoError(t, err)
+		assert.Equal(t, models.EnrollmentList{Enrollments: enrollments}, list)
+	})
+
+	t.Run("Unknown Course", func(t *testing.T) {
+		f := newFixture(t)
+		f.courses.EXPECT().Get(courseID).Return(nil, coursemodels.ErrCourseNotFound)
+
+		_, err := f.service.GetRoster(authtest.AdminContext, courseID, models.EnrollmentQuery{})
+		assert.ErrorIs(t, err, coursemodels.ErrCourseNotFound)
+	})
+}
@@ -0,0 +1,175 @@
+package controllers
+
+import (
+	"backend/internal/grade/models"
+	"backend/internal/problem"
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type GradeService interface {
+	GetGradebook(ctx context.Context, courseID uuid.UUID) (*models.Gradebook, error)
+	SetGradebook(ctx context.Context, courseID uuid.UUID, gradebook *models.Gradebook) error
+	AddAssessment(ctx context.Context, studentID uuid.UUID, courseID uuid.UUID, assessment *models.Assessment) error
+	DeleteAssessment(ctx context.Context, studentID uuid.UUID, courseID uuid.UUID, id uuid.UUID) error
+	GetAssessments(ctx context.Context, studentID uuid.UUID, courseID uuid.UUID) (models.AssessmentList, error)
+	GetGrade(ctx context.Context, studentID uuid.UUID, courseID uuid.UUID, scale string) (*models.Grade, error)
+	GetGPA(ctx context.Context, studentID uuid.UUID, scale string) (*models.GPAReport, error)
+}
+
+var (
+	errInvalidID           = &problem.ValidationError{Detail: "invalid UUID", Fields: []problem.FieldError{{Field: "id", Code: "uuid", Message: "must be a UUID"}}}
+	errInvalidCourseID     = &problem.ValidationError{Detail: "invalid UUID", Fields: []problem.FieldError{{Field: "course_id", Code: "uuid", Message: "must be a UUID"}}}
+	errInvalidAssessmentID = &problem.ValidationError{Detail: "invalid UUID", Fields: []problem.FieldError{{Field: "assessment_id", Code: "uuid", Message: "must be a UUID"}}}
+	errInvalidRequest      = &problem.ValidationError{Detail: "invalid request"}
+)
+
+type GradeController struct {
+	Service GradeService
+}
+
+func Controller(service GradeService) *GradeController {
+	return &GradeController{Service: service}
+}
+
+// enrollmentIDs parses the student and course of an enrollment route.
+func enrollmentIDs(ctx *gin.Context) (uuid.UUID, uuid.UUID, error) {
+	studentID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errInvalidID
+	}
+	courseID, err := uuid.Parse(ctx.Param("course_id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errInvalidCourseID
+	}
+	return studentID, courseID, nil
+}
+
+func (c *GradeController) GetGradebook(ctx *gin.Context) {
+	courseID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidID)
+		return
+	}
+
+	gradebook, err := c.Service.GetGradebook(ctx.Request.Context(), courseID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gradebook)
+}
+
+// SetGradebook replaces the categories of the course and answers with the
+// saved gradebook.
+func (c *GradeController) SetGradebook(ctx *gin.Context) {
+	courseID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidID)
+		return
+	}
+
+	var gradebook models.Gradebook
+	if err := ctx.ShouldBindJSON(&gradebook); err != nil {
+		ctx.Error(errInvalidRequest)
+		return
+	}
+
+	if err := c.Service.SetGradebook(ctx.Request.Context(), courseID, &gradebook); err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gradebook)
+}
+
+func (c *GradeController) GetAssessments(ctx *gin.Context) {
+	studentID, courseID, err := enrollmentIDs(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	list, err := c.Service.GetAssessments(ctx.Request.Context(), studentID, courseID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, list)
+}
+
+func (c *GradeController) AddAssessment(ctx *gin.Context) {
+	studentID, courseID, err := enrollmentIDs(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	var assessment models.Assessment
+	if err := ctx.ShouldBindJSON(&assessment); err != nil {
+		ctx.Error(errInvalidRequest)
+		return
+	}
+
+	if err := c.Service.AddAssessment(ctx.Request.Context(), studentID, courseID, &assessment); err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, assessment)
+}
+
+func (c *GradeController) DeleteAssessment(ctx *gin.Context) {
+	studentID, courseID, err := enrollmentIDs(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	id, err := uuid.Parse(ctx.Param("assessment_id"))
+	if err != nil {
+		ctx.Error(errInvalidAssessmentID)
+		return
+	}
+
+	if err := c.Service.DeleteAssessment(ctx.Request.Context(), studentID, courseID, id); err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Assessment deleted successfully"})
+}
+
+// GetGrade reports the grade of the student in the course on the scale named
+// by the scale query parameter.
+func (c *GradeController) GetGrade(ctx *gin.Context) {
+	studentID, courseID, err := enrollmentIDs(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	grade, err := c.Service.GetGrade(ctx.Request.Context(), studentID, courseID, strings.TrimSpace(ctx.Query("scale")))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, grade)
+}
+
+// GetGPA reports the term and cumulative GPAs of the student on the scale
+// named by the scale query parameter.
+func (c *GradeController) GetGPA(ctx *gin.Context) {
+	studentID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidID)
+		return
+	}
+
+	report, err := c.Service.GetGPA(ctx.Request.Context(), studentID, strings.TrimSpace(ctx.Query("scale")))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
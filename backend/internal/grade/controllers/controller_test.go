@@ -0,0 +1,175 @@
+package controllers
+
+import (
+	"backend/internal/apitest"
+	"backend/internal/grade/mocks"
+	"backend/internal/grade/models"
+	"backend/internal/problem"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRouter(controller *GradeController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(problem.Middleware())
+	router.GET("/courses/:id/gradebook", controller.GetGradebook)
+	router.PUT("/courses/:id/gradebook", controller.SetGradebook)
+	router.GET("/students/:id/enrollments/:course_id/assessments", controller.GetAssessments)
+	router.POST("/students/:id/enrollments/:course_id/assessments", controller.AddAssessment)
+	router.DELETE("/students/:id/enrollments/:course_id/assessments/:assessment_id", controller.DeleteAssessment)
+	router.GET("/students/:id/enrollments/:course_id/grade", controller.GetGrade)
+	router.GET("/students/:id/gpa", controller.GetGPA)
+	return router
+}
+
+var (
+	studentID     = uuid.MustParse("7995c72f-7d04-4136-8b5f-000d6d4aae23")
+	courseID      = uuid.MustParse("0b6f4b4e-53f4-4f3c-9a36-8d2b8c7c1f10")
+	enrollmentURL = "/students/" + studentID.String() + "/enrollments/" + courseID.String()
+)
+
+func TestSetGradebook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockGradeService(ctrl)
+	router := newRouter(Controller(mockService))
+	gradebook := models.Gradebook{Categories: []models.Category{{Name: "Exams", Weight: 100}}}
+	body, _ := json.Marshal(gradebook)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().SetGradebook(gomock.Any(), courseID, &gradebook).DoAndReturn(func(_ interface{}, _ uuid.UUID, book *models.Gradebook) error {
+			book.CourseID = courseID.String()
+			return nil
+		})
+
+		w := apitest.Request(router, http.MethodPut, "/courses/"+courseID.String()+"/gradebook", body)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var actual models.Gradebook
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+		assert.Equal(t, courseID.String(), actual.CourseID)
+	})
+
+	t.Run("Category In Use", func(t *testing.T) {
+		mockService.EXPECT().SetGradebook(gomock.Any(), courseID, gomock.Any()).Return(models.ErrCategoryInUse)
+
+		w := apitest.Request(router, http.MethodPut, "/courses/"+courseID.String()+"/gradebook", body)
+		apitest.AssertProblem(t, w, http.StatusConflict, models.ErrCategoryInUse.Detail)
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		w := apitest.Request(router, http.MethodPut, "/courses/"+courseID.String()+"/gradebook", []byte("{"))
+		apitest.AssertProblem(t, w, http.StatusBadRequest, "invalid request")
+	})
+}
+
+func TestAddAssessment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockGradeService(ctrl)
+	router := newRouter(Controller(mockService))
+	body := []byte(`{"category": "Exams", "title": "Midterm", "score": 42.5, "maxScore": 50}`)
+
+	t.Run("Success", func(t *testing.T) {
+		expected := &models.Assessment{Category: "Exams", Title: "Midterm", Score: 42.5, MaxScore: 50}
+		mockService.EXPECT().AddAssessment(gomock.Any(), studentID, courseID, expected).Return(nil)
+
+		w := apitest.Request(router, http.MethodPost, enrollmentURL+"/assessments", body)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"maxScore":50,"recordedAt":`)
+	})
+
+	t.Run("Waitlisted", func(t *testing.T) {
+		mockService.EXPECT().AddAssessment(gomock.Any(), studentID, courseID, gomock.Any()).Return(models.ErrNotEnrolled)
+
+		w := apitest.Request(router, http.MethodPost, enrollmentURL+"/assessments", body)
+		apitest.AssertProblem(t, w, http.StatusConflict, models.ErrNotEnrolled.Detail)
+	})
+
+	t.Run("Invalid Course ID", func(t *testing.T) {
+		w := apitest.Request(router, http.MethodPost, "/students/"+studentID.String()+"/enrollments/42/assessments", body)
+		apitest.AssertProblem(t, w, http.StatusBadRequest, "invalid UUID")
+	})
+}
+
+func TestDeleteAssessment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockGradeService(ctrl)
+	router := newRouter(Controller(mockService))
+	id := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().DeleteAssessment(gomock.Any(), studentID, courseID, id).Return(nil)
+
+		w := apitest.Request(router, http.MethodDelete, enrollmentURL+"/assessments/"+id.String(), nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"message": "Assessment deleted successfully"}`, w.Body.String())
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockService.EXPECT().DeleteAssessment(gomock.Any(), studentID, courseID, id).Return(models.ErrAssessmentNotFound)
+
+		w := apitest.Request(router, http.MethodDelete, enrollmentURL+"/assessments/"+id.String(), nil)
+		apitest.AssertProblem(t, w, http.StatusNotFound, models.ErrAssessmentNotFound.Detail)
+	})
+}
+
+func TestGetGrade(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockGradeService(ctrl)
+	router := newRouter(Controller(mockService))
+
+	percent, points := 84.0, 3.0
+	grade := &models.Grade{CourseID: courseID.String(), Percent: &percent, Grade: "BB", Points: &points, Complete: true, Categories: []models.CategoryGrade{}}
+	mockService.EXPECT().GetGrade(gomock.Any(), studentID, courseID, "turkish").Return(grade, nil)
+
+	w := apitest.Request(router, http.MethodGet, enrollmentURL+"/grade?scale=turkish", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var actual models.Grade
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+	assert.Equal(t, *grade, actual)
+}
+
+func TestGetGPA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockGradeService(ctrl)
+	router := newRouter(Controller(mockService))
+
+	t.Run("Success", func(t *testing.T) {
+		gpa := 3.5
+		report := &models.GPAReport{StudentID: studentID.String(), Scale: "letter", Max: 4, Terms: []models.TermGPA{}, Credits: 4, GPA: &gpa}
+		mockService.EXPECT().GetGPA(gomock.Any(), studentID, "").Return(report, nil)
+
+		w := apitest.Request(router, http.MethodGet, "/students/"+studentID.String()+"/gpa", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var actual models.GPAReport
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+		assert.Equal(t, *report, actual)
+	})
+
+	t.Run("Unknown Scale", func(t *testing.T) {
+		mockService.EXPECT().GetGPA(gomock.Any(), studentID, "ects").Return(nil, models.ErrUnknownScale)
+
+		w := apitest.Request(router, http.MethodGet, "/students/"+studentID.String()+"/gpa?scale=ects", nil)
+		apitest.AssertProblem(t, w, http.StatusBadRequest, models.ErrUnknownScale.Detail)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		w := apitest.Request(router, http.MethodGet, "/students/42/gpa", nil)
+		apitest.AssertProblem(t, w, http.StatusBadRequest, "invalid UUID")
+	})
+}
@@ -0,0 +1,241 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/grade/services/service.go
+
+// Package services is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/internal/course/models"
+	models0 "backend/internal/enrollment/models"
+	models1 "backend/internal/grade/models"
+	models2 "backend/internal/student/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddAssessment mocks base method.
+func (m *MockRepository) AddAssessment(ctx context.Context, assessment *models1.Assessment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAssessment", ctx, assessment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAssessment indicates an expected call of AddAssessment.
+func (mr *MockRepositoryMockRecorder) AddAssessment(ctx, assessment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAssessment", reflect.TypeOf((*MockRepository)(nil).AddAssessment), ctx, assessment)
+}
+
+// DeleteAssessment mocks base method.
+func (m *MockRepository) DeleteAssessment(ctx context.Context, studentID, courseID, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAssessment", ctx, studentID, courseID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAssessment indicates an expected call of DeleteAssessment.
+func (mr *MockRepositoryMockRecorder) DeleteAssessment(ctx, studentID, courseID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAssessment", reflect.TypeOf((*MockRepository)(nil).DeleteAssessment), ctx, studentID, courseID, id)
+}
+
+// GetAssessments mocks base method.
+func (m *MockRepository) GetAssessments(studentID, courseID uuid.UUID) ([]models1.Assessment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssessments", studentID, courseID)
+	ret0, _ := ret[0].([]models1.Assessment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssessments indicates an expected call of GetAssessments.
+func (mr *MockRepositoryMockRecorder) GetAssessments(studentID, courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssessments", reflect.TypeOf((*MockRepository)(nil).GetAssessments), studentID, courseID)
+}
+
+// GetGradebook mocks base method.
+func (m *MockRepository) GetGradebook(courseID uuid.UUID) ([]models1.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGradebook", courseID)
+	ret0, _ := ret[0].([]models1.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGradebook indicates an expected call of GetGradebook.
+func (mr *MockRepositoryMockRecorder) GetGradebook(courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGradebook", reflect.TypeOf((*MockRepository)(nil).GetGradebook), courseID)
+}
+
+// GetStudentAssessments mocks base method.
+func (m *MockRepository) GetStudentAssessments(studentID uuid.UUID) ([]models1.Assessment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStudentAssessments", studentID)
+	ret0, _ := ret[0].([]models1.Assessment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStudentAssessments indicates an expected call of GetStudentAssessments.
+func (mr *MockRepositoryMockRecorder) GetStudentAssessments(studentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentAssessments", reflect.TypeOf((*MockRepository)(nil).GetStudentAssessments), studentID)
+}
+
+// SaveGradebook mocks base method.
+func (m *MockRepository) SaveGradebook(ctx context.Context, courseID uuid.UUID, categories []models1.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveGradebook", ctx, courseID, categories)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveGradebook indicates an expected call of SaveGradebook.
+func (mr *MockRepositoryMockRecorder) SaveGradebook(ctx, courseID, categories interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveGradebook", reflect.TypeOf((*MockRepository)(nil).SaveGradebook), ctx, courseID, categories)
+}
+
+// MockStudents is a mock of Students interface.
+type MockStudents struct {
+	ctrl     *gomock.Controller
+	recorder *MockStudentsMockRecorder
+}
+
+// MockStudentsMockRecorder is the mock recorder for MockStudents.
+type MockStudentsMockRecorder struct {
+	mock *MockStudents
+}
+
+// NewMockStudents creates a new mock instance.
+func NewMockStudents(ctrl *gomock.Controller) *MockStudents {
+	mock := &MockStudents{ctrl: ctrl}
+	mock.recorder = &MockStudentsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStudents) EXPECT() *MockStudentsMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockStudents) Get(id uuid.UUID) (*models2.Student, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(*models2.Student)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockStudentsMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStudents)(nil).Get), id)
+}
+
+// MockCourses is a mock of Courses interface.
+type MockCourses struct {
+	ctrl     *gomock.Controller
+	recorder *MockCoursesMockRecorder
+}
+
+// MockCoursesMockRecorder is the mock recorder for MockCourses.
+type MockCoursesMockRecorder struct {
+	mock *MockCourses
+}
+
+// NewMockCourses creates a new mock instance.
+func NewMockCourses(ctrl *gomock.Controller) *MockCourses {
+	mock := &MockCourses{ctrl: ctrl}
+	mock.recorder = &MockCoursesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourses) EXPECT() *MockCoursesMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockCourses) Get(id uuid.UUID) (*models.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(*models.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCoursesMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCourses)(nil).Get), id)
+}
+
+// MockEnrollments is a mock of Enrollments interface.
+type MockEnrollments struct {
+	ctrl     *gomock.Controller
+	recorder *MockEnrollmentsMockRecorder
+}
+
+// MockEnrollmentsMockRecorder is the mock recorder for MockEnrollments.
+type MockEnrollmentsMockRecorder struct {
+	mock *MockEnrollments
+}
+
+// NewMockEnrollments creates a new mock instance.
+func NewMockEnrollments(ctrl *gomock.Controller) *MockEnrollments {
+	mock := &MockEnrollments{ctrl: ctrl}
+	mock.recorder = &MockEnrollmentsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEnrollments) EXPECT() *MockEnrollmentsMockRecorder {
+	return m.recorder
+}
+
+// GetByStudent mocks base method.
+func (m *MockEnrollments) GetByStudent(studentID uuid.UUID, query models0.EnrollmentQuery) ([]models0.Enrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStudent", studentID, query)
+	ret0, _ := ret[0].([]models0.Enrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByStudent indicates an expected call of GetByStudent.
+func (mr *MockEnrollmentsMockRecorder) GetByStudent(studentID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudent", reflect.TypeOf((*MockEnrollments)(nil).GetByStudent), studentID, query)
+}
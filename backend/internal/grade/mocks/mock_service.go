@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/grade/controllers/controller.go
+
+// Package controllers is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/internal/grade/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockGradeService is a mock of GradeService interface.
+type MockGradeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGradeServiceMockRecorder
+}
+
+// MockGradeServiceMockRecorder is the mock recorder for MockGradeService.
+type MockGradeServiceMockRecorder struct {
+	mock *MockGradeService
+}
+
+// NewMockGradeService creates a new mock instance.
+func NewMockGradeService(ctrl *gomock.Controller) *MockGradeService {
+	mock := &MockGradeService{ctrl: ctrl}
+	mock.recorder = &MockGradeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGradeService) EXPECT() *MockGradeServiceMockRecorder {
+	return m.recorder
+}
+
+// AddAssessment mocks base method.
+func (m *MockGradeService) AddAssessment(ctx context.Context, studentID, courseID uuid.UUID, assessment *models.Assessment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAssessment", ctx, studentID, courseID, assessment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAssessment indicates an expected call of AddAssessment.
+func (mr *MockGradeServiceMockRecorder) AddAssessment(ctx, studentID, courseID, assessment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAssessment", reflect.TypeOf((*MockGradeService)(nil).AddAssessment), ctx, studentID, courseID, assessment)
+}
+
+// DeleteAssessment mocks base method.
+func (m *MockGradeService) DeleteAssessment(ctx context.Context, studentID, courseID, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAssessment", ctx, studentID, courseID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAssessment indicates an expected call of DeleteAssessment.
+func (mr *MockGradeServiceMockRecorder) DeleteAssessment(ctx, studentID, courseID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAssessment", reflect.TypeOf((*MockGradeService)(nil).DeleteAssessment), ctx, studentID, courseID, id)
+}
+
+// GetAssessments mocks base method.
+func (m *MockGradeService) GetAssessments(ctx context.Context, studentID, courseID uuid.UUID) (models.AssessmentList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssessments", ctx, studentID, courseID)
+	ret0, _ := ret[0].(models.AssessmentList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssessments indicates an expected call of GetAssessments.
+func (mr *MockGradeServiceMockRecorder) GetAssessments(ctx, studentID, courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssessments", reflect.TypeOf((*MockGradeService)(nil).GetAssessments), ctx, studentID, courseID)
+}
+
+// GetGPA mocks base method.
+func (m *MockGradeService) GetGPA(ctx context.Context, studentID uuid.UUID, scale string) (*models.GPAReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGPA", ctx, studentID, scale)
+	ret0, _ := ret[0].(*models.GPAReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGPA indicates an expected call of GetGPA.
+func (mr *MockGradeServiceMockRecorder) GetGPA(ctx, studentID, scale interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGPA", reflect.TypeOf((*MockGradeService)(nil).GetGPA), ctx, studentID, scale)
+}
+
+// GetGrade mocks base method.
+func (m *MockGradeService) GetGrade(ctx context.Context, studentID, courseID uuid.UUID, scale string) (*models.Grade, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrade", ctx, studentID, courseID, scale)
+	ret0, _ := ret[0].(*models.Grade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrade indicates an expected call of GetGrade.
+func (mr *MockGradeServiceMockRecorder) GetGrade(ctx, studentID, courseID, scale interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrade", reflect.TypeOf((*MockGradeService)(nil).GetGrade), ctx, studentID, courseID, scale)
+}
+
+// GetGradebook mocks base method.
+func (m *MockGradeService) GetGradebook(ctx context.Context, courseID uuid.UUID) (*models.Gradebook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGradebook", ctx, courseID)
+	ret0, _ := ret[0].(*models.Gradebook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGradebook indicates an expected call of GetGradebook.
+func (mr *MockGradeServiceMockRecorder) GetGradebook(ctx, courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGradebook", reflect.TypeOf((*MockGradeService)(nil).GetGradebook), ctx, courseID)
+}
+
+// SetGradebook mocks base method.
+func (m *MockGradeService) SetGradebook(ctx context.Context, courseID uuid.UUID, gradebook *models.Gradebook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGradebook", ctx, courseID, gradebook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetGradebook indicates an expected call of SetGradebook.
+func (mr *MockGradeServiceMockRecorder) SetGradebook(ctx, courseID, gradebook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGradebook", reflect.TypeOf((*MockGradeService)(nil).SetGradebook), ctx, courseID, gradebook)
+}
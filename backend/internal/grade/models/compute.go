@@ -0,0 +1,116 @@
+package models
+
+import (
+	"backend/internal/course/models"
+	"math"
+	"sort"
+)
+
+// round rounds to two decimals, half away from zero. Percentages are rounded
+// before they are graded, so that a grade never hangs on floating-point noise.
+func round(x float64) float64 {
+	return math.Round(x*100) / 100
+}
+
+// ComputeGrade computes the final grade of a student in course from the
+// categories of its gradebook and the student's assessments in it. A category
+// scores the sum of its scores over the sum of their maximums; categories
+// without assessments are left out until they have some. The result does not
+// depend on the order of categories and assessments, and sums are taken in a
+// fixed order, so recomputing a grade always gives the same result.
+func ComputeGrade(course models.Course, categories []Category, assessments []Assessment, scale Scale) Grade {
+	grade := Grade{
+		CourseID:   course.ID,
+		Code:       course.Code,
+		Title:      course.Title,
+		Credits:    course.Credits,
+		Term:       course.Term,
+		Complete:   len(categories) > 0,
+		Categories: []CategoryGrade{},
+	}
+
+	categories = append([]Category(nil), categories...)
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+	assessments = append([]Assessment(nil), assessments...)
+	sort.Slice(assessments, func(i, j int) bool { return assessments[i].ID < assessments[j].ID })
+
+	var weighted, weights float64
+	for _, category := range categories {
+		var score, max float64
+		for _, assessment := range assessments {
+			if assessment.Category == category.Name {
+				score += assessment.Score
+				max += assessment.MaxScore
+			}
+		}
+		categoryGrade := CategoryGrade{Name: category.Name, Weight: category.Weight}
+		if max > 0 {
+			percent := 100 * score / max
+			rounded := round(percent)
+			categoryGrade.Percent = &rounded
+			weighted += float64(category.Weight) * percent
+			weights += float64(category.Weight)
+		} else {
+			grade.Complete = false
+		}
+		grade.Categories = append(grade.Categories, categoryGrade)
+	}
+
+	if weights > 0 {
+		percent := round(weighted / weights)
+		letter, points := scale.Grade(percent)
+		grade.Percent, grade.Grade, grade.Points = &percent, letter, &points
+	}
+	return grade
+}
+
+// ComputeGPA groups grades by term and averages their points weighted by
+// credits, per term and overall. Ungraded courses and courses without credits
+// are listed but not counted.
+func ComputeGPA(grades []Grade, scale Scale) GPAReport {
+	grades = append([]Grade(nil), grades...)
+	sort.Slice(grades, func(i, j int) bool {
+		if c := models.CompareTerms(grades[i].Term, grades[j].Term); c != 0 {
+			return c < 0
+		}
+		if grades[i].Code != grades[j].Code {
+			return grades[i].Code < grades[j].Code
+		}
+		return grades[i].CourseID < grades[j].CourseID
+	})
+
+	report := GPAReport{Scale: scale.Name, Max: scale.Max(), Terms: []TermGPA{}}
+	var points float64
+	for _, grade := range grades {
+		if len(report.Terms) == 0 || report.Terms[len(report.Terms)-1].Term != grade.Term {
+			report.Terms = append(report.Terms, TermGPA{Term: grade.Term})
+		}
+		report.Terms[len(report.Terms)-1].Courses = append(report.Terms[len(report.Terms)-1].Courses, grade)
+		if grade.Points != nil && grade.Credits > 0 {
+			report.Credits += grade.Credits
+			points += float64(grade.Credits) * *grade.Points
+		}
+	}
+	report.GPA = average(points, report.Credits)
+
+	for i := range report.Terms {
+		term := &report.Terms[i]
+		var termPoints float64
+		for _, grade := range term.Courses {
+			if grade.Points != nil && grade.Credits > 0 {
+				term.Credits += grade.Credits
+				termPoints += float64(grade.Credits) * *grade.Points
+			}
+		}
+		term.GPA = average(termPoints, term.Credits)
+	}
+	return report
+}
+
+func average(points float64, credits int) *float64 {
+	if credits == 0 {
+		return nil
+	}
+	gpa := round(points / float64(credits))
+	return &gpa
+}
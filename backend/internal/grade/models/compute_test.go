@@ -0,0 +1,135 @@
+package models
+
+import (
+	coursemodels "backend/internal/course/models"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	course     = coursemodels.Course{ID: "c1", Code: "CENG 242", Title: "Programming Language Concepts", Credits: 4, Term: "2026-fall"}
+	categories = []Category{{Name: "Homework", Weight: 30}, {Name: "Exams", Weight: 70}}
+)
+
+func assessment(id, category string, score, max float64) Assessment {
+	return Assessment{ID: id, Category: category, Score: score, MaxScore: max}
+}
+
+func TestScales(t *testing.T) {
+	scales := BuiltinScales()
+	for _, tc := range []struct {
+		Scale   string
+		Percent float64
+		Grade   string
+		Points  float64
+	}{
+		{"letter", 90, "A", 4},
+		{"letter", 89.99, "B", 3},
+		{"letter", 12, "F", 0},
+		{"4.0", 88, "3.3", 3.3},
+		{"4.0", 59.5, "0.0", 0},
+		{"100", 87.25, "87.25", 87.25},
+		{"turkish", 86, "BA", 3.5},
+		{"turkish", 55, "FD", 0.5},
+		{"turkish", 49.99, "FF", 0},
+	} {
+		grade, points := scales[tc.Scale].Grade(tc.Percent)
+		assert.Equal(t, tc.Grade, grade, "%s %v", tc.Scale, tc.Percent)
+		assert.Equal(t, tc.Points, points, "%s %v", tc.Scale, tc.Percent)
+	}
+	assert.Equal(t, 4.0, scales["turkish"].Max())
+	assert.Equal(t, 100.0, scales["100"].Max())
+}
+
+func TestComputeGrade(t *testing.T) {
+	t.Run("Weighted", func(t *testing.T) {
+		grade := ComputeGrade(course, categories, []Assessment{
+			assessment("a1", "Homework", 8, 10),
+			assessment("a2", "Homework", 10, 10),
+			assessment("a3", "Exams", 45, 50),
+			assessment("a4", "Exams", 80, 100),
+		}, BuiltinScales()["turkish"])
+
+		// Homework 90%, exams 125/150 = 83.33%: 0.3 * 90 + 0.7 * 83.33 = 85.33.
+		require.NotNil(t, grade.Percent)
+		assert.Equal(t, 85.33, *grade.Percent)
+		assert.Equal(t, "BA", grade.Grade)
+		assert.Equal(t, 3.5, *grade.Points)
+		assert.True(t, grade.Complete)
+		assert.Equal(t, "Exams", grade.Categories[0].Name)
+		assert.Equal(t, 83.33, *grade.Categories[0].Percent)
+	})
+
+	t.Run("Incomplete", func(t *testing.T) {
+		grade := ComputeGrade(course, categories, []Assessment{assessment("a1", "Homework", 7, 10)}, BuiltinScales()["letter"])
+		assert.Equal(t, 70.0, *grade.Percent, "the graded categories make up the whole grade")
+		assert.Equal(t, "C", grade.Grade)
+		assert.False(t, grade.Complete)
+		assert.Nil(t, grade.Categories[0].Percent)
+	})
+
+	t.Run("Ungraded", func(t *testing.T) {
+		grade := ComputeGrade(course, categories, nil, BuiltinScales()["letter"])
+		assert.Nil(t, grade.Percent)
+		assert.Nil(t, grade.Points)
+		assert.Empty(t, grade.Grade)
+		assert.False(t, grade.Complete)
+	})
+
+	t.Run("Deterministic", func(t *testing.T) {
+		categories := append([]Category(nil), categories...)
+		var assessments []Assessment
+		for i, score := range []float64{0.1, 0.2, 0.3, 7.7, 3.3, 9.9, 1.1, 2.2} {
+			category := "Homework"
+			if i%2 == 1 {
+				category = "Exams"
+			}
+			assessments = append(assessments, assessment(string(rune('a'+i)), category, score, 10))
+		}
+		expected := ComputeGrade(course, categories, assessments, BuiltinScales()["100"])
+
+		random := rand.New(rand.NewSource(1))
+		for i := 0; i < 50; i++ {
+			random.Shuffle(len(assessments), func(i, j int) { assessments[i], assessments[j] = assessments[j], assessments[i] })
+			random.Shuffle(len(categories), func(i, j int) { categories[i], categories[j] = categories[j], categories[i] })
+			assert.Equal(t, expected, ComputeGrade(course, categories, assessments, BuiltinScales()["100"]))
+		}
+	})
+}
+
+func TestComputeGPA(t *testing.T) {
+	points := func(p float64) *float64 { return &p }
+	report := ComputeGPA([]Grade{
+		{CourseID: "c3", Code: "CENG 351", Credits: 3, Term: "2027-spring", Points: points(2)},
+		{CourseID: "c1", Code: "CENG 242", Credits: 4, Term: "2026-fall", Points: points(4)},
+		{CourseID: "c2", Code: "CENG 213", Credits: 3, Term: "2026-fall", Points: points(3)},
+		{CourseID: "c4", Code: "MATH 119", Credits: 4, Term: "2027-spring"},
+		{CourseID: "c5", Code: "PE 101", Credits: 0, Term: "2027-spring", Points: points(0)},
+	}, BuiltinScales()["letter"])
+
+	assert.Equal(t, "letter", report.Scale)
+	assert.Equal(t, 4.0, report.Max)
+	require.Len(t, report.Terms, 2)
+
+	fall := report.Terms[0]
+	assert.Equal(t, "2026-fall", fall.Term)
+	assert.Equal(t, 7, fall.Credits)
+	assert.Equal(t, 3.57, *fall.GPA)
+	assert.Equal(t, "CENG 213", fall.Courses[0].Code)
+
+	spring := report.Terms[1]
+	assert.Equal(t, 3, spring.Credits, "ungraded courses and courses without credits do not count")
+	assert.Equal(t, 2.0, *spring.GPA)
+	assert.Len(t, spring.Courses, 3)
+
+	// (4 * 4 + 3 * 3 + 3 * 2) / 10
+	assert.Equal(t, 10, report.Credits)
+	assert.Equal(t, 3.1, *report.GPA)
+
+	empty := ComputeGPA(nil, BuiltinScales()["letter"])
+	assert.Nil(t, empty.GPA)
+	assert.Empty(t, empty.Terms)
+}
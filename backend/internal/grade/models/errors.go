@@ -0,0 +1,14 @@
+package models
+
+import "backend/internal/problem"
+
+var (
+	ErrAssessmentNotFound = &problem.NotFoundError{Detail: "assessment not found"}
+	ErrNotEnrolled        = &problem.ConflictError{Detail: "the student is on the waitlist of this course and cannot be graded"}
+	ErrCategoryInUse      = &problem.ConflictError{Detail: "categories with assessments cannot be removed from the gradebook"}
+	ErrInvalidWeights     = &problem.ValidationError{Detail: "invalid gradebook", Fields: []problem.FieldError{{Field: "categories", Code: "weights", Message: "weights must add up to 100"}}}
+	ErrDuplicateCategory  = &problem.ValidationError{Detail: "invalid gradebook", Fields: []problem.FieldError{{Field: "categories", Code: "unique", Message: "names must be unique"}}}
+	ErrUnknownCategory    = &problem.ValidationError{Detail: "invalid assessment", Fields: []problem.FieldError{{Field: "category", Code: "category", Message: "is not a category of the course's gradebook"}}}
+	ErrScoreAboveMax      = &problem.ValidationError{Detail: "invalid assessment", Fields: []problem.FieldError{{Field: "score", Code: "max", Message: "must be at most maxScore"}}}
+	ErrUnknownScale       = &problem.ValidationError{Detail: "unknown grading scale", Fields: []problem.FieldError{{Field: "scale", Code: "scale", Message: "is not a configured grading scale"}}}
+)
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Category is a weighted part of the final grade of a course, such as homework
+// or exams.
+type Category struct {
+	Name string `json:"name" validate:"trim,required,max=50"`
+	// Weight is the share of the final grade in percent. The weights of a
+	// gradebook add up to 100.
+	Weight int `json:"weight" validate:"min=1,max=100"`
+}
+
+// Gradebook lists the grade categories of a course.
+type Gradebook struct {
+	CourseID   string     `json:"courseId"`
+	Categories []Category `json:"categories"`
+}
+
+type CategoryEntity struct {
+	ID       uuid.UUID `gorm:"primary_key;type:char(36)"`
+	CourseID uuid.UUID `gorm:"type:char(36);uniqueIndex:idx_grade_categories_course_name"`
+	Name     string    `gorm:"size:50;uniqueIndex:idx_grade_categories_course_name"`
+	Weight   int
+}
+
+func (CategoryEntity) TableName() string {
+	return "grade_categories"
+}
+
+// Assessment is the result of a student in one homework, exam or other
+// assessment of a course, counted in the category it belongs to.
+type Assessment struct {
+	ID         string    `json:"id"`
+	StudentID  string    `json:"studentId"`
+	CourseID   string    `json:"courseId"`
+	Category   string    `json:"category" validate:"trim,required,max=50"`
+	Title      string    `json:"title" validate:"trim,required,max=100"`
+	Score      float64   `json:"score" validate:"min=0"`
+	MaxScore   float64   `json:"maxScore" validate:"min=1"`
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+type AssessmentEntity struct {
+	ID         uuid.UUID `gorm:"primary_key;type:char(36)"`
+	StudentID  uuid.UUID `gorm:"type:char(36);index:idx_assessments_student_course"`
+	CourseID   uuid.UUID `gorm:"type:char(36);index:idx_assessments_student_course;index:idx_assessments_course_category"`
+	Category   string    `gorm:"size:50;index:idx_assessments_course_category"`
+	Title      string    `gorm:"size:100"`
+	Score      float64
+	MaxScore   float64
+	RecordedAt time.Time
+}
+
+func (AssessmentEntity) TableName() string {
+	return "assessments"
+}
+
+// AssessmentList holds the assessments of a student in a course, in the order
+// they were recorded.
+type AssessmentList struct {
+	Assessments []Assessment `json:"assessments"`
+}
+
+// CategoryGrade is the result of a student in one category of a course.
+type CategoryGrade struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+	// Percent is the sum of the scores over the sum of their maximums, or nil
+	// while the category has no assessments.
+	Percent *float64 `json:"percent"`
+}
+
+// Grade is the final grade of a student in a course. Percent, Grade and Points
+// are empty until an assessment is recorded.
+type Grade struct {
+	CourseID string `json:"courseId"`
+	Code     string `json:"code"`
+	Title    string `json:"title"`
+	Credits  int    `json:"credits"`
+	Term     string `json:"term,omitempty"`
+	// Percent is the weighted average of the graded categories, rounded to two
+	// decimals.
+	Percent *float64 `json:"percent"`
+	Grade   string   `json:"grade,omitempty"`
+	// Points is what the grade counts for in a GPA.
+	Points *float64 `json:"points"`
+	// Complete is set once every category is graded. Until then, the weights
+	// of the graded categories are scaled up to make 100.
+	Complete   bool            `json:"complete"`
+	Categories []CategoryGrade `json:"categories"`
+}
+
+// TermGPA is the GPA of a student in the courses of one term.
+type TermGPA struct {
+	Term string `json:"term"`
+	// Credits adds up the credits of the graded courses.
+	Credits int `json:"credits"`
+	// GPA is the average of the points of the graded courses weighted by their
+	// credits, rounded to two decimals, or nil when none is graded.
+	GPA     *float64 `json:"gpa"`
+	Courses []Grade  `json:"courses"`
+}
+
+// GPAReport holds the term and cumulative GPAs of a student on a scale.
+type GPAReport struct {
+	StudentID string `json:"studentId"`
+	Scale     string `json:"scale"`
+	// Max is the highest GPA of the scale.
+	Max float64 `json:"max"`
+	// Terms are in chronological order.
+	Terms   []TermGPA `json:"terms"`
+	Credits int       `json:"credits"`
+	GPA     *float64  `json:"gpa"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import "strconv"
+
+// Band is a grade of a scale, earned from Min percent up to the Min of the
+// grade above it.
+type Band struct {
+	Min    float64 `json:"min"`
+	Grade  string  `json:"grade"`
+	Points float64 `json:"points"`
+}
+
+// Scale turns percentages into grades. Its bands run from the highest grade
+// down; a scale without bands reports the percentage itself.
+type Scale struct {
+	Name  string
+	Bands []Band
+}
+
+// Grade returns the grade percent earns and the points it counts for in a
+// GPA.
+func (s Scale) Grade(percent float64) (string, float64) {
+	if len(s.Bands) == 0 {
+		return strconv.FormatFloat(percent, 'f', -1, 64), percent
+	}
+	for _, band := range s.Bands {
+		if percent >= band.Min {
+			return band.Grade, band.Points
+		}
+	}
+	last := s.Bands[len(s.Bands)-1]
+	return last.Grade, last.Points
+}
+
+// Max returns the points of the highest grade.
+func (s Scale) Max() float64 {
+	if len(s.Bands) == 0 {
+		return 100
+	}
+	return s.Bands[0].Points
+}
+
+// Scales holds grading scales by name.
+type Scales map[string]Scale
+
+// BuiltinScales returns the letter, 4.0, 100-point and Turkish scales.
+func BuiltinScales() Scales {
+	return Scales{
+		"letter": {Name: "letter", Bands: []Band{
+			{90, "A", 4}, {80, "B", 3}, {70, "C", 2}, {60, "D", 1}, {0, "F", 0},
+		}},
+		"4.0": {Name: "4.0", Bands: []Band{
+			{93, "4.0", 4}, {90, "3.7", 3.7}, {87, "3.3", 3.3}, {83, "3.0", 3}, {80, "2.7", 2.7}, {77, "2.3", 2.3},
+			{73, "2.0", 2}, {70, "1.7", 1.7}, {67, "1.3", 1.3}, {63, "1.0", 1}, {60, "0.7", 0.7}, {0, "0.0", 0},
+		}},
+		"100": {Name: "100"},
+		"turkish": {Name: "turkish", Bands: []Band{
+			{90, "AA", 4}, {85, "BA", 3.5}, {80, "BB", 3}, {75, "CB", 2.5}, {70, "CC", 2},
+			{65, "DC", 1.5}, {60, "DD", 1}, {50, "FD", 0.5}, {0, "FF", 0},
+		}},
+	}
+}
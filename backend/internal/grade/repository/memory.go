@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"backend/internal/grade/models"
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// memoryRepository keeps gradebooks and assessments in process memory. It is
+// safe for concurrent use and meant for local development and tests.
+type memoryRepository struct {
+	mu          sync.RWMutex
+	gradebooks  map[uuid.UUID][]models.Category
+	assessments map[uuid.UUID]models.AssessmentEntity
+}
+
+func NewMemoryRepository() *memoryRepository {
+	return &memoryRepository{gradebooks: map[uuid.UUID][]models.Category{}, assessments: map[uuid.UUID]models.AssessmentEntity{}}
+}
+
+func (r *memoryRepository) GetGradebook(courseID uuid.UUID) ([]models.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categories := append([]models.Category{}, r.gradebooks[courseID]...)
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+	return categories, nil
+}
+
+func (r *memoryRepository) SaveGradebook(ctx context.Context, courseID uuid.UUID, categories []models.Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := map[string]bool{}
+	for _, category := range categories {
+		if names[category.Name] {
+			return models.ErrDuplicateCategory
+		}
+		names[category.Name] = true
+	}
+	for _, entity := range r.assessments {
+		if entity.CourseID == courseID && !names[entity.Category] {
+			return models.ErrCategoryInUse
+		}
+	}
+	r.gradebooks[courseID] = append([]models.Category{}, categories...)
+	return nil
+}
+
+func (r *memoryRepository) AddAssessment(ctx context.Context, assessment *models.Assessment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entity := ModelToEntity(assessment)
+	r.assessments[entity.ID] = *entity
+	return nil
+}
+
+func (r *memoryRepository) DeleteAssessment(ctx context.Context, studentID uuid.UUID, courseID uuid.UUID, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entity, ok := r.assessments[id]
+	if !ok || entity.StudentID != studentID || entity.CourseID != courseID {
+		return models.ErrAssessmentNotFound
+	}
+	delete(r.assessments, id)
+	return nil
+}
+
+func (r *memoryRepository) GetAssessments(studentID uuid.UUID, courseID uuid.UUID) ([]models.Assessment, error) {
+	return r.find(func(entity *models.AssessmentEntity) bool {
+		return entity.StudentID == studentID && entity.CourseID == courseID
+	}), nil
+}
+
+func (r *memoryRepository) GetStudentAssessments(studentID uuid.UUID) ([]models.Assessment, error) {
+	return r.find(func(entity *models.AssessmentEntity) bool { return entity.StudentID == studentID }), nil
+}
+
+// find returns the assessments keep accepts, in the order they were recorded.
+func (r *memoryRepository) find(keep func(entity *models.AssessmentEntity) bool) []models.Assessment {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	assessments := []models.Assessment{}
+	for _, entity := range r.assessments {
+		if keep(&entity) {
+			assessments = append(assessments, *EntityToModel(&entity))
+		}
+	}
+	sort.Slice(assessments, func(i, j int) bool {
+		if !assessments[i].RecordedAt.Equal(assessments[j].RecordedAt) {
+			return assessments[i].RecordedAt.Before(assessments[j].RecordedAt)
+		}
+		return assessments[i].ID < assessments[j].ID
+	})
+	return assessments
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"backend/internal/grade/models"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type gradeRepository struct {
+	DB *gorm.DB
+}
+
+// NewGradeRepository stores gradebooks and assessments in db, whose schema
+// schema.Migrate keeps up to date.
+func NewGradeRepository(db *gorm.DB) (*gradeRepository, error) {
+	return &gradeRepository{DB: db}, nil
+}
+
+// GetGradebook returns the categories of the course by name.
+func (r *gradeRepository) GetGradebook(courseID uuid.UUID) ([]models.Category, error) {
+	var entities []models.CategoryEntity
+	if err := r.DB.Where("course_id = ?", courseID).Order("name").Find(&entities).Error; err != nil {
+		return nil, translateError(err)
+	}
+	categories := []models.Category{}
+	for _, entity := range entities {
+		categories = append(categories, models.Category{Name: entity.Name, Weight: entity.Weight})
+	}
+	return categories, nil
+}
+
+// SaveGradebook replaces the categories of the course. Categories that have
+// assessments cannot be removed.
+func (r *gradeRepository) SaveGradebook(ctx context.Context, courseID uuid.UUID, categories []models.Category) error {
+	names := []string{}
+	entities := []models.CategoryEntity{}
+	for _, category := range categories {
+		names = append(names, category.Name)
+		entities = append(entities, models.CategoryEntity{ID: uuid.New(), CourseID: courseID, Name: category.Name, Weight: category.Weight})
+	}
+	return translateError(r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var removed int64
+		db := tx.Model(&models.AssessmentEntity{}).Where("course_id = ?", courseID)
+		if len(names) > 0 {
+			db = db.Where("category NOT IN ?", names)
+		}
+		if err := db.Count(&removed).Error; err != nil {
+			return err
+		}
+		if removed > 0 {
+			return models.ErrCategoryInUse
+		}
+
+		if err := tx.Where("course_id = ?", courseID).Delete(&models.CategoryEntity{}).Error; err != nil {
+			return err
+		}
+		if len(entities) == 0 {
+			return nil
+		}
+		return tx.Create(&entities).Error
+	}))
+}
+
+func (r *gradeRepository) AddAssessment(ctx context.Context, assessment *models.Assessment) error {
+	return translateError(r.DB.WithContext(ctx).Create(ModelToEntity(assessment)).Error)
+}
+
+func (r *gradeRepository) DeleteAssessment(ctx context.Context, studentID uuid.UUID, courseID uuid.UUID, id uuid.UUID) error {
+	result := r.DB.WithContext(ctx).Where("id = ? AND student_id = ? AND course_id = ?", id, studentID, courseID).Delete(&models.AssessmentEntity{})
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrAssessmentNotFound
+	}
+	return nil
+}
+
+// GetAssessments returns the assessments of the student in the course, in the
+// order they were recorded.
+func (r *gradeRepository) GetAssessments(studentID uuid.UUID, courseID uuid.UUID) ([]models.Assessment, error) {
+	return r.find(r.DB.Where("student_id = ? AND course_id = ?", studentID, courseID))
+}
+
+// GetStudentAssessments returns the assessments of the student in every course.
+func (r *gradeRepository) GetStudentAssessments(studentID uuid.UUID) ([]models.Assessment, error) {
+	return r.find(r.DB.Where("student_id = ?", studentID))
+}
+
+func (r *gradeRepository) find(db *gorm.DB) ([]models.Assessment, error) {
+	var entities []models.AssessmentEntity
+	if err := db.Order("recorded_at").Order("id").Find(&entities).Error; err != nil {
+		return nil, translateError(err)
+	}
+	assessments := []models.Assessment{}
+	for i := range entities {
+		assessments = append(assessments, *EntityToModel(&entities[i]))
+	}
+	return assessments, nil
+}
+
+// DeleteStudent keeps the assessments of a deleted student, who gets them back
+// when restored.
+func (r *gradeRepository) DeleteStudent(tx *gorm.DB, id uuid.UUID) error {
+	return nil
+}
+
+// PurgeStudents deletes the assessments of the students. The student
+// repository calls it in the transaction that purges them.
+func (r *gradeRepository) PurgeStudents(tx *gorm.DB, ids []uuid.UUID) error {
+	return tx.Where("student_id IN ?", ids).Delete(&models.AssessmentEntity{}).Error
+}
+
+func ModelToEntity(assessment *models.Assessment) *models.AssessmentEntity {
+	return &models.AssessmentEntity{
+		ID:         uuid.MustParse(assessment.ID),
+		StudentID:  uuid.MustParse(assessment.StudentID),
+		CourseID:   uuid.MustParse(assessment.CourseID),
+		Category:   assessment.Category,
+		Title:      assessment.Title,
+		Score:      assessment.Score,
+		MaxScore:   assessment.MaxScore,
+		RecordedAt: assessment.RecordedAt,
+	}
+}
+
+func EntityToModel(entity *models.AssessmentEntity) *models.Assessment {
+	return &models.Assessment{
+		ID:         entity.ID.String(),
+		StudentID:  entity.StudentID.String(),
+		CourseID:   entity.CourseID.String(),
+		Category:   entity.Category,
+		Title:      entity.Title,
+		Score:      entity.Score,
+		MaxScore:   entity.MaxScore,
+		RecordedAt: entity.RecordedAt,
+	}
+}
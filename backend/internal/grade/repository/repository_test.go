@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"backend/internal/database/databasetest"
+	"backend/internal/grade/models"
+	"backend/internal/grade/services"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type fixture struct {
+	repo services.Repository
+	// db stores the students and courses the SQL repository refers to; the
+	// memory repository does not look them up.
+	db *gorm.DB
+}
+
+func newMemoryFixture(t *testing.T) *fixture {
+	return &fixture{repo: NewMemoryRepository()}
+}
+
+func newSQLiteFixture(t *testing.T) *fixture {
+	store := databasetest.Open(t)
+	repo, err := NewGradeRepository(store.DB)
+	require.NoError(t, err)
+	return &fixture{repo: repo, db: store.DB}
+}
+
+// TestRepositories runs the same checks against every implementation.
+func TestRepositories(t *testing.T) {
+	databasetest.Run(t, map[string]func(t *testing.T) *fixture{
+		"Memory": newMemoryFixture,
+		"SQLite": newSQLiteFixture,
+	}, map[string]func(t *testing.T, f *fixture){
+		"Gradebook":   testGradebook,
+		"Assessments": testAssessments,
+	})
+}
+
+// TestPurgeStudents checks that purging students takes their assessments with
+// them. The memory repositories of students and assessments are unrelated, so
+// only the SQL one is checked.
+func TestPurgeStudents(t *testing.T) {
+	ctx := context.Background()
+	store := databasetest.Open(t)
+	repo, err := NewGradeRepository(store.DB)
+	require.NoError(t, err)
+	store.Attach(repo)
+
+	courseID := databasetest.AddCourse(t, store.DB)
+	purged, kept := databasetest.AddStudent(t, store.DB), databasetest.AddStudent(t, store.DB)
+	require.NoError(t, repo.SaveGradebook(ctx, courseID, []models.Category{{Name: "Exams", Weight: 100}}))
+	for _, studentID := range []uuid.UUID{purged, kept} {
+		require.NoError(t, repo.AddAssessment(ctx, newAssessment(studentID, courseID, "Exams", 0)))
+	}
+
+	require.NoError(t, store.Students.Delete(ctx, purged))
+	assessments, err := repo.GetStudentAssessments(purged)
+	require.NoError(t, err)
+	assert.Len(t, assessments, 1, "deleted students keep their assessments")
+	_, err = store.Students.Purge(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	assessments, err = repo.GetStudentAssessments(purged)
+	require.NoError(t, err)
+	assert.Empty(t, assessments, "the assessments of purged students are deleted")
+	assessments, err = repo.GetStudentAssessments(kept)
+	require.NoError(t, err)
+	assert.Len(t, assessments, 1)
+}
+
+var recordedAt = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func newAssessment(studentID, courseID uuid.UUID, category string, at time.Duration) *models.Assessment {
+	return &models.Assessment{
+		ID:         uuid.New().String(),
+		StudentID:  studentID.String(),
+		CourseID:   courseID.String(),
+		Category:   category,
+		Title:      category + " 1",
+		Score:      42,
+		MaxScore:   50,
+		RecordedAt: recordedAt.Add(at),
+	}
+}
+
+func testGradebook(t *testing.T, f *fixture) {
+	ctx, repo := context.Background(), f.repo
+	courseID, studentID := databasetest.AddCourse(t, f.db), databasetest.AddStudent(t, f.db)
+
+	categories, err := repo.GetGradebook(courseID)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Category{}, categories)
+
+	require.NoError(t, repo.SaveGradebook(ctx, courseID, []models.Category{{Name: "Homework", Weight: 40}, {Name: "Exams", Weight: 60}}))
+	categories, err = repo.GetGradebook(courseID)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Category{{Name: "Exams", Weight: 60}, {Name: "Homework", Weight: 40}}, categories)
+
+	// Saving replaces the categories.
+	require.NoError(t, repo.SaveGradebook(ctx, courseID, []models.Category{{Name: "Exams", Weight: 70}, {Name: "Project", Weight: 30}}))
+	categories, err = repo.GetGradebook(courseID)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Category{{Name: "Exams", Weight: 70}, {Name: "Project", Weight: 30}}, categories)
+
+	// A graded category has to stay.
+	require.NoError(t, repo.AddAssessment(ctx, newAssessment(studentID, courseID, "Project", 0)))
+	assert.ErrorIs(t, repo.SaveGradebook(ctx, courseID, []models.Category{{Name: "Exams", Weight: 100}}), models.ErrCategoryInUse)
+	categories, err = repo.GetGradebook(courseID)
+	require.NoError(t, err)
+	assert.Len(t, categories, 2)
+
+	assert.ErrorIs(t, repo.SaveGradebook(ctx, courseID, []models.Category{{Name: "Project", Weight: 50}, {Name: "Project", Weight: 50}}), models.ErrDuplicateCategory)
+}
+
+func testAssessments(t *testing.T, f *fixture) {
+	ctx, repo := context.Background(), f.repo
+	studentID, courseID, otherCourseID := databasetest.AddStudent(t, f.db), databasetest.AddCourse(t, f.db), databasetest.AddCourse(t, f.db)
+	late := newAssessment(studentID, courseID, "Exams", time.Hour)
+	early := newAssessment(studentID, courseID, "Homework", 0)
+	other := newAssessment(studentID, otherCourseID, "Exams", 0)
+	stranger := newAssessment(databasetest.AddStudent(t, f.db), courseID, "Exams", 0)
+	for _, assessment := range []*models.Assessment{late, early, other, stranger} {
+		require.NoError(t, repo.AddAssessment(ctx, assessment))
+	}
+
+	assessments, err := repo.GetAssessments(studentID, courseID)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Assessment{*early, *late}, assessments)
+
+	assessments, err = repo.GetStudentAssessments(studentID)
+	require.NoError(t, err)
+	assert.Len(t, assessments, 3)
+
+	// An assessment is only found under its own student and course.
+	assert.ErrorIs(t, repo.DeleteAssessment(ctx, studentID, otherCourseID, uuid.MustParse(late.ID)), models.ErrAssessmentNotFound)
+	require.NoError(t, repo.DeleteAssessment(ctx, studentID, courseID, uuid.MustParse(late.ID)))
+	assert.ErrorIs(t, repo.DeleteAssessment(ctx, studentID, courseID, uuid.MustParse(late.ID)), models.ErrAssessmentNotFound)
+
+	assessments, err = repo.GetAssessments(studentID, courseID)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Assessment{*early}, assessments)
+}
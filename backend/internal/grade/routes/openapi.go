@@ -0,0 +1,95 @@
+package routes
+
+import (
+	"backend/internal/auth"
+	"backend/internal/grade/models"
+	"backend/internal/openapi"
+	"net/http"
+)
+
+var problemDescriptions = map[int]string{
+	http.StatusBadRequest:          "The request is invalid; errors lists the invalid fields",
+	http.StatusNotFound:            "No such student, course, enrollment or assessment",
+	http.StatusConflict:            "The student is waitlisted, or a category with assessments would be removed",
+	http.StatusServiceUnavailable:  "The database is unavailable, try again later",
+	http.StatusInternalServerError: "Unexpected error",
+}
+
+// operation secures op with permission and documents the problems it may
+// answer with, besides the 503 and 500 every operation may.
+func operation(doc *openapi.Document, op *openapi.Operation, permission auth.Permission, problems ...int) *openapi.Operation {
+	op.Tags = []string{"grades"}
+	problems = append(problems, http.StatusServiceUnavailable, http.StatusInternalServerError)
+	for _, status := range problems {
+		op.Respond(status, doc.Problem(problemDescriptions[status]))
+	}
+	return auth.Secure(doc, op, permission)
+}
+
+// Describe documents the routes SetupRoutes registers.
+func Describe(doc *openapi.Document) {
+	doc.AddTag("grades", "Weighted gradebooks, assessments, course grades and GPAs")
+
+	uuidSchema := &openapi.Schema{Type: "string", Format: "uuid"}
+	courseID := openapi.Path("id", "The course ID", uuidSchema)
+	studentID := openapi.Path("id", "The student ID", uuidSchema)
+	enrollment := []*openapi.Parameter{studentID, openapi.Path("course_id", "The course ID", uuidSchema)}
+	scale := openapi.Query("scale", "The grading scale: letter, 4.0, 100, turkish or a configured one. Defaults to the configured scale.", &openapi.Schema{Type: "string"})
+
+	doc.Add(http.MethodGet, "/courses/:id/gradebook", operation(doc, &openapi.Operation{
+		Summary:     "Get the gradebook of a course",
+		OperationID: "getGradebook",
+		Parameters:  []*openapi.Parameter{courseID},
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.Gradebook{}, "The grade categories of the course")},
+	}, auth.PermGradesRead, http.StatusBadRequest, http.StatusNotFound))
+
+	doc.Add(http.MethodPut, "/courses/:id/gradebook", operation(doc, &openapi.Operation{
+		Summary: "Replace the gradebook of a course",
+		Description: "Category names must be unique regardless of case and their weights must add up to 100. " +
+			"Categories that have assessments cannot be removed.",
+		OperationID: "setGradebook",
+		Parameters:  []*openapi.Parameter{courseID},
+		RequestBody: doc.Body(models.Gradebook{}, ""),
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.Gradebook{}, "The saved gradebook")},
+	}, auth.PermGradesWrite, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict))
+
+	doc.Add(http.MethodGet, "/students/:id/enrollments/:course_id/assessments", operation(doc, &openapi.Operation{
+		Summary:     "List the assessments of a student in a course",
+		OperationID: "listAssessments",
+		Parameters:  enrollment,
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.AssessmentList{}, "The assessments, in the order they were recorded")},
+	}, auth.PermGradesRead, http.StatusBadRequest, http.StatusNotFound))
+
+	doc.Add(http.MethodPost, "/students/:id/enrollments/:course_id/assessments", operation(doc, &openapi.Operation{
+		Summary:     "Record an assessment",
+		Description: "The student must have a seat in the course and the category must be in its gradebook.",
+		OperationID: "addAssessment",
+		Parameters:  enrollment,
+		RequestBody: doc.Body(models.Assessment{}, ""),
+		Responses:   map[string]*openapi.Response{"201": doc.JSON(models.Assessment{}, "The recorded assessment")},
+	}, auth.PermGradesWrite, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict))
+
+	doc.Add(http.MethodDelete, "/students/:id/enrollments/:course_id/assessments/:assessment_id", operation(doc, &openapi.Operation{
+		Summary:     "Delete an assessment",
+		OperationID: "deleteAssessment",
+		Parameters:  append(enrollment, openapi.Path("assessment_id", "The assessment ID", uuidSchema)),
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(openapi.Message{}, "The assessment was deleted")},
+	}, auth.PermGradesWrite, http.StatusBadRequest, http.StatusNotFound))
+
+	doc.Add(http.MethodGet, "/students/:id/enrollments/:course_id/grade", operation(doc, &openapi.Operation{
+		Summary: "Get the grade of a student in a course",
+		Description: "The grade is the average of the category percentages weighted by the gradebook. " +
+			"Until every category is graded, the weights of the graded ones are scaled up to make 100.",
+		OperationID: "getGrade",
+		Parameters:  append(enrollment, scale),
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.Grade{}, "The grade of the student")},
+	}, auth.PermGradesRead, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict))
+
+	doc.Add(http.MethodGet, "/students/:id/gpa", operation(doc, &openapi.Operation{
+		Summary:     "Get the GPA of a student",
+		Description: "Grades are recomputed from the assessments of every course the student has a seat in, and averaged by credits per term and overall.",
+		OperationID: "getGPA",
+		Parameters:  []*openapi.Parameter{studentID, scale},
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.GPAReport{}, "The term and cumulative GPAs of the student")},
+	}, auth.PermGradesRead, http.StatusBadRequest, http.StatusNotFound))
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"backend/internal/auth"
+	"backend/internal/grade/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupRoutes registers the gradebook, assessment and grade routes behind
+// authenticate, each requiring the permission it needs.
+func SetupRoutes(router *gin.Engine, gradeController *controllers.GradeController, authenticate gin.HandlerFunc) {
+	grades := router.Group("", authenticate)
+	grades.GET("/courses/:id/gradebook", auth.Require(auth.PermGradesRead), gradeController.GetGradebook)
+	grades.PUT("/courses/:id/gradebook", auth.Require(auth.PermGradesWrite), gradeController.SetGradebook)
+	grades.GET("/students/:id/enrollments/:course_id/assessments", auth.Require(auth.PermGradesRead), gradeController.GetAssessments)
+	grades.POST("/students/:id/enrollments/:course_id/assessments", auth.Require(auth.PermGradesWrite), gradeController.AddAssessment)
+	grades.DELETE("/students/:id/enrollments/:course_id/assessments/:assessment_id", auth.Require(auth.PermGradesWrite), gradeController.DeleteAssessment)
+	grades.GET("/students/:id/enrollments/:course_id/grade", auth.Require(auth.PermGradesRead), gradeController.GetGrade)
+	grades.GET("/students/:id/gpa", auth.Require(auth.PermGradesRead), gradeController.GetGPA)
+}
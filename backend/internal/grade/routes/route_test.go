@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"backend/internal/auth"
+	"backend/internal/auth/authtest"
+	"backend/internal/grade/controllers"
+	"backend/internal/grade/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func TestSetupRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Every route must be rejected before it reaches the service.
+	controller := controllers.Controller(mocks.NewMockGradeService(ctrl))
+	router := gin.New()
+	SetupRoutes(router, controller, authtest.AuthenticateAs(auth.PermStudentsRead, auth.PermCoursesRead, auth.PermEnrollmentsRead))
+
+	authtest.AssertForbidden(t, router)
+}
@@ -0,0 +1,334 @@
+package services
+
+import (
+	"backend/internal/auth"
+	"backend/internal/config"
+	coursemodels "backend/internal/course/models"
+	enrollmentmodels "backend/internal/enrollment/models"
+	"backend/internal/grade/models"
+	studentmodels "backend/internal/student/models"
+	"backend/internal/validation"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	GetGradebook(courseID uuid.UUID) ([]models.Category, error)
+	SaveGradebook(ctx context.Context, courseID uuid.UUID, categories []models.Category) error
+	AddAssessment(ctx context.Context, assessment *models.Assessment) error
+	DeleteAssessment(ctx context.Context, studentID uuid.UUID, courseID uuid.UUID, id uuid.UUID) error
+	GetAssessments(studentID uuid.UUID, courseID uuid.UUID) ([]models.Assessment, error)
+	GetStudentAssessments(studentID uuid.UUID) ([]models.Assessment, error)
+}
+
+// Students looks up students, telling the grades of unknown or deleted
+// students apart.
+type Students interface {
+	Get(id uuid.UUID) (*studentmodels.Student, error)
+}
+
+// Courses looks up the courses grades are computed for.
+type Courses interface {
+	Get(id uuid.UUID) (*coursemodels.Course, error)
+}
+
+// Enrollments lists the courses a student is enrolled in; only students with a
+// seat are graded.
+type Enrollments interface {
+	GetByStudent(studentID uuid.UUID, query enrollmentmodels.EnrollmentQuery) ([]enrollmentmodels.Enrollment, error)
+}
+
+type GradeService struct {
+	repository  Repository
+	students    Students
+	courses     Courses
+	enrollments Enrollments
+	// scales holds the scales grades can be reported in, by name.
+	scales models.Scales
+	// defaultScale is used when a request names no scale.
+	defaultScale string
+}
+
+// Service reports grades on the built-in scales, in letter grades by default.
+// UseScales adds the configured ones.
+func Service(repository Repository, students Students, courses Courses, enrollments Enrollments) *GradeService {
+	return &GradeService{
+		repository:   repository,
+		students:     students,
+		courses:      courses,
+		enrollments:  enrollments,
+		scales:       models.BuiltinScales(),
+		defaultScale: "letter",
+	}
+}
+
+// UseScales adds the custom scales of cfg, which may replace built-in ones, and
+// makes cfg.Scale the default.
+func (s *GradeService) UseScales(cfg config.Grading) error {
+	for name, bands := range cfg.Scales {
+		scale := models.Scale{Name: name}
+		for _, band := range bands {
+			scale.Bands = append(scale.Bands, models.Band{Min: band.Min, Grade: band.Grade, Points: band.Points})
+		}
+		s.scales[name] = scale
+	}
+	if _, ok := s.scales[cfg.Scale]; !ok {
+		return fmt.Errorf("grading scale %q is not defined", cfg.Scale)
+	}
+	s.defaultScale = cfg.Scale
+	return nil
+}
+
+func (s *GradeService) scale(name string) (models.Scale, error) {
+	if name == "" {
+		name = s.defaultScale
+	}
+	scale, ok := s.scales[name]
+	if !ok {
+		return models.Scale{}, models.ErrUnknownScale
+	}
+	return scale, nil
+}
+
+// GetGradebook returns the grade categories of the course.
+func (s *GradeService) GetGradebook(ctx context.Context, courseID uuid.UUID) (*models.Gradebook, error) {
+	if err := auth.Authorize(ctx, auth.PermGradesRead); err != nil {
+		return nil, err
+	}
+	if _, err := s.courses.Get(courseID); err != nil {
+		return nil, err
+	}
+	categories, err := s.repository.GetGradebook(courseID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Gradebook{CourseID: courseID.String(), Categories: categories}, nil
+}
+
+// SetGradebook replaces the grade categories of the course. Names must be
+// unique regardless of case and the weights must add up to 100.
+func (s *GradeService) SetGradebook(ctx context.Context, courseID uuid.UUID, gradebook *models.Gradebook) error {
+	if err := auth.Authorize(ctx, auth.PermGradesWrite); err != nil {
+		return err
+	}
+	if err := validation.Validate(gradebook); err != nil {
+		return err
+	}
+	total := 0
+	seen := map[string]bool{}
+	for _, category := range gradebook.Categories {
+		name := strings.ToLower(category.Name)
+		if seen[name] {
+			return models.ErrDuplicateCategory
+		}
+		seen[name] = true
+		total += category.Weight
+	}
+	if total != 100 {
+		return models.ErrInvalidWeights
+	}
+	if _, err := s.courses.Get(courseID); err != nil {
+		return err
+	}
+	gradebook.CourseID = courseID.String()
+	return s.repository.SaveGradebook(ctx, courseID, gradebook.Categories)
+}
+
+// AddAssessment records the result of an enrolled student in one of the
+// categories of the course.
+func (s *GradeService) AddAssessment(ctx context.Context, studentID uuid.UUID, courseID uuid.UUID, assessment *models.Assessment) error {
+	if err := auth.Authorize(ctx, auth.PermGradesWrite); err != nil {
+		return err
+	}
+	if err := validation.Validate(assessment); err != nil {
+		return err
+	}
+	if assessment.Score > assessment.MaxScore {
+		return models.ErrScoreAboveMax
+	}
+	if err := s.checkEnrolled(studentID, courseID); err != nil {
+		return err
+	}
+	categories, err := s.repository.GetGradebook(courseID)
+	if err != nil {
+		return err
+	}
+	category, ok := findCategory(categories, assessment.Category)
+	if !ok {
+		return models.ErrUnknownCategory
+	}
+
+	assessment.ID = uuid.New().String()
+	assessment.StudentID = studentID.String()
+	assessment.CourseID = courseID.String()
+	assessment.Category = category.Name
+	assessment.RecordedAt = time.Now().UTC()
+	return s.repository.AddAssessment(ctx, assessment)
+}
+
+// findCategory looks name up in categories regardless of case.
+func findCategory(categories []models.Category, name string) (models.Category, bool) {
+	for _, category := range categories {
+		if strings.EqualFold(category.Name, name) {
+			return category, true
+		}
+	}
+	return models.Category{}, false
+}
+
+// checkEnrolled makes sure the student has a seat in the course.
+func (s *GradeService) checkEnrolled(studentID uuid.UUID, courseID uuid.UUID) error {
+	if _, err := s.students.Get(studentID); err != nil {
+		return err
+	}
+	enrollments, err := s.enrollments.GetByStudent(studentID, enrollmentmodels.EnrollmentQuery{})
+	if err != nil {
+		return err
+	}
+	for _, enrollment := range enrollments {
+		if enrollment.CourseID != courseID.String() {
+			continue
+		}
+		if enrollment.Status != enrollmentmodels.StatusEnrolled {
+			return models.ErrNotEnrolled
+		}
+		return nil
+	}
+	return enrollmentmodels.ErrEnrollmentNotFound
+}
+
+func (s *GradeService) DeleteAssessment(ctx context.Context, studentID uuid.UUID, courseID uuid.UUID, id uuid.UUID) error {
+	if err := auth.Authorize(ctx, auth.PermGradesWrite); err != nil {
+		return err
+	}
+	return s.repository.DeleteAssessment(ctx, studentID, courseID, id)
+}
+
+// GetAssessments lists the assessments of the student in the course.
+func (s *GradeService) GetAssessments(ctx context.Context, studentID uuid.UUID, courseID uuid.UUID) (models.AssessmentList, error) {
+	if err := auth.Authorize(ctx, auth.PermGradesRead); err != nil {
+		return models.AssessmentList{}, err
+	}
+	if _, err := s.students.Get(studentID); err != nil {
+		return models.AssessmentList{}, err
+	}
+	assessments, err := s.repository.GetAssessments(studentID, courseID)
+	if err != nil {
+		return models.AssessmentList{}, err
+	}
+	return models.AssessmentList{Assessments: assessments}, nil
+}
+
+// GetGrade computes the grade of the student in the course on the named scale,
+// or the default one.
+func (s *GradeService) GetGrade(ctx context.Context, studentID uuid.UUID, courseID uuid.UUID, scaleName string) (*models.Grade, error) {
+	if err := auth.Authorize(ctx, auth.PermGradesRead); err != nil {
+		return nil, err
+	}
+	scale, err := s.scale(scaleName)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkEnrolled(studentID, courseID); err != nil {
+		return nil, err
+	}
+	course, err := s.courses.Get(courseID)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.repository.GetGradebook(courseID)
+	if err != nil {
+		return nil, err
+	}
+	assessments, err := s.repository.GetAssessments(studentID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	grade := models.ComputeGrade(*course, categories, assessments, scale)
+	return &grade, nil
+}
+
+// GetGPA computes the grades of the student in every course they have a seat
+// in, and from them the term and cumulative GPAs on the named scale, or the
+// default one. Grades are recomputed from the assessments on every call.
+func (s *GradeService) GetGPA(ctx context.Context, studentID uuid.UUID, scaleName string) (*models.GPAReport, error) {
+	if err := auth.Authorize(ctx, auth.PermGradesRead); err != nil {
+		return nil, err
+	}
+	scale, err := s.scale(scaleName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.students.Get(studentID); err != nil {
+		return nil, err
+	}
+	grades, err := s.grades(studentID, scale)
+	if err != nil {
+		return nil, err
+	}
+
+	report := models.ComputeGPA(grades, scale)
+	report.StudentID = studentID.String()
+	return &report, nil
+}
+
+// GraduationRequirements reports what keeps the student from graduating: a
+// course they have a seat in that is not completely graded, or having no
+// course at all. It guards the student's transition to graduated, which the
+// student service authorizes, so it does not authorize itself.
+func (s *GradeService) GraduationRequirements(ctx context.Context, student *studentmodels.Student) ([]string, error) {
+	scale, err := s.scale("")
+	if err != nil {
+		return nil, err
+	}
+	grades, err := s.grades(uuid.MustParse(student.ID), scale)
+	if err != nil {
+		return nil, err
+	}
+	if len(grades) == 0 {
+		return []string{"is not enrolled in any course"}, nil
+	}
+	var unmet []string
+	for _, grade := range grades {
+		if !grade.Complete {
+			unmet = append(unmet, grade.Code+" is not completely graded")
+		}
+	}
+	return unmet, nil
+}
+
+// grades computes the grades of the student in every course they have a seat
+// in.
+func (s *GradeService) grades(studentID uuid.UUID, scale models.Scale) ([]models.Grade, error) {
+	enrollments, err := s.enrollments.GetByStudent(studentID, enrollmentmodels.EnrollmentQuery{Status: enrollmentmodels.StatusEnrolled})
+	if err != nil {
+		return nil, err
+	}
+	assessments, err := s.repository.GetStudentAssessments(studentID)
+	if err != nil {
+		return nil, err
+	}
+	byCourse := map[string][]models.Assessment{}
+	for _, assessment := range assessments {
+		byCourse[assessment.CourseID] = append(byCourse[assessment.CourseID], assessment)
+	}
+
+	grades := []models.Grade{}
+	for _, enrollment := range enrollments {
+		courseID := uuid.MustParse(enrollment.CourseID)
+		course, err := s.courses.Get(courseID)
+		if err != nil {
+			return nil, err
+		}
+		categories, err := s.repository.GetGradebook(courseID)
+		if err != nil {
+			return nil, err
+		}
+		grades = append(grades, models.ComputeGrade(*course, categories, byCourse[enrollment.CourseID], scale))
+	}
+	return grades, nil
+}
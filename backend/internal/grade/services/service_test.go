@@ -0,0 +1,284 @@
+package services
+
+import (
+	"backend/internal/auth"
+	"backend/internal/auth/authtest"
+	"backend/internal/config"
+	coursemodels "backend/internal/course/models"
+	enrollmentmodels "backend/internal/enrollment/models"
+	"backend/internal/grade/mocks"
+	"backend/internal/grade/models"
+	"backend/internal/problem"
+	studentmodels "backend/internal/student/models"
+	"context"
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixture struct {
+	repo        *mocks.MockRepository
+	students    *mocks.MockStudents
+	courses     *mocks.MockCourses
+	enrollments *mocks.MockEnrollments
+	service     *GradeService
+}
+
+func newFixture(t *testing.T) *fixture {
+	ctrl := gomock.NewController(t)
+	f := &fixture{
+		repo:        mocks.NewMockRepository(ctrl),
+		students:    mocks.NewMockStudents(ctrl),
+		courses:     mocks.NewMockCourses(ctrl),
+		enrollments: mocks.NewMockEnrollments(ctrl),
+	}
+	f.service = Service(f.repo, f.students, f.courses, f.enrollments)
+	return f
+}
+
+// enrolled expects the student to be looked up and to have the given
+// enrollment.
+func (f *fixture) enrolled(studentID, courseID uuid.UUID, status enrollmentmodels.Status) {
+	f.students.EXPECT().Get(studentID).Return(&studentmodels.Student{ID: studentID.String()}, nil)
+	f.enrollments.EXPECT().GetByStudent(studentID, enrollmentmodels.EnrollmentQuery{}).Return([]enrollmentmodels.Enrollment{
+		{StudentID: studentID.String(), CourseID: uuid.NewString(), Status: enrollmentmodels.StatusEnrolled},
+		{StudentID: studentID.String(), CourseID: courseID.String(), Status: status},
+	}, nil)
+}
+
+var gradebook = []models.Category{{Name: "Exams", Weight: 60}, {Name: "Homework", Weight: 40}}
+
+func TestUseScales(t *testing.T) {
+	f := newFixture(t)
+	passFail := config.Grading{Scale: "pass-fail", Scales: map[string][]config.GradeBand{
+		"pass-fail": {{Min: 50, Grade: "P", Points: 1}, {Min: 0, Grade: "F", Points: 0}},
+	}}
+	require.NoError(t, f.service.UseScales(passFail))
+	scale, err := f.service.scale("")
+	require.NoError(t, err)
+	assert.Equal(t, "pass-fail", scale.Name)
+	scale, err = f.service.scale("turkish")
+	require.NoError(t, err)
+	assert.Equal(t, "turkish", scale.Name)
+	_, err = f.service.scale("ects")
+	assert.ErrorIs(t, err, models.ErrUnknownScale)
+
+	assert.Error(t, f.service.UseScales(config.Grading{Scale: "ects"}))
+}
+
+func TestSetGradebook(t *testing.T) {
+	courseID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		f := newFixture(t)
+		f.courses.EXPECT().Get(courseID).Return(&coursemodels.Course{ID: courseID.String()}, nil)
+		f.repo.EXPECT().SaveGradebook(gomock.Any(), courseID, []models.Category{{Name: "Exams", Weight: 60}, {Name: "Homework", Weight: 40}}).Return(nil)
+
+		book := &models.Gradebook{Categories: []models.Category{{Name: " Exams ", Weight: 60}, {Name: "Homework", Weight: 40}}}
+		assert.NoError(t, f.service.SetGradebook(authtest.AdminContext, courseID, book))
+		assert.Equal(t, courseID.String(), book.CourseID)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		f := newFixture(t)
+		for _, tc := range []struct {
+			name       string
+			categories []models.Category
+			err        error
+		}{
+			{"Weights", []models.Category{{Name: "Exams", Weight: 60}, {Name: "Homework", Weight: 30}}, models.ErrInvalidWeights},
+			{"Empty", []models.Category{}, models.ErrInvalidWeights},
+			{"Duplicate", []models.Category{{Name: "Exams", Weight: 50}, {Name: "exams", Weight: 50}}, models.ErrDuplicateCategory},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				assert.Equal(t, tc.err, f.service.SetGradebook(authtest.AdminContext, courseID, &models.Gradebook{Categories: tc.categories}))
+			})
+		}
+
+		err := f.service.SetGradebook(authtest.AdminContext, courseID, &models.Gradebook{Categories: []models.Category{{Name: "", Weight: 100}}})
+		var validationErr *problem.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "categories[0].name", validationErr.Fields[0].Field)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		f := newFixture(t)
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Username: "auditor", Permissions: []auth.Permission{auth.PermGradesRead}})
+
+		err := f.service.SetGradebook(ctx, courseID, &models.Gradebook{Categories: gradebook})
+		assert.Equal(t, &auth.ForbiddenError{Permission: auth.PermGradesWrite}, err)
+	})
+}
+
+func TestAddAssessment(t *testing.T) {
+	studentID, courseID := uuid.New(), uuid.New()
+	newAssessment := func() *models.Assessment {
+		return &models.Assessment{Category: "exams", Title: "Midterm", Score: 42, MaxScore: 50}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		f := newFixture(t)
+		f.enrolled(studentID, courseID, enrollmentmodels.StatusEnrolled)
+		f.repo.EXPECT().GetGradebook(courseID).Return(gradebook, nil)
+		f.repo.EXPECT().AddAssessment(gomock.Any(), gomock.Any()).Return(nil)
+
+		assessment := newAssessment()
+		assert.NoError(t, f.service.AddAssessment(authtest.AdminContext, studentID, courseID, assessment))
+		assert.NotEmpty(t, assessment.ID)
+		assert.Equal(t, studentID.String(), assessment.StudentID)
+		assert.Equal(t, courseID.String(), assessment.CourseID)
+		// The category is spelled as in the gradebook.
+		assert.Equal(t, "Exams", assessment.Category)
+		assert.False(t, assessment.RecordedAt.IsZero())
+	})
+
+	t.Run("Score Above Max", func(t *testing.T) {
+		f := newFixture(t)
+		assessment := newAssessment()
+		assessment.Score = 51
+		assert.Equal(t, models.ErrScoreAboveMax, f.service.AddAssessment(authtest.AdminContext, studentID, courseID, assessment))
+	})
+
+	t.Run("Waitlisted", func(t *testing.T) {
+		f := newFixture(t)
+		f.enrolled(studentID, courseID, enrollmentmodels.StatusWaitlisted)
+		assert.Equal(t, models.ErrNotEnrolled, f.service.AddAssessment(authtest.AdminContext, studentID, courseID, newAssessment()))
+	})
+
+	t.Run("Not Enrolled", func(t *testing.T) {
+		f := newFixture(t)
+		f.enrolled(studentID, uuid.New(), enrollmentmodels.StatusEnrolled)
+		assert.Equal(t, enrollmentmodels.ErrEnrollmentNotFound, f.service.AddAssessment(authtest.AdminContext, studentID, courseID, newAssessment()))
+	})
+
+	t.Run("Unknown Category", func(t *testing.T) {
+		f := newFixture(t)
+		f.enrolled(studentID, courseID, enrollmentmodels.StatusEnrolled)
+		f.repo.EXPECT().GetGradebook(courseID).Return(gradebook, nil)
+
+		assessment := newAssessment()
+		assessment.Category = "Project"
+		assert.Equal(t, models.ErrUnknownCategory, f.service.AddAssessment(authtest.AdminContext, studentID, courseID, assessment))
+	})
+}
+
+func TestGetGrade(t *testing.T) {
+	studentID, courseID := uuid.New(), uuid.New()
+	course := &coursemodels.Course{ID: courseID.String(), Code: "CENG 242", Credits: 4, Term: "2026-fall"}
+	assessments := []models.Assessment{
+		{ID: "1", Category: "Exams", Score: 80, MaxScore: 100},
+		{ID: "2", Category: "Homework", Score: 45, MaxScore: 50},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		f := newFixture(t)
+		f.enrolled(studentID, courseID, enrollmentmodels.StatusEnrolled)
+		f.courses.EXPECT().Get(courseID).Return(course, nil)
+		f.repo.EXPECT().GetGradebook(courseID).Return(gradebook, nil)
+		f.repo.EXPECT().GetAssessments(studentID, courseID).Return(assessments, nil)
+
+		grade, err := f.service.GetGrade(authtest.AdminContext, studentID, courseID, "turkish")
+		require.NoError(t, err)
+		// 0.6 * 80 + 0.4 * 90
+		assert.Equal(t, 84.0, *grade.Percent)
+		assert.Equal(t, "BB", grade.Grade)
+		assert.True(t, grade.Complete)
+	})
+
+	t.Run("Unknown Scale", func(t *testing.T) {
+		f := newFixture(t)
+		_, err := f.service.GetGrade(authtest.AdminContext, studentID, courseID, "ects")
+		assert.Equal(t, models.ErrUnknownScale, err)
+	})
+}
+
+func TestGetGPA(t *testing.T) {
+	studentID := uuid.New()
+	fall, spring := uuid.New(), uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		f := newFixture(t)
+		f.students.EXPECT().Get(studentID).Return(&studentmodels.Student{ID: studentID.String()}, nil)
+		f.enrollments.EXPECT().GetByStudent(studentID, enrollmentmodels.EnrollmentQuery{Status: enrollmentmodels.StatusEnrolled}).Return([]enrollmentmodels.Enrollment{
+			{CourseID: spring.String()}, {CourseID: fall.String()},
+		}, nil)
+		f.repo.EXPECT().GetStudentAssessments(studentID).Return([]models.Assessment{
+			{ID: "1", CourseID: fall.String(), Category: "Exams", Score: 95, MaxScore: 100},
+			{ID: "2", CourseID: spring.String(), Category: "Exams", Score: 75, MaxScore: 100},
+		}, nil)
+		f.courses.EXPECT().Get(fall).Return(&coursemodels.Course{ID: fall.String(), Code: "CENG 213", Credits: 3, Term: "2026-fall"}, nil)
+		f.courses.EXPECT().Get(spring).Return(&coursemodels.Course{ID: spring.String(), Code: "CENG 242", Credits: 1, Term: "2027-spring"}, nil)
+		f.repo.EXPECT().GetGradebook(gomock.Any()).Return([]models.Category{{Name: "Exams", Weight: 100}}, nil).Times(2)
+
+		report, err := f.service.GetGPA(authtest.AdminContext, studentID, "")
+		require.NoError(t, err)
+		assert.Equal(t, studentID.String(), report.StudentID)
+		assert.Equal(t, "letter", report.Scale)
+		require.Len(t, report.Terms, 2)
+		assert.Equal(t, "2026-fall", report.Terms[0].Term)
+		assert.Equal(t, 4.0, *report.Terms[0].GPA)
+		assert.Equal(t, "2027-spring", report.Terms[1].Term)
+		assert.Equal(t, 2.0, *report.Terms[1].GPA)
+		// (3 * 4 + 1 * 2) / 4
+		assert.Equal(t, 4, report.Credits)
+		assert.Equal(t, 3.5, *report.GPA)
+	})
+
+	t.Run("Unknown Student", func(t *testing.T) {
+		f := newFixture(t)
+		f.students.EXPECT().Get(studentID).Return(nil, studentmodels.ErrStudentNotFound)
+
+		_, err := f.service.GetGPA(authtest.AdminContext, studentID, "")
+		assert.ErrorIs(t, err, studentmodels.ErrStudentNotFound)
+	})
+}
+
+func TestGraduationRequirements(t *testing.T) {
+	student := &studentmodels.Student{ID: uuid.New().String()}
+	studentID := uuid.MustParse(student.ID)
+	graded, partial := uuid.New(), uuid.New()
+
+	t.Run("Met", func(t *testing.T) {
+		f := newFixture(t)
+		f.enrollments.EXPECT().GetByStudent(studentID, gomock.Any()).Return([]enrollmentmodels.Enrollment{{CourseID: graded.String()}}, nil)
+		f.repo.EXPECT().GetStudentAssessments(studentID).Return([]models.Assessment{
+			{ID: "1", CourseID: graded.String(), Category: "Exams", Score: 95, MaxScore: 100},
+		}, nil)
+		f.courses.EXPECT().Get(graded).Return(&coursemodels.Course{ID: graded.String(), Code: "CENG 213", Credits: 3}, nil)
+		f.repo.EXPECT().GetGradebook(graded).Return([]models.Category{{Name: "Exams", Weight: 100}}, nil)
+
+		unmet, err := f.service.GraduationRequirements(context.Background(), student)
+		require.NoError(t, err)
+		assert.Empty(t, unmet)
+	})
+
+	t.Run("Incomplete Grades", func(t *testing.T) {
+		f := newFixture(t)
+		f.enrollments.EXPECT().GetByStudent(studentID, gomock.Any()).Return([]enrollmentmodels.Enrollment{{CourseID: graded.String()}, {CourseID: partial.String()}}, nil)
+		f.repo.EXPECT().GetStudentAssessments(studentID).Return([]models.Assessment{
+			{ID: "1", CourseID: graded.String(), Category: "Exams", Score: 95, MaxScore: 100},
+			{ID: "2", CourseID: partial.String(), Category: "Exams", Score: 60, MaxScore: 100},
+		}, nil)
+		f.courses.EXPECT().Get(graded).Return(&coursemodels.Course{ID: graded.String(), Code: "CENG 213", Credits: 3}, nil)
+		f.courses.EXPECT().Get(partial).Return(&coursemodels.Course{ID: partial.String(), Code: "CENG 242", Credits: 3}, nil)
+		f.repo.EXPECT().GetGradebook(graded).Return([]models.Category{{Name: "Exams", Weight: 100}}, nil)
+		f.repo.EXPECT().GetGradebook(partial).Return([]models.Category{{Name: "Exams", Weight: 60}, {Name: "Project", Weight: 40}}, nil)
+
+		unmet, err := f.service.GraduationRequirements(context.Background(), student)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"CENG 242 is not completely graded"}, unmet)
+	})
+
+	t.Run("No Courses", func(t *testing.T) {
+		f := newFixture(t)
+		f.enrollments.EXPECT().GetByStudent(studentID, gomock.Any()).Return(nil, nil)
+		f.repo.EXPECT().GetStudentAssessments(studentID).Return(nil, nil)
+
+		unmet, err := f.service.GraduationRequirements(context.Background(), student)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"is not enrolled in any course"}, unmet)
+	})
+}
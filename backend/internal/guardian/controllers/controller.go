@@ -0,0 +1,150 @@
+package controllers
+
+import (
+	"backend/internal/guardian/models"
+	"backend/internal/problem"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type GuardianService interface {
+	Add(ctx context.Context, studentID uuid.UUID, guardian *models.StudentGuardian) error
+	Update(ctx context.Context, studentID uuid.UUID, guardianID uuid.UUID, guardian *models.StudentGuardian) error
+	Remove(ctx context.Context, studentID uuid.UUID, guardianID uuid.UUID) error
+	Get(ctx context.Context, studentID uuid.UUID, guardianID uuid.UUID) (*models.StudentGuardian, error)
+	GetByStudent(ctx context.Context, studentID uuid.UUID) (models.GuardianList, error)
+	GetWards(ctx context.Context, guardianID uuid.UUID) (models.WardList, error)
+}
+
+var (
+	errInvalidID         = &problem.ValidationError{Detail: "invalid UUID", Fields: []problem.FieldError{{Field: "id", Code: "uuid", Message: "must be a UUID"}}}
+	errInvalidGuardianID = &problem.ValidationError{Detail: "invalid UUID", Fields: []problem.FieldError{{Field: "guardian_id", Code: "uuid", Message: "must be a UUID"}}}
+	errInvalidRequest    = &problem.ValidationError{Detail: "invalid request"}
+)
+
+type GuardianController struct {
+	Service GuardianService
+}
+
+func Controller(service GuardianService) *GuardianController {
+	return &GuardianController{Service: service}
+}
+
+// ids parses the student ID and, with withGuardian, the guardian ID of the
+// path.
+func ids(ctx *gin.Context, withGuardian bool) (uuid.UUID, uuid.UUID, error) {
+	studentID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errInvalidID
+	}
+	if !withGuardian {
+		return studentID, uuid.Nil, nil
+	}
+	guardianID, err := uuid.Parse(ctx.Param("guardian_id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errInvalidGuardianID
+	}
+	return studentID, guardianID, nil
+}
+
+func (c *GuardianController) Add(ctx *gin.Context) {
+	studentID, _, err := ids(ctx, false)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	var guardian models.StudentGuardian
+	if err := ctx.ShouldBindJSON(&guardian); err != nil {
+		ctx.Error(errInvalidRequest)
+		return
+	}
+
+	if err := c.Service.Add(ctx.Request.Context(), studentID, &guardian); err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, guardian)
+}
+
+func (c *GuardianController) Update(ctx *gin.Context) {
+	studentID, guardianID, err := ids(ctx, true)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	var guardian models.StudentGuardian
+	if err := ctx.ShouldBindJSON(&guardian); err != nil {
+		ctx.Error(errInvalidRequest)
+		return
+	}
+
+	if err := c.Service.Update(ctx.Request.Context(), studentID, guardianID, &guardian); err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, guardian)
+}
+
+func (c *GuardianController) Remove(ctx *gin.Context) {
+	studentID, guardianID, err := ids(ctx, true)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if err := c.Service.Remove(ctx.Request.Context(), studentID, guardianID); err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Guardian removed successfully"})
+}
+
+func (c *GuardianController) Get(ctx *gin.Context) {
+	studentID, guardianID, err := ids(ctx, true)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	guardian, err := c.Service.Get(ctx.Request.Context(), studentID, guardianID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, guardian)
+}
+
+// GetByStudent lists the guardians of the student by priority.
+func (c *GuardianController) GetByStudent(ctx *gin.Context) {
+	studentID, _, err := ids(ctx, false)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	list, err := c.Service.GetByStudent(ctx.Request.Context(), studentID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, list)
+}
+
+// GetWards lists the students of the guardian with the id of the path.
+func (c *GuardianController) GetWards(ctx *gin.Context) {
+	guardianID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidID)
+		return
+	}
+
+	list, err := c.Service.GetWards(ctx.Request.Context(), guardianID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, list)
+}
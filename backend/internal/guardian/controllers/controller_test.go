@@ -0,0 +1,210 @@
+package controllers
+
+import (
+	"backend/internal/apitest"
+	"backend/internal/guardian/mocks"
+	"backend/internal/guardian/models"
+	"backend/internal/problem"
+	studentmodels "backend/internal/student/models"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRouter(controller *GuardianController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(problem.Middleware())
+	router.GET("/students/:id/guardians", controller.GetByStudent)
+	router.POST("/students/:id/guardians", controller.Add)
+	router.GET("/students/:id/guardians/:guardian_id", controller.Get)
+	router.PUT("/students/:id/guardians/:guardian_id", controller.Update)
+	router.DELETE("/students/:id/guardians/:guardian_id", controller.Remove)
+	router.GET("/guardians/:id/students", controller.GetWards)
+	return router
+}
+
+var (
+	studentID  = uuid.MustParse("7995c72f-7d04-4136-8b5f-000d6d4aae23")
+	guardianID = uuid.MustParse("0b6f4b4e-53f4-4f3c-9a36-8d2b8c7c1f10")
+	guardian   = models.StudentGuardian{
+		Guardian: models.Guardian{
+			ID:      guardianID.String(),
+			Name:    "Anne",
+			Surname: "Byron",
+			Email:   "anne@example.com",
+			Phone:   "+441234567890",
+		},
+		Relationship: models.RelationshipMother,
+		Custody:      true,
+		Pickup:       true,
+		Priority:     1,
+	}
+)
+
+func TestAdd(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockGuardianService(ctrl)
+	router := newRouter(Controller(mockService))
+	url := "/students/" + studentID.String() + "/guardians"
+	body := []byte(`{"guardian": {"name": "Anne", "surname": "Byron", "email": "anne@example.com", "phone": "+441234567890"}, "relationship": "mother", "custody": true, "pickup": true}`)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().Add(gomock.Any(), studentID, gomock.Any()).DoAndReturn(func(ctx context.Context, studentID uuid.UUID, request *models.StudentGuardian) error {
+			assert.Equal(t, "Anne", request.Guardian.Name)
+			assert.True(t, request.Custody)
+			*request = guardian
+			return nil
+		})
+
+		w := apitest.Request(router, http.MethodPost, url, body)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var actual models.StudentGuardian
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+		assert.Equal(t, guardian, actual)
+	})
+
+	t.Run("Already Linked", func(t *testing.T) {
+		mockService.EXPECT().Add(gomock.Any(), studentID, gomock.Any()).Return(models.ErrAlreadyLinked)
+
+		w := apitest.Request(router, http.MethodPost, url, body)
+		apitest.AssertProblem(t, w, http.StatusConflict, models.ErrAlreadyLinked.Detail)
+	})
+
+	t.Run("Unknown Student", func(t *testing.T) {
+		mockService.EXPECT().Add(gomock.Any(), studentID, gomock.Any()).Return(studentmodels.ErrStudentNotFound)
+
+		w := apitest.Request(router, http.MethodPost, url, body)
+		apitest.AssertProblem(t, w, http.StatusNotFound, studentmodels.ErrStudentNotFound.Detail)
+	})
+
+	t.Run("Invalid Body", func(t *testing.T) {
+		w := apitest.Request(router, http.MethodPost, url, []byte(`{`))
+		apitest.AssertProblem(t, w, http.StatusBadRequest, errInvalidRequest.Detail)
+	})
+
+	t.Run("Invalid Student ID", func(t *testing.T) {
+		w := apitest.Request(router, http.MethodPost, "/students/1/guardians", body)
+		apitest.AssertProblem(t, w, http.StatusBadRequest, errInvalidID.Detail)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockGuardianService(ctrl)
+	router := newRouter(Controller(mockService))
+	url := "/students/" + studentID.String() + "/guardians/" + guardianID.String()
+	body := []byte(`{"guardian": {"name": "Anne", "surname": "Byron", "phone": "+441234567890"}, "relationship": "mother", "priority": 1}`)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().Update(gomock.Any(), studentID, guardianID, gomock.Any()).DoAndReturn(func(ctx context.Context, studentID uuid.UUID, guardianID uuid.UUID, request *models.StudentGuardian) error {
+			assert.Equal(t, 1, request.Priority)
+			*request = guardian
+			return nil
+		})
+
+		w := apitest.Request(router, http.MethodPut, url, body)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var actual models.StudentGuardian
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+		assert.Equal(t, guardian, actual)
+	})
+
+	t.Run("Not Linked", func(t *testing.T) {
+		mockService.EXPECT().Update(gomock.Any(), studentID, guardianID, gomock.Any()).Return(models.ErrNotLinked)
+
+		w := apitest.Request(router, http.MethodPut, url, body)
+		apitest.AssertProblem(t, w, http.StatusNotFound, models.ErrNotLinked.Detail)
+	})
+
+	t.Run("Invalid Guardian ID", func(t *testing.T) {
+		w := apitest.Request(router, http.MethodPut, "/students/"+studentID.String()+"/guardians/1", body)
+		apitest.AssertProblem(t, w, http.StatusBadRequest, errInvalidGuardianID.Detail)
+	})
+}
+
+func TestRemove(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockGuardianService(ctrl)
+	router := newRouter(Controller(mockService))
+	url := "/students/" + studentID.String() + "/guardians/" + guardianID.String()
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().Remove(gomock.Any(), studentID, guardianID).Return(nil)
+
+		w := apitest.Request(router, http.MethodDelete, url, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"message": "Guardian removed successfully"}`, w.Body.String())
+	})
+
+	t.Run("Not Linked", func(t *testing.T) {
+		mockService.EXPECT().Remove(gomock.Any(), studentID, guardianID).Return(models.ErrNotLinked)
+
+		w := apitest.Request(router, http.MethodDelete, url, nil)
+		apitest.AssertProblem(t, w, http.StatusNotFound, models.ErrNotLinked.Detail)
+	})
+}
+
+func TestGets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockGuardianService(ctrl)
+	router := newRouter(Controller(mockService))
+
+	t.Run("Get", func(t *testing.T) {
+		mockService.EXPECT().Get(gomock.Any(), studentID, guardianID).Return(&guardian, nil)
+
+		w := apitest.Request(router, http.MethodGet, "/students/"+studentID.String()+"/guardians/"+guardianID.String(), nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var actual models.StudentGuardian
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+		assert.Equal(t, guardian, actual)
+	})
+
+	t.Run("By Student", func(t *testing.T) {
+		list := models.GuardianList{Guardians: []models.StudentGuardian{guardian}}
+		mockService.EXPECT().GetByStudent(gomock.Any(), studentID).Return(list, nil)
+
+		w := apitest.Request(router, http.MethodGet, "/students/"+studentID.String()+"/guardians", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var actual models.GuardianList
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+		assert.Equal(t, list, actual)
+	})
+
+	t.Run("Wards", func(t *testing.T) {
+		list := models.WardList{Students: []models.Ward{{StudentID: studentID.String(), Name: "Ada", Surname: "Byron", Relationship: models.RelationshipMother, Priority: 1}}}
+		mockService.EXPECT().GetWards(gomock.Any(), guardianID).Return(list, nil)
+
+		w := apitest.Request(router, http.MethodGet, "/guardians/"+guardianID.String()+"/students", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var actual models.WardList
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+		assert.Equal(t, list, actual)
+	})
+
+	t.Run("Unknown Guardian", func(t *testing.T) {
+		mockService.EXPECT().GetWards(gomock.Any(), guardianID).Return(models.WardList{}, models.ErrGuardianNotFound)
+
+		w := apitest.Request(router, http.MethodGet, "/guardians/"+guardianID.String()+"/students", nil)
+		apitest.AssertProblem(t, w, http.StatusNotFound, models.ErrGuardianNotFound.Detail)
+	})
+
+	t.Run("Invalid Guardian ID", func(t *testing.T) {
+		w := apitest.Request(router, http.MethodGet, "/guardians/1/students", nil)
+		apitest.AssertProblem(t, w, http.StatusBadRequest, errInvalidID.Detail)
+	})
+}
@@ -0,0 +1,193 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/guardian/services/service.go
+
+// Package services is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/internal/guardian/models"
+	models0 "backend/internal/student/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockRepository) Add(ctx context.Context, studentID uuid.UUID, guardian *models.StudentGuardian) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, studentID, guardian)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockRepositoryMockRecorder) Add(ctx, studentID, guardian interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockRepository)(nil).Add), ctx, studentID, guardian)
+}
+
+// Get mocks base method.
+func (m *MockRepository) Get(studentID, guardianID uuid.UUID) (*models.StudentGuardian, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", studentID, guardianID)
+	ret0, _ := ret[0].(*models.StudentGuardian)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepositoryMockRecorder) Get(studentID, guardianID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), studentID, guardianID)
+}
+
+// GetByStudent mocks base method.
+func (m *MockRepository) GetByStudent(studentID uuid.UUID) ([]models.StudentGuardian, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStudent", studentID)
+	ret0, _ := ret[0].([]models.StudentGuardian)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByStudent indicates an expected call of GetByStudent.
+func (mr *MockRepositoryMockRecorder) GetByStudent(studentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudent", reflect.TypeOf((*MockRepository)(nil).GetByStudent), studentID)
+}
+
+// GetGuardian mocks base method.
+func (m *MockRepository) GetGuardian(id uuid.UUID) (*models.Guardian, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuardian", id)
+	ret0, _ := ret[0].(*models.Guardian)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGuardian indicates an expected call of GetGuardian.
+func (mr *MockRepositoryMockRecorder) GetGuardian(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuardian", reflect.TypeOf((*MockRepository)(nil).GetGuardian), id)
+}
+
+// GetWards mocks base method.
+func (m *MockRepository) GetWards(guardianID uuid.UUID) ([]models.Ward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWards", guardianID)
+	ret0, _ := ret[0].([]models.Ward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWards indicates an expected call of GetWards.
+func (mr *MockRepositoryMockRecorder) GetWards(guardianID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWards", reflect.TypeOf((*MockRepository)(nil).GetWards), guardianID)
+}
+
+// Remove mocks base method.
+func (m *MockRepository) Remove(ctx context.Context, studentID, guardianID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, studentID, guardianID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockRepositoryMockRecorder) Remove(ctx, studentID, guardianID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockRepository)(nil).Remove), ctx, studentID, guardianID)
+}
+
+// Update mocks base method.
+func (m *MockRepository) Update(ctx context.Context, studentID uuid.UUID, guardian *models.StudentGuardian) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, studentID, guardian)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder) Update(ctx, studentID, guardian interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, studentID, guardian)
+}
+
+// MockStudents is a mock of Students interface.
+type MockStudents struct {
+	ctrl     *gomock.Controller
+	recorder *MockStudentsMockRecorder
+}
+
+// MockStudentsMockRecorder is the mock recorder for MockStudents.
+type MockStudentsMockRecorder struct {
+	mock *MockStudents
+}
+
+// NewMockStudents creates a new mock instance.
+func NewMockStudents(ctrl *gomock.Controller) *MockStudents {
+	mock := &MockStudents{ctrl: ctrl}
+	mock.recorder = &MockStudentsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStudents) EXPECT() *MockStudentsMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockStudents) Get(id uuid.UUID) (*models0.Student, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(*models0.Student)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockStudentsMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStudents)(nil).Get), id)
+}
+
+// GetByIDs mocks base method.
+func (m *MockStudents) GetByIDs(ids []uuid.UUID) ([]models0.Student, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ids)
+	ret0, _ := ret[0].([]models0.Student)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockStudentsMockRecorder) GetByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockStudents)(nil).GetByIDs), ids)
+}
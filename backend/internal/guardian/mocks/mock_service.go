@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/guardian/controllers/controller.go
+
+// Package controllers is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/internal/guardian/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockGuardianService is a mock of GuardianService interface.
+type MockGuardianService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGuardianServiceMockRecorder
+}
+
+// MockGuardianServiceMockRecorder is the mock recorder for MockGuardianService.
+type MockGuardianServiceMockRecorder struct {
+	mock *MockGuardianService
+}
+
+// NewMockGuardianService creates a new mock instance.
+func NewMockGuardianService(ctrl *gomock.Controller) *MockGuardianService {
+	mock := &MockGuardianService{ctrl: ctrl}
+	mock.recorder = &MockGuardianServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGuardianService) EXPECT() *MockGuardianServiceMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockGuardianService) Add(ctx context.Context, studentID uuid.UUID, guardian *models.StudentGuardian) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, studentID, guardian)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockGuardianServiceMockRecorder) Add(ctx, studentID, guardian interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockGuardianService)(nil).Add), ctx, studentID, guardian)
+}
+
+// Get mocks base method.
+func (m *MockGuardianService) Get(ctx context.Context, studentID, guardianID uuid.UUID) (*models.StudentGuardian, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, studentID, guardianID)
+	ret0, _ := ret[0].(*models.StudentGuardian)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockGuardianServiceMockRecorder) Get(ctx, studentID, guardianID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockGuardianService)(nil).Get), ctx, studentID, guardianID)
+}
+
+// GetByStudent mocks base method.
+func (m *MockGuardianService) GetByStudent(ctx context.Context, studentID uuid.UUID) (models.GuardianList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStudent", ctx, studentID)
+	ret0, _ := ret[0].(models.GuardianList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByStudent indicates an expected call of GetByStudent.
+func (mr *MockGuardianServiceMockRecorder) GetByStudent(ctx, studentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudent", reflect.TypeOf((*MockGuardianService)(nil).GetByStudent), ctx, studentID)
+}
+
+// GetWards mocks base method.
+func (m *MockGuardianService) GetWards(ctx context.Context, guardianID uuid.UUID) (models.WardList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWards", ctx, guardianID)
+	ret0, _ := ret[0].(models.WardList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWards indicates an expected call of GetWards.
+func (mr *MockGuardianServiceMockRecorder) GetWards(ctx, guardianID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWards", reflect.TypeOf((*MockGuardianService)(nil).GetWards), ctx, guardianID)
+}
+
+// Remove mocks base method.
+func (m *MockGuardianService) Remove(ctx context.Context, studentID, guardianID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, studentID, guardianID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockGuardianServiceMockRecorder) Remove(ctx, studentID, guardianID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockGuardianService)(nil).Remove), ctx, studentID, guardianID)
+}
+
+// Update mocks base method.
+func (m *MockGuardianService) Update(ctx context.Context, studentID, guardianID uuid.UUID, guardian *models.StudentGuardian) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, studentID, guardianID, guardian)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGuardianServiceMockRecorder) Update(ctx, studentID, guardianID, guardian interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGuardianService)(nil).Update), ctx, studentID, guardianID, guardian)
+}
@@ -0,0 +1,9 @@
+package models
+
+import "backend/internal/problem"
+
+var (
+	ErrGuardianNotFound = &problem.NotFoundError{Detail: "guardian not found"}
+	ErrNotLinked        = &problem.NotFoundError{Detail: "the guardian is not a guardian of this student"}
+	ErrAlreadyLinked    = &problem.ConflictError{Detail: "the guardian is already a guardian of this student"}
+)
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// Relationship is what a guardian is to a student.
+type Relationship string
+
+const (
+	RelationshipMother        Relationship = "mother"
+	RelationshipFather        Relationship = "father"
+	RelationshipStepparent    Relationship = "stepparent"
+	RelationshipGrandparent   Relationship = "grandparent"
+	RelationshipSibling       Relationship = "sibling"
+	RelationshipRelative      Relationship = "relative"
+	RelationshipFosterParent  Relationship = "foster_parent"
+	RelationshipLegalGuardian Relationship = "legal_guardian"
+	RelationshipOther         Relationship = "other"
+)
+
+// Relationships lists every relationship.
+var Relationships = []Relationship{
+	RelationshipMother, RelationshipFather, RelationshipStepparent, RelationshipGrandparent, RelationshipSibling,
+	RelationshipRelative, RelationshipFosterParent, RelationshipLegalGuardian, RelationshipOther,
+}
+
+// Guardian is a parent or guardian of one or more students, such as siblings,
+// who share the same record.
+type Guardian struct {
+	ID      string `json:"id"`
+	Name    string `json:"name" validate:"trim,required,max=100,name,namecase"`
+	Surname string `json:"surname" validate:"trim,required,max=100,name,namecase"`
+	Email   string `json:"email,omitempty" validate:"trim,max=254,email" access:"students:read:pii"`
+	// Phone is the number the school calls first.
+	Phone     string `json:"phone,omitempty" validate:"trim,required,phone" access:"students:read:pii"`
+	WorkPhone string `json:"workPhone,omitempty" validate:"trim,phone" access:"students:read:pii"`
+}
+
+// StudentGuardian is a guardian of a student, with what ties them.
+type StudentGuardian struct {
+	Guardian     Guardian     `json:"guardian"`
+	Relationship Relationship `json:"relationship" validate:"required,relationship"`
+	// Custody is set for guardians with legal custody of the student.
+	Custody bool `json:"custody"`
+	// Pickup is set for guardians allowed to pick the student up from school.
+	Pickup bool `json:"pickup"`
+	// Priority is the order the school contacts the guardians of the student in,
+	// from 1. The priorities of a student's guardians are always 1 to n; a new
+	// priority moves the guardians from it on down, and 0 means last.
+	Priority int `json:"priority" validate:"min=0"`
+}
+
+// Ward is a student of a guardian, as the reverse lookup lists them.
+type Ward struct {
+	StudentID     string       `json:"studentId"`
+	StudentNumber string       `json:"studentNumber,omitempty"`
+	Name          string       `json:"name"`
+	Surname       string       `json:"surname"`
+	Relationship  Relationship `json:"relationship"`
+	Custody       bool         `json:"custody"`
+	Pickup        bool         `json:"pickup"`
+	Priority      int          `json:"priority"`
+}
+
+// GuardianEntity is shared by every student the guardian is linked to.
+type GuardianEntity struct {
+	ID        uuid.UUID `gorm:"primary_key;type:char(36)"`
+	Name      string    `gorm:"size:100"`
+	Surname   string    `gorm:"size:100"`
+	Email     string    `gorm:"size:254"`
+	Phone     string    `gorm:"size:16"`
+	WorkPhone string    `gorm:"size:16"`
+}
+
+func (GuardianEntity) TableName() string {
+	return "guardians"
+}
+
+// StudentGuardianEntity is the join table of the many-to-many relationship
+// between StudentEntity and GuardianEntity, holding what ties them. It is
+// keyed by student and indexed by guardian for the reverse lookup.
+type StudentGuardianEntity struct {
+	StudentID    uuid.UUID    `gorm:"primaryKey;type:char(36)"`
+	GuardianID   uuid.UUID    `gorm:"primaryKey;type:char(36);index"`
+	Relationship Relationship `gorm:"size:20"`
+	Custody      bool
+	Pickup       bool
+	Priority     int
+}
+
+func (StudentGuardianEntity) TableName() string {
+	return "student_guardians"
+}
+
+// GuardianList holds the guardians of a student by priority.
+type GuardianList struct {
+	Guardians []StudentGuardian `json:"guardians"`
+}
+
+// WardList holds the students of a guardian.
+type WardList struct {
+	Students []Ward `json:"students"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"backend/internal/validation"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStudentGuardian(t *testing.T) {
+	guardian := &StudentGuardian{
+		Guardian:     Guardian{Name: " ada ", Surname: "LOVELACE", Phone: "+441234567890"},
+		Relationship: " Legal_Guardian ",
+		Pickup:       true,
+	}
+	require.NoError(t, validation.Validate(guardian))
+	assert.Equal(t, "Ada", guardian.Guardian.Name)
+	assert.Equal(t, "Lovelace", guardian.Guardian.Surname)
+	assert.Equal(t, RelationshipLegalGuardian, guardian.Relationship)
+
+	err := validation.Validate(&StudentGuardian{Guardian: Guardian{Name: "Ada", Surname: "Lovelace", Phone: "+441234567890"}, Relationship: "neighbour", Priority: -1})
+	assert.EqualError(t, err, "relationship: must be one of mother, father, stepparent, grandparent, sibling, relative, foster_parent, legal_guardian, other, priority: must be at least 0")
+}
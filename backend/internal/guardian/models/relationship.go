@@ -0,0 +1,27 @@
+package models
+
+import (
+	"backend/internal/validation"
+	"reflect"
+	"strings"
+)
+
+func init() {
+	validation.Register("relationship", relationship)
+}
+
+// relationship accepts the relationships a guardian can have to a student.
+// Case is ignored.
+func relationship(value reflect.Value, _ string) string {
+	if value.Kind() != reflect.String || !value.CanSet() || value.String() == "" {
+		return ""
+	}
+	known := Relationship(strings.ToLower(strings.TrimSpace(value.String())))
+	for _, r := range Relationships {
+		if known == r {
+			value.SetString(string(known))
+			return ""
+		}
+	}
+	return "must be one of mother, father, stepparent, grandparent, sibling, relative, foster_parent, legal_guardian, other"
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"backend/internal/database"
+	"backend/internal/guardian/models"
+)
+
+// translateError maps database errors to the problem kinds the services and
+// controllers understand.
+func translateError(err error) error {
+	return database.TranslateError(err, models.ErrNotLinked, models.ErrAlreadyLinked)
+}
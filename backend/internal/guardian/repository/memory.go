@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"backend/internal/guardian/models"
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// linkKey identifies the link of a guardian to a student.
+type linkKey struct {
+	StudentID  uuid.UUID
+	GuardianID uuid.UUID
+}
+
+// memoryRepository keeps guardians in process memory. It is safe for
+// concurrent use and meant for local development and tests; its mutex plays
+// the part of the student row lock.
+type memoryRepository struct {
+	mu        sync.Mutex
+	guardians map[uuid.UUID]models.GuardianEntity
+	links     map[linkKey]models.StudentGuardianEntity
+}
+
+func NewMemoryRepository() *memoryRepository {
+	return &memoryRepository{guardians: map[uuid.UUID]models.GuardianEntity{}, links: map[linkKey]models.StudentGuardianEntity{}}
+}
+
+// ofStudent returns the links of the student by priority.
+func (r *memoryRepository) ofStudent(studentID uuid.UUID) []models.StudentGuardianEntity {
+	links := []models.StudentGuardianEntity{}
+	for _, link := range r.links {
+		if link.StudentID == studentID {
+			links = append(links, link)
+		}
+	}
+	sort.Slice(links, func(i, j int) bool { return links[i].Priority < links[j].Priority })
+	return links
+}
+
+// shift is the in-memory equivalent of the SQL shift.
+func (r *memoryRepository) shift(studentID uuid.UUID, first int, last int, delta int) {
+	for _, link := range r.ofStudent(studentID) {
+		if link.Priority >= first && link.Priority <= last {
+			link.Priority += delta
+			r.links[linkKey{link.StudentID, link.GuardianID}] = link
+		}
+	}
+}
+
+func (r *memoryRepository) GetGuardian(id uuid.UUID) (*models.Guardian, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entity, ok := r.guardians[id]
+	if !ok {
+		return nil, models.ErrGuardianNotFound
+	}
+	return EntityToGuardian(&entity), nil
+}
+
+func (r *memoryRepository) Add(ctx context.Context, studentID uuid.UUID, guardian *models.StudentGuardian) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entity := GuardianToEntity(&guardian.Guardian)
+	key := linkKey{studentID, entity.ID}
+	if _, ok := r.links[key]; ok {
+		return models.ErrAlreadyLinked
+	}
+	if _, ok := r.guardians[entity.ID]; !ok {
+		r.guardians[entity.ID] = *entity
+	}
+
+	n := len(r.ofStudent(studentID))
+	guardian.Priority = place(guardian.Priority, n+1)
+	r.shift(studentID, guardian.Priority, n, 1)
+	r.links[key] = *LinkToEntity(studentID, guardian)
+	return nil
+}
+
+func (r *memoryRepository) Update(ctx context.Context, studentID uuid.UUID, guardian *models.StudentGuardian) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entity := GuardianToEntity(&guardian.Guardian)
+	key := linkKey{studentID, entity.ID}
+	link, ok := r.links[key]
+	if !ok {
+		return models.ErrNotLinked
+	}
+
+	guardian.Priority = place(guardian.Priority, len(r.ofStudent(studentID)))
+	switch {
+	case guardian.Priority < link.Priority:
+		r.shift(studentID, guardian.Priority, link.Priority-1, 1)
+	case guardian.Priority > link.Priority:
+		r.shift(studentID, link.Priority+1, guardian.Priority, -1)
+	}
+	r.links[key] = *LinkToEntity(studentID, guardian)
+	r.guardians[entity.ID] = *entity
+	return nil
+}
+
+func (r *memoryRepository) Remove(ctx context.Context, studentID uuid.UUID, guardianID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := linkKey{studentID, guardianID}
+	link, ok := r.links[key]
+	if !ok {
+		return models.ErrNotLinked
+	}
+	delete(r.links, key)
+	r.shift(studentID, link.Priority+1, len(r.ofStudent(studentID))+1, -1)
+
+	for _, other := range r.links {
+		if other.GuardianID == guardianID {
+			return nil
+		}
+	}
+	delete(r.guardians, guardianID)
+	return nil
+}
+
+func (r *memoryRepository) Get(studentID uuid.UUID, guardianID uuid.UUID) (*models.StudentGuardian, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	link, ok := r.links[linkKey{studentID, guardianID}]
+	if !ok {
+		return nil, models.ErrNotLinked
+	}
+	entity := r.guardians[guardianID]
+	return EntityToLink(&link, &entity), nil
+}
+
+func (r *memoryRepository) GetByStudent(studentID uuid.UUID) ([]models.StudentGuardian, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	guardians := []models.StudentGuardian{}
+	for _, link := range r.ofStudent(studentID) {
+		entity := r.guardians[link.GuardianID]
+		guardians = append(guardians, *EntityToLink(&link, &entity))
+	}
+	return guardians, nil
+}
+
+func (r *memoryRepository) GetWards(guardianID uuid.UUID) ([]models.Ward, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.guardians[guardianID]; !ok {
+		return nil, models.ErrGuardianNotFound
+	}
+	wards := []models.Ward{}
+	for _, link := range r.links {
+		if link.GuardianID == guardianID {
+			wards = append(wards, *EntityToWard(&link))
+		}
+	}
+	sort.Slice(wards, func(i, j int) bool { return wards[i].StudentID < wards[j].StudentID })
+	return wards, nil
+}
@@ -0,0 +1,308 @@
+package repository
+
+import (
+	"backend/internal/guardian/models"
+	studentmodels "backend/internal/student/models"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type guardianRepository struct {
+	DB *gorm.DB
+}
+
+// NewGuardianRepository stores guardians in db, whose schema
+// schema.Migrate keeps up to date. The students must be stored in the
+// same database, since changing the guardians of a student locks it.
+func NewGuardianRepository(db *gorm.DB) (*guardianRepository, error) {
+	return &guardianRepository{DB: db}, nil
+}
+
+// lockStudent locks the row of the student until tx ends, so that the
+// priorities of its guardians are renumbered by one transaction at a time.
+// SQLite ignores the lock but serializes writers.
+func lockStudent(tx *gorm.DB, id uuid.UUID) error {
+	var student studentmodels.StudentEntity
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", id).Take(&student).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return studentmodels.ErrStudentNotFound
+	}
+	return err
+}
+
+func countLinks(tx *gorm.DB, studentID uuid.UUID) (int, error) {
+	var count int64
+	err := tx.Model(&models.StudentGuardianEntity{}).Where("student_id = ?", studentID).Count(&count).Error
+	return int(count), err
+}
+
+// shift adds delta to the priorities of the guardians of the student from
+// first to last.
+func shift(tx *gorm.DB, studentID uuid.UUID, first int, last int, delta int) error {
+	return tx.Model(&models.StudentGuardianEntity{}).
+		Where("student_id = ? AND priority BETWEEN ? AND ?", studentID, first, last).
+		Update("priority", gorm.Expr("priority + ?", delta)).Error
+}
+
+// place returns the priority a guardian takes among n: the requested one, or
+// the last when it is out of range.
+func place(priority int, n int) int {
+	if priority < 1 || priority > n {
+		return n
+	}
+	return priority
+}
+
+func (r *guardianRepository) GetGuardian(id uuid.UUID) (*models.Guardian, error) {
+	var entity models.GuardianEntity
+	err := r.DB.Where("id = ?", id).Take(&entity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, models.ErrGuardianNotFound
+	}
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return EntityToGuardian(&entity), nil
+}
+
+// Add links the guardian to the student at its priority, moving the guardians
+// from it on down, and creates the guardian when it is new. The priority it
+// took is written back.
+func (r *guardianRepository) Add(ctx context.Context, studentID uuid.UUID, guardian *models.StudentGuardian) error {
+	return translateError(r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockStudent(tx, studentID); err != nil {
+			return err
+		}
+		entity := GuardianToEntity(&guardian.Guardian)
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(entity).Error; err != nil {
+			return err
+		}
+		var existing int64
+		err := tx.Model(&models.StudentGuardianEntity{}).Where("student_id = ? AND guardian_id = ?", studentID, entity.ID).Count(&existing).Error
+		if err != nil {
+			return err
+		}
+		if existing > 0 {
+			return models.ErrAlreadyLinked
+		}
+
+		n, err := countLinks(tx, studentID)
+		if err != nil {
+			return err
+		}
+		guardian.Priority = place(guardian.Priority, n+1)
+		if err := shift(tx, studentID, guardian.Priority, n, 1); err != nil {
+			return err
+		}
+		return tx.Create(LinkToEntity(studentID, guardian)).Error
+	}))
+}
+
+// Update replaces the details of the guardian, for every student it is linked
+// to, and its link to the student, moving the guardians between its old and
+// new priority.
+func (r *guardianRepository) Update(ctx context.Context, studentID uuid.UUID, guardian *models.StudentGuardian) error {
+	return translateError(r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockStudent(tx, studentID); err != nil {
+			return err
+		}
+		var link models.StudentGuardianEntity
+		if err := tx.Where("student_id = ? AND guardian_id = ?", studentID, guardian.Guardian.ID).Take(&link).Error; err != nil {
+			return err
+		}
+		n, err := countLinks(tx, studentID)
+		if err != nil {
+			return err
+		}
+		guardian.Priority = place(guardian.Priority, n)
+		switch {
+		case guardian.Priority < link.Priority:
+			err = shift(tx, studentID, guardian.Priority, link.Priority-1, 1)
+		case guardian.Priority > link.Priority:
+			err = shift(tx, studentID, link.Priority+1, guardian.Priority, -1)
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Save(LinkToEntity(studentID, guardian)).Error; err != nil {
+			return err
+		}
+		return tx.Save(GuardianToEntity(&guardian.Guardian)).Error
+	}))
+}
+
+// Remove unlinks the guardian from the student, moving the guardians after it
+// up, and deletes the guardian once no student is left.
+func (r *guardianRepository) Remove(ctx context.Context, studentID uuid.UUID, guardianID uuid.UUID) error {
+	return translateError(r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockStudent(tx, studentID); err != nil {
+			return err
+		}
+		var link models.StudentGuardianEntity
+		if err := tx.Where("student_id = ? AND guardian_id = ?", studentID, guardianID).Take(&link).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("student_id = ? AND guardian_id = ?", studentID, guardianID).Delete(&models.StudentGuardianEntity{}).Error; err != nil {
+			return err
+		}
+		n, err := countLinks(tx, studentID)
+		if err != nil {
+			return err
+		}
+		if err := shift(tx, studentID, link.Priority+1, n+1, -1); err != nil {
+			return err
+		}
+
+		var left int64
+		if err := tx.Model(&models.StudentGuardianEntity{}).Where("guardian_id = ?", guardianID).Count(&left).Error; err != nil {
+			return err
+		}
+		if left > 0 {
+			return nil
+		}
+		return tx.Where("id = ?", guardianID).Delete(&models.GuardianEntity{}).Error
+	}))
+}
+
+func (r *guardianRepository) Get(studentID uuid.UUID, guardianID uuid.UUID) (*models.StudentGuardian, error) {
+	guardians, err := r.find(r.DB.Where("student_id = ? AND guardian_id = ?", studentID, guardianID))
+	if err != nil {
+		return nil, err
+	}
+	if len(guardians) == 0 {
+		return nil, models.ErrNotLinked
+	}
+	return &guardians[0], nil
+}
+
+// GetByStudent returns the guardians of the student by priority.
+func (r *guardianRepository) GetByStudent(studentID uuid.UUID) ([]models.StudentGuardian, error) {
+	return r.find(r.DB.Where("student_id = ?", studentID).Order("priority"))
+}
+
+func (r *guardianRepository) find(db *gorm.DB) ([]models.StudentGuardian, error) {
+	var links []models.StudentGuardianEntity
+	if err := db.Find(&links).Error; err != nil {
+		return nil, translateError(err)
+	}
+	guardians := []models.StudentGuardian{}
+	if len(links) == 0 {
+		return guardians, nil
+	}
+	var ids []uuid.UUID
+	for _, link := range links {
+		ids = append(ids, link.GuardianID)
+	}
+	var entities []models.GuardianEntity
+	if err := r.DB.Where("id IN ?", ids).Find(&entities).Error; err != nil {
+		return nil, translateError(err)
+	}
+	byID := map[uuid.UUID]*models.GuardianEntity{}
+	for i := range entities {
+		byID[entities[i].ID] = &entities[i]
+	}
+	for i := range links {
+		if entity, ok := byID[links[i].GuardianID]; ok {
+			guardians = append(guardians, *EntityToLink(&links[i], entity))
+		}
+	}
+	return guardians, nil
+}
+
+// GetWards returns the students of the guardian by ID, with only their IDs
+// and links set.
+func (r *guardianRepository) GetWards(guardianID uuid.UUID) ([]models.Ward, error) {
+	if _, err := r.GetGuardian(guardianID); err != nil {
+		return nil, err
+	}
+	var links []models.StudentGuardianEntity
+	if err := r.DB.Where("guardian_id = ?", guardianID).Order("student_id").Find(&links).Error; err != nil {
+		return nil, translateError(err)
+	}
+	wards := []models.Ward{}
+	for i := range links {
+		wards = append(wards, *EntityToWard(&links[i]))
+	}
+	return wards, nil
+}
+
+// DeleteStudent keeps the guardians of a deleted student, who gets them back
+// when restored.
+func (r *guardianRepository) DeleteStudent(tx *gorm.DB, id uuid.UUID) error {
+	return nil
+}
+
+// PurgeStudents unlinks the students with the given ids from their guardians
+// and deletes the guardians no other student is linked to. The student
+// repository calls it in the transaction that purges the students.
+func (r *guardianRepository) PurgeStudents(tx *gorm.DB, ids []uuid.UUID) error {
+	var guardianIDs []uuid.UUID
+	if err := tx.Model(&models.StudentGuardianEntity{}).Where("student_id IN ?", ids).Distinct().Pluck("guardian_id", &guardianIDs).Error; err != nil {
+		return err
+	}
+	if len(guardianIDs) == 0 {
+		return nil
+	}
+	if err := tx.Where("student_id IN ?", ids).Delete(&models.StudentGuardianEntity{}).Error; err != nil {
+		return err
+	}
+	linked := tx.Session(&gorm.Session{NewDB: true}).Model(&models.StudentGuardianEntity{}).Select("guardian_id")
+	return tx.Where("id IN ? AND id NOT IN (?)", guardianIDs, linked).Delete(&models.GuardianEntity{}).Error
+}
+
+func GuardianToEntity(guardian *models.Guardian) *models.GuardianEntity {
+	return &models.GuardianEntity{
+		ID:        uuid.MustParse(guardian.ID),
+		Name:      guardian.Name,
+		Surname:   guardian.Surname,
+		Email:     guardian.Email,
+		Phone:     guardian.Phone,
+		WorkPhone: guardian.WorkPhone,
+	}
+}
+
+func EntityToGuardian(entity *models.GuardianEntity) *models.Guardian {
+	return &models.Guardian{
+		ID:        entity.ID.String(),
+		Name:      entity.Name,
+		Surname:   entity.Surname,
+		Email:     entity.Email,
+		Phone:     entity.Phone,
+		WorkPhone: entity.WorkPhone,
+	}
+}
+
+func LinkToEntity(studentID uuid.UUID, guardian *models.StudentGuardian) *models.StudentGuardianEntity {
+	return &models.StudentGuardianEntity{
+		StudentID:    studentID,
+		GuardianID:   uuid.MustParse(guardian.Guardian.ID),
+		Relationship: guardian.Relationship,
+		Custody:      guardian.Custody,
+		Pickup:       guardian.Pickup,
+		Priority:     guardian.Priority,
+	}
+}
+
+func EntityToLink(link *models.StudentGuardianEntity, guardian *models.GuardianEntity) *models.StudentGuardian {
+	return &models.StudentGuardian{
+		Guardian:     *EntityToGuardian(guardian),
+		Relationship: link.Relationship,
+		Custody:      link.Custody,
+		Pickup:       link.Pickup,
+		Priority:     link.Priority,
+	}
+}
+
+func EntityToWard(link *models.StudentGuardianEntity) *models.Ward {
+	return &models.Ward{
+		StudentID:    link.StudentID.String(),
+		Relationship: link.Relationship,
+		Custody:      link.Custody,
+		Pickup:       link.Pickup,
+		Priority:     link.Priority,
+	}
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"backend/internal/database/databasetest"
+	"backend/internal/guardian/models"
+	"backend/internal/guardian/services"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type fixture struct {
+	repo services.Repository
+	// db stores the students whose rows the repository locks; the memory
+	// repository does not look them up.
+	db *gorm.DB
+}
+
+func newMemoryFixture(t *testing.T) *fixture {
+	return &fixture{repo: NewMemoryRepository()}
+}
+
+func newSQLiteFixture(t *testing.T) *fixture {
+	store := databasetest.Open(t)
+	repo, err := NewGuardianRepository(store.DB)
+	require.NoError(t, err)
+	return &fixture{repo: repo, db: store.DB}
+}
+
+// TestRepositories runs the same checks against every implementation.
+func TestRepositories(t *testing.T) {
+	databasetest.Run(t, map[string]func(t *testing.T) *fixture{
+		"Memory": newMemoryFixture,
+		"SQLite": newSQLiteFixture,
+	}, map[string]func(t *testing.T, f *fixture){
+		"Priorities": testPriorities,
+		"Siblings":   testSiblings,
+		"NotLinked":  testNotLinked,
+	})
+}
+
+// TestPurgeStudents checks that purging students takes their guardian links,
+// and the guardians no other student has, with them. The memory repositories
+// of students and guardians are unrelated, so only the SQL one is checked.
+func TestPurgeStudents(t *testing.T) {
+	ctx := context.Background()
+	store := databasetest.Open(t)
+	guardians, err := NewGuardianRepository(store.DB)
+	require.NoError(t, err)
+	store.Attach(guardians)
+
+	f := &fixture{repo: guardians, db: store.DB}
+	purged, kept := f.addStudent(t), f.addStudent(t)
+	mother, aunt := newGuardian("Anne"), newGuardian("Ada")
+	f.add(t, purged, mother, 0)
+	f.add(t, purged, aunt, 0)
+	f.add(t, kept, mother, 0)
+
+	require.NoError(t, store.Students.Delete(ctx, purged))
+	_, err = store.Students.Purge(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	links, err := guardians.GetByStudent(purged)
+	require.NoError(t, err)
+	assert.Empty(t, links, "the links of purged students are deleted")
+	wards, err := guardians.GetWards(uuid.MustParse(mother.ID))
+	require.NoError(t, err)
+	require.Len(t, wards, 1, "guardians with other students are kept")
+	assert.Equal(t, kept.String(), wards[0].StudentID)
+	_, err = guardians.GetGuardian(uuid.MustParse(aunt.ID))
+	assert.ErrorIs(t, err, models.ErrGuardianNotFound, "guardians without students are deleted")
+}
+
+func (f *fixture) addStudent(t *testing.T) uuid.UUID {
+	return databasetest.AddStudent(t, f.db)
+}
+
+func (f *fixture) add(t *testing.T, studentID uuid.UUID, guardian models.Guardian, priority int) *models.StudentGuardian {
+	link := &models.StudentGuardian{Guardian: guardian, Relationship: models.RelationshipMother, Priority: priority}
+	require.NoError(t, f.repo.Add(context.Background(), studentID, link))
+	return link
+}
+
+func newGuardian(name string) models.Guardian {
+	return models.Guardian{ID: uuid.New().String(), Name: name, Surname: "Byron", Phone: "+441234567890"}
+}
+
+// names returns the names of the guardians of the student by priority, after
+// checking that the priorities are 1..n.
+func (f *fixture) names(t *testing.T, studentID uuid.UUID) []string {
+	guardians, err := f.repo.GetByStudent(studentID)
+	require.NoError(t, err)
+	names := []string{}
+	for i, guardian := range guardians {
+		assert.Equal(t, i+1, guardian.Priority)
+		names = append(names, guardian.Guardian.Name)
+	}
+	return names
+}
+
+func testPriorities(t *testing.T, f *fixture) {
+	ctx := context.Background()
+	student := f.addStudent(t)
+	anne := f.add(t, student, newGuardian("Anne"), 0)
+	assert.Equal(t, 1, anne.Priority)
+	bob := f.add(t, student, newGuardian("Bob"), 0)
+	assert.Equal(t, 2, bob.Priority)
+	f.add(t, student, newGuardian("Carl"), 1)
+	f.add(t, student, newGuardian("Dora"), 9)
+	assert.Equal(t, []string{"Carl", "Anne", "Bob", "Dora"}, f.names(t, student))
+
+	bob.Priority = 1
+	bob.Guardian.Phone = "+440987654321"
+	require.NoError(t, f.repo.Update(ctx, student, bob))
+	assert.Equal(t, []string{"Bob", "Carl", "Anne", "Dora"}, f.names(t, student))
+	stored, err := f.repo.Get(student, uuid.MustParse(bob.Guardian.ID))
+	require.NoError(t, err)
+	assert.Equal(t, "+440987654321", stored.Guardian.Phone)
+
+	bob.Priority = 0
+	require.NoError(t, f.repo.Update(ctx, student, bob))
+	assert.Equal(t, 4, bob.Priority)
+	assert.Equal(t, []string{"Carl", "Anne", "Dora", "Bob"}, f.names(t, student))
+
+	require.NoError(t, f.repo.Remove(ctx, student, uuid.MustParse(anne.Guardian.ID)))
+	assert.Equal(t, []string{"Carl", "Dora", "Bob"}, f.names(t, student))
+}
+
+func testSiblings(t *testing.T, f *fixture) {
+	ctx := context.Background()
+	first, second := f.addStudent(t), f.addStudent(t)
+	mother := newGuardian("Anne")
+	f.add(t, first, mother, 0)
+	f.add(t, second, newGuardian("Bob"), 0)
+	f.add(t, second, mother, 0)
+
+	motherID := uuid.MustParse(mother.ID)
+	wards, err := f.repo.GetWards(motherID)
+	require.NoError(t, err)
+	require.Len(t, wards, 2)
+	priorities := map[string]int{}
+	for _, ward := range wards {
+		priorities[ward.StudentID] = ward.Priority
+	}
+	assert.Equal(t, map[string]int{first.String(): 1, second.String(): 2}, priorities)
+
+	err = f.repo.Add(ctx, first, &models.StudentGuardian{Guardian: mother, Relationship: models.RelationshipMother})
+	assert.ErrorIs(t, err, models.ErrAlreadyLinked)
+
+	// The guardian outlives its first student, and goes with the last.
+	require.NoError(t, f.repo.Remove(ctx, first, motherID))
+	_, err = f.repo.GetGuardian(motherID)
+	require.NoError(t, err)
+	require.NoError(t, f.repo.Remove(ctx, second, motherID))
+	_, err = f.repo.GetGuardian(motherID)
+	assert.ErrorIs(t, err, models.ErrGuardianNotFound)
+	_, err = f.repo.GetWards(motherID)
+	assert.ErrorIs(t, err, models.ErrGuardianNotFound)
+}
+
+func testNotLinked(t *testing.T, f *fixture) {
+	ctx := context.Background()
+	student := f.addStudent(t)
+	guardian := f.add(t, student, newGuardian("Anne"), 0)
+	other := f.addStudent(t)
+	guardianID := uuid.MustParse(guardian.Guardian.ID)
+
+	_, err := f.repo.Get(other, guardianID)
+	assert.ErrorIs(t, err, models.ErrNotLinked)
+	assert.ErrorIs(t, f.repo.Update(ctx, other, guardian), models.ErrNotLinked)
+	assert.ErrorIs(t, f.repo.Remove(ctx, other, guardianID), models.ErrNotLinked)
+	guardians, err := f.repo.GetByStudent(other)
+	require.NoError(t, err)
+	assert.Empty(t, guardians)
+}
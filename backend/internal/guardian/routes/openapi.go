@@ -0,0 +1,89 @@
+package routes
+
+import (
+	"backend/internal/auth"
+	"backend/internal/guardian/models"
+	"backend/internal/openapi"
+	"net/http"
+)
+
+var problemDescriptions = map[int]string{
+	http.StatusBadRequest:          "The request is invalid; errors lists the invalid fields",
+	http.StatusNotFound:            "No such student or guardian, or the guardian is not one of the student",
+	http.StatusConflict:            "The guardian is already a guardian of the student",
+	http.StatusServiceUnavailable:  "The database is unavailable, try again later",
+	http.StatusInternalServerError: "Unexpected error",
+}
+
+// operation secures op with permission and documents the problems it may
+// answer with, besides the 503 and 500 every operation may.
+func operation(doc *openapi.Document, op *openapi.Operation, permission auth.Permission, problems ...int) *openapi.Operation {
+	op.Tags = []string{"guardians"}
+	problems = append(problems, http.StatusServiceUnavailable, http.StatusInternalServerError)
+	for _, status := range problems {
+		op.Respond(status, doc.Problem(problemDescriptions[status]))
+	}
+	return auth.Secure(doc, op, permission)
+}
+
+// Describe documents the routes SetupRoutes registers.
+func Describe(doc *openapi.Document) {
+	doc.AddTag("guardians", "The parents and guardians of students, shared by siblings")
+	doc.Enum(models.RelationshipMother, models.RelationshipFather, models.RelationshipStepparent, models.RelationshipGrandparent,
+		models.RelationshipSibling, models.RelationshipRelative, models.RelationshipFosterParent, models.RelationshipLegalGuardian,
+		models.RelationshipOther)
+
+	uuidSchema := &openapi.Schema{Type: "string", Format: "uuid"}
+	studentID := openapi.Path("id", "The student ID", uuidSchema)
+	guardianID := openapi.Path("guardian_id", "The guardian ID", uuidSchema)
+
+	doc.Add(http.MethodGet, "/students/:id/guardians", operation(doc, &openapi.Operation{
+		Summary:     "List the guardians of a student",
+		Description: "Guardians are listed by priority, the order the school contacts them in. Contact details need students:read:pii.",
+		OperationID: "listStudentGuardians",
+		Parameters:  []*openapi.Parameter{studentID},
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.GuardianList{}, "The guardians of the student")},
+	}, auth.PermGuardiansRead, http.StatusBadRequest, http.StatusNotFound))
+
+	doc.Add(http.MethodPost, "/students/:id/guardians", operation(doc, &openapi.Operation{
+		Summary: "Add a guardian to a student",
+		Description: "Without a guardian id, a new guardian is created. With the id of an existing guardian, such as a parent of a sibling, " +
+			"that guardian is linked and keeps its details. The guardian takes the given priority, moving those from it on down, or the last one.",
+		OperationID: "addStudentGuardian",
+		Parameters:  []*openapi.Parameter{studentID},
+		RequestBody: doc.Body(models.StudentGuardian{}, ""),
+		Responses:   map[string]*openapi.Response{"201": doc.JSON(models.StudentGuardian{}, "The guardian of the student")},
+	}, auth.PermGuardiansWrite, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict))
+
+	doc.Add(http.MethodGet, "/students/:id/guardians/:guardian_id", operation(doc, &openapi.Operation{
+		Summary:     "Get a guardian of a student",
+		OperationID: "getStudentGuardian",
+		Parameters:  []*openapi.Parameter{studentID, guardianID},
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.StudentGuardian{}, "The guardian of the student")},
+	}, auth.PermGuardiansRead, http.StatusBadRequest, http.StatusNotFound))
+
+	doc.Add(http.MethodPut, "/students/:id/guardians/:guardian_id", operation(doc, &openapi.Operation{
+		Summary:     "Replace a guardian of a student",
+		Description: "The details of the guardian change for every student it is linked to; the relationship, permissions and priority only for this one.",
+		OperationID: "updateStudentGuardian",
+		Parameters:  []*openapi.Parameter{studentID, guardianID},
+		RequestBody: doc.Body(models.StudentGuardian{}, "The guardian id in the body is ignored"),
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.StudentGuardian{}, "The updated guardian of the student")},
+	}, auth.PermGuardiansWrite, http.StatusBadRequest, http.StatusNotFound))
+
+	doc.Add(http.MethodDelete, "/students/:id/guardians/:guardian_id", operation(doc, &openapi.Operation{
+		Summary:     "Remove a guardian from a student",
+		Description: "The guardians after it move up. A guardian left without students is deleted.",
+		OperationID: "removeStudentGuardian",
+		Parameters:  []*openapi.Parameter{studentID, guardianID},
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(openapi.Message{}, "The guardian was removed")},
+	}, auth.PermGuardiansWrite, http.StatusBadRequest, http.StatusNotFound))
+
+	doc.Add(http.MethodGet, "/guardians/:id/students", operation(doc, &openapi.Operation{
+		Summary:     "List the students of a guardian",
+		Description: "Finds siblings. Deleted students are left out.",
+		OperationID: "listGuardianStudents",
+		Parameters:  []*openapi.Parameter{openapi.Path("id", "The guardian ID", uuidSchema)},
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.WardList{}, "The students of the guardian")},
+	}, auth.PermGuardiansRead, http.StatusBadRequest, http.StatusNotFound))
+}
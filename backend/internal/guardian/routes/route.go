@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"backend/internal/auth"
+	"backend/internal/guardian/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupRoutes registers the guardian routes behind authenticate, each requiring
+// the permission it needs.
+func SetupRoutes(router *gin.Engine, guardianController *controllers.GuardianController, authenticate gin.HandlerFunc) {
+	guardians := router.Group("", authenticate)
+	guardians.GET("/students/:id/guardians", auth.Require(auth.PermGuardiansRead), guardianController.GetByStudent)
+	guardians.POST("/students/:id/guardians", auth.Require(auth.PermGuardiansWrite), guardianController.Add)
+	guardians.GET("/students/:id/guardians/:guardian_id", auth.Require(auth.PermGuardiansRead), guardianController.Get)
+	guardians.PUT("/students/:id/guardians/:guardian_id", auth.Require(auth.PermGuardiansWrite), guardianController.Update)
+	guardians.DELETE("/students/:id/guardians/:guardian_id", auth.Require(auth.PermGuardiansWrite), guardianController.Remove)
+	guardians.GET("/guardians/:id/students", auth.Require(auth.PermGuardiansRead), guardianController.GetWards)
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"backend/internal/auth"
+	"backend/internal/guardian/models"
+	studentmodels "backend/internal/student/models"
+	"backend/internal/validation"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	GetGuardian(id uuid.UUID) (*models.Guardian, error)
+	Add(ctx context.Context, studentID uuid.UUID, guardian *models.StudentGuardian) error
+	Update(ctx context.Context, studentID uuid.UUID, guardian *models.StudentGuardian) error
+	Remove(ctx context.Context, studentID uuid.UUID, guardianID uuid.UUID) error
+	Get(studentID uuid.UUID, guardianID uuid.UUID) (*models.StudentGuardian, error)
+	GetByStudent(studentID uuid.UUID) ([]models.StudentGuardian, error)
+	GetWards(guardianID uuid.UUID) ([]models.Ward, error)
+}
+
+// Students looks up students, telling the guardians of unknown or deleted
+// students apart and naming the students of a guardian.
+type Students interface {
+	Get(id uuid.UUID) (*studentmodels.Student, error)
+	GetByIDs(ids []uuid.UUID) ([]studentmodels.Student, error)
+}
+
+type GuardianService struct {
+	repository Repository
+	students   Students
+}
+
+func Service(repository Repository, students Students) *GuardianService {
+	return &GuardianService{repository: repository, students: students}
+}
+
+// Add gives the student a guardian. A guardian with an ID is an existing one,
+// such as a parent of a sibling, and keeps its stored details; otherwise a new
+// guardian is created.
+func (s *GuardianService) Add(ctx context.Context, studentID uuid.UUID, guardian *models.StudentGuardian) error {
+	if err := auth.Authorize(ctx, auth.PermGuardiansWrite); err != nil {
+		return err
+	}
+	if _, err := s.students.Get(studentID); err != nil {
+		return err
+	}
+	if guardian.Guardian.ID != "" {
+		id, err := uuid.Parse(guardian.Guardian.ID)
+		if err != nil {
+			return models.ErrGuardianNotFound
+		}
+		existing, err := s.repository.GetGuardian(id)
+		if err != nil {
+			return err
+		}
+		guardian.Guardian = *existing
+	} else {
+		guardian.Guardian.ID = uuid.New().String()
+	}
+	if err := validation.Validate(guardian); err != nil {
+		return err
+	}
+	if err := s.repository.Add(ctx, studentID, guardian); err != nil {
+		return err
+	}
+	auth.Redact(ctx, guardian)
+	return nil
+}
+
+// Update replaces the details of the guardian, which every student it is
+// linked to shares, and what ties it to the student.
+func (s *GuardianService) Update(ctx context.Context, studentID uuid.UUID, guardianID uuid.UUID, guardian *models.StudentGuardian) error {
+	if err := auth.Authorize(ctx, auth.PermGuardiansWrite); err != nil {
+		return err
+	}
+	if err := validation.Validate(guardian); err != nil {
+		return err
+	}
+	if _, err := s.students.Get(studentID); err != nil {
+		return err
+	}
+	guardian.Guardian.ID = guardianID.String()
+	if err := s.repository.Update(ctx, studentID, guardian); err != nil {
+		return err
+	}
+	auth.Redact(ctx, guardian)
+	return nil
+}
+
+// Remove unlinks the guardian from the student. A guardian left without
+// students is deleted.
+func (s *GuardianService) Remove(ctx context.Context, studentID uuid.UUID, guardianID uuid.UUID) error {
+	if err := auth.Authorize(ctx, auth.PermGuardiansWrite); err != nil {
+		return err
+	}
+	return s.repository.Remove(ctx, studentID, guardianID)
+}
+
+func (s *GuardianService) Get(ctx context.Context, studentID uuid.UUID, guardianID uuid.UUID) (*models.StudentGuardian, error) {
+	if err := auth.Authorize(ctx, auth.PermGuardiansRead); err != nil {
+		return nil, err
+	}
+	if _, err := s.students.Get(studentID); err != nil {
+		return nil, err
+	}
+	guardian, err := s.repository.Get(studentID, guardianID)
+	if err != nil {
+		return nil, err
+	}
+	auth.Redact(ctx, guardian)
+	return guardian, nil
+}
+
+// GetByStudent lists the guardians of the student by priority.
+func (s *GuardianService) GetByStudent(ctx context.Context, studentID uuid.UUID) (models.GuardianList, error) {
+	if err := auth.Authorize(ctx, auth.PermGuardiansRead); err != nil {
+		return models.GuardianList{}, err
+	}
+	if _, err := s.students.Get(studentID); err != nil {
+		return models.GuardianList{}, err
+	}
+	guardians, err := s.repository.GetByStudent(studentID)
+	if err != nil {
+		return models.GuardianList{}, err
+	}
+	auth.Redact(ctx, guardians)
+	return models.GuardianList{Guardians: guardians}, nil
+}
+
+// GetWards lists the students of the guardian, such as siblings, leaving out
+// deleted ones.
+func (s *GuardianService) GetWards(ctx context.Context, guardianID uuid.UUID) (models.WardList, error) {
+	if err := auth.Authorize(ctx, auth.PermGuardiansRead); err != nil {
+		return models.WardList{}, err
+	}
+	wards, err := s.repository.GetWards(guardianID)
+	if err != nil {
+		return models.WardList{}, err
+	}
+	ids := make([]uuid.UUID, 0, len(wards))
+	for _, ward := range wards {
+		ids = append(ids, uuid.MustParse(ward.StudentID))
+	}
+	students, err := s.students.GetByIDs(ids)
+	if err != nil {
+		return models.WardList{}, err
+	}
+	byID := map[string]studentmodels.Student{}
+	for _, student := range students {
+		byID[student.ID] = student
+	}
+
+	list := models.WardList{Students: []models.Ward{}}
+	for _, ward := range wards {
+		student, ok := byID[ward.StudentID]
+		if !ok {
+			continue
+		}
+		ward.StudentNumber, ward.Name, ward.Surname = student.StudentNumber, student.Name, student.Surname
+		list.Students = append(list.Students, ward)
+	}
+	return list, nil
+}
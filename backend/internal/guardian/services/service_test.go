@@ -0,0 +1,198 @@
+package services
+
+import (
+	"backend/internal/auth"
+	"backend/internal/auth/authtest"
+	"backend/internal/guardian/mocks"
+	"backend/internal/guardian/models"
+	"backend/internal/problem"
+	studentmodels "backend/internal/student/models"
+	"context"
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixture struct {
+	repo     *mocks.MockRepository
+	students *mocks.MockStudents
+	service  *GuardianService
+}
+
+func newFixture(t *testing.T) *fixture {
+	ctrl := gomock.NewController(t)
+	f := &fixture{
+		repo:     mocks.NewMockRepository(ctrl),
+		students: mocks.NewMockStudents(ctrl),
+	}
+	f.service = Service(f.repo, f.students)
+	return f
+}
+
+func newGuardian() *models.StudentGuardian {
+	return &models.StudentGuardian{
+		Guardian:     models.Guardian{Name: "Anne", Surname: "Byron", Email: "anne@example.com", Phone: "+441234567890"},
+		Relationship: models.RelationshipMother,
+		Custody:      true,
+	}
+}
+
+func TestAdd(t *testing.T) {
+	studentID := uuid.New()
+	student := &studentmodels.Student{ID: studentID.String()}
+
+	t.Run("New Guardian", func(t *testing.T) {
+		f := newFixture(t)
+		f.students.EXPECT().Get(studentID).Return(student, nil)
+		f.repo.EXPECT().Add(gomock.Any(), studentID, gomock.Any()).DoAndReturn(func(ctx context.Context, studentID uuid.UUID, guardian *models.StudentGuardian) error {
+			guardian.Priority = 1
+			return nil
+		})
+
+		guardian := newGuardian()
+		assert.NoError(t, f.service.Add(authtest.AdminContext, studentID, guardian))
+		assert.NotEmpty(t, guardian.Guardian.ID)
+		assert.Equal(t, 1, guardian.Priority)
+	})
+
+	t.Run("Existing Guardian", func(t *testing.T) {
+		f := newFixture(t)
+		existing := newGuardian().Guardian
+		existing.ID = uuid.New().String()
+		f.students.EXPECT().Get(studentID).Return(student, nil)
+		f.repo.EXPECT().GetGuardian(uuid.MustParse(existing.ID)).Return(&existing, nil)
+		f.repo.EXPECT().Add(gomock.Any(), studentID, gomock.Any()).Return(nil)
+
+		guardian := &models.StudentGuardian{Guardian: models.Guardian{ID: existing.ID}, Relationship: models.RelationshipMother}
+		assert.NoError(t, f.service.Add(authtest.AdminContext, studentID, guardian))
+		assert.Equal(t, existing, guardian.Guardian)
+	})
+
+	t.Run("Unknown Guardian", func(t *testing.T) {
+		f := newFixture(t)
+		f.students.EXPECT().Get(studentID).Return(student, nil).Times(2)
+		guardianID := uuid.New()
+		f.repo.EXPECT().GetGuardian(guardianID).Return(nil, models.ErrGuardianNotFound)
+
+		guardian := &models.StudentGuardian{Guardian: models.Guardian{ID: guardianID.String()}, Relationship: models.RelationshipMother}
+		assert.ErrorIs(t, f.service.Add(authtest.AdminContext, studentID, guardian), models.ErrGuardianNotFound)
+		guardian.Guardian.ID = "not-a-uuid"
+		assert.ErrorIs(t, f.service.Add(authtest.AdminContext, studentID, guardian), models.ErrGuardianNotFound)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		f := newFixture(t)
+		f.students.EXPECT().Get(studentID).Return(student, nil)
+
+		guardian := newGuardian()
+		guardian.Relationship = "neighbour"
+		var validationErr *problem.ValidationError
+		assert.ErrorAs(t, f.service.Add(authtest.AdminContext, studentID, guardian), &validationErr)
+	})
+
+	t.Run("Unknown Student", func(t *testing.T) {
+		f := newFixture(t)
+		f.students.EXPECT().Get(studentID).Return(nil, studentmodels.ErrStudentNotFound)
+
+		assert.ErrorIs(t, f.service.Add(authtest.AdminContext, studentID, newGuardian()), studentmodels.ErrStudentNotFound)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		f := newFixture(t)
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Username: "teacher", Permissions: []auth.Permission{auth.PermGuardiansRead}})
+
+		assert.Equal(t, &auth.ForbiddenError{Permission: auth.PermGuardiansWrite}, f.service.Add(ctx, studentID, newGuardian()))
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	f := newFixture(t)
+	studentID, guardianID := uuid.New(), uuid.New()
+	f.students.EXPECT().Get(studentID).Return(&studentmodels.Student{ID: studentID.String()}, nil)
+	f.repo.EXPECT().Update(gomock.Any(), studentID, gomock.Any()).DoAndReturn(func(ctx context.Context, studentID uuid.UUID, guardian *models.StudentGuardian) error {
+		assert.Equal(t, guardianID.String(), guardian.Guardian.ID)
+		return models.ErrNotLinked
+	})
+
+	guardian := newGuardian()
+	guardian.Guardian.ID = uuid.New().String()
+	assert.ErrorIs(t, f.service.Update(authtest.AdminContext, studentID, guardianID, guardian), models.ErrNotLinked)
+}
+
+func TestRemove(t *testing.T) {
+	f := newFixture(t)
+	studentID, guardianID := uuid.New(), uuid.New()
+	f.repo.EXPECT().Remove(gomock.Any(), studentID, guardianID).Return(models.ErrNotLinked)
+
+	assert.ErrorIs(t, f.service.Remove(authtest.AdminContext, studentID, guardianID), models.ErrNotLinked)
+}
+
+func TestGetByStudent(t *testing.T) {
+	studentID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		f := newFixture(t)
+		guardians := []models.StudentGuardian{*newGuardian()}
+		f.students.EXPECT().Get(studentID).Return(&studentmodels.Student{ID: studentID.String()}, nil)
+		f.repo.EXPECT().GetByStudent(studentID).Return(guardians, nil)
+
+		list, err := f.service.GetByStudent(authtest.AdminContext, studentID)
+		assert.NoError(t, err)
+		assert.Equal(t, models.GuardianList{Guardians: guardians}, list)
+	})
+
+	t.Run("Without PII", func(t *testing.T) {
+		f := newFixture(t)
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Username: "teacher", Permissions: []auth.Permission{auth.PermGuardiansRead}})
+		f.students.EXPECT().Get(studentID).Return(&studentmodels.Student{ID: studentID.String()}, nil)
+		f.repo.EXPECT().GetByStudent(studentID).Return([]models.StudentGuardian{*newGuardian()}, nil)
+
+		list, err := f.service.GetByStudent(ctx, studentID)
+		require.NoError(t, err)
+		require.Len(t, list.Guardians, 1)
+		assert.Equal(t, "Anne", list.Guardians[0].Guardian.Name)
+		assert.Empty(t, list.Guardians[0].Guardian.Email)
+		assert.Empty(t, list.Guardians[0].Guardian.Phone)
+	})
+
+	t.Run("Unknown Student", func(t *testing.T) {
+		f := newFixture(t)
+		f.students.EXPECT().Get(studentID).Return(nil, studentmodels.ErrStudentNotFound)
+
+		_, err := f.service.GetByStudent(authtest.AdminContext, studentID)
+		assert.ErrorIs(t, err, studentmodels.ErrStudentNotFound)
+	})
+}
+
+func TestGetWards(t *testing.T) {
+	guardianID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		f := newFixture(t)
+		first, deleted := uuid.New(), uuid.New()
+		f.repo.EXPECT().GetWards(guardianID).Return([]models.Ward{
+			{StudentID: first.String(), Relationship: models.RelationshipMother, Priority: 1},
+			{StudentID: deleted.String(), Relationship: models.RelationshipMother, Priority: 2},
+		}, nil)
+		f.students.EXPECT().GetByIDs([]uuid.UUID{first, deleted}).Return([]studentmodels.Student{
+			{ID: first.String(), StudentNumber: "S2026000001", Name: "Ada", Surname: "Byron"},
+		}, nil)
+
+		list, err := f.service.GetWards(authtest.AdminContext, guardianID)
+		assert.NoError(t, err)
+		assert.Equal(t, models.WardList{Students: []models.Ward{
+			{StudentID: first.String(), StudentNumber: "S2026000001", Name: "Ada", Surname: "Byron", Relationship: models.RelationshipMother, Priority: 1},
+		}}, list)
+	})
+
+	t.Run("Unknown Guardian", func(t *testing.T) {
+		f := newFixture(t)
+		f.repo.EXPECT().GetWards(guardianID).Return(nil, models.ErrGuardianNotFound)
+
+		_, err := f.service.GetWards(authtest.AdminContext, guardianID)
+		assert.ErrorIs(t, err, models.ErrGuardianNotFound)
+	})
+}
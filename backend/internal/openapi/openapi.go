@@ -0,0 +1,261 @@
+// Package openapi builds the OpenAPI 3.1 description of the API next to the
+// routes it describes. Each package that registers routes also adds their
+// operations to a Document, and Check compares the two so that a route cannot
+// be added without being documented:
+//
+//	doc.Add(http.MethodGet, "/students/:id", &openapi.Operation{...})
+//
+// Request and response schemas are generated from the Go types the handlers
+// bind and render.
+package openapi
+
+import (
+	"backend/internal/problem"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const Version = "3.1.0"
+
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Tags       []Tag                `json:"tags,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+
+	// operations maps "METHOD /gin/path" to its operation, for Check.
+	operations map[string]*Operation
+	// ignored routes are served but not part of the API, like the Swagger UI.
+	ignored map[string]bool
+	types   map[reflect.Type]string
+	enums   map[reflect.Type][]interface{}
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem holds the operations of one path, keyed by lower case method.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []SecurityRequirement `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+type RequestBody struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]*Header   `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Description  string `json:"description,omitempty"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	Name         string `json:"name,omitempty"`
+	In           string `json:"in,omitempty"`
+}
+
+// SecurityRequirement names a security scheme and the scopes it needs.
+type SecurityRequirement map[string][]string
+
+// New returns an empty document with the Problem schema that error responses
+// refer to.
+func New(info Info) *Document {
+	doc := &Document{
+		OpenAPI:    Version,
+		Info:       info,
+		Paths:      map[string]*PathItem{},
+		Components: Components{Schemas: map[string]*Schema{}, SecuritySchemes: map[string]*SecurityScheme{}},
+		operations: map[string]*Operation{},
+		ignored:    map[string]bool{},
+		types:      map[reflect.Type]string{},
+		enums:      map[reflect.Type][]interface{}{},
+	}
+	doc.Schema(problem.Problem{})
+	problemSchema := doc.Components.Schemas["Problem"]
+	problemSchema.Description = "An RFC 7807 problem detail. Some problems add extension members, such as the missing permission of a 403."
+	problemSchema.Required = []string{"type", "title", "status"}
+	problemSchema.AdditionalProperties = &Schema{}
+	return doc
+}
+
+// Add documents the route registered with method and the gin path, such as
+// "/students/:id". Path parameters the operation does not describe itself are
+// added as required strings.
+func (d *Document) Add(method string, path string, op *Operation) {
+	key := method + " " + path
+	if _, ok := d.operations[key]; ok {
+		panic("openapi: " + key + " is documented twice")
+	}
+	if op.Responses == nil {
+		op.Responses = map[string]*Response{}
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			name := segment[1:]
+			if op.parameter(name, "path") == nil {
+				op.Parameters = append(op.Parameters, &Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+			}
+			segment = "{" + name + "}"
+		}
+		segments = append(segments, segment)
+	}
+	openapiPath := strings.Join(segments, "/")
+
+	item, ok := d.Paths[openapiPath]
+	if !ok {
+		item = &PathItem{}
+		d.Paths[openapiPath] = item
+	}
+	(*item)[strings.ToLower(method)] = op
+	d.operations[key] = op
+}
+
+// Ignore marks a route as served but deliberately left out of the document.
+func (d *Document) Ignore(method string, path string) {
+	d.ignored[method+" "+path] = true
+}
+
+// Operation returns the operation documented for the route, or nil.
+func (d *Document) Operation(method string, path string) *Operation {
+	return d.operations[method+" "+path]
+}
+
+// AddTag describes a tag operations are grouped by.
+func (d *Document) AddTag(name string, description string) {
+	d.Tags = append(d.Tags, Tag{Name: name, Description: description})
+}
+
+// AddSecurityScheme registers a scheme operations can require by name.
+func (d *Document) AddSecurityScheme(name string, scheme *SecurityScheme) {
+	d.Components.SecuritySchemes[name] = scheme
+}
+
+// Check returns an error naming every route that is not documented and every
+// documented operation that is not routed.
+func (d *Document) Check(routes gin.RoutesInfo) error {
+	routed := map[string]bool{}
+	var problems []string
+	for _, route := range routes {
+		key := route.Method + " " + route.Path
+		routed[key] = true
+		if d.operations[key] == nil && !d.ignored[key] {
+			problems = append(problems, key+" is not documented")
+		}
+	}
+	for key := range d.operations {
+		if !routed[key] {
+			problems = append(problems, key+" is documented but not routed")
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("openapi: the document is out of sync with the routes:\n\t%s", strings.Join(problems, "\n\t"))
+}
+
+// JSON is a response with a JSON body of v's type.
+func (d *Document) JSON(v interface{}, description string) *Response {
+	return &Response{Description: description, Content: map[string]MediaType{"application/json": {Schema: d.Schema(v)}}}
+}
+
+// Body is a required JSON request body of v's type.
+func (d *Document) Body(v interface{}, description string) *RequestBody {
+	return &RequestBody{Description: description, Required: true, Content: map[string]MediaType{"application/json": {Schema: d.Schema(v)}}}
+}
+
+// Problem is an error response rendered by the problem package.
+func (d *Document) Problem(description string) *Response {
+	return &Response{Description: description, Content: map[string]MediaType{problem.ContentType: {Schema: d.Schema(problem.Problem{})}}}
+}
+
+// Query is an optional query parameter.
+func Query(name string, description string, schema *Schema) *Parameter {
+	return &Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+// Path is a path parameter.
+func Path(name string, description string, schema *Schema) *Parameter {
+	return &Parameter{Name: name, In: "path", Description: description, Required: true, Schema: schema}
+}
+
+// HeaderParameter is an optional request header.
+func HeaderParameter(name string, description string, schema *Schema) *Parameter {
+	return &Parameter{Name: name, In: "header", Description: description, Schema: schema}
+}
+
+func (o *Operation) parameter(name string, in string) *Parameter {
+	for _, p := range o.Parameters {
+		if p.Name == name && p.In == in {
+			return p
+		}
+	}
+	return nil
+}
+
+// Respond adds or replaces the response for status.
+func (o *Operation) Respond(status int, response *Response) *Operation {
+	if o.Responses == nil {
+		o.Responses = map[string]*Response{}
+	}
+	o.Responses[fmt.Sprint(status)] = response
+	return o
+}
+
+// Message is the body of responses that only confirm an action, such as a
+// deletion.
+type Message struct {
+	Message string `json:"message"`
+}
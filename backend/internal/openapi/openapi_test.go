@@ -0,0 +1,133 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Status string
+
+type Base struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type Pet struct {
+	Base
+	Name     string          `json:"name" validate:"trim,required,min=2,max=50"`
+	Status   Status          `json:"status"`
+	Born     *time.Time      `json:"born"`
+	Tags     []string        `json:"tags,omitempty"`
+	Owner    *Owner          `json:"owner"`
+	Labels   map[string]int  `json:"labels"`
+	Raw      json.RawMessage `json:"raw"`
+	Secret   string          `json:"-"`
+	Internal string          `json:"internal" access:"pets:admin"`
+	private  string
+	Friends  []Pet   `json:"friends"`
+	Legs     int     `json:"legs" validate:"min=0,max=8"`
+	Weight   float64 `json:"weight" validate:"min=0"`
+	Adopted  string  `json:"adopted" validate:"date"`
+	Contact  string  `json:"contact" validate:"email"`
+}
+
+type Owner struct {
+	Name string `json:"name"`
+}
+
+func TestSchema(t *testing.T) {
+	doc := New(Info{Title: "Pets", Version: "1"})
+	doc.Enum(Status("available"), Status("sold"))
+
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/Pet"}, doc.Schema(Pet{}))
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Ref: "#/components/schemas/Pet"}}, doc.Schema([]Pet{}))
+
+	pet := doc.Components.Schemas["Pet"]
+	require.NotNil(t, pet)
+	assert.Equal(t, []string{"name"}, pet.Required, "required comes from the validate tag")
+	two, fifty := 2, 50
+	assert.Equal(t, &Schema{Type: "string", MinLength: &two, MaxLength: &fifty}, pet.Properties["name"])
+	zero, eight := 0.0, 8.0
+	assert.Equal(t, &Schema{Type: "integer", Minimum: &zero, Maximum: &eight}, pet.Properties["legs"], "numbers are bounded by value")
+	assert.Equal(t, &Schema{Type: "number", Minimum: &zero}, pet.Properties["weight"])
+	assert.Equal(t, &Schema{Type: "string", Format: "uuid"}, pet.Properties["id"], "embedded fields are flattened")
+	assert.Equal(t, &Schema{Type: "string", Enum: []interface{}{Status("available"), Status("sold")}}, pet.Properties["status"])
+	assert.Equal(t, &Schema{Type: []string{"string", "null"}, Format: "date-time"}, pet.Properties["born"])
+	assert.Equal(t, &Schema{Type: "string", Format: "date"}, pet.Properties["adopted"])
+	assert.Equal(t, &Schema{Type: "string", Format: "email"}, pet.Properties["contact"])
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/Owner"}, pet.Properties["owner"])
+	assert.Equal(t, &Schema{Type: "object", AdditionalProperties: &Schema{Type: "integer"}}, pet.Properties["labels"])
+	assert.Equal(t, &Schema{}, pet.Properties["raw"], "types with their own encoding are any JSON")
+	assert.Equal(t, "Only present for principals with the pets:admin permission.", pet.Properties["internal"].Description)
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Ref: "#/components/schemas/Pet"}}, pet.Properties["friends"], "recursive types refer to themselves")
+	assert.NotContains(t, pet.Properties, "Secret")
+	assert.NotContains(t, pet.Properties, "private")
+	assert.Contains(t, doc.Components.Schemas, "Owner")
+
+	type Problem struct{}
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/OpenapiProblem"}, doc.Schema(Problem{}), "names taken by another package are prefixed")
+}
+
+func TestAdd(t *testing.T) {
+	doc := New(Info{Title: "Pets", Version: "1"})
+	id := Path("id", "The pet ID", &Schema{Type: "string", Format: "uuid"})
+	doc.Add(http.MethodGet, "/pets/:id/photos/:photo", &Operation{OperationID: "getPhoto", Parameters: []*Parameter{id}})
+	doc.Add(http.MethodDelete, "/pets/:id/photos/:photo", &Operation{OperationID: "deletePhoto"})
+
+	item := doc.Paths["/pets/{id}/photos/{photo}"]
+	require.NotNil(t, item)
+	get := (*item)["get"]
+	assert.Equal(t, []*Parameter{id, {Name: "photo", In: "path", Required: true, Schema: &Schema{Type: "string"}}}, get.Parameters)
+	assert.Same(t, get, doc.Operation(http.MethodGet, "/pets/:id/photos/:photo"))
+	assert.Contains(t, *item, "delete")
+
+	assert.Panics(t, func() {
+		doc.Add(http.MethodGet, "/pets/:id/photos/:photo", &Operation{})
+	})
+}
+
+func TestCheck(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := func(*gin.Context) {}
+	router := gin.New()
+	router.GET("/pets", handler)
+	router.POST("/pets", handler)
+
+	doc := New(Info{Title: "Pets", Version: "1"})
+	doc.Add(http.MethodGet, "/pets", &Operation{OperationID: "listPets"})
+	doc.Add(http.MethodPost, "/pets", &Operation{OperationID: "createPet"})
+	assert.NoError(t, doc.Check(router.Routes()))
+
+	router.DELETE("/pets/:id", handler)
+	router.GET("/internal", handler)
+	doc.Ignore(http.MethodGet, "/internal")
+	doc.Add(http.MethodPut, "/pets/:id", &Operation{OperationID: "updatePet"})
+	assert.EqualError(t, doc.Check(router.Routes()), "openapi: the document is out of sync with the routes:\n"+
+		"\tDELETE /pets/:id is not documented\n"+
+		"\tPUT /pets/:id is documented but not routed")
+}
+
+func TestSetupRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	doc := New(Info{Title: "Pets", Version: "1"})
+	SetupRoutes(router, doc)
+	require.NoError(t, doc.Check(router.Routes()))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var served map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &served))
+	assert.Equal(t, Version, served["openapi"])
+	assert.Contains(t, served["paths"], "/openapi.json")
+	problem := served["components"].(map[string]interface{})["schemas"].(map[string]interface{})["Problem"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"type", "title", "status"}, problem["required"])
+}
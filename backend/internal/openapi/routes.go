@@ -0,0 +1,46 @@
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+// swaggerInitializer replaces the one bundled with Swagger UI, which loads the
+// petstore example.
+const swaggerInitializer = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: "/openapi.json",
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+// SetupRoutes serves the document at /openapi.json and Swagger UI at /docs/.
+// Both are public, like the API's own description of its authentication.
+func SetupRoutes(router *gin.Engine, doc *Document) {
+	router.GET("/openapi.json", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, doc)
+	})
+	doc.Add(http.MethodGet, "/openapi.json", &Operation{
+		Tags:        []string{"meta"},
+		Summary:     "This OpenAPI document",
+		OperationID: "getOpenAPI",
+		Responses:   map[string]*Response{"200": {Description: "The OpenAPI 3.1 document", Content: map[string]MediaType{"application/json": {Schema: &Schema{Type: "object"}}}}},
+	})
+
+	files := http.FS(swaggerFiles.FS)
+	router.GET("/docs/*filepath", func(ctx *gin.Context) {
+		if ctx.Param("filepath") == "/swagger-initializer.js" {
+			ctx.Data(http.StatusOK, "application/javascript; charset=utf-8", []byte(swaggerInitializer))
+			return
+		}
+		ctx.FileFromFS(ctx.Param("filepath"), files)
+	})
+	doc.Ignore(http.MethodGet, "/docs/*filepath")
+}
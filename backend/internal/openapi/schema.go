@@ -0,0 +1,199 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Schema is a JSON Schema, the 2020-12 dialect OpenAPI 3.1 uses. Type is a
+// string, or a list of them for nullable values.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 interface{}        `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Enum documents the values a named string type may take. Pass every value,
+// before the schemas of the types using it are generated.
+func (d *Document) Enum(values ...interface{}) {
+	if len(values) > 0 {
+		d.enums[reflect.TypeOf(values[0])] = values
+	}
+}
+
+// Schema returns the schema of v's type. Structs are added to the components
+// once and referred to by name; the json tags name their properties, and the
+// validate tags make them required, bound their length or value and mark
+// dates and email addresses.
+func (d *Document) Schema(v interface{}) *Schema {
+	return d.schemaOf(reflect.TypeOf(v))
+}
+
+func (d *Document) schemaOf(t reflect.Type) *Schema {
+	if values, ok := d.enums[t]; ok {
+		return &Schema{Type: "string", Enum: values}
+	}
+	if t.Kind() == reflect.Pointer {
+		schema := d.schemaOf(t.Elem())
+		if schema.Ref != "" || schema.Type == nil {
+			return schema
+		}
+		nullable := *schema
+		nullable.Type = []string{schema.Type.(string), "null"}
+		return &nullable
+	}
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Implements(jsonMarshalerType):
+		// Encoded by its own rules, such as an audit snapshot: any JSON.
+		return &Schema{}
+	case t.Implements(textMarshalerType):
+		schema := &Schema{Type: "string"}
+		if t.PkgPath() == "github.com/google/uuid" {
+			schema.Format = "uuid"
+		}
+		return schema
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: d.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return d.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + d.component(t)}
+	}
+	return &Schema{}
+}
+
+// component adds the schema of the named struct t, once, and returns its name.
+// A name taken by a type of another package is prefixed with the subsystem of
+// the package, the directory under internal, or else the package itself:
+// backend/internal/course/models.Page becomes CoursePage.
+func (d *Document) component(t reflect.Type) string {
+	if name, ok := d.types[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, taken := d.Components.Schemas[name]; taken {
+		pkg := path.Base(t.PkgPath())
+		if _, after, found := strings.Cut(t.PkgPath(), "/internal/"); found {
+			pkg, _, _ = strings.Cut(after, "/")
+		}
+		prefix := []rune(pkg)
+		name = string(unicode.ToUpper(prefix[0])) + string(prefix[1:]) + name
+	}
+	d.types[t] = name
+	// Registered before its fields so that recursive types refer to themselves.
+	d.Components.Schemas[name] = &Schema{}
+	*d.Components.Schemas[name] = *d.structSchema(t)
+	return name
+}
+
+func (d *Document) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	d.addFields(schema, t)
+	return schema
+}
+
+func (d *Document) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			// Embedded fields are flattened into the outer object, like
+			// encoding/json does.
+			d.addFields(schema, field.Type)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := d.schemaOf(field.Type)
+		if property.Ref == "" {
+			copied := *property
+			property = &copied
+		}
+		for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+			rule, param, _ := strings.Cut(rule, "=")
+			switch rule {
+			case "required":
+				schema.Required = append(schema.Required, name)
+			case "min":
+				if n, err := strconv.Atoi(param); err == nil {
+					if property.Type == "integer" || property.Type == "number" {
+						bound := float64(n)
+						property.Minimum = &bound
+					} else {
+						property.MinLength = &n
+					}
+				}
+			case "max":
+				if n, err := strconv.Atoi(param); err == nil {
+					if property.Type == "integer" || property.Type == "number" {
+						bound := float64(n)
+						property.Maximum = &bound
+					} else {
+						property.MaxLength = &n
+					}
+				}
+			case "date":
+				property.Format = "date"
+			case "email":
+				property.Format = "email"
+			}
+		}
+		if permission := field.Tag.Get("access"); permission != "" && property.Ref == "" {
+			property.Description = "Only present for principals with the " + permission + " permission."
+		}
+		schema.Properties[name] = property
+	}
+}
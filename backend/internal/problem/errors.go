@@ -0,0 +1,93 @@
+// Package problem holds the error kinds shared by every layer and renders them
+// as RFC 7807 problem details. Repositories return these errors, services pass
+// them on and Middleware turns them into application/problem+json responses.
+package problem
+
+import (
+	"errors"
+	"strings"
+)
+
+// The kinds of error. Match them with errors.Is; the typed errors below carry
+// the details.
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrValidation  = errors.New("validation failed")
+	ErrConflict    = errors.New("conflict")
+	ErrUnavailable = errors.New("service unavailable")
+)
+
+// NotFoundError reports a missing resource. It matches ErrNotFound.
+type NotFoundError struct {
+	Detail string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Detail
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// FieldError is the problem with a single field of the request. Code is stable
+// and meant for programs, Message for people.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidationError reports invalid input, field by field where the fields are
+// known. It matches ErrValidation.
+type ValidationError struct {
+	Detail string
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	if e.Detail != "" || len(e.Fields) == 0 {
+		return e.Detail
+	}
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Field+": "+field.Message)
+	}
+	return strings.Join(messages, ", ")
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// ConflictError reports a request that clashes with the current state, such as a
+// duplicate key. It matches ErrConflict.
+type ConflictError struct {
+	Detail string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Detail
+}
+
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// UnavailableError wraps the failure of a dependency, usually the database, that
+// may go away by retrying. It matches ErrUnavailable.
+type UnavailableError struct {
+	Err error
+}
+
+func (e *UnavailableError) Error() string {
+	return ErrUnavailable.Error() + ": " + e.Err.Error()
+}
+
+func (e *UnavailableError) Unwrap() error {
+	return e.Err
+}
+
+func (e *UnavailableError) Is(target error) bool {
+	return target == ErrUnavailable
+}
@@ -0,0 +1,63 @@
+package problem
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// graphqlCodes maps the status of a problem to the extensions.code GraphQL
+// clients conventionally switch on.
+var graphqlCodes = map[int]string{
+	http.StatusBadRequest:          "BAD_USER_INPUT",
+	http.StatusUnauthorized:        "UNAUTHENTICATED",
+	http.StatusForbidden:           "FORBIDDEN",
+	http.StatusNotFound:            "NOT_FOUND",
+	http.StatusConflict:            "CONFLICT",
+	http.StatusTooManyRequests:     "RATE_LIMITED",
+	http.StatusServiceUnavailable:  "UNAVAILABLE",
+	http.StatusInternalServerError: "INTERNAL_SERVER_ERROR",
+}
+
+// GraphQLError is an error of a GraphQL resolver. Its message is the detail of
+// the problem and its extensions the rest of it.
+type GraphQLError struct {
+	Message    string
+	extensions map[string]interface{}
+}
+
+func (e *GraphQLError) Error() string { return e.Message }
+
+// Extensions is read by the GraphQL server into the extensions of the error.
+func (e *GraphQLError) Extensions() map[string]interface{} { return e.extensions }
+
+// GraphQL is the GraphQL counterpart of For and GRPCStatus.
+func GraphQL(err error) *GraphQLError {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return &GraphQLError{Message: err.Error(), extensions: map[string]interface{}{"code": "CANCELED"}}
+	case errors.Is(err, context.DeadlineExceeded):
+		return &GraphQLError{Message: err.Error(), extensions: map[string]interface{}{"code": "TIMEOUT"}}
+	}
+
+	problem := For(err)
+	message := problem.Detail
+	if message == "" {
+		message = problem.Title
+	}
+	code, ok := graphqlCodes[problem.Status]
+	if !ok {
+		code = "UNKNOWN"
+	}
+	extensions := map[string]interface{}{"code": code, "status": problem.Status}
+	if problem.Type != "" && problem.Type != "about:blank" {
+		extensions["type"] = problem.Type
+	}
+	if len(problem.Errors) > 0 {
+		extensions["errors"] = problem.Errors
+	}
+	for name, value := range problem.Extensions {
+		extensions[name] = value
+	}
+	return &GraphQLError{Message: message, extensions: extensions}
+}
@@ -0,0 +1,42 @@
+package problem
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphQL(t *testing.T) {
+	for _, tc := range []struct {
+		Description string
+		Err         error
+		Message     string
+		Extensions  map[string]interface{}
+	}{
+		{"NotFound", &NotFoundError{Detail: "student not found"}, "student not found", map[string]interface{}{"code": "NOT_FOUND", "status": http.StatusNotFound}},
+		{"Unexpected", errors.New("syntax error near SELECT"), "Internal Server Error", map[string]interface{}{"code": "INTERNAL_SERVER_ERROR", "status": http.StatusInternalServerError}},
+		{"Unmapped", teapotError{}, "short and stout", map[string]interface{}{"code": "UNKNOWN", "status": http.StatusTeapot, "spout": true}},
+		{"Canceled", context.Canceled, "context canceled", map[string]interface{}{"code": "CANCELED"}},
+		{
+			"Validation",
+			&ValidationError{Fields: []FieldError{{Field: "name", Code: "required", Message: "is required"}}},
+			"name: is required",
+			map[string]interface{}{"code": "BAD_USER_INPUT", "status": http.StatusBadRequest, "errors": []FieldError{{Field: "name", Code: "required", Message: "is required"}}},
+		},
+		{
+			"Forbidden",
+			forbiddenError{},
+			"missing permission students:write",
+			map[string]interface{}{"code": "FORBIDDEN", "status": http.StatusForbidden, "reason": "missing_permission", "permission": "students:write"},
+		},
+	} {
+		t.Run(tc.Description, func(t *testing.T) {
+			err := GraphQL(tc.Err)
+			assert.Equal(t, tc.Message, err.Error())
+			assert.Equal(t, tc.Extensions, err.Extensions())
+		})
+	}
+}
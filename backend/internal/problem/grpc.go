@@ -0,0 +1,75 @@
+package problem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+// grpcCodes maps the status of a problem to the gRPC code with the same meaning.
+var grpcCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+	http.StatusInternalServerError: codes.Internal,
+}
+
+// GRPCStatus is the gRPC counterpart of For. Invalid fields become a BadRequest
+// detail and extension members such as the reason of a 403 an ErrorInfo.
+func GRPCStatus(err error) *status.Status {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.New(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.New(codes.DeadlineExceeded, err.Error())
+	}
+
+	problem := For(err)
+	code, ok := grpcCodes[problem.Status]
+	if !ok {
+		code = codes.Unknown
+	}
+	message := problem.Detail
+	if message == "" {
+		message = problem.Title
+	}
+	st := status.New(code, message)
+
+	var details []protoiface.MessageV1
+	if len(problem.Errors) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range problem.Errors {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: field.Field, Description: field.Message})
+		}
+		details = append(details, badRequest)
+	}
+	if reason, ok := problem.Extensions["reason"].(string); ok {
+		// ErrorInfo reasons are UPPER_SNAKE_CASE by convention.
+		info := &errdetails.ErrorInfo{Reason: strings.ToUpper(reason), Domain: "students", Metadata: map[string]string{}}
+		for name, value := range problem.Extensions {
+			if name != "reason" {
+				info.Metadata[name] = fmt.Sprint(value)
+			}
+		}
+		details = append(details, info)
+	}
+	if len(details) > 0 {
+		if withDetails, err := st.WithDetails(details...); err == nil {
+			return withDetails
+		}
+	}
+	return st
+}
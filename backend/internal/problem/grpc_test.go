@@ -0,0 +1,67 @@
+package problem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+type forbiddenError struct{}
+
+func (forbiddenError) Error() string { return "missing permission students:write" }
+
+func (forbiddenError) Problem() *Problem {
+	forbidden := New(http.StatusForbidden, "missing permission students:write")
+	forbidden.Extensions = map[string]interface{}{"reason": "missing_permission", "permission": "students:write"}
+	return forbidden
+}
+
+func TestGRPCStatus(t *testing.T) {
+	for _, tc := range []struct {
+		Description string
+		Err         error
+		Code        codes.Code
+		Message     string
+	}{
+		{"NotFound", &NotFoundError{Detail: "student not found"}, codes.NotFound, "student not found"},
+		{"Conflict", &ConflictError{Detail: "student already exists"}, codes.AlreadyExists, "student already exists"},
+		{"Unavailable", &UnavailableError{Err: errors.New("connection refused")}, codes.Unavailable, "a backing service is unavailable, try again later"},
+		{"Unexpected", errors.New("syntax error near SELECT"), codes.Internal, "Internal Server Error"},
+		{"Unmapped", teapotError{}, codes.Unknown, "short and stout"},
+		{"Canceled", fmt.Errorf("query: %w", context.Canceled), codes.Canceled, "query: context canceled"},
+		{"DeadlineExceeded", context.DeadlineExceeded, codes.DeadlineExceeded, "context deadline exceeded"},
+	} {
+		t.Run(tc.Description, func(t *testing.T) {
+			st := GRPCStatus(tc.Err)
+			assert.Equal(t, tc.Code, st.Code())
+			assert.Equal(t, tc.Message, st.Message())
+			assert.Empty(t, st.Details())
+		})
+	}
+
+	t.Run("Validation", func(t *testing.T) {
+		st := GRPCStatus(&ValidationError{Fields: []FieldError{{Field: "name", Code: "required", Message: "is required"}}})
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		require.Len(t, st.Details(), 1)
+		badRequest := st.Details()[0].(*errdetails.BadRequest)
+		require.Len(t, badRequest.FieldViolations, 1)
+		assert.Equal(t, "name", badRequest.FieldViolations[0].Field)
+		assert.Equal(t, "is required", badRequest.FieldViolations[0].Description)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		st := GRPCStatus(forbiddenError{})
+		assert.Equal(t, codes.PermissionDenied, st.Code())
+		require.Len(t, st.Details(), 1)
+		info := st.Details()[0].(*errdetails.ErrorInfo)
+		assert.Equal(t, "MISSING_PERMISSION", info.Reason)
+		assert.Equal(t, map[string]string{"permission": "students:write"}, info.Metadata)
+	})
+}
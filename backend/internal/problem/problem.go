@@ -0,0 +1,108 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContentType is the media type of a rendered Problem.
+const ContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details object. Extensions are marshalled as
+// additional members.
+type Problem struct {
+	Type       string                 `json:"type"`
+	Title      string                 `json:"title"`
+	Status     int                    `json:"status"`
+	Detail     string                 `json:"detail,omitempty"`
+	Instance   string                 `json:"instance,omitempty"`
+	Errors     []FieldError           `json:"errors,omitempty"`
+	Extensions map[string]interface{} `json:"-"`
+	// Header is added to the response, e.g. WWW-Authenticate for a 401.
+	Header http.Header `json:"-"`
+}
+
+// Error is implemented by errors of other packages that describe their own
+// problem, such as the authorization errors.
+type Error interface {
+	error
+	Problem() *Problem
+}
+
+// New returns a problem with the given status and its standard title.
+func New(status int, detail string) *Problem {
+	return &Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: detail}
+}
+
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+	raw, err := json.Marshal((*problem)(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return raw, err
+	}
+	members := map[string]interface{}{}
+	for name, value := range p.Extensions {
+		members[name] = value
+	}
+	if err := json.Unmarshal(raw, &members); err != nil {
+		return nil, err
+	}
+	return json.Marshal(members)
+}
+
+// For describes err. The details of unexpected errors are left out, since they
+// may reveal internals; those errors answer 500.
+func For(err error) *Problem {
+	var described Error
+	var validation *ValidationError
+	switch {
+	case errors.As(err, &described):
+		return described.Problem()
+	case errors.As(err, &validation):
+		problem := New(http.StatusBadRequest, err.Error())
+		problem.Errors = validation.Fields
+		return problem
+	case errors.Is(err, ErrNotFound):
+		return New(http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrConflict):
+		return New(http.StatusConflict, err.Error())
+	case errors.Is(err, ErrUnavailable):
+		return New(http.StatusServiceUnavailable, "a backing service is unavailable, try again later")
+	default:
+		return New(http.StatusInternalServerError, "")
+	}
+}
+
+// Abort answers the request with the problem describing err.
+func Abort(ctx *gin.Context, err error) {
+	Render(ctx, For(err))
+}
+
+// Render answers the request with problem.
+func Render(ctx *gin.Context, problem *Problem) {
+	if problem.Instance == "" {
+		problem.Instance = ctx.Request.URL.Path
+	}
+	for name, values := range problem.Header {
+		for _, value := range values {
+			ctx.Writer.Header().Add(name, value)
+		}
+	}
+	ctx.Header("Content-Type", ContentType)
+	ctx.AbortWithStatusJSON(problem.Status, problem)
+}
+
+// Middleware renders the last error a handler added with ctx.Error, unless the
+// handler already wrote a response.
+func Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+		if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+			return
+		}
+		Abort(ctx, ctx.Errors.Last().Err)
+	}
+}
@@ -0,0 +1,114 @@
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type teapotError struct{}
+
+func (teapotError) Error() string { return "short and stout" }
+
+func (teapotError) Problem() *Problem {
+	teapot := New(http.StatusTeapot, "short and stout")
+	teapot.Extensions = map[string]interface{}{"spout": true}
+	teapot.Header = http.Header{"Retry-After": {"60"}}
+	return teapot
+}
+
+func TestKinds(t *testing.T) {
+	notFound := &NotFoundError{Detail: "student not found"}
+	assert.ErrorIs(t, fmt.Errorf("get: %w", notFound), ErrNotFound)
+	assert.ErrorIs(t, &ValidationError{Detail: "invalid"}, ErrValidation)
+	assert.ErrorIs(t, &ConflictError{Detail: "exists"}, ErrConflict)
+	assert.NotErrorIs(t, notFound, ErrConflict)
+
+	cause := errors.New("connection refused")
+	unavailable := &UnavailableError{Err: cause}
+	assert.ErrorIs(t, unavailable, ErrUnavailable)
+	assert.ErrorIs(t, unavailable, cause)
+
+	assert.EqualError(t, &ValidationError{Fields: []FieldError{{Field: "name", Message: "is required"}, {Field: "email", Message: "is invalid"}}},
+		"name: is required, email: is invalid")
+}
+
+func TestFor(t *testing.T) {
+	for _, tc := range []struct {
+		Description string
+		Err         error
+		Status      int
+		Detail      string
+	}{
+		{"NotFound", &NotFoundError{Detail: "student not found"}, http.StatusNotFound, "student not found"},
+		{"Validation", fmt.Errorf("%w: cannot sort by %q", &ValidationError{Detail: "invalid query"}, "age"), http.StatusBadRequest, `invalid query: cannot sort by "age"`},
+		{"Conflict", &ConflictError{Detail: "student already exists"}, http.StatusConflict, "student already exists"},
+		{"Unavailable", &UnavailableError{Err: errors.New("dial tcp: connection refused")}, http.StatusServiceUnavailable, "a backing service is unavailable, try again later"},
+		{"Described", teapotError{}, http.StatusTeapot, "short and stout"},
+		{"Unexpected", errors.New("syntax error near SELECT"), http.StatusInternalServerError, ""},
+	} {
+		t.Run(tc.Description, func(t *testing.T) {
+			problem := For(tc.Err)
+			assert.Equal(t, tc.Status, problem.Status)
+			assert.Equal(t, http.StatusText(tc.Status), problem.Title)
+			assert.Equal(t, tc.Detail, problem.Detail)
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	router := gin.New()
+	router.Use(Middleware())
+	router.GET("/validation", func(ctx *gin.Context) {
+		ctx.Error(&ValidationError{Detail: "invalid student", Fields: []FieldError{{Field: "name", Message: "is required"}}})
+	})
+	router.GET("/described", func(ctx *gin.Context) {
+		ctx.Error(teapotError{})
+	})
+	router.GET("/written", func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, "partial")
+		ctx.Error(errors.New("stream failed"))
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/validation", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, ContentType, w.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "invalid student",
+			"instance": "/validation",
+			"errors": [{"field": "name", "message": "is required"}]
+		}`, w.Body.String())
+	})
+
+	t.Run("Described", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/described", nil))
+		assert.Equal(t, http.StatusTeapot, w.Code)
+		assert.Equal(t, "60", w.Header().Get("Retry-After"))
+		assert.JSONEq(t, `{
+			"type": "about:blank",
+			"title": "I'm a teapot",
+			"status": 418,
+			"detail": "short and stout",
+			"instance": "/described",
+			"spout": true
+		}`, w.Body.String())
+	})
+
+	t.Run("AlreadyWritten", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/written", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "partial", w.Body.String())
+	})
+}
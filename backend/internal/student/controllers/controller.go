@@ -1,41 +1,77 @@
 package controllers
 
 import (
+	"backend/internal/audit"
+	"backend/internal/auth"
+	"backend/internal/problem"
 	"backend/internal/student/models"
+	"context"
+	"io"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type StudentService interface {
-	GetAll(page int, pageSize int) (models.PaginationResponse, error)
-	Get(id uuid.UUID) (*models.Student, error)
-	Add(student *models.Student) error
-	Delete(id uuid.UUID) error
+	GetAll(ctx context.Context, query models.StudentQuery, page int, pageSize int) (models.PaginationResponse, error)
+	GetAllByCursor(ctx context.Context, query models.StudentQuery, cursor string, pageSize int, withTotal bool) (models.CursorResponse, error)
+	Get(ctx context.Context, id uuid.UUID) (*models.Student, error)
+	Add(ctx context.Context, student *models.Student) error
+	Update(ctx context.Context, id uuid.UUID, student *models.Student) error
+	Patch(ctx context.Context, id uuid.UUID, patch []byte) (*models.Student, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) (*models.Student, error)
+	GetDeleted(ctx context.Context, page int, pageSize int) (models.PaginationResponse, error)
+	Import(ctx context.Context, file io.Reader, format models.FileFormat, dryRun bool) (*models.ImportReport, error)
+	Export(ctx context.Context, query models.StudentQuery, format models.FileFormat, w io.Writer) error
+	History(ctx context.Context, id uuid.UUID, page int, pageSize int) (models.AuditResponse, error)
+	Audit(ctx context.Context, filter audit.Filter, page int, pageSize int) (models.AuditResponse, error)
+	Transition(ctx context.Context, id uuid.UUID, request *models.TransitionRequest) (*models.Transition, error)
+	GetTransitions(ctx context.Context, id uuid.UUID) (models.TransitionList, error)
+}
+
+var (
+	errInvalidID      = &problem.ValidationError{Detail: "invalid UUID", Fields: []problem.FieldError{{Field: "id", Code: "uuid", Message: "must be a UUID"}}}
+	errInvalidRequest = &problem.ValidationError{Detail: "invalid request"}
+)
+
+var contentTypes = map[models.FileFormat]string{
+	models.FormatCSV:    "text/csv",
+	models.FormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	models.FormatNDJSON: "application/x-ndjson",
 }
 
 type StudentController struct {
-	Service StudentService
+	Service         StudentService
+	DefaultPageSize int
+	MaxPageSize     int
 }
 
 func Controller(Service StudentService) *StudentController {
 	return &StudentController{Service: Service}
 }
 
+// requestContext is the context handed to the service. The authenticated user
+// becomes the actor of the changes it records in the audit trail.
+func requestContext(ctx *gin.Context) context.Context {
+	return auth.AuditContext(ctx.Request.Context())
+}
+
 func (c *StudentController) Get(ctx *gin.Context) {
 	idString := ctx.Param("id")
 	id, err := uuid.Parse(idString)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"message": "invalid UUID"})
+		ctx.Error(errInvalidID)
 		return
 	}
 
-	student, err := c.Service.Get(id)
-
+	student, err := c.Service.Get(requestContext(ctx), id)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"message": "student not found", "student_id": id})
+		ctx.Error(err)
 		return
 	}
 
@@ -46,48 +82,353 @@ func (c *StudentController) Delete(ctx *gin.Context) {
 	idString := ctx.Param("id")
 	id, err := uuid.Parse(idString)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid UUID"})
+		ctx.Error(errInvalidID)
 		return
 	}
 
-	err = c.Service.Delete(id)
-
+	err = c.Service.Delete(requestContext(ctx), id)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "student not found"})
+		ctx.Error(err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "Student deleted successfully"})
 }
 
+func (c *StudentController) Restore(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidID)
+		return
+	}
+
+	student, err := c.Service.Restore(requestContext(ctx), id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, student)
+}
+
+// GetDeleted lists soft deleted students for administrators.
+func (c *StudentController) GetDeleted(ctx *gin.Context) {
+	page, pageSize, err := c.pagination(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response, err := c.Service.GetDeleted(requestContext(ctx), page, pageSize)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// History lists the recorded changes of a student, newest first.
+func (c *StudentController) History(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidID)
+		return
+	}
+	page, pageSize, err := c.pagination(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response, err := c.Service.History(requestContext(ctx), id, page, pageSize)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// Transition moves a student to another status.
+func (c *StudentController) Transition(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidID)
+		return
+	}
+	var request models.TransitionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errInvalidRequest)
+		return
+	}
+
+	transition, err := c.Service.Transition(requestContext(ctx), id, &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, transition)
+}
+
+// GetTransitions lists the status changes of a student, oldest first.
+func (c *StudentController) GetTransitions(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidID)
+		return
+	}
+
+	response, err := c.Service.GetTransitions(requestContext(ctx), id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// Audit lists audit entries filtered by entityType, entityId, actor, action and
+// the from/to time range.
+func (c *StudentController) Audit(ctx *gin.Context) {
+	filter, err := audit.ParseFilter(ctx.Request.URL.Query())
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	page, pageSize, err := c.pagination(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response, err := c.Service.Audit(requestContext(ctx), filter, page, pageSize)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
 func (c *StudentController) Add(ctx *gin.Context) {
 	var student models.Student
 	if err := ctx.ShouldBindJSON(&student); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		ctx.Error(errInvalidRequest)
 		return
 	}
-	err := c.Service.Add(&student)
+	err := c.Service.Add(requestContext(ctx), &student)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create student"})
+		ctx.Error(err)
 		return
 	}
 	ctx.JSON(http.StatusCreated, student)
 }
 
-func (c *StudentController) GetAll(ctx *gin.Context) {
+func (c *StudentController) Update(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidID)
+		return
+	}
+
+	var student models.Student
+	if err := ctx.ShouldBindJSON(&student); err != nil {
+		ctx.Error(errInvalidRequest)
+		return
+	}
+
+	err = c.Service.Update(requestContext(ctx), id, &student)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, student)
+}
+
+func (c *StudentController) Patch(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidID)
+		return
+	}
+
+	patch, err := ctx.GetRawData()
+	if err != nil {
+		ctx.Error(errInvalidRequest)
+		return
+	}
+
+	student, err := c.Service.Patch(requestContext(ctx), id, patch)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, student)
+}
+
+// Import accepts a CSV or XLSX file either as the "file" field of a multipart form or
+// as the raw request body. dryRun=true validates the rows without saving them.
+func (c *StudentController) Import(ctx *gin.Context) {
+	dryRun, _ := strconv.ParseBool(ctx.Query("dryRun"))
+
+	var file io.Reader = ctx.Request.Body
+	format := models.FileFormat(ctx.Query("format"))
+	if upload, err := ctx.FormFile("file"); err == nil {
+		opened, err := upload.Open()
+		if err != nil {
+			ctx.Error(errInvalidRequest)
+			return
+		}
+		defer opened.Close()
+		file = opened
+		if format == "" {
+			format = formatFromExtension(upload.Filename)
+		}
+	} else if format == "" {
+		format = formatFromContentType(ctx.ContentType())
+	}
+
+	report, err := c.Service.Import(requestContext(ctx), file, format, dryRun)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
+
+func formatFromExtension(filename string) models.FileFormat {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return models.FormatCSV
+	case ".xlsx":
+		return models.FormatXLSX
+	}
+	return ""
+}
+
+func formatFromContentType(contentType string) models.FileFormat {
+	for format, formatContentType := range contentTypes {
+		if contentType == formatContentType {
+			return format
+		}
+	}
+	return ""
+}
+
+// Export streams every student matching the list endpoint's filters and sort. The
+// format comes from ?format=, then the Accept header, and defaults to CSV.
+func (c *StudentController) Export(ctx *gin.Context) {
+	format := models.FileFormat(ctx.Query("format"))
+	if format == "" {
+		for _, accepted := range strings.Split(ctx.GetHeader("Accept"), ",") {
+			mediaType, _, _ := strings.Cut(accepted, ";")
+			if format = formatFromContentType(strings.TrimSpace(mediaType)); format != "" {
+				break
+			}
+		}
+	}
+	if format == "" {
+		format = models.FormatCSV
+	}
+	contentType, ok := contentTypes[format]
+	if !ok {
+		problem.Render(ctx, problem.New(http.StatusNotAcceptable, "unsupported export format"))
+		return
+	}
+
+	query, err := models.ParseStudentQuery(ctx.Request.URL.Query())
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", `attachment; filename="students.`+string(format)+`"`)
+	ctx.Status(http.StatusOK)
+	err = c.Service.Export(requestContext(ctx), query, format, ctx.Writer)
+	if err != nil && !ctx.Writer.Written() {
+		ctx.Writer.Header().Del("Content-Type")
+		ctx.Writer.Header().Del("Content-Disposition")
+		ctx.Error(err)
+		return
+	}
+	if err != nil {
+		// The status line is gone, so the client only sees a truncated body.
+		ctx.Error(err)
+		ctx.Abort()
+	}
+}
+
+// pagination reads the page and size query parameters, applying the configured
+// default and maximum page size. Values that are not positive integers are a
+// validation error.
+func (c *StudentController) pagination(ctx *gin.Context) (int, int, error) {
 	page := 1
-	pageSize := 10
-	if pageStr := ctx.Query("page"); pageStr != "" {
-		page, _ = strconv.Atoi(pageStr)
+	pageSize := c.DefaultPageSize
+	if pageSize <= 0 {
+		pageSize = 10
 	}
 
+	var fields []problem.FieldError
+	if pageStr := ctx.Query("page"); pageStr != "" {
+		var err error
+		if page, err = strconv.Atoi(pageStr); err != nil || page < 1 {
+			fields = append(fields, problem.FieldError{Field: "page", Code: "positive", Message: "must be a positive integer"})
+		}
+	}
 	if pageSizeStr := ctx.Query("size"); pageSizeStr != "" {
-		pageSize, _ = strconv.Atoi(pageSizeStr)
+		var err error
+		if pageSize, err = strconv.Atoi(pageSizeStr); err != nil || pageSize < 1 {
+			fields = append(fields, problem.FieldError{Field: "size", Code: "positive", Message: "must be a positive integer"})
+		}
+	}
+	if len(fields) > 0 {
+		return 0, 0, &problem.ValidationError{Detail: models.ErrInvalidPage.Detail, Fields: fields}
 	}
 
-	response, err := c.Service.GetAll(page, pageSize)
+	if c.MaxPageSize > 0 && pageSize > c.MaxPageSize {
+		pageSize = c.MaxPageSize
+	}
+	return page, pageSize, nil
+}
+
+func (c *StudentController) GetAll(ctx *gin.Context) {
+	page, pageSize, err := c.pagination(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	query, err := models.ParseStudentQuery(ctx.Request.URL.Query())
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if cursor, ok := ctx.GetQuery("cursor"); ok {
+		c.getAllByCursor(ctx, query, cursor, pageSize)
+		return
+	}
+
+	response, err := c.Service.GetAll(requestContext(ctx), query, page, pageSize)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// getAllByCursor serves GET /students?cursor=... An empty cursor starts at the first
+// page; total=true adds the number of matching students.
+func (c *StudentController) getAllByCursor(ctx *gin.Context, query models.StudentQuery, cursor string, pageSize int) {
+	withTotal, _ := strconv.ParseBool(ctx.Query("total"))
+
+	response, err := c.Service.GetAllByCursor(requestContext(ctx), query, cursor, pageSize, withTotal)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to retreive students"})
+		ctx.Error(err)
 		return
 	}
 
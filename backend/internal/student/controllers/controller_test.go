@@ -1,14 +1,23 @@
 package controllers
 
 import (
+	"backend/internal/apitest"
+	"backend/internal/audit"
+	"backend/internal/auth"
+	"backend/internal/problem"
 	"backend/internal/student/mocks"
 	"backend/internal/student/models"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	gomock "github.com/golang/mock/gomock"
@@ -16,6 +25,20 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// assertProblem checks that w is a problem+json response with the given detail.
+func assertProblem(t *testing.T, w *httptest.ResponseRecorder, detail string) {
+	t.Helper()
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, float64(w.Code), body["status"])
+	if detail == "" {
+		assert.NotContains(t, body, "detail", "unexpected errors are not described")
+	} else {
+		assert.Equal(t, detail, body["detail"])
+	}
+}
+
 func TestGet(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -26,6 +49,7 @@ func TestGet(t *testing.T) {
 	}
 
 	router := gin.Default()
+	router.Use(problem.Middleware())
 	router.GET("/students/:id", controller.Get)
 
 	t.Run("GetSuccess", func(t *testing.T) {
@@ -36,9 +60,9 @@ func TestGet(t *testing.T) {
 		}
 		id := uuid.MustParse(expectedStudent.ID)
 
-		mockService.EXPECT().Get(id).Return(expectedStudent, nil)
+		mockService.EXPECT().Get(gomock.Any(), id).Return(expectedStudent, nil)
 
-		w := performRequest(router, "GET", "/students/"+id.String(), nil)
+		w := apitest.Request(router, "GET", "/students/"+id.String(), nil)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
@@ -51,17 +75,34 @@ func TestGet(t *testing.T) {
 	t.Run("GetFail", func(t *testing.T) {
 		id := uuid.New()
 
-		mockService.EXPECT().Get(id).Return(nil, errors.New("student not found"))
+		mockService.EXPECT().Get(gomock.Any(), id).Return(nil, models.ErrStudentNotFound)
 
-		w := performRequest(router, "GET", "/students/"+id.String(), nil)
+		w := apitest.Request(router, "GET", "/students/"+id.String(), nil)
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
+		assertProblem(t, w, "student not found")
+	})
 
-		var response map[string]interface{}
-		err := json.Unmarshal(w.Body.Bytes(), &response)
-		assert.NoError(t, err)
-		assert.Equal(t, "student not found", response["message"])
-		assert.Equal(t, id.String(), response["student_id"])
+	t.Run("GetUnavailable", func(t *testing.T) {
+		id := uuid.New()
+
+		mockService.EXPECT().Get(gomock.Any(), id).Return(nil, &problem.UnavailableError{Err: errors.New("connection refused")})
+
+		w := apitest.Request(router, "GET", "/students/"+id.String(), nil)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.NotContains(t, w.Body.String(), "connection refused")
+	})
+
+	t.Run("GetError", func(t *testing.T) {
+		id := uuid.New()
+
+		mockService.EXPECT().Get(gomock.Any(), id).Return(nil, errors.New("unexpected"))
+
+		w := apitest.Request(router, "GET", "/students/"+id.String(), nil)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assertProblem(t, w, "")
 	})
 }
 
@@ -75,14 +116,15 @@ func TestDelete(t *testing.T) {
 	}
 
 	router := gin.Default()
+	router.Use(problem.Middleware())
 	router.DELETE("/students/:id", controller.Delete)
 
 	t.Run("valid student ID", func(t *testing.T) {
 		validID := uuid.New()
 
-		mockService.EXPECT().Delete(validID).Return(nil)
+		mockService.EXPECT().Delete(gomock.Any(), validID).Return(nil)
 
-		w := performRequest(router, "DELETE", "/students/"+validID.String(), nil)
+		w := apitest.Request(router, "DELETE", "/students/"+validID.String(), nil)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.JSONEq(t, `{"message": "Student deleted successfully"}`, w.Body.String())
@@ -91,25 +133,98 @@ func TestDelete(t *testing.T) {
 	t.Run("invalid ID", func(t *testing.T) {
 		invalidID := "invalid-uuid"
 
-		w := performRequest(router, "DELETE", "/students/"+invalidID, nil)
+		w := apitest.Request(router, "DELETE", "/students/"+invalidID, nil)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
-		assert.JSONEq(t, `{"error": "invalid UUID"}`, w.Body.String())
+		assertProblem(t, w, "invalid UUID")
 	})
 
 	t.Run("student not found", func(t *testing.T) {
 		notFoundID := uuid.New()
 
-		expectedError := errors.New("student not found")
-		mockService.EXPECT().Delete(notFoundID).Return(expectedError)
+		mockService.EXPECT().Delete(gomock.Any(), notFoundID).Return(models.ErrStudentNotFound)
 
-		w := performRequest(router, "DELETE", "/students/"+notFoundID.String(), nil)
+		w := apitest.Request(router, "DELETE", "/students/"+notFoundID.String(), nil)
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
-		assert.JSONEq(t, `{"error": "student not found"}`, w.Body.String())
+		assertProblem(t, w, "student not found")
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		id := uuid.New()
+
+		mockService.EXPECT().Delete(gomock.Any(), id).Return(errors.New("connection refused"))
+
+		w := apitest.Request(router, "DELETE", "/students/"+id.String(), nil)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assertProblem(t, w, "")
 	})
 }
 
+func TestRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStudentService(ctrl)
+	controller := &StudentController{
+		Service: mockService,
+	}
+
+	router := gin.Default()
+	router.Use(problem.Middleware())
+	router.POST("/students/:id/restore", controller.Restore)
+
+	id := uuid.MustParse("7995c72f-7d04-4136-8b5f-000d6d4aae23")
+
+	t.Run("RestoreSuccess", func(t *testing.T) {
+		mockService.EXPECT().Restore(gomock.Any(), id).Return(&models.Student{ID: id.String(), Name: "John", Surname: "Doe"}, nil)
+
+		w := apitest.Request(router, "POST", "/students/"+id.String()+"/restore", nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"id": "7995c72f-7d04-4136-8b5f-000d6d4aae23", "name": "John", "surname": "Doe"}`, w.Body.String())
+	})
+
+	t.Run("NotDeleted", func(t *testing.T) {
+		mockService.EXPECT().Restore(gomock.Any(), id).Return(nil, models.ErrStudentNotFound)
+
+		w := apitest.Request(router, "POST", "/students/"+id.String()+"/restore", nil)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assertProblem(t, w, "student not found")
+	})
+}
+
+func TestGetDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStudentService(ctrl)
+	controller := &StudentController{
+		Service: mockService,
+	}
+
+	router := gin.Default()
+	router.Use(problem.Middleware())
+	router.GET("/admin/students/deleted", controller.GetDeleted)
+
+	deletedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockResponse := models.PaginationResponse{
+		Students: []models.Student{{ID: "1", Name: "John", Surname: "Doe", DeletedAt: &deletedAt}},
+		Page:     models.Page{Number: 2, Size: 5, Elements: 6, Pages: 2},
+	}
+	mockService.EXPECT().GetDeleted(gomock.Any(), 2, 5).Return(mockResponse, nil)
+
+	w := apitest.Request(router, "GET", "/admin/students/deleted?page=2&size=5", nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"students": [{"id": "1", "name": "John", "surname": "Doe", "deletedAt": "2026-01-02T03:04:05Z"}],
+		"page": {"pageNumber": 2, "pageSize": 5, "totalElements": 6, "totalPages": 2}
+	}`, w.Body.String())
+}
+
 func TestAdd(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -120,6 +235,7 @@ func TestAdd(t *testing.T) {
 	}
 
 	router := gin.Default()
+	router.Use(problem.Middleware())
 	router.POST("/students", controller.Add)
 
 	t.Run("ValidStudent", func(t *testing.T) {
@@ -131,9 +247,9 @@ func TestAdd(t *testing.T) {
 
 		requestBody, _ := json.Marshal(studentToAdd)
 
-		mockService.EXPECT().Add(gomock.Any()).Return(nil)
+		mockService.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil)
 
-		w := performRequest(router, "POST", "/students", requestBody)
+		w := apitest.Request(router, "POST", "/students", requestBody)
 
 		assert.Equal(t, http.StatusCreated, w.Code)
 
@@ -146,10 +262,10 @@ func TestAdd(t *testing.T) {
 	t.Run("InvalidRequestBody", func(t *testing.T) {
 		invalidRequestBody := []byte(`{"invalid_json": }`)
 
-		w := performRequest(router, "POST", "/students", invalidRequestBody)
+		w := apitest.Request(router, "POST", "/students", invalidRequestBody)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
-		assert.JSONEq(t, `{"error": "invalid request"}`, w.Body.String())
+		assertProblem(t, w, "invalid request")
 	})
 
 	t.Run("AddError", func(t *testing.T) {
@@ -161,12 +277,12 @@ func TestAdd(t *testing.T) {
 
 		requestBody, _ := json.Marshal(studentToAdd)
 
-		mockService.EXPECT().Add(gomock.Any()).Return(errors.New("failed to create student"))
+		mockService.EXPECT().Add(gomock.Any(), gomock.Any()).Return(errors.New("failed to create student"))
 
-		w := performRequest(router, "POST", "/students", requestBody)
+		w := apitest.Request(router, "POST", "/students", requestBody)
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
-		assert.JSONEq(t, `{"error": "failed to create student"}`, w.Body.String())
+		assertProblem(t, w, "")
 	})
 
 }
@@ -181,6 +297,7 @@ func TestGetAll(t *testing.T) {
 	}
 
 	router := gin.Default()
+	router.Use(problem.Middleware())
 	router.GET("/students", controller.GetAll)
 
 	t.Run("GetAllSuccess", func(t *testing.T) {
@@ -197,9 +314,9 @@ func TestGetAll(t *testing.T) {
 			},
 		}
 
-		mockService.EXPECT().GetAll(gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+		mockService.EXPECT().GetAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 
-		w := performRequest(router, "GET", "/students", nil)
+		w := apitest.Request(router, "GET", "/students", nil)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
@@ -210,22 +327,538 @@ func TestGetAll(t *testing.T) {
 	})
 
 	t.Run("GetAllError", func(t *testing.T) {
-		mockService.EXPECT().GetAll(gomock.Any(), gomock.Any()).Return(models.PaginationResponse{}, errors.New("failed to retrieve students"))
+		mockService.EXPECT().GetAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(models.PaginationResponse{}, errors.New("failed to retrieve students"))
 
-		w := performRequest(router, "GET", "/students", nil)
+		w := apitest.Request(router, "GET", "/students", nil)
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assertProblem(t, w, "")
+	})
 
-		var response map[string]interface{}
-		err := json.Unmarshal(w.Body.Bytes(), &response)
+	t.Run("InvalidPage", func(t *testing.T) {
+		mockService.EXPECT().GetAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		w := apitest.Request(router, "GET", "/students?page=abc&size=0", nil)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertProblem(t, w, "page and size must be positive integers")
+		assert.Contains(t, w.Body.String(), `{"field":"page","code":"positive","message":"must be a positive integer"}`)
+		assert.Contains(t, w.Body.String(), `{"field":"size","code":"positive","message":"must be a positive integer"}`)
+	})
+
+	t.Run("ConfiguredPageSizes", func(t *testing.T) {
+		controller.DefaultPageSize = 25
+		controller.MaxPageSize = 50
+		defer func() {
+			controller.DefaultPageSize = 0
+			controller.MaxPageSize = 0
+		}()
+
+		mockService.EXPECT().GetAll(gomock.Any(), gomock.Any(), 1, 25).Return(models.PaginationResponse{}, nil)
+		w := apitest.Request(router, "GET", "/students", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		mockService.EXPECT().GetAll(gomock.Any(), gomock.Any(), 2, 50).Return(models.PaginationResponse{}, nil)
+		w = apitest.Request(router, "GET", "/students?page=2&size=500", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("FilterAndSort", func(t *testing.T) {
+		expectedQuery := models.StudentQuery{
+			Filters: []models.Filter{
+				{Field: "name", Op: models.FilterPrefix, Value: "ah"},
+				{Field: "surname", Op: models.FilterEquals, Value: "ceylan"},
+			},
+			Search: "met",
+			Sort:   []models.SortKey{{Field: "surname"}, {Field: "name", Desc: true}, {Field: "id"}},
+		}
+
+		mockService.EXPECT().GetAll(gomock.Any(), expectedQuery, 1, 10).Return(models.PaginationResponse{}, nil)
+
+		w := apitest.Request(router, "GET", "/students?name[prefix]=ah&surname=ceylan&q=met&sort=surname,-name", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("CursorMode", func(t *testing.T) {
+		total := int64(2)
+		mockResponse := models.CursorResponse{
+			Students: []models.Student{{ID: "1", Name: "John", Surname: "Doe"}},
+			Cursors:  models.Cursors{Next: "next-cursor"},
+			Total:    &total,
+		}
+		mockService.EXPECT().GetAllByCursor(gomock.Any(), models.DefaultStudentQuery(), "", 1, true).Return(mockResponse, nil)
+
+		w := apitest.Request(router, "GET", "/students?cursor=&size=1&total=true", nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"students": [{"id": "1", "name": "John", "surname": "Doe"}], "cursors": {"next": "next-cursor"}, "totalElements": 2}`, w.Body.String())
+	})
+
+	t.Run("InvalidCursor", func(t *testing.T) {
+		mockService.EXPECT().GetAllByCursor(gomock.Any(), gomock.Any(), "bad", 10, false).Return(models.CursorResponse{}, models.ErrInvalidCursor)
+
+		w := apitest.Request(router, "GET", "/students?cursor=bad", nil)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertProblem(t, w, "invalid cursor")
+	})
+
+	t.Run("InvalidQuery", func(t *testing.T) {
+		w := apitest.Request(router, "GET", "/students?sort=age", nil)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertProblem(t, w, `invalid query: cannot sort by "age"`)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStudentService(ctrl)
+	controller := &StudentController{
+		Service: mockService,
+	}
+
+	router := gin.Default()
+	router.Use(problem.Middleware())
+	router.PUT("/students/:id", controller.Update)
+
+	id := uuid.MustParse("7995c72f-7d04-4136-8b5f-000d6d4aae23")
+
+	t.Run("UpdateSuccess", func(t *testing.T) {
+		requestBody, _ := json.Marshal(&models.Student{Name: "John", Surname: "Doe"})
+
+		mockService.EXPECT().Update(gomock.Any(), id, gomock.Any()).DoAndReturn(func(_ context.Context, id uuid.UUID, student *models.Student) error {
+			student.ID = id.String()
+			return nil
+		})
+
+		w := apitest.Request(router, "PUT", "/students/"+id.String(), requestBody)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"id": "7995c72f-7d04-4136-8b5f-000d6d4aae23", "name": "John", "surname": "Doe"}`, w.Body.String())
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		w := apitest.Request(router, "PUT", "/students/invalid-uuid", []byte(`{}`))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertProblem(t, w, "invalid UUID")
+	})
+
+	t.Run("ValidationError", func(t *testing.T) {
+		mockService.EXPECT().Update(gomock.Any(), id, gomock.Any()).Return(&problem.ValidationError{Detail: "name and surname are required", Fields: []problem.FieldError{{Field: "surname", Message: "is required"}}})
+
+		w := apitest.Request(router, "PUT", "/students/"+id.String(), []byte(`{"name": "John"}`))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertProblem(t, w, "name and surname are required")
+	})
+
+	t.Run("StudentNotFound", func(t *testing.T) {
+		mockService.EXPECT().Update(gomock.Any(), id, gomock.Any()).Return(models.ErrStudentNotFound)
+
+		w := apitest.Request(router, "PUT", "/students/"+id.String(), []byte(`{"name": "John", "surname": "Doe"}`))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assertProblem(t, w, "student not found")
+	})
+
+	t.Run("UpdateError", func(t *testing.T) {
+		mockService.EXPECT().Update(gomock.Any(), id, gomock.Any()).Return(errors.New("connection refused"))
+
+		w := apitest.Request(router, "PUT", "/students/"+id.String(), []byte(`{"name": "John", "surname": "Doe"}`))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assertProblem(t, w, "")
+	})
+}
+
+func TestPatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStudentService(ctrl)
+	controller := &StudentController{
+		Service: mockService,
+	}
+
+	router := gin.Default()
+	router.Use(problem.Middleware())
+	router.PATCH("/students/:id", controller.Patch)
+
+	id := uuid.MustParse("7995c72f-7d04-4136-8b5f-000d6d4aae23")
+
+	t.Run("PatchSuccess", func(t *testing.T) {
+		patch := []byte(`{"surname": "Doe"}`)
+		patchedStudent := &models.Student{ID: id.String(), Name: "John", Surname: "Doe"}
+
+		mockService.EXPECT().Patch(gomock.Any(), id, patch).Return(patchedStudent, nil)
+
+		w := apitest.Request(router, "PATCH", "/students/"+id.String(), patch)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var actualStudent models.Student
+		err := json.Unmarshal(w.Body.Bytes(), &actualStudent)
 		assert.NoError(t, err)
-		assert.Equal(t, "failed to retreive students", response["message"])
+		assert.Equal(t, patchedStudent, &actualStudent)
+	})
+
+	t.Run("InvalidPatch", func(t *testing.T) {
+		mockService.EXPECT().Patch(gomock.Any(), id, gomock.Any()).Return(nil, models.ErrInvalidPatch)
+
+		w := apitest.Request(router, "PATCH", "/students/"+id.String(), []byte(`[]`))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertProblem(t, w, "patch must be a JSON object")
+	})
+
+	t.Run("StudentNotFound", func(t *testing.T) {
+		mockService.EXPECT().Patch(gomock.Any(), id, gomock.Any()).Return(nil, models.ErrStudentNotFound)
+
+		w := apitest.Request(router, "PATCH", "/students/"+id.String(), []byte(`{"name": "John"}`))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
 }
 
-func performRequest(router *gin.Engine, method, url string, body []byte) *httptest.ResponseRecorder {
-	w := httptest.NewRecorder()
-	req, _ := http.NewRequest(method, url, bytes.NewBuffer(body))
-	router.ServeHTTP(w, req)
-	return w
+func TestImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStudentService(ctrl)
+	controller := &StudentController{
+		Service: mockService,
+	}
+
+	router := gin.Default()
+	router.Use(problem.Middleware())
+	router.POST("/students/import", controller.Import)
+
+	report := &models.ImportReport{Created: 1, Rows: []models.ImportRow{{Row: 2, Status: models.ImportCreated, StudentID: "1"}}}
+
+	t.Run("MultipartUpload", func(t *testing.T) {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, _ := form.CreateFormFile("file", "students.XLSX")
+		part.Write([]byte("workbook"))
+		form.Close()
+
+		mockService.EXPECT().Import(gomock.Any(), gomock.Any(), models.FormatXLSX, false).DoAndReturn(func(_ context.Context, file io.Reader, format models.FileFormat, dryRun bool) (*models.ImportReport, error) {
+			content, _ := io.ReadAll(file)
+			assert.Equal(t, "workbook", string(content))
+			return report, nil
+		})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/students/import", &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"dryRun": false, "created": 1, "skipped": 0, "failed": 0, "rows": [{"row": 2, "status": "created", "studentId": "1"}]}`, w.Body.String())
+	})
+
+	t.Run("RawBodyDryRun", func(t *testing.T) {
+		mockService.EXPECT().Import(gomock.Any(), gomock.Any(), models.FormatCSV, true).Return(report, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/students/import?dryRun=true", bytes.NewBufferString("name,surname\n"))
+		req.Header.Set("Content-Type", "text/csv")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("InvalidFile", func(t *testing.T) {
+		mockService.EXPECT().Import(gomock.Any(), gomock.Any(), models.FileFormat(""), false).Return(nil, fmt.Errorf("%w: unsupported format \"\"", models.ErrInvalidImport))
+
+		w := apitest.Request(router, "POST", "/students/import", []byte("???"))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertProblem(t, w, `invalid import file: unsupported format ""`)
+	})
+}
+
+func TestExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStudentService(ctrl)
+	controller := &StudentController{
+		Service: mockService,
+	}
+
+	router := gin.Default()
+	router.Use(problem.Middleware())
+	router.GET("/students/export", controller.Export)
+
+	t.Run("AcceptHeader", func(t *testing.T) {
+		expectedQuery := models.DefaultStudentQuery()
+		expectedQuery.Filters = []models.Filter{{Field: "surname", Op: models.FilterEquals, Value: "doe"}}
+
+		mockService.EXPECT().Export(gomock.Any(), expectedQuery, models.FormatNDJSON, gomock.Any()).DoAndReturn(func(_ context.Context, query models.StudentQuery, format models.FileFormat, w io.Writer) error {
+			_, err := w.Write([]byte(`{"id":"1","name":"John","surname":"doe"}` + "\n"))
+			return err
+		})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/students/export?surname=doe", nil)
+		req.Header.Set("Accept", "application/json;q=0.5, application/x-ndjson")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="students.ndjson"`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, `{"id":"1","name":"John","surname":"doe"}`+"\n", w.Body.String())
+	})
+
+	t.Run("DefaultsToCSV", func(t *testing.T) {
+		mockService.EXPECT().Export(gomock.Any(), gomock.Any(), models.FormatCSV, gomock.Any()).Return(nil)
+
+		w := apitest.Request(router, "GET", "/students/export", nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	})
+
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		w := apitest.Request(router, "GET", "/students/export?format=pdf", nil)
+
+		assert.Equal(t, http.StatusNotAcceptable, w.Code)
+	})
+
+	t.Run("ExportError", func(t *testing.T) {
+		mockService.EXPECT().Export(gomock.Any(), gomock.Any(), models.FormatXLSX, gomock.Any()).Return(errors.New("connection refused"))
+
+		w := apitest.Request(router, "GET", "/students/export?format=xlsx", nil)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "", w.Header().Get("Content-Disposition"))
+		assertProblem(t, w, "")
+	})
+}
+
+func TestHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStudentService(ctrl)
+	controller := &StudentController{
+		Service: mockService,
+	}
+
+	router := gin.Default()
+	router.Use(problem.Middleware())
+	router.GET("/students/:id/history", controller.History)
+
+	t.Run("HistorySuccess", func(t *testing.T) {
+		id := uuid.New()
+		response := models.AuditResponse{
+			Entries: []audit.Entry{{ID: 1, EntityType: "student", EntityID: id.String(), Action: audit.ActionCreate, Actor: "registrar", After: audit.Snapshot(`{"name":"hasan"}`)}},
+			Page:    models.Page{Number: 1, Size: 5, Elements: 1, Pages: 1},
+		}
+		mockService.EXPECT().History(gomock.Any(), id, 1, 5).Return(response, nil)
+
+		w := apitest.Request(router, "GET", "/students/"+id.String()+"/history?size=5", nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var actual map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+		entry := actual["entries"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "registrar", entry["actor"])
+		assert.Equal(t, map[string]interface{}{"name": "hasan"}, entry["after"], "snapshots are nested JSON")
+		assert.Nil(t, entry["before"])
+	})
+
+	t.Run("HistoryInvalidID", func(t *testing.T) {
+		w := apitest.Request(router, "GET", "/students/not-a-uuid/history", nil)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStudentService(ctrl)
+	controller := &StudentController{
+		Service: mockService,
+	}
+
+	router := gin.Default()
+	router.Use(problem.Middleware())
+	router.POST("/students/:id/transitions", controller.Transition)
+	router.GET("/students/:id/transitions", controller.GetTransitions)
+	id := uuid.New()
+
+	t.Run("TransitionSuccess", func(t *testing.T) {
+		request := &models.TransitionRequest{To: models.StatusSuspended, Reason: "unpaid fees"}
+		transition := &models.Transition{ID: 1, StudentID: id.String(), From: models.StatusEnrolled, To: models.StatusSuspended, Reason: "unpaid fees", Actor: "registrar"}
+		mockService.EXPECT().Transition(gomock.Any(), id, request).Return(transition, nil)
+
+		w := apitest.Request(router, "POST", "/students/"+id.String()+"/transitions", []byte(`{"to": "suspended", "reason": "unpaid fees"}`))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var actual map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+		assert.Equal(t, "enrolled", actual["from"])
+		assert.Equal(t, "suspended", actual["to"])
+	})
+
+	t.Run("IllegalTransition", func(t *testing.T) {
+		mockService.EXPECT().Transition(gomock.Any(), id, gomock.Any()).Return(nil, &models.TransitionError{From: models.StatusGraduated, To: models.StatusEnrolled})
+
+		w := apitest.Request(router, "POST", "/students/"+id.String()+"/transitions", []byte(`{"to": "enrolled"}`))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assertProblem(t, w, "cannot change status from graduated to enrolled")
+		var body map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "illegal_transition", body["reason"])
+	})
+
+	t.Run("RequirementsUnmet", func(t *testing.T) {
+		unmet := []string{"MATH101 is not completely graded"}
+		mockService.EXPECT().Transition(gomock.Any(), id, gomock.Any()).Return(nil, &models.TransitionError{From: models.StatusEnrolled, To: models.StatusGraduated, Unmet: unmet})
+
+		w := apitest.Request(router, "POST", "/students/"+id.String()+"/transitions", []byte(`{"to": "graduated"}`))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		var body map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "requirements_unmet", body["reason"])
+		assert.Equal(t, []interface{}{"MATH101 is not completely graded"}, body["unmet"])
+	})
+
+	t.Run("InvalidRequestBody", func(t *testing.T) {
+		w := apitest.Request(router, "POST", "/students/"+id.String()+"/transitions", []byte(`{"to": `))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		w := apitest.Request(router, "POST", "/students/not-a-uuid/transitions", []byte(`{"to": "enrolled"}`))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("GetTransitionsSuccess", func(t *testing.T) {
+		list := models.TransitionList{Transitions: []models.Transition{{ID: 1, StudentID: id.String(), From: models.StatusEnrolled, To: models.StatusSuspended}}}
+		mockService.EXPECT().GetTransitions(gomock.Any(), id).Return(list, nil)
+
+		w := apitest.Request(router, "GET", "/students/"+id.String()+"/transitions", nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var actual models.TransitionList
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+		assert.Equal(t, list, actual)
+	})
+
+	t.Run("GetTransitionsNotFound", func(t *testing.T) {
+		mockService.EXPECT().GetTransitions(gomock.Any(), id).Return(models.TransitionList{}, models.ErrStudentNotFound)
+
+		w := apitest.Request(router, "GET", "/students/"+id.String()+"/transitions", nil)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestAudit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStudentService(ctrl)
+	controller := &StudentController{
+		Service: mockService,
+	}
+
+	router := gin.Default()
+	router.Use(problem.Middleware())
+	router.GET("/audit", controller.Audit)
+
+	t.Run("AuditSuccess", func(t *testing.T) {
+		from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		filter := audit.Filter{Actor: "registrar", Action: audit.ActionDelete, From: &from}
+		mockService.EXPECT().Audit(gomock.Any(), filter, 1, 10).Return(models.AuditResponse{Entries: []audit.Entry{}}, nil)
+
+		w := apitest.Request(router, "GET", "/audit?actor=registrar&action=delete&from=2026-01-01T00:00:00Z", nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("AuditInvalidFilter", func(t *testing.T) {
+		w := apitest.Request(router, "GET", "/audit?from=yesterday", nil)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Contains(t, response["detail"], "from")
+	})
+
+	t.Run("AuditFail", func(t *testing.T) {
+		mockService.EXPECT().Audit(gomock.Any(), audit.Filter{}, 1, 10).Return(models.AuditResponse{}, errors.New("connection refused"))
+
+		w := apitest.Request(router, "GET", "/audit", nil)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestRequestContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStudentService(ctrl)
+	controller := &StudentController{
+		Service: mockService,
+	}
+
+	router := gin.Default()
+	router.Use(problem.Middleware())
+	router.DELETE("/students/:id", func(ctx *gin.Context) {
+		principal := &auth.Principal{Subject: uuid.NewString(), Username: "registrar"}
+		ctx.Request = ctx.Request.WithContext(auth.WithPrincipal(ctx.Request.Context(), principal))
+	}, controller.Delete)
+
+	id := uuid.New()
+	mockService.EXPECT().Delete(gomock.Any(), id).DoAndReturn(func(ctx context.Context, id uuid.UUID) error {
+		assert.Equal(t, "registrar", audit.Actor(ctx), "the principal is the audit actor")
+		return nil
+	})
+
+	w := apitest.Request(router, "DELETE", "/students/"+id.String(), nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStudentService(ctrl)
+	controller := &StudentController{
+		Service: mockService,
+	}
+
+	router := gin.Default()
+	router.Use(problem.Middleware())
+	router.DELETE("/students/:id", controller.Delete)
+	router.PATCH("/students/:id", controller.Patch)
+
+	id := uuid.New()
+	mockService.EXPECT().Delete(gomock.Any(), id).Return(&auth.ForbiddenError{Permission: auth.PermStudentsDelete})
+	mockService.EXPECT().Patch(gomock.Any(), id, gomock.Any()).Return(nil, auth.ErrUnauthenticated)
+
+	w := apitest.Request(router, "DELETE", "/students/"+id.String(), nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "missing_permission", response["reason"])
+	assert.Equal(t, "students:delete", response["permission"])
+
+	w = apitest.Request(router, "PATCH", "/students/"+id.String(), []byte(`{}`))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
@@ -0,0 +1,229 @@
+// Package graph serves the student service over GraphQL. The schema is in
+// schema.graphql; the root resolver is GraphServer.
+package graph
+
+import (
+	"backend/internal/problem"
+	"backend/internal/student/models"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schema string
+
+// StudentService is the part of the student service the GraphQL API exposes.
+type StudentService interface {
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Student, error)
+	GetConnection(ctx context.Context, query models.StudentQuery, args models.ConnectionArgs) (models.StudentConnection, error)
+	Count(ctx context.Context, query models.StudentQuery) (int64, error)
+	Add(ctx context.Context, student *models.Student) error
+	Patch(ctx context.Context, id uuid.UUID, patch []byte) (*models.Student, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+var errInvalidID = &problem.ValidationError{Detail: "invalid UUID", Fields: []problem.FieldError{{Field: "id", Code: "uuid", Message: "must be a UUID"}}}
+
+type GraphServer struct {
+	Service         StudentService
+	DefaultPageSize int
+	MaxPageSize     int
+}
+
+func Server(service StudentService) *GraphServer {
+	return &GraphServer{Service: service}
+}
+
+// resolverError converts the errors of the service to GraphQL errors.
+func resolverError(err error) error {
+	return problem.GraphQL(err)
+}
+
+func parseID(id graphql.ID) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(string(id))
+	if err != nil {
+		return uuid.UUID{}, resolverError(errInvalidID)
+	}
+	return parsed, nil
+}
+
+// Student loads the student through the request's loader, so that a query
+// asking for several students costs a single lookup.
+func (s *GraphServer) Student(ctx context.Context, args struct{ ID graphql.ID }) (*studentResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	student, err := loadersFrom(ctx, s.Service).students.Load(ctx, id)()
+	if err != nil {
+		return nil, resolverError(err)
+	}
+	if student == nil {
+		return nil, nil
+	}
+	return &studentResolver{student}, nil
+}
+
+type studentsArgs struct {
+	First   *int32
+	After   *string
+	Last    *int32
+	Before  *string
+	Search  *string
+	Filter  *studentFilter
+	OrderBy *[]studentOrder
+}
+
+type studentFilter struct {
+	Name    *stringFilter
+	Surname *stringFilter
+	Status  *string
+}
+
+type stringFilter struct {
+	Eq       *string
+	Prefix   *string
+	Contains *string
+}
+
+type studentOrder struct {
+	Field     string
+	Direction string
+}
+
+func (s *GraphServer) Students(ctx context.Context, args studentsArgs) (*connectionResolver, error) {
+	query, err := args.query()
+	if err != nil {
+		return nil, resolverError(err)
+	}
+	connection, err := s.Service.GetConnection(ctx, query, s.connectionArgs(args))
+	if err != nil {
+		return nil, resolverError(err)
+	}
+	return &connectionResolver{connection: connection, query: query, service: s.Service}, nil
+}
+
+// connectionArgs applies the configured default and maximum page size, like
+// the REST controller. Invalid combinations are left to the service to reject.
+func (s *GraphServer) connectionArgs(args studentsArgs) models.ConnectionArgs {
+	var connection models.ConnectionArgs
+	if args.First != nil {
+		connection.First = int(*args.First)
+	}
+	if args.Last != nil {
+		connection.Last = int(*args.Last)
+	}
+	if args.After != nil {
+		connection.After = *args.After
+	}
+	if args.Before != nil {
+		connection.Before = *args.Before
+	}
+
+	if args.First == nil && args.Last == nil {
+		connection.First = s.DefaultPageSize
+		if connection.First <= 0 {
+			connection.First = 10
+		}
+	}
+	if s.MaxPageSize > 0 && connection.First > s.MaxPageSize {
+		connection.First = s.MaxPageSize
+	}
+	if s.MaxPageSize > 0 && connection.Last > s.MaxPageSize {
+		connection.Last = s.MaxPageSize
+	}
+	return connection
+}
+
+// query renders the arguments as the list query parameters, so that they are
+// validated and normalized the same way.
+func (args studentsArgs) query() (models.StudentQuery, error) {
+	values := url.Values{}
+	if args.Search != nil {
+		values.Set("q", *args.Search)
+	}
+	if args.Filter != nil {
+		addFilter(values, "name", args.Filter.Name)
+		addFilter(values, "surname", args.Filter.Surname)
+		if args.Filter.Status != nil {
+			values.Add("status", *args.Filter.Status)
+		}
+	}
+	if args.OrderBy != nil {
+		var keys []string
+		for _, order := range *args.OrderBy {
+			key := strings.ToLower(order.Field)
+			if order.Direction == "DESC" {
+				key = "-" + key
+			}
+			keys = append(keys, key)
+		}
+		values.Set("sort", strings.Join(keys, ","))
+	}
+	return models.ParseStudentQuery(values)
+}
+
+func addFilter(values url.Values, field string, filter *stringFilter) {
+	if filter == nil {
+		return
+	}
+	if filter.Eq != nil {
+		values.Add(field, *filter.Eq)
+	}
+	if filter.Prefix != nil {
+		values.Add(field+"["+string(models.FilterPrefix)+"]", *filter.Prefix)
+	}
+	if filter.Contains != nil {
+		values.Add(field+"["+string(models.FilterContains)+"]", *filter.Contains)
+	}
+}
+
+type studentInput struct {
+	Name    string `json:"name"`
+	Surname string `json:"surname"`
+}
+
+func (s *GraphServer) AddStudent(ctx context.Context, args struct{ Input studentInput }) (*studentResolver, error) {
+	student := &models.Student{Name: args.Input.Name, Surname: args.Input.Surname}
+	if err := s.Service.Add(ctx, student); err != nil {
+		return nil, resolverError(err)
+	}
+	return &studentResolver{student}, nil
+}
+
+func (s *GraphServer) UpdateStudent(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input studentInput
+}) (*studentResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Only the names are patched, so the rest of the profile is kept.
+	patch, err := json.Marshal(args.Input)
+	if err != nil {
+		return nil, err
+	}
+	student, err := s.Service.Patch(ctx, id, patch)
+	if err != nil {
+		return nil, resolverError(err)
+	}
+	return &studentResolver{student}, nil
+}
+
+func (s *GraphServer) DeleteStudent(ctx context.Context, args struct{ ID graphql.ID }) (graphql.ID, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return "", err
+	}
+	if err := s.Service.Delete(ctx, id); err != nil {
+		return "", resolverError(err)
+	}
+	return graphql.ID(id.String()), nil
+}
@@ -0,0 +1,260 @@
+package graph
+
+import (
+	"backend/internal/auth"
+	"backend/internal/student/models"
+	"backend/internal/student/repository"
+	"backend/internal/student/services"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingRepository counts the lookups that reach the repository.
+type countingRepository struct {
+	services.Repository
+	gets     atomic.Int32
+	getByIDs atomic.Int32
+}
+
+func (r *countingRepository) Get(id uuid.UUID) (*models.Student, error) {
+	r.gets.Add(1)
+	return r.Repository.Get(id)
+}
+
+func (r *countingRepository) GetByIDs(ids []uuid.UUID) ([]models.Student, error) {
+	r.getByIDs.Add(1)
+	return r.Repository.GetByIDs(ids)
+}
+
+var admin = &auth.Principal{Username: "admin", Permissions: []auth.Permission{auth.PermStudentsRead, auth.PermStudentsWrite, auth.PermStudentsDelete}}
+
+type fixture struct {
+	repo     *countingRepository
+	router   *gin.Engine
+	students []models.Student
+}
+
+// newFixture serves the schema over the real service and an in-memory
+// repository holding three students, ordered by ID.
+func newFixture(t *testing.T, principal *auth.Principal) *fixture {
+	gin.SetMode(gin.TestMode)
+	repo := &countingRepository{Repository: repository.NewMemoryRepository()}
+	service := services.Service(repo)
+
+	f := &fixture{repo: repo, router: gin.New()}
+	ctx := auth.WithPrincipal(context.Background(), admin)
+	for _, names := range [][2]string{{"Ahmet", "Talha"}, {"Matrak", "Efe"}, {"Hasan", "Huseyin"}} {
+		student := &models.Student{Name: names[0], Surname: names[1]}
+		require.NoError(t, service.Add(ctx, student))
+		f.students = append(f.students, *student)
+	}
+	sort.Slice(f.students, func(i, j int) bool { return f.students[i].ID < f.students[j].ID })
+
+	server := Server(service)
+	server.DefaultPageSize = 2
+	server.MaxPageSize = 2
+	SetupRoutes(f.router, server, func(ctx *gin.Context) {
+		ctx.Request = ctx.Request.WithContext(auth.WithPrincipal(ctx.Request.Context(), principal))
+	})
+	return f
+}
+
+type result struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []Error                    `json:"errors"`
+}
+
+func (f *fixture) do(t *testing.T, query string, variables map[string]interface{}) result {
+	body, err := json.Marshal(Request{Query: query, Variables: variables})
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var r result
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &r))
+	return r
+}
+
+type connection struct {
+	Edges []struct {
+		Cursor string
+		Node   struct{ ID, Name, Surname string }
+	}
+	PageInfo struct {
+		HasNextPage, HasPreviousPage bool
+		StartCursor, EndCursor       *string
+	}
+	TotalCount int
+}
+
+func (c connection) ids() []string {
+	var ids []string
+	for _, edge := range c.Edges {
+		ids = append(ids, edge.Node.ID)
+	}
+	return ids
+}
+
+const studentsQuery = `query($first: Int, $after: String, $last: Int, $before: String) {
+	students(first: $first, after: $after, last: $last, before: $before) {
+		edges { cursor node { id name surname } }
+		pageInfo { hasNextPage hasPreviousPage startCursor endCursor }
+		totalCount
+	}
+}`
+
+func TestStudents(t *testing.T) {
+	f := newFixture(t, admin)
+	ids := []string{f.students[0].ID, f.students[1].ID, f.students[2].ID}
+	page := func(t *testing.T, variables map[string]interface{}) connection {
+		r := f.do(t, studentsQuery, variables)
+		require.Empty(t, r.Errors)
+		var c connection
+		require.NoError(t, json.Unmarshal(r.Data["students"], &c))
+		return c
+	}
+
+	t.Run("Forward", func(t *testing.T) {
+		first := page(t, nil)
+		assert.Equal(t, ids[:2], first.ids())
+		assert.Equal(t, 3, first.TotalCount)
+		assert.True(t, first.PageInfo.HasNextPage)
+		assert.False(t, first.PageInfo.HasPreviousPage)
+		assert.Equal(t, first.Edges[1].Cursor, *first.PageInfo.EndCursor)
+
+		second := page(t, map[string]interface{}{"first": 2, "after": *first.PageInfo.EndCursor})
+		assert.Equal(t, ids[2:], second.ids())
+		assert.False(t, second.PageInfo.HasNextPage)
+		assert.True(t, second.PageInfo.HasPreviousPage)
+	})
+
+	t.Run("Backward", func(t *testing.T) {
+		last := page(t, map[string]interface{}{"last": 2})
+		assert.Equal(t, ids[1:], last.ids())
+		assert.True(t, last.PageInfo.HasPreviousPage)
+
+		before := page(t, map[string]interface{}{"last": 2, "before": *last.PageInfo.StartCursor})
+		assert.Equal(t, ids[:1], before.ids())
+		assert.False(t, before.PageInfo.HasPreviousPage)
+		assert.True(t, before.PageInfo.HasNextPage)
+	})
+
+	t.Run("Maximum Page Size", func(t *testing.T) {
+		assert.Len(t, page(t, map[string]interface{}{"first": 100}).Edges, 2)
+	})
+
+	t.Run("Filter And Order", func(t *testing.T) {
+		r := f.do(t, `{ students(filter: {surname: {contains: "e"}}, orderBy: [{field: NAME, direction: DESC}]) { edges { node { name } } totalCount } }`, nil)
+		require.Empty(t, r.Errors)
+		assert.JSONEq(t, `{"edges": [{"node": {"name": "Matrak"}}, {"node": {"name": "Hasan"}}], "totalCount": 2}`, string(r.Data["students"]))
+	})
+
+	t.Run("Filter By Status", func(t *testing.T) {
+		r := f.do(t, `{ enrolled: students(filter: {status: "enrolled"}) { totalCount } applicants: students(filter: {status: "applicant"}) { totalCount } }`, nil)
+		require.Empty(t, r.Errors)
+		assert.JSONEq(t, `{"totalCount": 3}`, string(r.Data["enrolled"]))
+		assert.JSONEq(t, `{"totalCount": 0}`, string(r.Data["applicants"]))
+	})
+
+	t.Run("Invalid Arguments", func(t *testing.T) {
+		r := f.do(t, studentsQuery, map[string]interface{}{"first": 2, "last": 2})
+		require.Len(t, r.Errors, 1)
+		assert.Equal(t, models.ErrInvalidPaging.Detail, r.Errors[0].Message)
+		assert.Equal(t, "BAD_USER_INPUT", r.Errors[0].Extensions["code"])
+	})
+}
+
+func TestStudentBatching(t *testing.T) {
+	f := newFixture(t, admin)
+
+	r := f.do(t, `query($a: ID!, $b: ID!, $c: ID!, $missing: ID!) {
+		a: student(id: $a) { name }
+		b: student(id: $b) { name }
+		c: student(id: $c) { name }
+		missing: student(id: $missing) { name }
+	}`, map[string]interface{}{"a": f.students[0].ID, "b": f.students[1].ID, "c": f.students[2].ID, "missing": uuid.New().String()})
+	require.Empty(t, r.Errors)
+	assert.JSONEq(t, `{"name": "`+f.students[1].Name+`"}`, string(r.Data["b"]))
+	assert.Equal(t, "null", string(r.Data["missing"]))
+
+	assert.Equal(t, int32(1), f.repo.getByIDs.Load())
+	assert.Equal(t, int32(0), f.repo.gets.Load())
+}
+
+func TestMutations(t *testing.T) {
+	f := newFixture(t, admin)
+
+	r := f.do(t, `mutation { addStudent(input: {name: "  ayse", surname: "yilmaz"}) { id name surname } }`, nil)
+	require.Empty(t, r.Errors)
+	var added struct{ ID, Name, Surname string }
+	require.NoError(t, json.Unmarshal(r.Data["addStudent"], &added))
+	assert.Equal(t, "Ayse", added.Name)
+
+	r = f.do(t, `mutation($id: ID!) { updateStudent(id: $id, input: {name: "Fatma", surname: "Yilmaz"}) { name } }`, map[string]interface{}{"id": added.ID})
+	require.Empty(t, r.Errors)
+	assert.JSONEq(t, `{"name": "Fatma"}`, string(r.Data["updateStudent"]))
+
+	r = f.do(t, `mutation($id: ID!) { deleteStudent(id: $id) }`, map[string]interface{}{"id": added.ID})
+	require.Empty(t, r.Errors)
+	assert.JSONEq(t, `"`+added.ID+`"`, string(r.Data["deleteStudent"]))
+
+	r = f.do(t, `mutation($id: ID!) { deleteStudent(id: $id) }`, map[string]interface{}{"id": added.ID})
+	require.Len(t, r.Errors, 1)
+	assert.Equal(t, "NOT_FOUND", r.Errors[0].Extensions["code"])
+
+	t.Run("Invalid", func(t *testing.T) {
+		r := f.do(t, `mutation { addStudent(input: {name: "", surname: "yilmaz"}) { id } }`, nil)
+		require.Len(t, r.Errors, 1)
+		assert.Equal(t, "BAD_USER_INPUT", r.Errors[0].Extensions["code"])
+		assert.NotEmpty(t, r.Errors[0].Extensions["errors"])
+
+		r = f.do(t, `mutation { deleteStudent(id: "not-a-uuid") }`, nil)
+		require.Len(t, r.Errors, 1)
+		assert.Equal(t, "invalid UUID", r.Errors[0].Message)
+	})
+}
+
+func TestProfile(t *testing.T) {
+	counselor := &auth.Principal{Username: "counselor", Permissions: []auth.Permission{auth.PermStudentsRead, auth.PermStudentsReadPII, auth.PermStudentsWrite}}
+	f := newFixture(t, counselor)
+	student := f.students[0]
+	student.Email = "ahmet@example.com"
+	student.Addresses = []models.Address{{Kind: models.AddressHome, Line1: "Atatürk Cd. 12", City: "İzmir", Country: "Türkiye"}}
+	require.NoError(t, f.repo.Update(context.Background(), &student))
+
+	r := f.do(t, `mutation($id: ID!) { updateStudent(id: $id, input: {name: "Fatma", surname: "Talha"}) { name studentNumber status email addresses { kind city line2 } } }`, map[string]interface{}{"id": student.ID})
+	require.Empty(t, r.Errors)
+	assert.JSONEq(t, `{"name": "Fatma", "studentNumber": "`+student.StudentNumber+`", "status": "enrolled", "email": "ahmet@example.com",
+		"addresses": [{"kind": "home", "city": "İzmir", "line2": null}]}`, string(r.Data["updateStudent"]), "updating the names keeps the rest of the profile")
+
+	reader := newFixture(t, admin)
+	r = reader.do(t, `query($id: ID!) { student(id: $id) { studentNumber email addresses { city } } }`, map[string]interface{}{"id": reader.students[0].ID})
+	require.Empty(t, r.Errors)
+	assert.JSONEq(t, `{"studentNumber": "`+reader.students[0].StudentNumber+`", "email": null, "addresses": []}`, string(r.Data["student"]), "personal details need students:read:pii")
+}
+
+func TestAuthorization(t *testing.T) {
+	reader := &auth.Principal{Username: "teacher", Permissions: []auth.Permission{auth.PermStudentsRead}}
+	f := newFixture(t, reader)
+
+	r := f.do(t, `mutation { addStudent(input: {name: "Ayse", surname: "Yilmaz"}) { id } }`, nil)
+	require.Len(t, r.Errors, 1)
+	assert.Equal(t, "FORBIDDEN", r.Errors[0].Extensions["code"])
+	assert.Equal(t, string(auth.PermStudentsWrite), r.Errors[0].Extensions["permission"])
+
+	r = f.do(t, `{ students { totalCount } }`, nil)
+	assert.Empty(t, r.Errors)
+	assert.JSONEq(t, `{"totalCount": 3}`, string(r.Data["students"]))
+}
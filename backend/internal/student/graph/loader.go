@@ -0,0 +1,49 @@
+package graph
+
+import (
+	"backend/internal/student/models"
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+// loaders batch the lookups of one request: the resolvers running in parallel
+// queue their keys and a single query fetches them all. Every related entity
+// resolved by ID gets a loader here.
+type loaders struct {
+	students *dataloader.Loader[uuid.UUID, *models.Student]
+}
+
+type loadersKey struct{}
+
+func newLoaders(service StudentService) *loaders {
+	return &loaders{
+		students: dataloader.NewBatchedLoader(func(ctx context.Context, ids []uuid.UUID) []*dataloader.Result[*models.Student] {
+			results := make([]*dataloader.Result[*models.Student], len(ids))
+			students, err := service.GetByIDs(ctx, ids)
+			byID := map[string]*models.Student{}
+			for i := range students {
+				byID[students[i].ID] = &students[i]
+			}
+			// A missing student is null rather than an error, as GraphQL has it.
+			for i, id := range ids {
+				results[i] = &dataloader.Result[*models.Student]{Data: byID[id.String()], Error: err}
+			}
+			return results
+		}),
+	}
+}
+
+func withLoaders(ctx context.Context, service StudentService) context.Context {
+	return context.WithValue(ctx, loadersKey{}, newLoaders(service))
+}
+
+// loadersFrom returns the request's loaders, or unshared ones outside of a
+// request.
+func loadersFrom(ctx context.Context, service StudentService) *loaders {
+	if l, ok := ctx.Value(loadersKey{}).(*loaders); ok {
+		return l
+	}
+	return newLoaders(service)
+}
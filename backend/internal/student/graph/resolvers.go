@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"backend/internal/student/models"
+	"context"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+type studentResolver struct {
+	student *models.Student
+}
+
+func (r *studentResolver) ID() graphql.ID  { return graphql.ID(r.student.ID) }
+func (r *studentResolver) Name() string    { return r.student.Name }
+func (r *studentResolver) Surname() string { return r.student.Surname }
+func (r *studentResolver) StudentNumber() *string {
+	return optional(r.student.StudentNumber)
+}
+func (r *studentResolver) Status() *string         { return optional(string(r.student.Status)) }
+func (r *studentResolver) EnrollmentDate() *string { return optional(r.student.EnrollmentDate) }
+func (r *studentResolver) DateOfBirth() *string    { return optional(r.student.DateOfBirth) }
+func (r *studentResolver) Email() *string          { return optional(r.student.Email) }
+func (r *studentResolver) Phone() *string          { return optional(r.student.Phone) }
+
+func (r *studentResolver) Addresses() []*addressResolver {
+	addresses := make([]*addressResolver, len(r.student.Addresses))
+	for i := range r.student.Addresses {
+		addresses[i] = &addressResolver{&r.student.Addresses[i]}
+	}
+	return addresses
+}
+
+func (r *studentResolver) DeletedAt() *graphql.Time {
+	if r.student.DeletedAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.student.DeletedAt}
+}
+
+// optional resolves empty strings to null.
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+type addressResolver struct {
+	address *models.Address
+}
+
+func (r *addressResolver) Kind() string        { return string(r.address.Kind) }
+func (r *addressResolver) Line1() string       { return r.address.Line1 }
+func (r *addressResolver) Line2() *string      { return optional(r.address.Line2) }
+func (r *addressResolver) City() string        { return r.address.City }
+func (r *addressResolver) Region() *string     { return optional(r.address.Region) }
+func (r *addressResolver) PostalCode() *string { return optional(r.address.PostalCode) }
+func (r *addressResolver) Country() string     { return r.address.Country }
+
+type connectionResolver struct {
+	connection models.StudentConnection
+	query      models.StudentQuery
+	service    StudentService
+}
+
+func (r *connectionResolver) Edges() []*edgeResolver {
+	edges := make([]*edgeResolver, len(r.connection.Edges))
+	for i := range r.connection.Edges {
+		edges[i] = &edgeResolver{&r.connection.Edges[i]}
+	}
+	return edges
+}
+
+func (r *connectionResolver) PageInfo() *pageInfoResolver {
+	return &pageInfoResolver{&r.connection}
+}
+
+// TotalCount is only resolved when selected, so only then is it counted.
+func (r *connectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	total, err := r.service.Count(ctx, r.query)
+	if err != nil {
+		return 0, resolverError(err)
+	}
+	return int32(total), nil
+}
+
+type edgeResolver struct {
+	edge *models.StudentEdge
+}
+
+func (r *edgeResolver) Cursor() string         { return r.edge.Cursor }
+func (r *edgeResolver) Node() *studentResolver { return &studentResolver{&r.edge.Student} }
+
+type pageInfoResolver struct {
+	connection *models.StudentConnection
+}
+
+func (r *pageInfoResolver) HasNextPage() bool     { return r.connection.HasNextPage }
+func (r *pageInfoResolver) HasPreviousPage() bool { return r.connection.HasPreviousPage }
+
+func (r *pageInfoResolver) StartCursor() *string {
+	if len(r.connection.Edges) == 0 {
+		return nil
+	}
+	return &r.connection.Edges[0].Cursor
+}
+
+func (r *pageInfoResolver) EndCursor() *string {
+	if len(r.connection.Edges) == 0 {
+		return nil
+	}
+	return &r.connection.Edges[len(r.connection.Edges)-1].Cursor
+}
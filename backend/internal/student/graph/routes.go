@@ -0,0 +1,61 @@
+package graph
+
+import (
+	"backend/internal/auth"
+	"backend/internal/openapi"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+)
+
+// Request is the body of a GraphQL request.
+type Request struct {
+	Query         string                 `json:"query" validate:"required"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Response is the body of a GraphQL response. Errors do not fail the request:
+// data has the fields that could be resolved, errors the others.
+type Response struct {
+	Data   json.RawMessage `json:"data,omitempty"`
+	Errors []Error         `json:"errors,omitempty"`
+}
+
+// Error is a GraphQL error. extensions.code and extensions.status classify it
+// like the status of a problem detail; extensions.errors lists invalid fields.
+type Error struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// SetupRoutes serves the schema at POST /graphql, behind authenticate. Each
+// field is authorized by the service, so a principal may read students
+// without being allowed to change them.
+func SetupRoutes(router *gin.Engine, server *GraphServer, authenticate gin.HandlerFunc) {
+	handler := &relay.Handler{Schema: graphql.MustParseSchema(schema, server)}
+	router.POST("/graphql", authenticate, func(ctx *gin.Context) {
+		reqCtx := withLoaders(auth.AuditContext(ctx.Request.Context()), server.Service)
+		handler.ServeHTTP(ctx.Writer, ctx.Request.WithContext(reqCtx))
+	})
+}
+
+// Describe documents the route SetupRoutes registers.
+func Describe(doc *openapi.Document) {
+	doc.AddTag("graphql", "The student API as a GraphQL schema")
+	doc.Add(http.MethodPost, "/graphql", auth.Secure(doc, &openapi.Operation{
+		Tags:        []string{"graphql"},
+		Summary:     "Run a GraphQL query or mutation",
+		Description: "The schema is available by introspection. Students are paged as Relay connections. Errors are reported in the errors of a 200 response, each field requiring the permission its REST counterpart does.",
+		OperationID: "graphql",
+		RequestBody: doc.Body(Request{}, ""),
+		Responses: map[string]*openapi.Response{
+			"200": doc.JSON(Response{}, "The result, with the errors of the fields that failed"),
+			"400": {Description: "The body is not a GraphQL request"},
+		},
+	}, ""))
+}
@@ -5,8 +5,11 @@
 package mocks
 
 import (
+	audit "backend/internal/audit"
 	models "backend/internal/student/models"
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -36,31 +39,45 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 }
 
 // Add mocks base method.
-func (m *MockRepository) Add(student *models.Student) error {
+func (m *MockRepository) Add(ctx context.Context, student *models.Student) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Add", student)
+	ret := m.ctrl.Call(m, "Add", ctx, student)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Add indicates an expected call of Add.
-func (mr *MockRepositoryMockRecorder) Add(student interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Add(ctx, student interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockRepository)(nil).Add), student)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockRepository)(nil).Add), ctx, student)
+}
+
+// AddBatch mocks base method.
+func (m *MockRepository) AddBatch(ctx context.Context, students []models.Student) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBatch", ctx, students)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBatch indicates an expected call of AddBatch.
+func (mr *MockRepositoryMockRecorder) AddBatch(ctx, students interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBatch", reflect.TypeOf((*MockRepository)(nil).AddBatch), ctx, students)
 }
 
 // Delete mocks base method.
-func (m *MockRepository) Delete(id uuid.UUID) error {
+func (m *MockRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id)
 }
 
 // Get mocks base method.
@@ -79,31 +96,193 @@ func (mr *MockRepositoryMockRecorder) Get(id interface{}) *gomock.Call {
 }
 
 // GetAll mocks base method.
-func (m *MockRepository) GetAll(page, pageSize int) ([]models.Student, error) {
+func (m *MockRepository) GetAll(query models.StudentQuery, page, pageSize int) ([]models.Student, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", page, pageSize)
+	ret := m.ctrl.Call(m, "GetAll", query, page, pageSize)
 	ret0, _ := ret[0].([]models.Student)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockRepositoryMockRecorder) GetAll(page, pageSize interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetAll(query, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRepository)(nil).GetAll), query, page, pageSize)
+}
+
+// GetAllByKeyset mocks base method.
+func (m *MockRepository) GetAllByKeyset(query models.StudentQuery, keyset *models.Keyset, limit int) ([]models.Student, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByKeyset", query, keyset, limit)
+	ret0, _ := ret[0].([]models.Student)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByKeyset indicates an expected call of GetAllByKeyset.
+func (mr *MockRepositoryMockRecorder) GetAllByKeyset(query, keyset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByKeyset", reflect.TypeOf((*MockRepository)(nil).GetAllByKeyset), query, keyset, limit)
+}
+
+// GetAudit mocks base method.
+func (m *MockRepository) GetAudit(filter audit.Filter, page, pageSize int) ([]audit.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAudit", filter, page, pageSize)
+	ret0, _ := ret[0].([]audit.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAudit indicates an expected call of GetAudit.
+func (mr *MockRepositoryMockRecorder) GetAudit(filter, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAudit", reflect.TypeOf((*MockRepository)(nil).GetAudit), filter, page, pageSize)
+}
+
+// GetByIDs mocks base method.
+func (m *MockRepository) GetByIDs(ids []uuid.UUID) ([]models.Student, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ids)
+	ret0, _ := ret[0].([]models.Student)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockRepositoryMockRecorder) GetByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockRepository)(nil).GetByIDs), ids)
+}
+
+// GetDeleted mocks base method.
+func (m *MockRepository) GetDeleted(page, pageSize int) ([]models.Student, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeleted", page, pageSize)
+	ret0, _ := ret[0].([]models.Student)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeleted indicates an expected call of GetDeleted.
+func (mr *MockRepositoryMockRecorder) GetDeleted(page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeleted", reflect.TypeOf((*MockRepository)(nil).GetDeleted), page, pageSize)
+}
+
+// GetTransitions mocks base method.
+func (m *MockRepository) GetTransitions(studentID uuid.UUID) ([]models.Transition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransitions", studentID)
+	ret0, _ := ret[0].([]models.Transition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransitions indicates an expected call of GetTransitions.
+func (mr *MockRepositoryMockRecorder) GetTransitions(studentID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRepository)(nil).GetAll), page, pageSize)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransitions", reflect.TypeOf((*MockRepository)(nil).GetTransitions), studentID)
+}
+
+// Purge mocks base method.
+func (m *MockRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockRepositoryMockRecorder) Purge(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockRepository)(nil).Purge), ctx, before)
+}
+
+// Restore mocks base method.
+func (m *MockRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockRepositoryMockRecorder) Restore(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockRepository)(nil).Restore), ctx, id)
+}
+
+// TotalAuditCount mocks base method.
+func (m *MockRepository) TotalAuditCount(filter audit.Filter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TotalAuditCount", filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TotalAuditCount indicates an expected call of TotalAuditCount.
+func (mr *MockRepositoryMockRecorder) TotalAuditCount(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TotalAuditCount", reflect.TypeOf((*MockRepository)(nil).TotalAuditCount), filter)
+}
+
+// TotalDeletedCount mocks base method.
+func (m *MockRepository) TotalDeletedCount() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TotalDeletedCount")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TotalDeletedCount indicates an expected call of TotalDeletedCount.
+func (mr *MockRepositoryMockRecorder) TotalDeletedCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TotalDeletedCount", reflect.TypeOf((*MockRepository)(nil).TotalDeletedCount))
 }
 
 // TotalStudentCount mocks base method.
-func (m *MockRepository) TotalStudentCount() (int64, error) {
+func (m *MockRepository) TotalStudentCount(query models.StudentQuery) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TotalStudentCount")
+	ret := m.ctrl.Call(m, "TotalStudentCount", query)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // TotalStudentCount indicates an expected call of TotalStudentCount.
-func (mr *MockRepositoryMockRecorder) TotalStudentCount() *gomock.Call {
+func (mr *MockRepositoryMockRecorder) TotalStudentCount(query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TotalStudentCount", reflect.TypeOf((*MockRepository)(nil).TotalStudentCount), query)
+}
+
+// Transition mocks base method.
+func (m *MockRepository) Transition(ctx context.Context, transition *models.Transition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", ctx, transition)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockRepositoryMockRecorder) Transition(ctx, transition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*MockRepository)(nil).Transition), ctx, transition)
+}
+
+// Update mocks base method.
+func (m *MockRepository) Update(ctx context.Context, student *models.Student) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, student)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder) Update(ctx, student interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TotalStudentCount", reflect.TypeOf((*MockRepository)(nil).TotalStudentCount))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, student)
 }
@@ -5,7 +5,10 @@
 package mocks
 
 import (
+	audit "backend/internal/audit"
 	models "backend/internal/student/models"
+	context "context"
+	io "io"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -36,59 +39,222 @@ func (m *MockStudentService) EXPECT() *MockStudentServiceMockRecorder {
 }
 
 // Add mocks base method.
-func (m *MockStudentService) Add(student *models.Student) error {
+func (m *MockStudentService) Add(ctx context.Context, student *models.Student) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Add", student)
+	ret := m.ctrl.Call(m, "Add", ctx, student)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Add indicates an expected call of Add.
-func (mr *MockStudentServiceMockRecorder) Add(student interface{}) *gomock.Call {
+func (mr *MockStudentServiceMockRecorder) Add(ctx, student interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockStudentService)(nil).Add), student)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockStudentService)(nil).Add), ctx, student)
+}
+
+// Audit mocks base method.
+func (m *MockStudentService) Audit(ctx context.Context, filter audit.Filter, page, pageSize int) (models.AuditResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Audit", ctx, filter, page, pageSize)
+	ret0, _ := ret[0].(models.AuditResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Audit indicates an expected call of Audit.
+func (mr *MockStudentServiceMockRecorder) Audit(ctx, filter, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Audit", reflect.TypeOf((*MockStudentService)(nil).Audit), ctx, filter, page, pageSize)
 }
 
 // Delete mocks base method.
-func (m *MockStudentService) Delete(id uuid.UUID) error {
+func (m *MockStudentService) Delete(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockStudentServiceMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockStudentServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStudentService)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStudentService)(nil).Delete), ctx, id)
+}
+
+// Export mocks base method.
+func (m *MockStudentService) Export(ctx context.Context, query models.StudentQuery, format models.FileFormat, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, query, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockStudentServiceMockRecorder) Export(ctx, query, format, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockStudentService)(nil).Export), ctx, query, format, w)
 }
 
 // Get mocks base method.
-func (m *MockStudentService) Get(id uuid.UUID) (*models.Student, error) {
+func (m *MockStudentService) Get(ctx context.Context, id uuid.UUID) (*models.Student, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", id)
+	ret := m.ctrl.Call(m, "Get", ctx, id)
 	ret0, _ := ret[0].(*models.Student)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockStudentServiceMockRecorder) Get(id interface{}) *gomock.Call {
+func (mr *MockStudentServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStudentService)(nil).Get), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStudentService)(nil).Get), ctx, id)
 }
 
 // GetAll mocks base method.
-func (m *MockStudentService) GetAll(page, pageSize int) (models.PaginationResponse, error) {
+func (m *MockStudentService) GetAll(ctx context.Context, query models.StudentQuery, page, pageSize int) (models.PaginationResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", page, pageSize)
+	ret := m.ctrl.Call(m, "GetAll", ctx, query, page, pageSize)
 	ret0, _ := ret[0].(models.PaginationResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockStudentServiceMockRecorder) GetAll(page, pageSize interface{}) *gomock.Call {
+func (mr *MockStudentServiceMockRecorder) GetAll(ctx, query, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockStudentService)(nil).GetAll), ctx, query, page, pageSize)
+}
+
+// GetAllByCursor mocks base method.
+func (m *MockStudentService) GetAllByCursor(ctx context.Context, query models.StudentQuery, cursor string, pageSize int, withTotal bool) (models.CursorResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByCursor", ctx, query, cursor, pageSize, withTotal)
+	ret0, _ := ret[0].(models.CursorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByCursor indicates an expected call of GetAllByCursor.
+func (mr *MockStudentServiceMockRecorder) GetAllByCursor(ctx, query, cursor, pageSize, withTotal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByCursor", reflect.TypeOf((*MockStudentService)(nil).GetAllByCursor), ctx, query, cursor, pageSize, withTotal)
+}
+
+// GetDeleted mocks base method.
+func (m *MockStudentService) GetDeleted(ctx context.Context, page, pageSize int) (models.PaginationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeleted", ctx, page, pageSize)
+	ret0, _ := ret[0].(models.PaginationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeleted indicates an expected call of GetDeleted.
+func (mr *MockStudentServiceMockRecorder) GetDeleted(ctx, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeleted", reflect.TypeOf((*MockStudentService)(nil).GetDeleted), ctx, page, pageSize)
+}
+
+// GetTransitions mocks base method.
+func (m *MockStudentService) GetTransitions(ctx context.Context, id uuid.UUID) (models.TransitionList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransitions", ctx, id)
+	ret0, _ := ret[0].(models.TransitionList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransitions indicates an expected call of GetTransitions.
+func (mr *MockStudentServiceMockRecorder) GetTransitions(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransitions", reflect.TypeOf((*MockStudentService)(nil).GetTransitions), ctx, id)
+}
+
+// History mocks base method.
+func (m *MockStudentService) History(ctx context.Context, id uuid.UUID, page, pageSize int) (models.AuditResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", ctx, id, page, pageSize)
+	ret0, _ := ret[0].(models.AuditResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockStudentServiceMockRecorder) History(ctx, id, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockStudentService)(nil).History), ctx, id, page, pageSize)
+}
+
+// Import mocks base method.
+func (m *MockStudentService) Import(ctx context.Context, file io.Reader, format models.FileFormat, dryRun bool) (*models.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, file, format, dryRun)
+	ret0, _ := ret[0].(*models.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockStudentServiceMockRecorder) Import(ctx, file, format, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockStudentService)(nil).Import), ctx, file, format, dryRun)
+}
+
+// Patch mocks base method.
+func (m *MockStudentService) Patch(ctx context.Context, id uuid.UUID, patch []byte) (*models.Student, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, id, patch)
+	ret0, _ := ret[0].(*models.Student)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockStudentServiceMockRecorder) Patch(ctx, id, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockStudentService)(nil).Patch), ctx, id, patch)
+}
+
+// Restore mocks base method.
+func (m *MockStudentService) Restore(ctx context.Context, id uuid.UUID) (*models.Student, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(*models.Student)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockStudentServiceMockRecorder) Restore(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockStudentService)(nil).Restore), ctx, id)
+}
+
+// Transition mocks base method.
+func (m *MockStudentService) Transition(ctx context.Context, id uuid.UUID, request *models.TransitionRequest) (*models.Transition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", ctx, id, request)
+	ret0, _ := ret[0].(*models.Transition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockStudentServiceMockRecorder) Transition(ctx, id, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*MockStudentService)(nil).Transition), ctx, id, request)
+}
+
+// Update mocks base method.
+func (m *MockStudentService) Update(ctx context.Context, id uuid.UUID, student *models.Student) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, student)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockStudentServiceMockRecorder) Update(ctx, id, student interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockStudentService)(nil).GetAll), page, pageSize)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStudentService)(nil).Update), ctx, id, student)
 }
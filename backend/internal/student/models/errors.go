@@ -0,0 +1,18 @@
+package models
+
+import "backend/internal/problem"
+
+var (
+	ErrStudentNotFound = &problem.NotFoundError{Detail: "student not found"}
+	ErrStudentExists   = &problem.ConflictError{Detail: "student already exists"}
+	ErrEmailTaken      = &problem.ConflictError{Detail: "email is already used by another student"}
+	ErrStatusChanged   = &problem.ConflictError{Detail: "the status of the student changed meanwhile, try again"}
+	ErrStatusReadOnly  = &problem.ValidationError{Detail: "invalid student", Fields: []problem.FieldError{{Field: "status", Code: "transition", Message: "can only be changed with a transition"}}}
+	ErrInitialStatus   = &problem.ValidationError{Detail: "invalid student", Fields: []problem.FieldError{{Field: "status", Code: "initial", Message: "must be applicant or enrolled"}}}
+	ErrReasonRequired  = &problem.ValidationError{Detail: "invalid transition", Fields: []problem.FieldError{{Field: "reason", Code: "required", Message: "is required"}}}
+	ErrInvalidPatch    = &problem.ValidationError{Detail: "patch must be a JSON object"}
+	ErrInvalidCursor   = &problem.ValidationError{Detail: "invalid cursor"}
+	ErrInvalidImport   = &problem.ValidationError{Detail: "invalid import file"}
+	ErrInvalidPage     = &problem.ValidationError{Detail: "page and size must be positive integers"}
+	ErrInvalidPaging   = &problem.ValidationError{Detail: "either first, optionally with after, or last, optionally with before"}
+)
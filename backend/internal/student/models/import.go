@@ -0,0 +1,38 @@
+package models
+
+// FileFormat is a spreadsheet or data format students can be imported from or
+// exported to. NDJSON is export only.
+type FileFormat string
+
+const (
+	FormatCSV    FileFormat = "csv"
+	FormatXLSX   FileFormat = "xlsx"
+	FormatNDJSON FileFormat = "ndjson"
+)
+
+type ImportStatus string
+
+const (
+	ImportCreated ImportStatus = "created"
+	ImportSkipped ImportStatus = "skipped"
+	ImportFailed  ImportStatus = "failed"
+)
+
+// ImportRow is the outcome of one data row. Row is the spreadsheet row number,
+// counting the header as row 1.
+type ImportRow struct {
+	Row       int          `json:"row"`
+	Status    ImportStatus `json:"status"`
+	StudentID string       `json:"studentId,omitempty"`
+	Reason    string       `json:"reason,omitempty"`
+}
+
+// ImportReport summarizes an import. In a dry run nothing is written and Created
+// counts the rows that would have been created.
+type ImportReport struct {
+	DryRun  bool        `json:"dryRun"`
+	Created int         `json:"created"`
+	Skipped int         `json:"skipped"`
+	Failed  int         `json:"failed"`
+	Rows    []ImportRow `json:"rows"`
+}
@@ -1,17 +1,94 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"backend/internal/audit"
+	"fmt"
+	"time"
 
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Student is validated with the validation package before it is stored.
 type Student struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	Surname string `json:"surname"`
+	ID string `json:"id"`
+	// StudentNumber, such as 2026-000123, is assigned when the student is
+	// added, from the year of the enrollment date, and never changes.
+	StudentNumber string `json:"studentNumber,omitempty"`
+	Name          string `json:"name" validate:"trim,required,max=100,name,namecase"`
+	Surname       string `json:"surname" validate:"trim,required,max=100,name,namecase"`
+	// Status defaults to enrolled.
+	Status Status `json:"status,omitempty" validate:"studentstatus"`
+	// EnrollmentDate defaults to the day the student is added.
+	EnrollmentDate string `json:"enrollmentDate,omitempty" validate:"trim,date"`
+	// The personal details below are only shown to principals who may read them.
+	DateOfBirth string    `json:"dateOfBirth,omitempty" validate:"trim,date" access:"students:read:pii"`
+	Email       string    `json:"email,omitempty" validate:"trim,max=254,email" access:"students:read:pii"`
+	Phone       string    `json:"phone,omitempty" validate:"trim,phone" access:"students:read:pii"`
+	Addresses   []Address `json:"addresses,omitempty" access:"students:read:pii"`
+	// DeletedAt is only shown to principals who may delete and restore students.
+	DeletedAt *time.Time `json:"deletedAt,omitempty" access:"students:delete"`
+}
+
+// Address is one of the postal addresses of a student, such as their home.
+type Address struct {
+	Kind       AddressKind `json:"kind" validate:"required,addresskind"`
+	Line1      string      `json:"line1" validate:"trim,required,max=200"`
+	Line2      string      `json:"line2,omitempty" validate:"trim,max=200"`
+	City       string      `json:"city" validate:"trim,required,max=100"`
+	Region     string      `json:"region,omitempty" validate:"trim,max=100"`
+	PostalCode string      `json:"postalCode,omitempty" validate:"trim,max=20"`
+	Country    string      `json:"country" validate:"trim,required,max=100"`
 }
 
 type StudentEntity struct {
-	ID      uuid.UUID `gorm:"primary_key;type:uuid"`
-	Name    string
-	Surname string
+	ID uuid.UUID `gorm:"primary_key;type:char(36)"` // MySQL has no uuid type, char(36) works on every driver.
+	// StudentNumber and Email are unique when set. They are NULL rather than
+	// empty when missing, as unique indexes allow any number of NULLs.
+	StudentNumber  *string `gorm:"size:16;uniqueIndex:idx_students_student_number"`
+	Name           string
+	Surname        string
+	Status         string          `gorm:"size:16;index"`
+	EnrollmentDate string          `gorm:"size:10"`
+	DateOfBirth    string          `gorm:"size:10"`
+	Email          *string         `gorm:"size:254;uniqueIndex:idx_students_email"`
+	Phone          string          `gorm:"size:16"`
+	Addresses      []AddressEntity `gorm:"foreignKey:StudentID"`
+	DeletedAt      gorm.DeletedAt  `gorm:"index"` // Soft delete: gorm excludes rows where this is set.
+}
+
+// AddressEntity is kept in its own table; Position keeps the addresses in the
+// order they were given.
+type AddressEntity struct {
+	ID         uint      `gorm:"primaryKey"`
+	StudentID  uuid.UUID `gorm:"type:char(36);index"`
+	Position   int
+	Kind       string `gorm:"size:16"`
+	Line1      string `gorm:"size:200"`
+	Line2      string `gorm:"size:200"`
+	City       string `gorm:"size:100"`
+	Region     string `gorm:"size:100"`
+	PostalCode string `gorm:"size:20"`
+	Country    string `gorm:"size:100"`
+}
+
+func (AddressEntity) TableName() string {
+	return "student_addresses"
+}
+
+// SequenceEntity holds the last student number handed out in a year.
+type SequenceEntity struct {
+	Year  int `gorm:"primaryKey;autoIncrement:false"`
+	Value int
+}
+
+func (SequenceEntity) TableName() string {
+	return "student_sequences"
+}
+
+// FormatStudentNumber writes the nth student number of year, such as 2026-000123.
+func FormatStudentNumber(year int, n int) string {
+	return fmt.Sprintf("%d-%06d", year, n)
 }
 
 func (Student) TableName() string { // By default, plural of struct's name ('students') is the table name used.
@@ -33,3 +110,44 @@ type PaginationResponse struct {
 	Students []Student `json:"students"`
 	Page     Page      `json:"page"`
 }
+
+type Cursors struct {
+	Next string `json:"next,omitempty"`
+	Prev string `json:"prev,omitempty"`
+}
+
+// CursorResponse is returned instead of PaginationResponse in cursor mode. The total
+// is only counted when the client asks for it.
+type CursorResponse struct {
+	Students []Student `json:"students"`
+	Cursors  Cursors   `json:"cursors"`
+	Total    *int64    `json:"totalElements,omitempty"`
+}
+
+// ConnectionArgs select a page of a Relay connection: the First students after
+// the After cursor, or the Last students before the Before cursor.
+type ConnectionArgs struct {
+	First  int
+	After  string
+	Last   int
+	Before string
+}
+
+// StudentEdge is a student and the cursor of its position in the connection.
+type StudentEdge struct {
+	Cursor  string
+	Student Student
+}
+
+// StudentConnection is a page of a Relay connection over students.
+type StudentConnection struct {
+	Edges           []StudentEdge
+	HasNextPage     bool
+	HasPreviousPage bool
+}
+
+// AuditResponse pages through audit entries, newest first.
+type AuditResponse struct {
+	Entries []audit.Entry `json:"entries"`
+	Page    Page          `json:"page"`
+}
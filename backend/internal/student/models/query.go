@@ -0,0 +1,196 @@
+package models
+
+import (
+	"backend/internal/problem"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+var ErrInvalidQuery = &problem.ValidationError{Detail: "invalid query"}
+
+type FilterOp string
+
+const (
+	FilterEquals   FilterOp = "eq"
+	FilterPrefix   FilterOp = "prefix"
+	FilterContains FilterOp = "contains"
+)
+
+// Filter restricts a list to students whose Field matches Value using Op.
+type Filter struct {
+	Field string
+	Op    FilterOp
+	Value string
+}
+
+// SortKey orders a list by Field, descending when Desc is set.
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// StudentQuery is the validated form of the list endpoint's query parameters.
+// Sort always ends with "id" so that pages are deterministic.
+type StudentQuery struct {
+	Filters []Filter
+	Search  string
+	Sort    []SortKey
+}
+
+// Keyset is a decoded cursor: the values of the query's sort keys for the row the
+// page starts after, or before when Backward is set.
+type Keyset struct {
+	Values   []string
+	Backward bool
+}
+
+var (
+	FilterableFields = []string{"name", "surname"}
+	SortableFields   = []string{"id", "name", "surname"}
+	filterOps        = []FilterOp{FilterEquals, FilterPrefix, FilterContains}
+)
+
+// DefaultStudentQuery lists every student ordered by id.
+func DefaultStudentQuery() StudentQuery {
+	return StudentQuery{Sort: []SortKey{{Field: "id"}}}
+}
+
+// SortString renders Sort in the same syntax as the sort query parameter.
+func (q StudentQuery) SortString() string {
+	var keys []string
+	for _, key := range q.Sort {
+		if key.Desc {
+			keys = append(keys, "-"+key.Field)
+		} else {
+			keys = append(keys, key.Field)
+		}
+	}
+	return strings.Join(keys, ",")
+}
+
+// Reversed returns the query with every sort direction flipped.
+func (q StudentQuery) Reversed() StudentQuery {
+	reversed := q
+	reversed.Sort = make([]SortKey, len(q.Sort))
+	for i, key := range q.Sort {
+		reversed.Sort[i] = SortKey{Field: key.Field, Desc: !key.Desc}
+	}
+	return reversed
+}
+
+// Field returns the value of a sortable or filterable field by name.
+func (s *Student) Field(name string) string {
+	switch name {
+	case "name":
+		return s.Name
+	case "surname":
+		return s.Surname
+	case "status":
+		return string(s.Status)
+	default:
+		return s.ID
+	}
+}
+
+// ParseStudentQuery reads filters ("name=ali", "surname[prefix]=ce", "name[contains]=me",
+// "status=enrolled"), a free-text search ("q=ali") and the sort order ("sort=surname,-name") from values.
+// Unrelated parameters such as page and size are ignored.
+func ParseStudentQuery(values url.Values) (StudentQuery, error) {
+	query := StudentQuery{Search: strings.TrimSpace(values.Get("q"))}
+
+	for key, vals := range values {
+		field, op, err := parseFilterKey(key)
+		if err != nil {
+			return StudentQuery{}, err
+		}
+		if field == "" {
+			continue
+		}
+		for _, value := range vals {
+			if field == "status" {
+				if value, err = parseStatus(value); err != nil {
+					return StudentQuery{}, err
+				}
+			}
+			query.Filters = append(query.Filters, Filter{Field: field, Op: op, Value: value})
+		}
+	}
+	// Keep the filter order independent of map iteration.
+	sort.Slice(query.Filters, func(i, j int) bool {
+		a, b := query.Filters[i], query.Filters[j]
+		if a.Field != b.Field {
+			return a.Field < b.Field
+		}
+		if a.Op != b.Op {
+			return a.Op < b.Op
+		}
+		return a.Value < b.Value
+	})
+
+	seen := map[string]bool{}
+	if sortParam := values.Get("sort"); sortParam != "" {
+		for _, key := range strings.Split(sortParam, ",") {
+			key = strings.TrimSpace(key)
+			sortKey := SortKey{Field: strings.TrimPrefix(key, "-"), Desc: strings.HasPrefix(key, "-")}
+			if !containsString(SortableFields, sortKey.Field) {
+				return StudentQuery{}, fmt.Errorf("%w: cannot sort by %q", ErrInvalidQuery, sortKey.Field)
+			}
+			if seen[sortKey.Field] {
+				return StudentQuery{}, fmt.Errorf("%w: %q is sorted more than once", ErrInvalidQuery, sortKey.Field)
+			}
+			seen[sortKey.Field] = true
+			query.Sort = append(query.Sort, sortKey)
+		}
+	}
+	if !seen["id"] {
+		query.Sort = append(query.Sort, SortKey{Field: "id"})
+	}
+	return query, nil
+}
+
+// parseFilterKey returns an empty field for parameters that are not filters.
+func parseFilterKey(key string) (string, FilterOp, error) {
+	field, op := key, FilterEquals
+	if open := strings.Index(key, "["); open >= 0 && strings.HasSuffix(key, "]") {
+		field, op = key[:open], FilterOp(key[open+1:len(key)-1])
+	}
+	if field == "status" {
+		if op != FilterEquals {
+			return "", "", fmt.Errorf("%w: status can only be filtered by equality", ErrInvalidQuery)
+		}
+		return field, op, nil
+	}
+	if !containsString(FilterableFields, field) {
+		if op != FilterEquals || field != key {
+			return "", "", fmt.Errorf("%w: cannot filter by %q", ErrInvalidQuery, field)
+		}
+		return "", "", nil
+	}
+	for _, known := range filterOps {
+		if op == known {
+			return field, op, nil
+		}
+	}
+	return "", "", fmt.Errorf("%w: unknown filter operator %q", ErrInvalidQuery, op)
+}
+
+func parseStatus(value string) (string, error) {
+	status := Status(strings.ToLower(strings.TrimSpace(value)))
+	for _, known := range Statuses {
+		if status == known {
+			return string(status), nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown status %q", ErrInvalidQuery, value)
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
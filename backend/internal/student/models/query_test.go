@@ -0,0 +1,106 @@
+package models
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStudentQuery(t *testing.T) {
+	testCases := []struct {
+		Description string
+		RawQuery    string
+		Expected    StudentQuery
+		Error       string
+	}{
+		{
+			Description: "empty",
+			RawQuery:    "",
+			Expected:    DefaultStudentQuery(),
+		},
+		{
+			Description: "pagination parameters are ignored",
+			RawQuery:    "page=2&size=20",
+			Expected:    DefaultStudentQuery(),
+		},
+		{
+			Description: "filters",
+			RawQuery:    "surname[contains]=ey&name=ali&name[prefix]=a",
+			Expected: StudentQuery{
+				Filters: []Filter{
+					{Field: "name", Op: FilterEquals, Value: "ali"},
+					{Field: "name", Op: FilterPrefix, Value: "a"},
+					{Field: "surname", Op: FilterContains, Value: "ey"},
+				},
+				Sort: []SortKey{{Field: "id"}},
+			},
+		},
+		{
+			Description: "status",
+			RawQuery:    "status=+Suspended",
+			Expected:    StudentQuery{Filters: []Filter{{Field: "status", Op: FilterEquals, Value: "suspended"}}, Sort: []SortKey{{Field: "id"}}},
+		},
+		{
+			Description: "unknown status",
+			RawQuery:    "status=expelled",
+			Error:       `invalid query: unknown status "expelled"`,
+		},
+		{
+			Description: "status by prefix",
+			RawQuery:    "status[prefix]=enr",
+			Error:       "invalid query: status can only be filtered by equality",
+		},
+		{
+			Description: "search",
+			RawQuery:    "q=+ahmet+",
+			Expected:    StudentQuery{Search: "ahmet", Sort: []SortKey{{Field: "id"}}},
+		},
+		{
+			Description: "sort with id tiebreaker",
+			RawQuery:    "sort=surname,-name",
+			Expected:    StudentQuery{Sort: []SortKey{{Field: "surname"}, {Field: "name", Desc: true}, {Field: "id"}}},
+		},
+		{
+			Description: "explicit id sort",
+			RawQuery:    "sort=-id",
+			Expected:    StudentQuery{Sort: []SortKey{{Field: "id", Desc: true}}},
+		},
+		{
+			Description: "unknown sort field",
+			RawQuery:    "sort=age",
+			Error:       `invalid query: cannot sort by "age"`,
+		},
+		{
+			Description: "duplicate sort field",
+			RawQuery:    "sort=name,-name",
+			Error:       `invalid query: "name" is sorted more than once`,
+		},
+		{
+			Description: "unknown filter field",
+			RawQuery:    "id[prefix]=79",
+			Error:       `invalid query: cannot filter by "id"`,
+		},
+		{
+			Description: "unknown operator",
+			RawQuery:    "name[like]=a",
+			Error:       `invalid query: unknown filter operator "like"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			values, err := url.ParseQuery(tc.RawQuery)
+			assert.NoError(t, err)
+
+			query, err := ParseStudentQuery(values)
+			if tc.Error != "" {
+				assert.EqualError(t, err, tc.Error)
+				assert.ErrorIs(t, err, ErrInvalidQuery)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, query)
+		})
+	}
+}
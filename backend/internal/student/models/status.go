@@ -0,0 +1,68 @@
+package models
+
+import (
+	"backend/internal/validation"
+	"reflect"
+	"strings"
+)
+
+func init() {
+	validation.Register("studentstatus", studentStatus)
+	validation.Register("addresskind", addressKind)
+}
+
+// Status is where a student is in their lifecycle.
+type Status string
+
+const (
+	StatusApplicant Status = "applicant"
+	StatusEnrolled  Status = "enrolled"
+	StatusSuspended Status = "suspended"
+	StatusGraduated Status = "graduated"
+	StatusWithdrawn Status = "withdrawn"
+)
+
+// Statuses lists every status, in lifecycle order.
+var Statuses = []Status{StatusApplicant, StatusEnrolled, StatusSuspended, StatusGraduated, StatusWithdrawn}
+
+// studentStatus accepts the lifecycle statuses. Case is ignored.
+func studentStatus(value reflect.Value, _ string) string {
+	if value.Kind() != reflect.String || !value.CanSet() || value.String() == "" {
+		return ""
+	}
+	status := Status(strings.ToLower(strings.TrimSpace(value.String())))
+	for _, known := range Statuses {
+		if status == known {
+			value.SetString(string(status))
+			return ""
+		}
+	}
+	return "must be one of applicant, enrolled, suspended, graduated, withdrawn"
+}
+
+// AddressKind tells what an address is used for.
+type AddressKind string
+
+const (
+	AddressHome    AddressKind = "home"
+	AddressMailing AddressKind = "mailing"
+	AddressTerm    AddressKind = "term"
+)
+
+// AddressKinds lists every kind of address.
+var AddressKinds = []AddressKind{AddressHome, AddressMailing, AddressTerm}
+
+// addressKind accepts the kinds of address. Case is ignored.
+func addressKind(value reflect.Value, _ string) string {
+	if value.Kind() != reflect.String || !value.CanSet() || value.String() == "" {
+		return ""
+	}
+	kind := AddressKind(strings.ToLower(strings.TrimSpace(value.String())))
+	for _, known := range AddressKinds {
+		if kind == known {
+			value.SetString(string(kind))
+			return ""
+		}
+	}
+	return "must be one of home, mailing, term"
+}
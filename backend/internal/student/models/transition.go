@@ -0,0 +1,117 @@
+package models
+
+import (
+	"backend/internal/problem"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Transitions lists the statuses a student may move to from each status.
+// Graduated students stay graduated; withdrawn ones may apply again.
+var Transitions = map[Status][]Status{
+	StatusApplicant: {StatusEnrolled, StatusWithdrawn},
+	StatusEnrolled:  {StatusSuspended, StatusGraduated, StatusWithdrawn},
+	StatusSuspended: {StatusEnrolled, StatusWithdrawn},
+	StatusGraduated: {},
+	StatusWithdrawn: {StatusApplicant},
+}
+
+// InitialStatuses are the statuses a student may be added with.
+var InitialStatuses = []Status{StatusApplicant, StatusEnrolled}
+
+// ReasonRequired lists the statuses a student may only move to with a reason.
+var ReasonRequired = []Status{StatusSuspended, StatusWithdrawn}
+
+// CanTransition reports whether the lifecycle allows moving from one status to
+// the other.
+func CanTransition(from Status, to Status) bool {
+	return containsStatus(Transitions[from], to)
+}
+
+func containsStatus(statuses []Status, status Status) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionRequest is the body of a transition.
+type TransitionRequest struct {
+	To     Status `json:"to" validate:"required,studentstatus"`
+	Reason string `json:"reason,omitempty" validate:"trim,max=500"`
+}
+
+// Transition is a recorded change of status.
+type Transition struct {
+	ID        uint64 `json:"id"`
+	StudentID string `json:"studentId"`
+	From      Status `json:"from"`
+	To        Status `json:"to"`
+	// Reason may explain a suspension, so it is only shown to principals who
+	// may read personal details.
+	Reason string    `json:"reason,omitempty" access:"students:read:pii"`
+	Actor  string    `json:"actor"`
+	At     time.Time `json:"at"`
+}
+
+// TransitionEntity names its status columns from_status and to_status, as
+// FROM and TO are reserved words in SQL.
+type TransitionEntity struct {
+	ID        uint64    `gorm:"primaryKey"`
+	StudentID uuid.UUID `gorm:"type:char(36);index"`
+	From      string    `gorm:"column:from_status;size:16"`
+	To        string    `gorm:"column:to_status;size:16"`
+	Reason    string    `gorm:"size:500"`
+	Actor     string    `gorm:"size:255"`
+	At        time.Time
+}
+
+func (TransitionEntity) TableName() string {
+	return "student_transitions"
+}
+
+// TransitionList is the history of a student's status, oldest first.
+type TransitionList struct {
+	Transitions []Transition `json:"transitions"`
+}
+
+// TransitionError rejects a transition the lifecycle does not allow, or one
+// whose requirements the student does not meet. It matches problem.ErrConflict:
+//
+//	{"status": 409, "reason": "illegal_transition", "from": "applicant", "to": "graduated", "allowed": ["enrolled", "withdrawn"], ...}
+type TransitionError struct {
+	From Status
+	To   Status
+	// Unmet lists the requirements of an allowed transition that are not met.
+	Unmet []string
+}
+
+func (e *TransitionError) Error() string {
+	if len(e.Unmet) > 0 {
+		return fmt.Sprintf("cannot change status from %s to %s: %s", e.From, e.To, strings.Join(e.Unmet, "; "))
+	}
+	return fmt.Sprintf("cannot change status from %s to %s", e.From, e.To)
+}
+
+func (e *TransitionError) Is(target error) bool {
+	return target == problem.ErrConflict
+}
+
+func (e *TransitionError) Problem() *problem.Problem {
+	conflict := problem.New(http.StatusConflict, e.Error())
+	conflict.Extensions = map[string]interface{}{"from": e.From, "to": e.To}
+	if len(e.Unmet) > 0 {
+		conflict.Extensions["reason"] = "requirements_unmet"
+		conflict.Extensions["unmet"] = e.Unmet
+	} else {
+		conflict.Extensions["reason"] = "illegal_transition"
+		conflict.Extensions["allowed"] = Transitions[e.From]
+	}
+	return conflict
+}
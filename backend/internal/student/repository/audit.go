@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"backend/internal/audit"
+	"backend/internal/student/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// auditEntityType is the entity type of the audit entries written for students.
+const auditEntityType = "student"
+
+// recordAudit writes an audit entry inside tx. Either snapshot may be nil.
+func recordAudit(ctx context.Context, tx *gorm.DB, action audit.Action, before *models.Student, after *models.Student) error {
+	entry, err := newAuditEntry(ctx, action, before, after)
+	if err != nil {
+		return err
+	}
+	return tx.Create(entry).Error
+}
+
+func newAuditEntry(ctx context.Context, action audit.Action, before *models.Student, after *models.Student) (*audit.Entry, error) {
+	id := ""
+	var beforeSnapshot, afterSnapshot interface{}
+	if before != nil {
+		id, beforeSnapshot = before.ID, before
+	}
+	if after != nil {
+		id, afterSnapshot = after.ID, after
+	}
+	return audit.NewEntry(ctx, auditEntityType, id, action, beforeSnapshot, afterSnapshot)
+}
+
+// newPurgeEntry builds the entry recording the purge of a student. Like the
+// entries scrubbed by scrubAudit, it keeps no snapshot of the student.
+func newPurgeEntry(ctx context.Context, id string) (*audit.Entry, error) {
+	return audit.NewEntry(ctx, auditEntityType, id, audit.ActionPurge, nil, nil)
+}
+
+// scrubAudit clears the snapshots of the entries recorded for the students
+// with the given IDs, so that their history outlives them without their data.
+func scrubAudit(tx *gorm.DB, ids []string) error {
+	return tx.Model(&audit.Entry{}).Where("entity_type = ? AND entity_id IN ?", auditEntityType, ids).
+		Updates(map[string]interface{}{"before": nil, "after": nil}).Error
+}
+
+func applyAuditFilter(db *gorm.DB, filter audit.Filter) *gorm.DB {
+	if filter.EntityType != "" {
+		db = db.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		db = db.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.Actor != "" {
+		db = db.Where("actor = ?", filter.Actor)
+	}
+	if filter.Action != "" {
+		db = db.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		db = db.Where("at >= ?", filter.From.UTC())
+	}
+	if filter.To != nil {
+		db = db.Where("at < ?", filter.To.UTC())
+	}
+	return db
+}
+
+// GetAudit lists audit entries matching filter, newest first.
+func (r *studentRepository) GetAudit(filter audit.Filter, page int, pageSize int) ([]audit.Entry, error) {
+	var entries []audit.Entry
+	offset := (page - 1) * pageSize
+	err := applyAuditFilter(r.DB, filter).Order("at DESC").Order("id DESC").
+		Offset(offset).Limit(pageSize).Find(&entries).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return entries, nil
+}
+
+func (r *studentRepository) TotalAuditCount(filter audit.Filter) (int64, error) {
+	var total int64
+	err := applyAuditFilter(r.DB.Model(&audit.Entry{}), filter).Count(&total).Error
+	if err != nil {
+		return 0, translateError(err)
+	}
+	return total, nil
+}
@@ -0,0 +1,695 @@
+// Package conformance checks that an implementation of services.Repository behaves
+// the way the student service expects, independently of the storage behind it.
+package conformance
+
+import (
+	"backend/internal/audit"
+	"backend/internal/problem"
+	"backend/internal/student/models"
+	"backend/internal/student/services"
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Factory returns a new, empty repository. It is called once per subtest.
+type Factory func(t *testing.T) services.Repository
+
+// Run runs the whole suite against repositories created by newRepository.
+func Run(t *testing.T, newRepository Factory) {
+	t.Run("AddGet", func(t *testing.T) { testAddGet(t, newRepository(t)) })
+	t.Run("GetNotFound", func(t *testing.T) { testGetNotFound(t, newRepository(t)) })
+	t.Run("GetByIDs", func(t *testing.T) { testGetByIDs(t, newRepository(t)) })
+	t.Run("AddDuplicate", func(t *testing.T) { testAddDuplicate(t, newRepository(t)) })
+	t.Run("AddBatch", func(t *testing.T) { testAddBatch(t, newRepository(t)) })
+	t.Run("Update", func(t *testing.T) { testUpdate(t, newRepository(t)) })
+	t.Run("Delete", func(t *testing.T) { testDelete(t, newRepository(t)) })
+	t.Run("SoftDelete", func(t *testing.T) { testSoftDelete(t, newRepository(t)) })
+	t.Run("GetAllOrdering", func(t *testing.T) { testGetAllOrdering(t, newRepository(t)) })
+	t.Run("GetAllPagination", func(t *testing.T) { testGetAllPagination(t, newRepository(t)) })
+	t.Run("TotalStudentCount", func(t *testing.T) { testTotalStudentCount(t, newRepository(t)) })
+	t.Run("Filter", func(t *testing.T) { testFilter(t, newRepository(t)) })
+	t.Run("Search", func(t *testing.T) { testSearch(t, newRepository(t)) })
+	t.Run("Sort", func(t *testing.T) { testSort(t, newRepository(t)) })
+	t.Run("Keyset", func(t *testing.T) { testKeyset(t, newRepository(t)) })
+	t.Run("ConcurrentAdd", func(t *testing.T) { testConcurrentAdd(t, newRepository(t)) })
+	t.Run("Profile", func(t *testing.T) { testProfile(t, newRepository(t)) })
+	t.Run("StudentNumbers", func(t *testing.T) { testStudentNumbers(t, newRepository(t)) })
+	t.Run("EmailTaken", func(t *testing.T) { testEmailTaken(t, newRepository(t)) })
+	t.Run("Transitions", func(t *testing.T) { testTransitions(t, newRepository(t)) })
+	t.Run("Audit", func(t *testing.T) { testAudit(t, newRepository(t)) })
+}
+
+func newStudent(name, surname string) *models.Student {
+	return &models.Student{
+		ID:      uuid.New().String(),
+		Name:    name,
+		Surname: surname,
+	}
+}
+
+func addStudents(t *testing.T, repo services.Repository, n int) []models.Student {
+	var students []models.Student
+	for i := 0; i < n; i++ {
+		student := newStudent("name", "surname")
+		require.NoError(t, repo.Add(context.Background(), student))
+		students = append(students, *student)
+	}
+	sort.Slice(students, func(i, j int) bool { return students[i].ID < students[j].ID })
+	return students
+}
+
+func testAddGet(t *testing.T, repo services.Repository) {
+	student := newStudent("hasan", "huseyin")
+	require.NoError(t, repo.Add(context.Background(), student))
+
+	fetched, err := repo.Get(uuid.MustParse(student.ID))
+	require.NoError(t, err)
+	assert.Equal(t, student, fetched)
+}
+
+func testGetNotFound(t *testing.T, repo services.Repository) {
+	student, err := repo.Get(uuid.New())
+	assert.ErrorIs(t, err, models.ErrStudentNotFound)
+	assert.ErrorIs(t, err, problem.ErrNotFound)
+	assert.Nil(t, student)
+}
+
+func testGetByIDs(t *testing.T, repo services.Repository) {
+	students := addStudents(t, repo, 3)
+	require.NoError(t, repo.Delete(context.Background(), uuid.MustParse(students[2].ID)))
+
+	found, err := repo.GetByIDs([]uuid.UUID{
+		uuid.MustParse(students[0].ID),
+		uuid.MustParse(students[1].ID),
+		uuid.MustParse(students[2].ID),
+		uuid.New(),
+		uuid.MustParse(students[0].ID),
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, students[:2], found)
+
+	found, err = repo.GetByIDs(nil)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func testAddDuplicate(t *testing.T, repo services.Repository) {
+	student := newStudent("hasan", "huseyin")
+	require.NoError(t, repo.Add(context.Background(), student))
+
+	err := repo.Add(context.Background(), student)
+	assert.ErrorIs(t, err, models.ErrStudentExists)
+	assert.ErrorIs(t, err, problem.ErrConflict)
+}
+
+func testAddBatch(t *testing.T, repo services.Repository) {
+	batch := []models.Student{*newStudent("hasan", "huseyin"), *newStudent("ahmet", "ceylan")}
+	require.NoError(t, repo.AddBatch(context.Background(), batch))
+	for _, student := range batch {
+		fetched, err := repo.Get(uuid.MustParse(student.ID))
+		require.NoError(t, err)
+		assert.Equal(t, student, *fetched)
+	}
+
+	failing := []models.Student{*newStudent("matrak", "efe"), batch[0]}
+	assert.Error(t, repo.AddBatch(context.Background(), failing), "a batch containing an existing id fails")
+	_, err := repo.Get(uuid.MustParse(failing[0].ID))
+	assert.ErrorIs(t, err, models.ErrStudentNotFound, "a failed batch writes nothing")
+
+	count, err := repo.TotalStudentCount(models.DefaultStudentQuery())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func testUpdate(t *testing.T, repo services.Repository) {
+	student := newStudent("hasan", "huseyin")
+	require.NoError(t, repo.Add(context.Background(), student))
+
+	student.Surname = "hüseyin"
+	require.NoError(t, repo.Update(context.Background(), student))
+
+	fetched, err := repo.Get(uuid.MustParse(student.ID))
+	require.NoError(t, err)
+	assert.Equal(t, student, fetched)
+
+	unchanged := *student
+	assert.NoError(t, repo.Update(context.Background(), &unchanged), "updating without changes must not report not found")
+
+	deletedAt := time.Now().UTC()
+	deleting := *student
+	deleting.DeletedAt = &deletedAt
+	require.NoError(t, repo.Update(context.Background(), &deleting))
+	assert.Nil(t, deleting.DeletedAt, "only Delete deletes a student")
+	fetched, err = repo.Get(uuid.MustParse(student.ID))
+	require.NoError(t, err, "the deletedAt of an update is ignored")
+	assert.Nil(t, fetched.DeletedAt)
+
+	err = repo.Update(context.Background(), newStudent("ahmet", "ceylan"))
+	assert.ErrorIs(t, err, models.ErrStudentNotFound)
+}
+
+func testDelete(t *testing.T, repo services.Repository) {
+	student := newStudent("Johnny", "Bravo")
+	require.NoError(t, repo.Add(context.Background(), student))
+	id := uuid.MustParse(student.ID)
+
+	require.NoError(t, repo.Delete(context.Background(), id))
+	_, err := repo.Get(id)
+	assert.ErrorIs(t, err, models.ErrStudentNotFound)
+
+	assert.ErrorIs(t, repo.Delete(context.Background(), id), models.ErrStudentNotFound, "a deleted student cannot be deleted again")
+	assert.ErrorIs(t, repo.Delete(context.Background(), uuid.New()), models.ErrStudentNotFound)
+}
+
+func testSoftDelete(t *testing.T, repo services.Repository) {
+	students := addStudents(t, repo, 3)
+	deleted := students[1]
+	id := uuid.MustParse(deleted.ID)
+	require.NoError(t, repo.Delete(context.Background(), id))
+
+	listed, err := repo.GetAll(models.DefaultStudentQuery(), 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Student{students[0], students[2]}, listed, "deleted students are not listed")
+
+	count, err := repo.TotalStudentCount(models.DefaultStudentQuery())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	assert.ErrorIs(t, repo.Update(context.Background(), &deleted), models.ErrStudentNotFound, "deleted students cannot be updated")
+
+	trash, err := repo.GetDeleted(1, 10)
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	assert.Equal(t, deleted.ID, trash[0].ID)
+	assert.NotNil(t, trash[0].DeletedAt)
+
+	count, err = repo.TotalDeletedCount()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	require.NoError(t, repo.Restore(context.Background(), id))
+	restored, err := repo.Get(id)
+	require.NoError(t, err)
+	assert.Equal(t, deleted, *restored)
+	assert.ErrorIs(t, repo.Restore(context.Background(), id), models.ErrStudentNotFound, "only deleted students can be restored")
+	assert.ErrorIs(t, repo.Restore(context.Background(), uuid.New()), models.ErrStudentNotFound)
+
+	require.NoError(t, repo.Delete(context.Background(), id))
+	purged, err := repo.Purge(context.Background(), time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), purged, "recently deleted students are kept")
+
+	purged, err = repo.Purge(context.Background(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	assert.ErrorIs(t, repo.Restore(context.Background(), id), models.ErrStudentNotFound, "purged students cannot be restored")
+
+	count, err = repo.TotalStudentCount(models.DefaultStudentQuery())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func testGetAllOrdering(t *testing.T, repo services.Repository) {
+	expected := addStudents(t, repo, 5)
+
+	students, err := repo.GetAll(models.DefaultStudentQuery(), 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, expected, students, "students are ordered by id")
+}
+
+func testGetAllPagination(t *testing.T, repo services.Repository) {
+	empty, err := repo.GetAll(models.DefaultStudentQuery(), 1, 10)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	expected := addStudents(t, repo, 5)
+
+	testCases := []struct {
+		Description string
+		Page        int
+		PageSize    int
+		Expected    []models.Student
+	}{
+		{"first page", 1, 2, expected[0:2]},
+		{"middle page", 2, 2, expected[2:4]},
+		{"last partial page", 3, 2, expected[4:5]},
+		{"past the end", 4, 2, nil},
+		{"page size larger than total", 1, 100, expected},
+		{"page size of one", 5, 1, expected[4:5]},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			students, err := repo.GetAll(models.DefaultStudentQuery(), tc.Page, tc.PageSize)
+			require.NoError(t, err)
+			if tc.Expected == nil {
+				assert.Empty(t, students)
+				return
+			}
+			assert.Equal(t, tc.Expected, students)
+		})
+	}
+}
+
+func testTotalStudentCount(t *testing.T, repo services.Repository) {
+	count, err := repo.TotalStudentCount(models.DefaultStudentQuery())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	students := addStudents(t, repo, 3)
+	count, err = repo.TotalStudentCount(models.DefaultStudentQuery())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	require.NoError(t, repo.Delete(context.Background(), uuid.MustParse(students[0].ID)))
+	count, err = repo.TotalStudentCount(models.DefaultStudentQuery())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+// addRoster adds students with names that exercise filtering and sorting and
+// returns them keyed by "name surname".
+func addRoster(t *testing.T, repo services.Repository) map[string]models.Student {
+	roster := map[string]models.Student{}
+	for _, fullName := range [][2]string{
+		{"ahmet", "ceylan"},
+		{"ahmet", "yilmaz"},
+		{"mehmet", "ceylan"},
+		{"hasan", "huseyin"},
+		{"ali_can", "demir"},
+		{"ali", "demir"},
+	} {
+		student := newStudent(fullName[0], fullName[1])
+		require.NoError(t, repo.Add(context.Background(), student))
+		roster[fullName[0]+" "+fullName[1]] = *student
+	}
+	return roster
+}
+
+func names(students []models.Student) []string {
+	var result []string
+	for _, student := range students {
+		result = append(result, student.Name+" "+student.Surname)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func testFilter(t *testing.T, repo services.Repository) {
+	addRoster(t, repo)
+
+	testCases := []struct {
+		Description string
+		Filters     []models.Filter
+		Expected    []string
+	}{
+		{"exact", []models.Filter{{Field: "surname", Op: models.FilterEquals, Value: "ceylan"}}, []string{"ahmet ceylan", "mehmet ceylan"}},
+		{"exact does not match prefix", []models.Filter{{Field: "name", Op: models.FilterEquals, Value: "ahm"}}, nil},
+		{"prefix", []models.Filter{{Field: "name", Op: models.FilterPrefix, Value: "ah"}}, []string{"ahmet ceylan", "ahmet yilmaz"}},
+		{"contains", []models.Filter{{Field: "name", Op: models.FilterContains, Value: "met"}}, []string{"ahmet ceylan", "ahmet yilmaz", "mehmet ceylan"}},
+		{"exact ignores case", []models.Filter{{Field: "surname", Op: models.FilterEquals, Value: "CEYLAN"}}, []string{"ahmet ceylan", "mehmet ceylan"}},
+		{"prefix ignores case", []models.Filter{{Field: "name", Op: models.FilterPrefix, Value: "AH"}}, []string{"ahmet ceylan", "ahmet yilmaz"}},
+		{"contains ignores case", []models.Filter{{Field: "name", Op: models.FilterContains, Value: "MeT"}}, []string{"ahmet ceylan", "ahmet yilmaz", "mehmet ceylan"}},
+		{"wildcards are literal", []models.Filter{{Field: "name", Op: models.FilterPrefix, Value: "ali_"}}, []string{"ali_can demir"}},
+		{"percent is literal", []models.Filter{{Field: "name", Op: models.FilterContains, Value: "%"}}, nil},
+		{"combined", []models.Filter{
+			{Field: "name", Op: models.FilterEquals, Value: "ahmet"},
+			{Field: "surname", Op: models.FilterPrefix, Value: "y"},
+		}, []string{"ahmet yilmaz"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			query := models.DefaultStudentQuery()
+			query.Filters = tc.Filters
+
+			students, err := repo.GetAll(query, 1, 100)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, names(students))
+
+			count, err := repo.TotalStudentCount(query)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(tc.Expected)), count)
+		})
+	}
+}
+
+func testSearch(t *testing.T, repo services.Repository) {
+	addRoster(t, repo)
+
+	query := models.DefaultStudentQuery()
+	query.Search = "CEY"
+	students, err := repo.GetAll(query, 1, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ahmet ceylan", "mehmet ceylan"}, names(students), "search is case-insensitive across fields")
+
+	query.Search = "an"
+	query.Filters = []models.Filter{{Field: "name", Op: models.FilterPrefix, Value: "a"}}
+	students, err = repo.GetAll(query, 1, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ahmet ceylan", "ali_can demir"}, names(students))
+
+	count, err := repo.TotalStudentCount(query)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func testSort(t *testing.T, repo services.Repository) {
+	roster := addRoster(t, repo)
+
+	query := models.StudentQuery{Sort: []models.SortKey{
+		{Field: "surname"},
+		{Field: "name", Desc: true},
+		{Field: "id"},
+	}}
+	students, err := repo.GetAll(query, 1, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Student{
+		roster["mehmet ceylan"],
+		roster["ahmet ceylan"],
+		roster["ali_can demir"],
+		roster["ali demir"],
+		roster["hasan huseyin"],
+		roster["ahmet yilmaz"],
+	}, students)
+
+	t.Run("tiebreaker", func(t *testing.T) {
+		twins := addStudents(t, repo, 3)
+		query := models.StudentQuery{
+			Filters: []models.Filter{{Field: "name", Op: models.FilterEquals, Value: "name"}},
+			Sort:    []models.SortKey{{Field: "surname"}, {Field: "id"}},
+		}
+		for page := 1; page <= 3; page++ {
+			students, err := repo.GetAll(query, page, 1)
+			require.NoError(t, err)
+			assert.Equal(t, twins[page-1:page], students)
+		}
+	})
+}
+
+func testKeyset(t *testing.T, repo services.Repository) {
+	roster := addRoster(t, repo)
+	query := models.StudentQuery{Sort: []models.SortKey{{Field: "surname"}, {Field: "name", Desc: true}, {Field: "id"}}}
+	ordered := []models.Student{
+		roster["mehmet ceylan"],
+		roster["ahmet ceylan"],
+		roster["ali_can demir"],
+		roster["ali demir"],
+		roster["hasan huseyin"],
+		roster["ahmet yilmaz"],
+	}
+	keysetOf := func(student models.Student, backward bool) *models.Keyset {
+		return &models.Keyset{Values: []string{student.Surname, student.Name, student.ID}, Backward: backward}
+	}
+
+	students, err := repo.GetAllByKeyset(query, nil, 2)
+	require.NoError(t, err)
+	assert.Equal(t, ordered[0:2], students, "nil keyset starts at the beginning")
+
+	students, err = repo.GetAllByKeyset(query, keysetOf(ordered[1], false), 3)
+	require.NoError(t, err)
+	assert.Equal(t, ordered[2:5], students, "forward crosses ties on the first sort key")
+
+	students, err = repo.GetAllByKeyset(query, keysetOf(ordered[4], true), 2)
+	require.NoError(t, err)
+	assert.Equal(t, ordered[2:4], students, "backward returns the rows before, in sort order")
+
+	students, err = repo.GetAllByKeyset(query, keysetOf(ordered[5], false), 2)
+	require.NoError(t, err)
+	assert.Empty(t, students)
+
+	filtered := query
+	filtered.Filters = []models.Filter{{Field: "name", Op: models.FilterPrefix, Value: "a"}}
+	students, err = repo.GetAllByKeyset(filtered, keysetOf(ordered[1], false), 10)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Student{ordered[2], ordered[3], ordered[5]}, students)
+}
+
+func testConcurrentAdd(t *testing.T, repo services.Repository) {
+	const writers = 20
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	added := make(chan *models.Student, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			student := newStudent("kamil", "koc")
+			errs <- repo.Add(context.Background(), student)
+			added <- student
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	close(added)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	numbers := map[string]bool{}
+	for student := range added {
+		_, err := repo.Get(uuid.MustParse(student.ID))
+		assert.NoError(t, err)
+		assert.False(t, numbers[student.StudentNumber], "student number %s is handed out twice", student.StudentNumber)
+		numbers[student.StudentNumber] = true
+	}
+
+	count, err := repo.TotalStudentCount(models.DefaultStudentQuery())
+	require.NoError(t, err)
+	assert.Equal(t, int64(writers), count)
+}
+
+func testProfile(t *testing.T, repo services.Repository) {
+	student := newStudent("ayşe", "öztürk")
+	student.Status = models.StatusApplicant
+	student.EnrollmentDate = "2026-09-01"
+	student.DateOfBirth = "2008-04-23"
+	student.Email = "ayse@example.com"
+	student.Phone = "+905551234567"
+	student.Addresses = []models.Address{
+		{Kind: models.AddressHome, Line1: "Atatürk Cd. 12", City: "İzmir", PostalCode: "35210", Country: "Türkiye"},
+		{Kind: models.AddressTerm, Line1: "Yurt B Blok", Line2: "Oda 204", City: "Ankara", Country: "Türkiye"},
+	}
+	require.NoError(t, repo.Add(context.Background(), student))
+
+	fetched, err := repo.Get(uuid.MustParse(student.ID))
+	require.NoError(t, err)
+	assert.Equal(t, student, fetched, "addresses keep their order")
+
+	student.Addresses = student.Addresses[1:]
+	student.Addresses[0].City = "Eskişehir"
+	student.Email, student.Phone = "", ""
+	require.NoError(t, repo.Update(context.Background(), student))
+	fetched, err = repo.Get(uuid.MustParse(student.ID))
+	require.NoError(t, err)
+	assert.Equal(t, student, fetched, "the addresses are replaced")
+
+	require.NoError(t, repo.Delete(context.Background(), uuid.MustParse(student.ID)))
+	_, err = repo.Purge(context.Background(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	deleted, err := repo.GetDeleted(1, 10)
+	require.NoError(t, err)
+	assert.Empty(t, deleted)
+}
+
+func testStudentNumbers(t *testing.T, repo services.Repository) {
+	first := newStudent("hasan", "huseyin")
+	first.EnrollmentDate = "2026-09-01"
+	require.NoError(t, repo.Add(context.Background(), first))
+	assert.Equal(t, "2026-000001", first.StudentNumber)
+
+	batch := []models.Student{*newStudent("ahmet", "ceylan"), *newStudent("matrak", "efe")}
+	batch[0].EnrollmentDate, batch[1].EnrollmentDate = "2026-09-02", "2027-02-01"
+	require.NoError(t, repo.AddBatch(context.Background(), batch))
+	assert.Equal(t, "2026-000002", batch[0].StudentNumber)
+	assert.Equal(t, "2027-000001", batch[1].StudentNumber, "every year has its own sequence")
+
+	changed := *first
+	changed.StudentNumber = "2026-999999"
+	changed.Status, changed.EnrollmentDate = models.StatusGraduated, ""
+	require.NoError(t, repo.Update(context.Background(), &changed))
+	assert.Equal(t, first, &changed, "the number and status never change, the enrollment date is kept when empty")
+	fetched, err := repo.Get(uuid.MustParse(first.ID))
+	require.NoError(t, err)
+	assert.Equal(t, "2026-000001", fetched.StudentNumber)
+}
+
+func testEmailTaken(t *testing.T, repo services.Repository) {
+	taken := newStudent("hasan", "huseyin")
+	taken.Email = "hasan@example.com"
+	require.NoError(t, repo.Add(context.Background(), taken))
+
+	student := newStudent("ahmet", "ceylan")
+	student.Email = taken.Email
+	err := repo.Add(context.Background(), student)
+	assert.ErrorIs(t, err, models.ErrEmailTaken)
+	assert.ErrorIs(t, err, problem.ErrConflict)
+
+	student.Email = "ahmet@example.com"
+	require.NoError(t, repo.Add(context.Background(), student))
+	student.Email = taken.Email
+	assert.ErrorIs(t, repo.Update(context.Background(), student), models.ErrEmailTaken)
+
+	batch := []models.Student{*newStudent("matrak", "efe"), *newStudent("kamil", "koc")}
+	batch[0].Email, batch[1].Email = "efe@example.com", "efe@example.com"
+	assert.ErrorIs(t, repo.AddBatch(context.Background(), batch), models.ErrEmailTaken, "within a batch")
+
+	require.NoError(t, repo.Delete(context.Background(), uuid.MustParse(taken.ID)))
+	student = newStudent("kamil", "koc")
+	student.Email = taken.Email
+	assert.ErrorIs(t, repo.Add(context.Background(), student), models.ErrEmailTaken, "deleted students keep their email until purged")
+
+	count, err := repo.TotalStudentCount(models.DefaultStudentQuery())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func testTransitions(t *testing.T, repo services.Repository) {
+	ctx := audit.WithActor(context.Background(), "registrar")
+	addStudents(t, repo, 1)
+	student := newStudent("hasan", "huseyin")
+	student.Status = models.StatusEnrolled
+	require.NoError(t, repo.Add(ctx, student))
+	id := uuid.MustParse(student.ID)
+
+	none, err := repo.GetTransitions(id)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+	assert.NotNil(t, none, "an empty history is an empty list")
+
+	at := time.Now().UTC().Truncate(time.Second)
+	suspend := &models.Transition{StudentID: student.ID, From: models.StatusEnrolled, To: models.StatusSuspended, Reason: "unpaid fees", Actor: "registrar", At: at}
+	require.NoError(t, repo.Transition(ctx, suspend))
+	assert.NotZero(t, suspend.ID)
+	reenroll := &models.Transition{StudentID: student.ID, From: models.StatusSuspended, To: models.StatusEnrolled, Actor: "registrar", At: at}
+	require.NoError(t, repo.Transition(ctx, reenroll))
+
+	fetched, err := repo.Get(id)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusEnrolled, fetched.Status)
+
+	history, err := repo.GetTransitions(id)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, []models.Status{models.StatusSuspended, models.StatusEnrolled}, []models.Status{history[0].To, history[1].To}, "oldest first")
+	assert.Equal(t, suspend.ID, history[0].ID)
+	assert.Equal(t, models.StatusEnrolled, history[0].From)
+	assert.Equal(t, "unpaid fees", history[0].Reason)
+	assert.Equal(t, "registrar", history[0].Actor)
+	assert.WithinDuration(t, at, history[0].At, time.Second)
+
+	stale := &models.Transition{StudentID: student.ID, From: models.StatusSuspended, To: models.StatusWithdrawn, Reason: "moved", At: at}
+	assert.ErrorIs(t, repo.Transition(ctx, stale), models.ErrStatusChanged, "the student is no longer suspended")
+	assert.ErrorIs(t, repo.Transition(ctx, &models.Transition{StudentID: uuid.New().String(), From: models.StatusEnrolled, To: models.StatusSuspended, At: at}), models.ErrStudentNotFound)
+	history, err = repo.GetTransitions(id)
+	require.NoError(t, err)
+	assert.Len(t, history, 2, "failed transitions are not recorded")
+
+	entries, err := repo.GetAudit(audit.Filter{EntityType: "student", EntityID: student.ID, Action: audit.ActionUpdate}, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "one for each transition")
+	var before, after models.Student
+	require.NoError(t, json.Unmarshal(entries[0].Before, &before))
+	require.NoError(t, json.Unmarshal(entries[0].After, &after))
+	assert.Equal(t, []models.Status{models.StatusSuspended, models.StatusEnrolled}, []models.Status{before.Status, after.Status})
+
+	query := models.DefaultStudentQuery()
+	query.Filters = []models.Filter{{Field: "status", Op: models.FilterEquals, Value: string(models.StatusEnrolled)}}
+	listed, err := repo.GetAll(query, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, student.ID, listed[0].ID, "filtered by status")
+
+	require.NoError(t, repo.Delete(ctx, id))
+	_, err = repo.Purge(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	history, err = repo.GetTransitions(id)
+	require.NoError(t, err)
+	assert.Empty(t, history, "purging a student purges their history")
+}
+
+func testAudit(t *testing.T, repo services.Repository) {
+	ctx := audit.WithRequestID(audit.WithActor(context.Background(), "registrar"), "req-1")
+	student := newStudent("hasan", "huseyin")
+	require.NoError(t, repo.Add(ctx, student))
+	updated := *student
+	updated.Surname = "kaya"
+	require.NoError(t, repo.Update(ctx, &updated))
+	id := uuid.MustParse(student.ID)
+	require.NoError(t, repo.Delete(context.Background(), id))
+	other := newStudent("ali", "veli")
+	require.NoError(t, repo.Add(ctx, other))
+
+	history := audit.Filter{EntityType: "student", EntityID: student.ID}
+	entries, err := repo.GetAudit(history, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, []audit.Action{audit.ActionDelete, audit.ActionUpdate, audit.ActionCreate},
+		[]audit.Action{entries[0].Action, entries[1].Action, entries[2].Action}, "newest first")
+
+	created := entries[2]
+	assert.Equal(t, "registrar", created.Actor)
+	assert.Equal(t, "req-1", created.RequestID)
+	assert.Nil(t, created.Before)
+	assert.WithinDuration(t, time.Now(), created.At, time.Minute)
+
+	change := entries[1]
+	var before, after models.Student
+	require.NoError(t, json.Unmarshal(change.Before, &before))
+	require.NoError(t, json.Unmarshal(change.After, &after))
+	assert.Equal(t, "huseyin", before.Surname)
+	assert.Equal(t, "kaya", after.Surname)
+
+	assert.Equal(t, audit.AnonymousActor, entries[0].Actor)
+	assert.Nil(t, entries[0].After)
+
+	total, err := repo.TotalAuditCount(history)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+
+	byActor := audit.Filter{Actor: "registrar", Action: audit.ActionCreate}
+	total, err = repo.TotalAuditCount(byActor)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+
+	page, err := repo.GetAudit(audit.Filter{}, 2, 3)
+	require.NoError(t, err)
+	assert.Len(t, page, 1)
+
+	future := time.Now().Add(time.Hour)
+	total, err = repo.TotalAuditCount(audit.Filter{From: &future})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+
+	// A failed change leaves no trace.
+	assert.Error(t, repo.Update(ctx, newStudent("not", "stored")))
+	total, err = repo.TotalAuditCount(audit.Filter{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+
+	// A purge keeps the history of the student but none of its data.
+	purged, err := repo.Purge(context.Background(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	entries, err = repo.GetAudit(history, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	assert.Equal(t, audit.ActionPurge, entries[0].Action)
+	for _, entry := range entries {
+		assert.Nil(t, entry.Before, entry.Action)
+		assert.Nil(t, entry.After, entry.Action)
+	}
+	others, err := repo.GetAudit(audit.Filter{EntityType: "student", EntityID: other.ID}, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, others, 1)
+	assert.NotNil(t, others[0].After)
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"backend/internal/config"
+	"backend/internal/student/repository/conformance"
+	"backend/internal/student/services"
+	"testing"
+
+	"gorm.io/gorm/logger"
+)
+
+func TestConformance(t *testing.T) {
+	t.Run("Memory", func(t *testing.T) {
+		conformance.Run(t, func(t *testing.T) services.Repository {
+			return NewMemoryRepository()
+		})
+	})
+
+	t.Run("SQLite", func(t *testing.T) {
+		conformance.Run(t, func(t *testing.T) services.Repository {
+			store, err := Open(config.Database{DSN: "sqlite://:memory:"}, logger.Silent)
+			if err != nil {
+				t.Fatalf("Failed to open SQLite: %v", err)
+			}
+			t.Cleanup(func() {
+				sqlDB, _ := store.DB.DB()
+				sqlDB.Close()
+			})
+			return store.Students
+		})
+	})
+
+	t.Run("MySQL", func(t *testing.T) {
+		conformance.Run(t, func(t *testing.T) services.Repository {
+			db := openTestDB(t)
+			for _, table := range []string{"students", "student_addresses", "student_sequences", "audit_entries"} {
+				if err := db.Exec("DELETE FROM " + table).Error; err != nil {
+					t.Fatalf("Failed to delete records: %v", err)
+				}
+			}
+			repo, err := NewStudentRepository(db)
+			if err != nil {
+				t.Fatalf("Failed to create student repository: %v", err)
+			}
+			return repo
+		})
+	})
+}
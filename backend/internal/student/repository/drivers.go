@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"backend/internal/config"
+	"backend/internal/database/schema"
+	"strings"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func init() {
+	Register("mysql", openMySQL)
+	Register("postgres", openPostgres)
+	Register("postgresql", openPostgres)
+	Register("sqlite", openSQLite)
+	Register("memory", openMemory)
+}
+
+func openMySQL(dsn string, cfg config.Database, logLevel logger.LogLevel) (*Store, error) {
+	return openGorm(mysql.Open(mysqlDSN(dsn)), cfg, logLevel)
+}
+
+// mysqlDSN has the driver scan DATETIME columns, such as the deletion times,
+// into time.Time rather than []byte, whatever the configured DSN says.
+func mysqlDSN(dsn string) string {
+	return withParam(dsn, "parseTime=true")
+}
+
+func openPostgres(dsn string, cfg config.Database, logLevel logger.LogLevel) (*Store, error) {
+	// pgx only understands the URL form, so the scheme is put back.
+	return openGorm(postgres.Open("postgres://"+dsn), cfg, logLevel)
+}
+
+func openSQLite(dsn string, cfg config.Database, logLevel logger.LogLevel) (*Store, error) {
+	name, _, _ := strings.Cut(dsn, "?")
+	if name == ":memory:" {
+		// Every connection to :memory: gets its own empty database.
+		cfg.MaxOpenConns = 1
+		cfg.MaxIdleConns = 1
+		cfg.ConnMaxLifetime = 0
+	}
+	// SQLite only enforces foreign keys when asked to, on every connection.
+	return openGorm(sqlite.Open(withParam(dsn, "_foreign_keys=1")), cfg, logLevel)
+}
+
+// withParam adds the query parameter param to dsn.
+func withParam(dsn string, param string) string {
+	if strings.Contains(dsn, "?") {
+		return dsn + "&" + param
+	}
+	return dsn + "?" + param
+}
+
+func openMemory(dsn string, cfg config.Database, logLevel logger.LogLevel) (*Store, error) {
+	return &Store{Students: NewMemoryRepository()}, nil
+}
+
+func openGorm(dialector gorm.Dialector, cfg config.Database, logLevel logger.LogLevel) (*Store, error) {
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger:         logger.Default.LogMode(logLevel),
+		TranslateError: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	if err := schema.Migrate(db); err != nil {
+		return nil, err
+	}
+
+	repo, err := NewStudentRepository(db)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{Students: repo, DB: db, sql: repo}, nil
+}
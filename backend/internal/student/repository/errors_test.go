@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"backend/internal/student/models"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestTranslateError(t *testing.T) {
+	assert.NoError(t, translateError(nil))
+	assert.ErrorIs(t, translateError(gorm.ErrRecordNotFound), models.ErrStudentNotFound)
+	assert.ErrorIs(t, translateError(fmt.Errorf("insert: %w", gorm.ErrDuplicatedKey)), models.ErrStudentExists)
+}
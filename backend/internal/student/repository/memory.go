@@ -0,0 +1,478 @@
+package repository
+
+import (
+	"backend/internal/audit"
+	"backend/internal/student/models"
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// memoryRepository keeps students ordered by id in process memory. It is safe
+// for concurrent use and meant for local development and tests.
+type memoryRepository struct {
+	mu       sync.RWMutex
+	students map[uuid.UUID]models.StudentEntity
+	order    []uuid.UUID
+	audit    []audit.Entry
+	// sequences holds the last student number handed out per year.
+	sequences map[int]int
+	// transitions holds the transitions of every student, oldest first.
+	// Purges remove some, so IDs come from lastTransitionID.
+	transitions      []models.Transition
+	lastTransitionID uint64
+}
+
+func NewMemoryRepository() *memoryRepository {
+	return &memoryRepository{
+		students:  map[uuid.UUID]models.StudentEntity{},
+		sequences: map[int]int{},
+	}
+}
+
+func (r *memoryRepository) GetAll(query models.StudentQuery, page int, pageSize int) ([]models.Student, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matching := r.find(query)
+	sort.SliceStable(matching, func(i, j int) bool { return less(&matching[i], &matching[j], query) })
+
+	offset := (page - 1) * pageSize
+	if offset < 0 || offset >= len(matching) {
+		return nil, nil
+	}
+	end := offset + pageSize
+	if end > len(matching) {
+		end = len(matching)
+	}
+	return matching[offset:end], nil
+}
+
+func (r *memoryRepository) GetAllByKeyset(query models.StudentQuery, keyset *models.Keyset, limit int) ([]models.Student, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	order := query
+	if keyset != nil && keyset.Backward {
+		order = query.Reversed()
+	}
+	matching := r.find(query)
+	sort.SliceStable(matching, func(i, j int) bool { return less(&matching[i], &matching[j], order) })
+
+	var students []models.Student
+	for i := range matching {
+		if len(students) == limit {
+			break
+		}
+		if keyset == nil || afterKeyset(&matching[i], order, keyset) {
+			students = append(students, matching[i])
+		}
+	}
+	if keyset != nil && keyset.Backward {
+		reverse(students)
+	}
+	return students, nil
+}
+
+// find returns copies of the students matching query, ordered by id.
+func (r *memoryRepository) find(query models.StudentQuery) []models.Student {
+	var students []models.Student
+	for _, id := range r.order {
+		entity := r.students[id]
+		if entity.DeletedAt.Valid {
+			continue
+		}
+		student := EntityToModel(&entity)
+		if matches(student, query) {
+			students = append(students, *student)
+		}
+	}
+	return students
+}
+
+func (r *memoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entity, ok := r.students[id]
+	if !ok || entity.DeletedAt.Valid {
+		return models.ErrStudentNotFound
+	}
+	entry, err := newAuditEntry(ctx, audit.ActionDelete, EntityToModel(&entity), nil)
+	if err != nil {
+		return err
+	}
+	entity.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	r.students[id] = entity
+	r.record(entry)
+	return nil
+}
+
+func (r *memoryRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entity, ok := r.students[id]
+	if !ok || !entity.DeletedAt.Valid {
+		return models.ErrStudentNotFound
+	}
+	before := EntityToModel(&entity)
+	entity.DeletedAt = gorm.DeletedAt{}
+	entry, err := newAuditEntry(ctx, audit.ActionRestore, before, EntityToModel(&entity))
+	if err != nil {
+		return err
+	}
+	r.students[id] = entity
+	r.record(entry)
+	return nil
+}
+
+func (r *memoryRepository) GetDeleted(page int, pageSize int) ([]models.Student, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deleted := r.deleted()
+	offset := (page - 1) * pageSize
+	if offset < 0 || offset >= len(deleted) {
+		return nil, nil
+	}
+	end := offset + pageSize
+	if end > len(deleted) {
+		end = len(deleted)
+	}
+	return deleted[offset:end], nil
+}
+
+func (r *memoryRepository) TotalDeletedCount() (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return int64(len(r.deleted())), nil
+}
+
+func (r *memoryRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var entries []*audit.Entry
+	for _, id := range r.order {
+		entity := r.students[id]
+		if entity.DeletedAt.Valid && entity.DeletedAt.Time.Before(before) {
+			entry, err := newPurgeEntry(ctx, id.String())
+			if err != nil {
+				return 0, err
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	kept := r.order[:0]
+	for _, id := range r.order {
+		entity := r.students[id]
+		if entity.DeletedAt.Valid && entity.DeletedAt.Time.Before(before) {
+			delete(r.students, id)
+			continue
+		}
+		kept = append(kept, id)
+	}
+	r.order = kept
+	transitions := r.transitions[:0]
+	for _, transition := range r.transitions {
+		if _, ok := r.students[uuid.MustParse(transition.StudentID)]; ok {
+			transitions = append(transitions, transition)
+		}
+	}
+	r.transitions = transitions
+	for _, entry := range entries {
+		for i := range r.audit {
+			if r.audit[i].EntityType == auditEntityType && r.audit[i].EntityID == entry.EntityID {
+				r.audit[i].Before, r.audit[i].After = nil, nil
+			}
+		}
+	}
+	for _, entry := range entries {
+		r.record(entry)
+	}
+	return int64(len(entries)), nil
+}
+
+// deleted returns the soft deleted students, most recently deleted first.
+func (r *memoryRepository) deleted() []models.Student {
+	var students []models.Student
+	for _, id := range r.order {
+		entity := r.students[id]
+		if entity.DeletedAt.Valid {
+			students = append(students, *EntityToModel(&entity))
+		}
+	}
+	sort.SliceStable(students, func(i, j int) bool { return students[i].DeletedAt.After(*students[j].DeletedAt) })
+	return students
+}
+
+func (r *memoryRepository) Get(id uuid.UUID) (*models.Student, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entity, ok := r.students[id]
+	if !ok || entity.DeletedAt.Valid {
+		return nil, models.ErrStudentNotFound
+	}
+	return EntityToModel(&entity), nil
+}
+
+func (r *memoryRepository) GetByIDs(ids []uuid.UUID) ([]models.Student, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	students := []models.Student{}
+	seen := map[uuid.UUID]bool{}
+	for _, id := range ids {
+		entity, ok := r.students[id]
+		if !ok || entity.DeletedAt.Valid || seen[id] {
+			continue
+		}
+		seen[id] = true
+		students = append(students, *EntityToModel(&entity))
+	}
+	return students, nil
+}
+
+func (r *memoryRepository) Add(ctx context.Context, student *models.Student) error {
+	entity, err := parseEntity(student)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.students[entity.ID]; ok {
+		return models.ErrStudentExists
+	}
+	if r.emailTaken(entity) {
+		return models.ErrEmailTaken
+	}
+	year := enrollmentYear(student)
+	number := models.FormatStudentNumber(year, r.sequences[year]+1)
+	entity.StudentNumber = &number
+	entry, err := newAuditEntry(ctx, audit.ActionCreate, nil, EntityToModel(entity))
+	if err != nil {
+		return err
+	}
+	r.sequences[year]++
+	r.insert(entity)
+	r.record(entry)
+	student.StudentNumber = number
+	return nil
+}
+
+func (r *memoryRepository) AddBatch(ctx context.Context, students []models.Student) error {
+	entities := make([]*models.StudentEntity, 0, len(students))
+	ids := map[uuid.UUID]bool{}
+	emails := map[string]bool{}
+	for i := range students {
+		entity, err := parseEntity(&students[i])
+		if err != nil {
+			return err
+		}
+		if ids[entity.ID] {
+			return models.ErrStudentExists
+		}
+		if entity.Email != nil && emails[*entity.Email] {
+			return models.ErrEmailTaken
+		}
+		ids[entity.ID] = true
+		if entity.Email != nil {
+			emails[*entity.Email] = true
+		}
+		entities = append(entities, entity)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entity := range entities {
+		if _, ok := r.students[entity.ID]; ok {
+			return models.ErrStudentExists
+		}
+		if r.emailTaken(entity) {
+			return models.ErrEmailTaken
+		}
+	}
+	// Numbers are only taken once nothing can fail, so that a rejected batch
+	// leaves no gaps, as a rolled back transaction does.
+	entries := make([]*audit.Entry, 0, len(entities))
+	sequences := map[int]int{}
+	for i, entity := range entities {
+		year := enrollmentYear(&students[i])
+		if _, ok := sequences[year]; !ok {
+			sequences[year] = r.sequences[year]
+		}
+		sequences[year]++
+		number := models.FormatStudentNumber(year, sequences[year])
+		entity.StudentNumber = &number
+		entry, err := newAuditEntry(ctx, audit.ActionCreate, nil, EntityToModel(entity))
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+	for year, value := range sequences {
+		r.sequences[year] = value
+	}
+	for i, entity := range entities {
+		r.insert(entity)
+		r.record(entries[i])
+		students[i].StudentNumber = *entity.StudentNumber
+	}
+	return nil
+}
+
+// insert adds entity keeping order sorted by id. The caller holds the write lock.
+func (r *memoryRepository) insert(entity *models.StudentEntity) {
+	r.students[entity.ID] = *entity
+	i := sort.Search(len(r.order), func(i int) bool { return r.order[i].String() > entity.ID.String() })
+	r.order = append(r.order, uuid.UUID{})
+	copy(r.order[i+1:], r.order[i:])
+	r.order[i] = entity.ID
+}
+
+func (r *memoryRepository) Update(ctx context.Context, student *models.Student) error {
+	entity, err := parseEntity(student)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.students[entity.ID]
+	if !ok || existing.DeletedAt.Valid {
+		return models.ErrStudentNotFound
+	}
+	keepAssigned(entity, &existing)
+	if r.emailTaken(entity) {
+		return models.ErrEmailTaken
+	}
+	entry, err := newAuditEntry(ctx, audit.ActionUpdate, EntityToModel(&existing), EntityToModel(entity))
+	if err != nil {
+		return err
+	}
+	r.students[entity.ID] = *entity
+	r.record(entry)
+	*student = *EntityToModel(entity)
+	return nil
+}
+
+func (r *memoryRepository) Transition(ctx context.Context, transition *models.Transition) error {
+	id, err := uuid.Parse(transition.StudentID)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entity, ok := r.students[id]
+	if !ok || entity.DeletedAt.Valid {
+		return models.ErrStudentNotFound
+	}
+	if entity.Status != string(transition.From) {
+		return models.ErrStatusChanged
+	}
+	before := EntityToModel(&entity)
+	entity.Status = string(transition.To)
+	entry, err := newAuditEntry(ctx, audit.ActionUpdate, before, EntityToModel(&entity))
+	if err != nil {
+		return err
+	}
+	r.students[id] = entity
+	r.lastTransitionID++
+	transition.ID = r.lastTransitionID
+	r.transitions = append(r.transitions, *transition)
+	r.record(entry)
+	return nil
+}
+
+func (r *memoryRepository) GetTransitions(studentID uuid.UUID) ([]models.Transition, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	transitions := []models.Transition{}
+	for _, transition := range r.transitions {
+		if transition.StudentID == studentID.String() {
+			transitions = append(transitions, transition)
+		}
+	}
+	return transitions, nil
+}
+
+// emailTaken reports whether another student, deleted or not, has the email of
+// entity. The caller holds the lock.
+func (r *memoryRepository) emailTaken(entity *models.StudentEntity) bool {
+	if entity.Email == nil {
+		return false
+	}
+	for id, other := range r.students {
+		if id != entity.ID && other.Email != nil && *other.Email == *entity.Email {
+			return true
+		}
+	}
+	return false
+}
+
+// record appends entry with the next ID. The caller holds the write lock.
+func (r *memoryRepository) record(entry *audit.Entry) {
+	entry.ID = uint64(len(r.audit) + 1)
+	r.audit = append(r.audit, *entry)
+}
+
+func (r *memoryRepository) GetAudit(filter audit.Filter, page int, pageSize int) ([]audit.Entry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matching := r.findAudit(filter)
+	offset := (page - 1) * pageSize
+	if offset < 0 || offset >= len(matching) {
+		return nil, nil
+	}
+	end := offset + pageSize
+	if end > len(matching) {
+		end = len(matching)
+	}
+	return matching[offset:end], nil
+}
+
+func (r *memoryRepository) TotalAuditCount(filter audit.Filter) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return int64(len(r.findAudit(filter))), nil
+}
+
+// findAudit returns the entries matching filter, newest first.
+func (r *memoryRepository) findAudit(filter audit.Filter) []audit.Entry {
+	var entries []audit.Entry
+	for i := len(r.audit) - 1; i >= 0; i-- {
+		if filter.Matches(&r.audit[i]) {
+			entries = append(entries, r.audit[i])
+		}
+	}
+	return entries
+}
+
+func (r *memoryRepository) TotalStudentCount(query models.StudentQuery) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return int64(len(r.find(query))), nil
+}
+
+// parseEntity is ModelToEntity without the panic on a malformed ID.
+func parseEntity(student *models.Student) (*models.StudentEntity, error) {
+	if _, err := uuid.Parse(student.ID); err != nil {
+		return nil, err
+	}
+	return ModelToEntity(student), nil
+}
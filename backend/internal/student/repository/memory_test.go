@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"backend/internal/student/models"
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	repo := NewMemoryRepository()
+
+	t.Run("AddDuplicate", func(t *testing.T) {
+		student := &models.Student{
+			ID:      uuid.New().String(),
+			Name:    "hasan",
+			Surname: "huseyin",
+		}
+		assert.NoError(t, repo.Add(context.Background(), student))
+		assert.ErrorIs(t, repo.Add(context.Background(), student), models.ErrStudentExists)
+	})
+
+	t.Run("AddMalformedID", func(t *testing.T) {
+		err := repo.Add(context.Background(), &models.Student{ID: "not-a-uuid", Name: "hasan", Surname: "huseyin"})
+		assert.Error(t, err)
+	})
+
+	t.Run("ReturnsCopies", func(t *testing.T) {
+		student := &models.Student{
+			ID:      uuid.New().String(),
+			Name:    "ahmet",
+			Surname: "ceylan",
+		}
+		assert.NoError(t, repo.Add(context.Background(), student))
+		student.Name = "changed"
+
+		fetched, err := repo.Get(uuid.MustParse(student.ID))
+		assert.NoError(t, err)
+		assert.Equal(t, "ahmet", fetched.Name)
+	})
+
+	t.Run("ConcurrentAdd", func(t *testing.T) {
+		repo := NewMemoryRepository()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				repo.Add(context.Background(), &models.Student{ID: uuid.New().String(), Name: "kamil", Surname: "koc"})
+			}()
+		}
+		wg.Wait()
+
+		count, err := repo.TotalStudentCount(models.DefaultStudentQuery())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(50), count)
+	})
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"backend/internal/database"
+	"backend/internal/student/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// applyFilters adds the WHERE clauses of query. Field names have been validated by
+// models.ParseStudentQuery, so they are safe to use as column names. Filters, like
+// the search, ignore case whatever the collation of the database; SQLite only
+// lowers ASCII letters, though.
+func applyFilters(db *gorm.DB, query models.StudentQuery) *gorm.DB {
+	for _, filter := range query.Filters {
+		column, value := "LOWER("+filter.Field+")", strings.ToLower(filter.Value)
+		switch filter.Op {
+		case models.FilterPrefix:
+			db = db.Where(column+" LIKE ? ESCAPE '!'", database.EscapeLike(value)+"%")
+		case models.FilterContains:
+			db = db.Where(column+" LIKE ? ESCAPE '!'", "%"+database.EscapeLike(value)+"%")
+		default:
+			db = db.Where(column+" = ?", value)
+		}
+	}
+	if query.Search != "" {
+		pattern := "%" + database.EscapeLike(strings.ToLower(query.Search)) + "%"
+		db = db.Where("(LOWER(name) LIKE ? ESCAPE '!' OR LOWER(surname) LIKE ? ESCAPE '!')", pattern, pattern)
+	}
+	return db
+}
+
+// applyKeyset keeps the rows after keyset in the query's sort order, or before it
+// when keyset.Backward is set:
+// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... with > flipped for descending keys.
+func applyKeyset(db *gorm.DB, query models.StudentQuery, keyset *models.Keyset) *gorm.DB {
+	if keyset == nil {
+		return db
+	}
+
+	var clauses []string
+	var args []interface{}
+	for i, key := range query.Sort {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, query.Sort[j].Field+" = ?")
+			args = append(args, keyset.Values[j])
+		}
+		op := ">"
+		if key.Desc != keyset.Backward {
+			op = "<"
+		}
+		parts = append(parts, key.Field+" "+op+" ?")
+		args = append(args, keyset.Values[i])
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+	return db.Where("("+strings.Join(clauses, " OR ")+")", args...)
+}
+
+func applySort(db *gorm.DB, query models.StudentQuery) *gorm.DB {
+	if len(query.Sort) == 0 {
+		return db.Order("id")
+	}
+	for _, key := range query.Sort {
+		if key.Desc {
+			db = db.Order(key.Field + " DESC")
+		} else {
+			db = db.Order(key.Field)
+		}
+	}
+	return db
+}
+
+// matches is the in-memory equivalent of applyFilters.
+func matches(student *models.Student, query models.StudentQuery) bool {
+	for _, filter := range query.Filters {
+		value, want := strings.ToLower(student.Field(filter.Field)), strings.ToLower(filter.Value)
+		switch filter.Op {
+		case models.FilterPrefix:
+			if !strings.HasPrefix(value, want) {
+				return false
+			}
+		case models.FilterContains:
+			if !strings.Contains(value, want) {
+				return false
+			}
+		default:
+			if value != want {
+				return false
+			}
+		}
+	}
+	if query.Search != "" {
+		search := strings.ToLower(query.Search)
+		if !strings.Contains(strings.ToLower(student.Name), search) &&
+			!strings.Contains(strings.ToLower(student.Surname), search) {
+			return false
+		}
+	}
+	return true
+}
+
+// less is the in-memory equivalent of applySort.
+func less(a, b *models.Student, query models.StudentQuery) bool {
+	for _, key := range query.Sort {
+		x, y := a.Field(key.Field), b.Field(key.Field)
+		if x == y {
+			continue
+		}
+		if key.Desc {
+			return x > y
+		}
+		return x < y
+	}
+	return a.ID < b.ID
+}
+
+// afterKeyset is the in-memory equivalent of applyKeyset for a query already
+// reversed when going backward.
+func afterKeyset(student *models.Student, query models.StudentQuery, keyset *models.Keyset) bool {
+	for i, key := range query.Sort {
+		x, y := student.Field(key.Field), keyset.Values[i]
+		if x == y {
+			continue
+		}
+		if key.Desc {
+			return x < y
+		}
+		return x > y
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"backend/internal/config"
+	"backend/internal/student/services"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Store is what a Driver opens: the student repository and, for SQL backends,
+// the underlying connection so other subsystems can share it.
+type Store struct {
+	Students services.Repository
+	DB       *gorm.DB
+
+	sql *studentRepository
+}
+
+// Attach makes the dependents follow the students of a SQL store when they are
+// deleted or purged. Stores without a database have nothing to attach them to.
+func (s *Store) Attach(dependents ...Dependent) {
+	if s.sql != nil {
+		s.sql.dependents = append(s.sql.dependents, dependents...)
+	}
+}
+
+// Driver opens a Store for dsn, which has already had its "scheme://" prefix removed.
+type Driver func(dsn string, cfg config.Database, logLevel logger.LogLevel) (*Store, error)
+
+var (
+	driversMu sync.RWMutex
+	drivers   = map[string]Driver{}
+)
+
+// Register makes a driver available under the given DSN scheme.
+func Register(scheme string, driver Driver) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	if driver == nil {
+		panic("repository: Register driver is nil")
+	}
+	if _, dup := drivers[scheme]; dup {
+		panic("repository: Register called twice for scheme " + scheme)
+	}
+	drivers[scheme] = driver
+}
+
+// Drivers returns the registered DSN schemes in sorted order.
+func Drivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	var schemes []string
+	for scheme := range drivers {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
+// Open picks the driver by the scheme of cfg.DSN. A DSN without a scheme is
+// treated as a plain MySQL DSN.
+func Open(cfg config.Database, logLevel logger.LogLevel) (*Store, error) {
+	scheme, dsn, found := strings.Cut(cfg.DSN, "://")
+	if !found {
+		scheme, dsn = "mysql", cfg.DSN
+	}
+
+	driversMu.RLock()
+	driver, ok := drivers[scheme]
+	driversMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown database driver %q (registered: %s)", scheme, strings.Join(Drivers(), ", "))
+	}
+	return driver(dsn, cfg, logLevel)
+}
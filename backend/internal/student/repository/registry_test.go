@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"backend/internal/config"
+	"backend/internal/student/models"
+	"context"
+	"testing"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/logger"
+)
+
+func TestOpen(t *testing.T) {
+	t.Run("Memory", func(t *testing.T) {
+		store, err := Open(config.Database{DSN: "memory://"}, logger.Silent)
+		assert.NoError(t, err)
+		assert.IsType(t, &memoryRepository{}, store.Students)
+		assert.Nil(t, store.DB)
+	})
+
+	t.Run("SQLite", func(t *testing.T) {
+		store, err := Open(config.Database{DSN: "sqlite://:memory:", MaxOpenConns: 10}, logger.Silent)
+		assert.NoError(t, err)
+		assert.NotNil(t, store.DB)
+
+		student := &models.Student{
+			ID:      uuid.New().String(),
+			Name:    "hasan",
+			Surname: "huseyin",
+		}
+		assert.NoError(t, store.Students.Add(context.Background(), student))
+
+		fetched, err := store.Students.Get(uuid.MustParse(student.ID))
+		assert.NoError(t, err)
+		assert.Equal(t, student, fetched)
+	})
+
+	t.Run("UnknownScheme", func(t *testing.T) {
+		store, err := Open(config.Database{DSN: "oracle://localhost"}, logger.Silent)
+		assert.EqualError(t, err, `unknown database driver "oracle" (registered: memory, mysql, postgres, postgresql, sqlite)`)
+		assert.Nil(t, store)
+	})
+}
+
+func TestMySQLDSN(t *testing.T) {
+	for _, dsn := range []string{
+		"root:secret@tcp(localhost:3306)/students",
+		"root:secret@tcp(localhost:3306)/students?charset=utf8mb4",
+		"root:secret@tcp(localhost:3306)/students?parseTime=false",
+	} {
+		cfg, err := mysqldriver.ParseDSN(mysqlDSN(dsn))
+		assert.NoError(t, err, dsn)
+		assert.True(t, cfg.ParseTime, dsn)
+		assert.Equal(t, "students", cfg.DBName, dsn)
+	}
+}
@@ -1,23 +1,32 @@
 package repository
 
 import (
+	"backend/internal/audit"
 	"backend/internal/student/models"
+	"backend/internal/validation"
+	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-type Database interface {
-	GetDB() (*gorm.DB, error)
-}
 type studentRepository struct {
-	DB *gorm.DB
+	DB         *gorm.DB
+	dependents []Dependent
 }
 
-func GetDB() (*gorm.DB, error) {
-	dsn := "kiyam:password@tcp(127.0.0.1:3306)/fakedatabase?charset=utf8mb4&parseTime=True&loc=Local"
-	return gorm.Open(mysql.Open(dsn), &gorm.Config{})
+// Dependent is a repository of another subsystem that keeps rows about
+// students in the same database. Delete and Purge call it in their
+// transactions, before the students themselves are changed, so that those rows
+// follow them.
+type Dependent interface {
+	// DeleteStudent drops what a soft deleted student no longer takes part in.
+	DeleteStudent(tx *gorm.DB, id uuid.UUID) error
+	// PurgeStudents removes every row about the students.
+	PurgeStudents(tx *gorm.DB, ids []uuid.UUID) error
 }
 
 func NewStudentRepository(db *gorm.DB) (*studentRepository, error) {
@@ -27,13 +36,14 @@ func NewStudentRepository(db *gorm.DB) (*studentRepository, error) {
 	return r, nil
 }
 
-func (r *studentRepository) GetAll(page int, pageSize int) ([]models.Student, error) {
+func (r *studentRepository) GetAll(query models.StudentQuery, page int, pageSize int) ([]models.Student, error) {
 	var studentEntities []models.StudentEntity
 	offset := (page - 1) * pageSize
-	err := r.DB.Offset(offset).Limit(pageSize).Find(&studentEntities).Error
+	db := applySort(applyFilters(withAddresses(r.DB), query), query)
+	err := db.Offset(offset).Limit(pageSize).Find(&studentEntities).Error
 
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	var students []models.Student
@@ -45,56 +55,524 @@ func (r *studentRepository) GetAll(page int, pageSize int) ([]models.Student, er
 	return students, nil
 }
 
-func (r *studentRepository) Delete(id uuid.UUID) error {
-	entity := &models.StudentEntity{ID: id}
-	err := r.DB.Delete(entity).Error
+// GetAllByKeyset returns up to limit students following keyset in the query's sort
+// order, starting from the beginning when keyset is nil.
+func (r *studentRepository) GetAllByKeyset(query models.StudentQuery, keyset *models.Keyset, limit int) ([]models.Student, error) {
+	order := query
+	if keyset != nil && keyset.Backward {
+		order = query.Reversed()
+	}
+
+	var studentEntities []models.StudentEntity
+	db := applySort(applyKeyset(applyFilters(withAddresses(r.DB), query), query, keyset), order)
+	err := db.Limit(limit).Find(&studentEntities).Error
 	if err != nil {
-		return err
+		return nil, translateError(err)
 	}
-	return nil
+
+	var students []models.Student
+	for _, entity := range studentEntities {
+		students = append(students, *EntityToModel(&entity))
+	}
+	if keyset != nil && keyset.Backward {
+		reverse(students)
+	}
+	return students, nil
+}
+
+// Delete soft deletes the student, who stays restorable until purged, and lets
+// the dependents drop what the student takes part in.
+func (r *studentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return translateError(r.DB.Transaction(func(tx *gorm.DB) error {
+		var entity models.StudentEntity
+		err := withAddresses(tx).Where("id = ?", id).First(&entity).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ErrStudentNotFound
+		}
+		if err != nil {
+			return err
+		}
+		for _, dependent := range r.dependents {
+			if err := dependent.DeleteStudent(tx, entity.ID); err != nil {
+				return err
+			}
+		}
+		if err := tx.Delete(&entity).Error; err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, audit.ActionDelete, EntityToModel(&entity), nil)
+	}))
+}
+
+// Restore undoes the soft deletion of a student.
+func (r *studentRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return translateError(r.DB.Transaction(func(tx *gorm.DB) error {
+		var entity models.StudentEntity
+		err := withAddresses(tx).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&entity).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ErrStudentNotFound
+		}
+		if err != nil {
+			return err
+		}
+		before := EntityToModel(&entity)
+		err = tx.Unscoped().Model(&entity).Update("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+		entity.DeletedAt = gorm.DeletedAt{}
+		return recordAudit(ctx, tx, audit.ActionRestore, before, EntityToModel(&entity))
+	}))
+}
+
+// GetDeleted lists soft deleted students, most recently deleted first.
+func (r *studentRepository) GetDeleted(page int, pageSize int) ([]models.Student, error) {
+	var studentEntities []models.StudentEntity
+	offset := (page - 1) * pageSize
+	err := withAddresses(r.DB).Unscoped().Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").Order("id").
+		Offset(offset).Limit(pageSize).Find(&studentEntities).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	var students []models.Student
+	for _, entity := range studentEntities {
+		students = append(students, *EntityToModel(&entity))
+	}
+	return students, nil
+}
+
+func (r *studentRepository) TotalDeletedCount() (int64, error) {
+	var totalStudents int64
+	err := r.DB.Unscoped().Model(&models.StudentEntity{}).Where("deleted_at IS NOT NULL").Count(&totalStudents).Error
+	if err != nil {
+		return 0, translateError(err)
+	}
+	return totalStudents, nil
+}
+
+// Purge permanently removes students soft deleted before the given time, with
+// their addresses, transitions and the rows the dependents keep about them,
+// and clears the snapshots of their audit entries.
+func (r *studentRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var entities []models.StudentEntity
+		err := withAddresses(tx).Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Find(&entities).Error
+		if err != nil || len(entities) == 0 {
+			return err
+		}
+		ids := make([]uuid.UUID, len(entities))
+		for i := range entities {
+			ids[i] = entities[i].ID
+		}
+		for _, dependent := range r.dependents {
+			if err := dependent.PurgeStudents(tx, ids); err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("student_id IN ?", ids).Delete(&models.AddressEntity{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("student_id IN ?", ids).Delete(&models.TransitionEntity{}).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Delete(&entities)
+		if result.Error != nil {
+			return result.Error
+		}
+		purged = result.RowsAffected
+		purgedIDs := make([]string, len(ids))
+		for i, id := range ids {
+			purgedIDs[i] = id.String()
+		}
+		if err := scrubAudit(tx, purgedIDs); err != nil {
+			return err
+		}
+		for _, id := range purgedIDs {
+			entry, err := newPurgeEntry(ctx, id)
+			if err != nil {
+				return err
+			}
+			if err := tx.Create(entry).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, translateError(err)
+	}
+	return purged, nil
 }
 
 func (r *studentRepository) Get(id uuid.UUID) (*models.Student, error) {
 	var entity models.StudentEntity
-	err := r.DB.Where("id = ?", id).First(&entity).Error
+	err := withAddresses(r.DB).Where("id = ?", id).First(&entity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, models.ErrStudentNotFound
+	}
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 	student := EntityToModel(&entity)
 	return student, nil
 }
 
-func (r *studentRepository) Add(student *models.Student) error {
+// GetByIDs returns the students with the given IDs that exist and are not
+// deleted.
+func (r *studentRepository) GetByIDs(ids []uuid.UUID) ([]models.Student, error) {
+	students := []models.Student{}
+	if len(ids) == 0 {
+		return students, nil
+	}
+	var entities []models.StudentEntity
+	if err := withAddresses(r.DB).Where("id IN ?", ids).Find(&entities).Error; err != nil {
+		return nil, translateError(err)
+	}
+	for i := range entities {
+		students = append(students, *EntityToModel(&entities[i]))
+	}
+	return students, nil
+}
+
+// Add inserts the student with the next student number of the year it enrolls
+// in, and sets that number on student.
+func (r *studentRepository) Add(ctx context.Context, student *models.Student) error {
+	entity := ModelToEntity(student)
+	err := translateError(r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := checkEmail(tx, entity); err != nil {
+			return err
+		}
+		number, err := nextStudentNumber(tx, enrollmentYear(student))
+		if err != nil {
+			return err
+		}
+		entity.StudentNumber = &number
+		if err := tx.Create(entity).Error; err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, audit.ActionCreate, nil, EntityToModel(entity))
+	}))
+	if err != nil {
+		return err
+	}
+	student.StudentNumber = *entity.StudentNumber
+	return nil
+}
+
+// AddBatch inserts all students in one transaction, or none of them, numbering
+// them like Add.
+func (r *studentRepository) AddBatch(ctx context.Context, students []models.Student) error {
+	entities := make([]*models.StudentEntity, 0, len(students))
+	for i := range students {
+		entities = append(entities, ModelToEntity(&students[i]))
+	}
+	err := translateError(r.DB.Transaction(func(tx *gorm.DB) error {
+		emails := map[string]bool{}
+		for i, entity := range entities {
+			if entity.Email != nil && emails[*entity.Email] {
+				return models.ErrEmailTaken
+			}
+			if entity.Email != nil {
+				emails[*entity.Email] = true
+			}
+			if err := checkEmail(tx, entity); err != nil {
+				return err
+			}
+			number, err := nextStudentNumber(tx, enrollmentYear(&students[i]))
+			if err != nil {
+				return err
+			}
+			entity.StudentNumber = &number
+		}
+		if err := tx.Create(entities).Error; err != nil {
+			return err
+		}
+		for _, entity := range entities {
+			if err := recordAudit(ctx, tx, audit.ActionCreate, nil, EntityToModel(entity)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	if err != nil {
+		return err
+	}
+	for i, entity := range entities {
+		students[i].StudentNumber = *entity.StudentNumber
+	}
+	return nil
+}
+
+// Update replaces the student and their addresses. The student number and
+// status are kept, as is the enrollment date when left empty; student is
+// updated to match.
+func (r *studentRepository) Update(ctx context.Context, student *models.Student) error {
 	entity := ModelToEntity(student)
-	err := r.DB.Create(entity).Error
+	err := translateError(r.DB.Transaction(func(tx *gorm.DB) error {
+		var existing models.StudentEntity
+		err := withAddresses(tx).Where("id = ?", entity.ID).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ErrStudentNotFound
+		}
+		if err != nil {
+			return err
+		}
+		keepAssigned(entity, &existing)
+		if err := checkEmail(tx, entity); err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Save(entity).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("student_id = ?", entity.ID).Delete(&models.AddressEntity{}).Error; err != nil {
+			return err
+		}
+		if len(entity.Addresses) > 0 {
+			if err := tx.Create(&entity.Addresses).Error; err != nil {
+				return err
+			}
+		}
+		return recordAudit(ctx, tx, audit.ActionUpdate, EntityToModel(&existing), EntityToModel(entity))
+	}))
+	if err != nil {
+		return err
+	}
+	*student = *EntityToModel(entity)
+	return nil
+}
+
+// Transition moves the student from transition.From to transition.To and
+// records the transition, setting its ID. It returns ErrStatusChanged when the
+// student is no longer in transition.From.
+func (r *studentRepository) Transition(ctx context.Context, transition *models.Transition) error {
+	entity := transitionToEntity(transition)
+	return translateError(r.DB.Transaction(func(tx *gorm.DB) error {
+		var existing models.StudentEntity
+		err := withAddresses(tx).Where("id = ?", entity.StudentID).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ErrStudentNotFound
+		}
+		if err != nil {
+			return err
+		}
+		// The condition on the status makes concurrent transitions of one student
+		// wait for each other, and all but the first fail.
+		result := tx.Model(&models.StudentEntity{}).
+			Where("id = ? AND status = ?", entity.StudentID, entity.From).
+			Update("status", entity.To)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return models.ErrStatusChanged
+		}
+		if err := tx.Create(entity).Error; err != nil {
+			return err
+		}
+		transition.ID = entity.ID
+
+		before := EntityToModel(&existing)
+		after := *before
+		after.Status = transition.To
+		return recordAudit(ctx, tx, audit.ActionUpdate, before, &after)
+	}))
+}
+
+// GetTransitions lists the transitions of a student, oldest first.
+func (r *studentRepository) GetTransitions(studentID uuid.UUID) ([]models.Transition, error) {
+	var entities []models.TransitionEntity
+	err := r.DB.Where("student_id = ?", studentID).Order("at").Order("id").Find(&entities).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	transitions := []models.Transition{}
+	for i := range entities {
+		transitions = append(transitions, *entityToTransition(&entities[i]))
+	}
+	return transitions, nil
+}
+
+func transitionToEntity(transition *models.Transition) *models.TransitionEntity {
+	return &models.TransitionEntity{
+		ID:        transition.ID,
+		StudentID: uuid.MustParse(transition.StudentID),
+		From:      string(transition.From),
+		To:        string(transition.To),
+		Reason:    transition.Reason,
+		Actor:     transition.Actor,
+		At:        transition.At,
+	}
+}
+
+func entityToTransition(entity *models.TransitionEntity) *models.Transition {
+	return &models.Transition{
+		ID:        entity.ID,
+		StudentID: entity.StudentID.String(),
+		From:      models.Status(entity.From),
+		To:        models.Status(entity.To),
+		Reason:    entity.Reason,
+		Actor:     entity.Actor,
+		At:        entity.At,
+	}
+}
+
+// withAddresses loads the addresses of the students a query finds.
+func withAddresses(db *gorm.DB) *gorm.DB {
+	return db.Preload("Addresses", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	})
+}
+
+// checkEmail returns ErrEmailTaken when another student, deleted or not, has
+// the email of entity. The unique index still catches concurrent writers, as
+// ErrStudentExists.
+func checkEmail(tx *gorm.DB, entity *models.StudentEntity) error {
+	if entity.Email == nil {
+		return nil
+	}
+	var taken int64
+	err := tx.Unscoped().Model(&models.StudentEntity{}).
+		Where("email = ? AND id <> ?", *entity.Email, entity.ID).Count(&taken).Error
 	if err != nil {
 		return err
 	}
+	if taken > 0 {
+		return models.ErrEmailTaken
+	}
 	return nil
 }
 
+// nextStudentNumber takes the next number of year from its sequence. The
+// update locks the year's row until tx ends, so concurrent transactions never
+// get the same number.
+func nextStudentNumber(tx *gorm.DB, year int) (string, error) {
+	sequences := func() *gorm.DB { return tx.Table("student_sequences") }
+	err := sequences().Clauses(clause.OnConflict{DoNothing: true}).
+		Create(map[string]interface{}{"year": year, "value": 0}).Error
+	if err != nil {
+		return "", err
+	}
+	err = sequences().Where("year = ?", year).Update("value", gorm.Expr("value + 1")).Error
+	if err != nil {
+		return "", err
+	}
+	var value int
+	if err := sequences().Where("year = ?", year).Select("value").Scan(&value).Error; err != nil {
+		return "", err
+	}
+	return models.FormatStudentNumber(year, value), nil
+}
+
+// enrollmentYear is the year student numbers are counted in, that of the
+// enrollment date or else the current one.
+func enrollmentYear(student *models.Student) int {
+	if enrolled, err := time.Parse(validation.DateLayout, student.EnrollmentDate); err == nil {
+		return enrolled.Year()
+	}
+	return time.Now().Year()
+}
+
+// keepAssigned copies the fields that are assigned when a student is added
+// from existing to entity: the number, the status, which only transitions
+// change, the deletion time, which only Delete and Restore change, and the
+// enrollment date unless entity sets it.
+func keepAssigned(entity *models.StudentEntity, existing *models.StudentEntity) {
+	entity.StudentNumber = existing.StudentNumber
+	entity.Status = existing.Status
+	entity.DeletedAt = existing.DeletedAt
+	if entity.EnrollmentDate == "" {
+		entity.EnrollmentDate = existing.EnrollmentDate
+	}
+}
+
 func ModelToEntity(student *models.Student) *models.StudentEntity {
-	return &models.StudentEntity{
-		ID:      uuid.MustParse(student.ID),
-		Name:    student.Name,
-		Surname: student.Surname,
+	entity := &models.StudentEntity{
+		ID:             uuid.MustParse(student.ID),
+		StudentNumber:  nullable(student.StudentNumber),
+		Name:           student.Name,
+		Surname:        student.Surname,
+		Status:         string(student.Status),
+		EnrollmentDate: student.EnrollmentDate,
+		DateOfBirth:    student.DateOfBirth,
+		Email:          nullable(student.Email),
+		Phone:          student.Phone,
+	}
+	for i, address := range student.Addresses {
+		entity.Addresses = append(entity.Addresses, models.AddressEntity{
+			StudentID:  entity.ID,
+			Position:   i,
+			Kind:       string(address.Kind),
+			Line1:      address.Line1,
+			Line2:      address.Line2,
+			City:       address.City,
+			Region:     address.Region,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+		})
 	}
+	if student.DeletedAt != nil {
+		entity.DeletedAt = gorm.DeletedAt{Time: *student.DeletedAt, Valid: true}
+	}
+	return entity
 }
 
 func EntityToModel(entity *models.StudentEntity) *models.Student {
-	return &models.Student{
-		ID:      entity.ID.String(),
-		Name:    entity.Name,
-		Surname: entity.Surname,
+	student := &models.Student{
+		ID:             entity.ID.String(),
+		Name:           entity.Name,
+		Surname:        entity.Surname,
+		Status:         models.Status(entity.Status),
+		EnrollmentDate: entity.EnrollmentDate,
+		DateOfBirth:    entity.DateOfBirth,
+		Phone:          entity.Phone,
+	}
+	if entity.StudentNumber != nil {
+		student.StudentNumber = *entity.StudentNumber
+	}
+	if entity.Email != nil {
+		student.Email = *entity.Email
+	}
+	for _, address := range entity.Addresses {
+		student.Addresses = append(student.Addresses, models.Address{
+			Kind:       models.AddressKind(address.Kind),
+			Line1:      address.Line1,
+			Line2:      address.Line2,
+			City:       address.City,
+			Region:     address.Region,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+		})
+	}
+	if entity.DeletedAt.Valid {
+		deletedAt := entity.DeletedAt.Time
+		student.DeletedAt = &deletedAt
 	}
+	return student
 }
 
-func (r *studentRepository) TotalStudentCount() (int64, error) {
+// nullable stores empty unique values as NULL.
+func nullable(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func (r *studentRepository) TotalStudentCount(query models.StudentQuery) (int64, error) {
 	var totalStudents int64
 
-	err := r.DB.Model(&models.Student{}).Count(&totalStudents).Error
+	err := applyFilters(r.DB.Model(&models.StudentEntity{}), query).Count(&totalStudents).Error
 	if err != nil {
-		return 0, err
+		return 0, translateError(err)
 	}
 	return totalStudents, nil
 }
+
+func reverse(students []models.Student) {
+	for i, j := 0, len(students)-1; i < j; i, j = i+1, j-1 {
+		students[i], students[j] = students[j], students[i]
+	}
+}
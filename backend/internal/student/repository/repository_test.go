@@ -1,8 +1,13 @@
 package repository
 
 import (
+	"backend/internal/config"
+	"backend/internal/database/schema"
 	"backend/internal/student/models"
+	"context"
 	"fmt"
+	"os"
+	"sort"
 	"testing"
 
 	"github.com/google/uuid"
@@ -11,16 +16,33 @@ import (
 	"gorm.io/gorm"
 )
 
-func TestAdd(t *testing.T) {
-	dsn := "kiyam:password@tcp(127.0.0.1:3306)/teststudent?charset=utf8mb4&parseTime=True&loc=Local"
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+// testDSN returns the MySQL DSN of the test database, overridable with STUDENTS_TEST_DB_DSN.
+func testDSN() string {
+	if dsn, ok := os.LookupEnv(config.EnvPrefix + "TEST_DB_DSN"); ok {
+		return dsn
+	}
+	return "kiyam:password@tcp(127.0.0.1:3306)/teststudent?charset=utf8mb4&parseTime=True&loc=Local"
+}
+
+// openTestDB connects to the MySQL test database and skips the test when it is not reachable.
+func openTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(mysql.Open(testDSN()), &gorm.Config{TranslateError: true})
 	if err != nil {
-		t.Fatalf("Failed to connect to the database: %v", err)
+		t.Skipf("MySQL test database is not available: %v", err)
 	}
-	defer func() {
+	t.Cleanup(func() {
 		sqlDB, _ := db.DB()
 		sqlDB.Close()
-	}()
+	})
+
+	if err := schema.Migrate(db); err != nil {
+		t.Fatalf("Failed to migrate the database: %v", err)
+	}
+	return db
+}
+
+func TestAdd(t *testing.T) {
+	db := openTestDB(t)
 
 	repo, err := NewStudentRepository(db)
 	if err != nil {
@@ -29,7 +51,7 @@ func TestAdd(t *testing.T) {
 
 	t.Run("AddSuccess", func(t *testing.T) {
 		// Get the initial count of students in the database
-		initialCount, err := repo.TotalStudentCount()
+		initialCount, err := repo.TotalStudentCount(models.DefaultStudentQuery())
 		fmt.Println("initial student count:", initialCount)
 		if err != nil {
 			t.Fatalf("Failed to get initial student count: %v", err)
@@ -39,11 +61,11 @@ func TestAdd(t *testing.T) {
 			Name:    "kamil",
 			Surname: "koc",
 		}
-		err = repo.Add(testStudent)
+		err = repo.Add(context.Background(), testStudent)
 		assert.NoError(t, err, "Expected Add to succeed, but it didn't")
 
 		//Get the final count of students in the database
-		finalCount, err := repo.TotalStudentCount()
+		finalCount, err := repo.TotalStudentCount(models.DefaultStudentQuery())
 		fmt.Println("final student count:", finalCount)
 
 		if err != nil {
@@ -55,15 +77,7 @@ func TestAdd(t *testing.T) {
 }
 
 func TestGet(t *testing.T) {
-	dsn := "kiyam:password@tcp(127.0.0.1:3306)/teststudent?charset=utf8mb4&parseTime=True&loc=Local"
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
-	if err != nil {
-		t.Fatalf("Failed to connect to the database: %v", err)
-	}
-	defer func() {
-		sqlDB, _ := db.DB()
-		sqlDB.Close()
-	}()
+	db := openTestDB(t)
 
 	repo, err := NewStudentRepository(db)
 	if err != nil {
@@ -78,7 +92,7 @@ func TestGet(t *testing.T) {
 
 	newStudent := EntityToModel(expectedStudent)
 
-	repo.Add(newStudent)
+	repo.Add(context.Background(), newStudent)
 
 	t.Run("GetExistingStudent", func(t *testing.T) {
 		studentEntity := ModelToEntity(newStudent)
@@ -97,15 +111,7 @@ func TestGet(t *testing.T) {
 
 func TestGetAllStudent(t *testing.T) {
 	TestCleanup(t)
-	dsn := "kiyam:password@tcp(127.0.0.1:3306)/teststudent?charset=utf8mb4&parseTime=True&loc=Local"
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
-	if err != nil {
-		t.Fatalf("Failed to connect to the database: %v", err)
-	}
-	defer func() {
-		sqlDB, _ := db.DB()
-		sqlDB.Close()
-	}()
+	db := openTestDB(t)
 
 	repo, err := NewStudentRepository(db)
 	if err != nil {
@@ -127,8 +133,12 @@ func TestGetAllStudent(t *testing.T) {
 
 	for _, student := range studentsToAdd {
 		newStudent := EntityToModel(&student)
-		repo.Add(newStudent)
+		repo.Add(context.Background(), newStudent)
 	}
+	// GetAll orders students by id.
+	sort.Slice(studentsToAdd, func(i, j int) bool {
+		return studentsToAdd[i].ID.String() < studentsToAdd[j].ID.String()
+	})
 
 	testCases := []struct {
 		Page        int
@@ -158,7 +168,7 @@ func TestGetAllStudent(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.Description, func(t *testing.T) {
-			students, err := repo.GetAll(tc.Page, tc.PageSize)
+			students, err := repo.GetAll(models.DefaultStudentQuery(), tc.Page, tc.PageSize)
 
 			assert.NoError(t, err, "Error calling GetAll")
 			assert.Equal(t, tc.Expected, students)
@@ -166,15 +176,7 @@ func TestGetAllStudent(t *testing.T) {
 	}
 }
 func TestDelete(t *testing.T) {
-	dsn := "kiyam:password@tcp(127.0.0.1:3306)/teststudent?charset=utf8mb4&parseTime=True&loc=Local"
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
-	if err != nil {
-		t.Fatalf("Failed to connect to the database: %v", err)
-	}
-	defer func() {
-		sqlDB, _ := db.DB()
-		sqlDB.Close()
-	}()
+	db := openTestDB(t)
 
 	repo, err := NewStudentRepository(db)
 	if err != nil {
@@ -191,35 +193,59 @@ func TestDelete(t *testing.T) {
 		}
 
 		newStudent := EntityToModel(&freshStudent)
-		repo.Add(newStudent)
+		repo.Add(context.Background(), newStudent)
 
-		err = repo.Delete(freshStudent.ID)
+		err = repo.Delete(context.Background(), freshStudent.ID)
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("UUID does not exist", func(t *testing.T) {
-		err = repo.Delete(uuid.New())
-		assert.Nil(t, err)
+		err = repo.Delete(context.Background(), uuid.New())
+		assert.ErrorIs(t, err, models.ErrStudentNotFound)
 	})
 
 }
 
-func TestCleanup(t *testing.T) {
-	dsn := "kiyam:password@tcp(127.0.0.1:3306)/teststudent?charset=utf8mb4&parseTime=True&loc=Local"
-	db, err := gorm.Open(mysql.New(mysql.Config{
-		DriverName: "mysql",
-		DSN:        dsn,
-	}), &gorm.Config{})
+func TestUpdate(t *testing.T) {
+	db := openTestDB(t)
+
+	repo, err := NewStudentRepository(db)
 	if err != nil {
-		t.Fatalf("Failed to connect to the database: %v", err)
+		t.Fatalf("Failed to create student repository: %v", err)
 	}
-	defer func() {
-		sqlDB, _ := db.DB()
-		sqlDB.Close()
-	}()
 
-	// Cleanup: Delete all records from the students table using plain SQL
+	t.Run("UpdateSuccess", func(t *testing.T) {
+		student := &models.Student{
+			ID:      uuid.New().String(),
+			Name:    "hasan",
+			Surname: "huseyin",
+		}
+		repo.Add(context.Background(), student)
+
+		student.Surname = "hüseyin"
+		err := repo.Update(context.Background(), student)
+		assert.NoError(t, err)
+
+		updated, err := repo.Get(uuid.MustParse(student.ID))
+		assert.NoError(t, err)
+		assert.Equal(t, student, updated)
+	})
+
+	t.Run("UpdateNonExistingStudent", func(t *testing.T) {
+		err := repo.Update(context.Background(), &models.Student{
+			ID:      uuid.New().String(),
+			Name:    "hasan",
+			Surname: "huseyin",
+		})
+		assert.ErrorIs(t, err, models.ErrStudentNotFound)
+	})
+}
+
+func TestCleanup(t *testing.T) {
+	db := openTestDB(t)
+
+	// Cleanup: Delete all records, soft deleted ones included, from the students table using plain SQL
 	if err := db.Exec("DELETE FROM students").Error; err != nil {
 		t.Fatalf("Failed to delete records: %v", err)
 	}
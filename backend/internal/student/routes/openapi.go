@@ -0,0 +1,215 @@
+package routes
+
+import (
+	"backend/internal/audit"
+	"backend/internal/auth"
+	"backend/internal/openapi"
+	"backend/internal/student/models"
+	"net/http"
+)
+
+var problemDescriptions = map[int]string{
+	http.StatusBadRequest:          "The request is invalid; errors lists the invalid fields",
+	http.StatusNotFound:            "No such student",
+	http.StatusConflict:            "Another student already has this ID or email, or the status cannot change this way",
+	http.StatusServiceUnavailable:  "The database is unavailable, try again later",
+	http.StatusInternalServerError: "Unexpected error",
+}
+
+// operation secures op with permission and documents the problems it may
+// answer with, besides the 503 and 500 every operation may.
+func operation(doc *openapi.Document, op *openapi.Operation, permission auth.Permission, problems ...int) *openapi.Operation {
+	op.Tags = []string{"students"}
+	problems = append(problems, http.StatusServiceUnavailable, http.StatusInternalServerError)
+	for _, status := range problems {
+		op.Respond(status, doc.Problem(problemDescriptions[status]))
+	}
+	return auth.Secure(doc, op, permission)
+}
+
+// Describe documents the routes SetupRoutes registers.
+func Describe(doc *openapi.Document) {
+	doc.AddTag("students", "Students, their history and the audit trail")
+	doc.Enum(models.StatusApplicant, models.StatusEnrolled, models.StatusSuspended, models.StatusGraduated, models.StatusWithdrawn)
+	doc.Enum(models.AddressHome, models.AddressMailing, models.AddressTerm)
+	doc.Enum(models.ImportCreated, models.ImportSkipped, models.ImportFailed)
+	doc.Enum(audit.ActionCreate, audit.ActionUpdate, audit.ActionDelete, audit.ActionRestore, audit.ActionPurge)
+
+	one := 1.0
+	id := openapi.Path("id", "The student ID", &openapi.Schema{Type: "string", Format: "uuid"})
+	pagination := []*openapi.Parameter{
+		openapi.Query("page", "The page number, starting at 1", &openapi.Schema{Type: "integer", Minimum: &one, Default: 1}),
+		openapi.Query("size", "The page size. The server's configured maximum applies", &openapi.Schema{Type: "integer", Minimum: &one}),
+	}
+	studentQuery := []*openapi.Parameter{
+		openapi.Query("q", "Free-text search over the name and surname", &openapi.Schema{Type: "string"}),
+		openapi.Query("sort", "Comma separated fields to sort by, descending when prefixed with -, such as surname,-name. Sortable fields: id, name, surname", &openapi.Schema{Type: "string"}),
+	}
+	for _, field := range models.FilterableFields {
+		studentQuery = append(studentQuery,
+			openapi.Query(field, "Only students whose "+field+" equals the value, ignoring case", &openapi.Schema{Type: "string"}),
+			openapi.Query(field+"[prefix]", "Only students whose "+field+" starts with the value, ignoring case", &openapi.Schema{Type: "string"}),
+			openapi.Query(field+"[contains]", "Only students whose "+field+" contains the value, ignoring case", &openapi.Schema{Type: "string"}),
+		)
+	}
+	studentQuery = append(studentQuery, openapi.Query("status", "Only students with this status", doc.Schema(models.StatusEnrolled)))
+	formats := &openapi.Schema{Type: "string", Enum: []interface{}{models.FormatCSV, models.FormatXLSX, models.FormatNDJSON}}
+
+	list := append(append([]*openapi.Parameter{}, pagination...), studentQuery...)
+	list = append(list,
+		openapi.Query("cursor", "Switches to cursor pagination. Empty for the first page, then the next or prev cursor of the previous response; page is ignored", &openapi.Schema{Type: "string"}),
+		openapi.Query("total", "In cursor mode, also count the matching students", &openapi.Schema{Type: "boolean"}),
+	)
+	doc.Add(http.MethodGet, "/students", operation(doc, &openapi.Operation{
+		Summary:     "List students",
+		Description: "Filters, search and sort combine. With cursor the response is a CursorResponse instead of a PaginationResponse.",
+		OperationID: "listStudents",
+		Parameters:  list,
+		Responses: map[string]*openapi.Response{"200": {
+			Description: "A page of students",
+			Content: map[string]openapi.MediaType{"application/json": {Schema: &openapi.Schema{
+				OneOf: []*openapi.Schema{doc.Schema(models.PaginationResponse{}), doc.Schema(models.CursorResponse{})},
+			}}},
+		}},
+	}, auth.PermStudentsRead, http.StatusBadRequest))
+
+	export := append([]*openapi.Parameter{
+		openapi.Query("format", "The file format. Defaults to the Accept header, then csv", formats),
+	}, studentQuery...)
+	doc.Add(http.MethodGet, "/students/export", operation(doc, &openapi.Operation{
+		Summary:     "Export students",
+		Description: "Streams every student matching the list filters, in the list order, as a file download. CSV and XLSX files have the columns id, studentNumber, name, surname, status, enrollmentDate, dateOfBirth, email and phone, the personal details left empty for principals who may not read them. XLSX sheets hold 1,048,576 rows; the students past them continue on Sheet2, Sheet3 and so on.",
+		OperationID: "exportStudents",
+		Parameters:  export,
+		Responses: map[string]*openapi.Response{
+			"200": {
+				Description: "The students file",
+				Headers:     map[string]*openapi.Header{"Content-Disposition": {Schema: &openapi.Schema{Type: "string"}}},
+				Content: map[string]openapi.MediaType{
+					"text/csv": {Schema: &openapi.Schema{Type: "string"}},
+					"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": {Schema: &openapi.Schema{Type: "string", Format: "binary"}},
+					"application/x-ndjson": {Schema: &openapi.Schema{Type: "string"}},
+				},
+			},
+			"406": doc.Problem("The format is not supported"),
+		},
+	}, auth.PermStudentsRead, http.StatusBadRequest))
+
+	doc.Add(http.MethodGet, "/students/:id", operation(doc, &openapi.Operation{
+		Summary:     "Get a student",
+		OperationID: "getStudent",
+		Parameters:  []*openapi.Parameter{id},
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.Student{}, "The student")},
+	}, auth.PermStudentsRead, http.StatusBadRequest, http.StatusNotFound))
+
+	doc.Add(http.MethodDelete, "/students/:id", operation(doc, &openapi.Operation{
+		Summary:     "Delete a student",
+		Description: "The student is soft deleted and can be restored until it is purged.",
+		OperationID: "deleteStudent",
+		Parameters:  []*openapi.Parameter{id},
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(openapi.Message{}, "The student was deleted")},
+	}, auth.PermStudentsDelete, http.StatusBadRequest, http.StatusNotFound))
+
+	doc.Add(http.MethodPost, "/students", operation(doc, &openapi.Operation{
+		Summary:     "Create a student",
+		Description: "Names are trimmed, and capitalized when typed in a single case. The ID and student number are generated; the status is applicant or enrolled, the default, and the enrollment date defaults to today.",
+		OperationID: "createStudent",
+		RequestBody: doc.Body(models.Student{}, ""),
+		Responses:   map[string]*openapi.Response{"201": doc.JSON(models.Student{}, "The created student")},
+	}, auth.PermStudentsWrite, http.StatusBadRequest, http.StatusConflict))
+
+	doc.Add(http.MethodPost, "/students/import", operation(doc, &openapi.Operation{
+		Summary:     "Import students from a file",
+		Description: "The file is either the file field of a multipart form or the raw body. The header names the columns: name and surname are required, and status, enrollmentDate, dateOfBirth, email and phone are read when present, so an export can be imported again. Every row is validated like the body of a create, so the ID and student number are generated. Rows with the email of an earlier row are skipped.",
+		OperationID: "importStudents",
+		Parameters: []*openapi.Parameter{
+			openapi.Query("dryRun", "Validate the rows without saving them", &openapi.Schema{Type: "boolean"}),
+			openapi.Query("format", "The file format. Defaults to the file extension or the Content-Type", &openapi.Schema{Type: "string", Enum: []interface{}{models.FormatCSV, models.FormatXLSX}}),
+		},
+		RequestBody: &openapi.RequestBody{Required: true, Content: map[string]openapi.MediaType{
+			"multipart/form-data": {Schema: &openapi.Schema{
+				Type:       "object",
+				Properties: map[string]*openapi.Schema{"file": {Type: "string", Format: "binary"}},
+				Required:   []string{"file"},
+			}},
+			"text/csv": {Schema: &openapi.Schema{Type: "string"}},
+			"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": {Schema: &openapi.Schema{Type: "string", Format: "binary"}},
+		}},
+		Responses: map[string]*openapi.Response{"200": doc.JSON(models.ImportReport{}, "The outcome of every row")},
+	}, auth.PermStudentsWrite, http.StatusBadRequest))
+
+	doc.Add(http.MethodPut, "/students/:id", operation(doc, &openapi.Operation{
+		Summary:     "Replace a student",
+		Description: "The student number never changes and the status only changes with a transition; the enrollment date is kept when left out.",
+		OperationID: "updateStudent",
+		Parameters:  []*openapi.Parameter{id},
+		RequestBody: doc.Body(models.Student{}, "The id and student number in the body are ignored"),
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.Student{}, "The updated student")},
+	}, auth.PermStudentsWrite, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict))
+
+	doc.Add(http.MethodPatch, "/students/:id", operation(doc, &openapi.Operation{
+		Summary:     "Update some fields of a student",
+		OperationID: "patchStudent",
+		Parameters:  []*openapi.Parameter{id},
+		RequestBody: &openapi.RequestBody{
+			Description: "A JSON Merge Patch (RFC 7386) of the student",
+			Required:    true,
+			Content: map[string]openapi.MediaType{
+				"application/merge-patch+json": {Schema: &openapi.Schema{Type: "object"}},
+				"application/json":             {Schema: &openapi.Schema{Type: "object"}},
+			},
+		},
+		Responses: map[string]*openapi.Response{"200": doc.JSON(models.Student{}, "The updated student")},
+	}, auth.PermStudentsWrite, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict))
+
+	doc.Add(http.MethodPost, "/students/:id/restore", operation(doc, &openapi.Operation{
+		Summary:     "Restore a deleted student",
+		OperationID: "restoreStudent",
+		Parameters:  []*openapi.Parameter{id},
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.Student{}, "The restored student")},
+	}, auth.PermStudentsDelete, http.StatusBadRequest, http.StatusNotFound))
+
+	doc.Add(http.MethodGet, "/students/:id/history", operation(doc, &openapi.Operation{
+		Summary:     "List the changes of a student",
+		OperationID: "getStudentHistory",
+		Parameters:  append([]*openapi.Parameter{id}, pagination...),
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.AuditResponse{}, "The changes, newest first")},
+	}, auth.PermAuditRead, http.StatusBadRequest))
+
+	doc.Add(http.MethodPost, "/students/:id/transitions", operation(doc, &openapi.Operation{
+		Summary:     "Change the status of a student",
+		Description: "Only the transitions of the student lifecycle are allowed, and only once the student meets the requirements of the new status, such as complete grades to graduate. Suspensions and withdrawals need a reason. Otherwise the 409 problem lists the allowed statuses or the unmet requirements.",
+		OperationID: "transitionStudent",
+		Parameters:  []*openapi.Parameter{id},
+		RequestBody: doc.Body(models.TransitionRequest{}, ""),
+		Responses:   map[string]*openapi.Response{"201": doc.JSON(models.Transition{}, "The recorded transition")},
+	}, auth.PermStudentsWrite, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict))
+
+	doc.Add(http.MethodGet, "/students/:id/transitions", operation(doc, &openapi.Operation{
+		Summary:     "List the status changes of a student",
+		OperationID: "listStudentTransitions",
+		Parameters:  []*openapi.Parameter{id},
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.TransitionList{}, "The transitions, oldest first")},
+	}, auth.PermStudentsRead, http.StatusBadRequest, http.StatusNotFound))
+
+	doc.Add(http.MethodGet, "/audit", operation(doc, &openapi.Operation{
+		Summary:     "Search the audit trail",
+		OperationID: "listAuditEntries",
+		Parameters: append([]*openapi.Parameter{
+			openapi.Query("entityType", "Only entries of this entity type, such as student", &openapi.Schema{Type: "string"}),
+			openapi.Query("entityId", "Only entries of this entity", &openapi.Schema{Type: "string"}),
+			openapi.Query("actor", "Only changes made by this user", &openapi.Schema{Type: "string"}),
+			openapi.Query("action", "Only changes of this kind", doc.Schema(audit.ActionCreate)),
+			openapi.Query("from", "Only changes at or after this time", &openapi.Schema{Type: "string", Format: "date-time"}),
+			openapi.Query("to", "Only changes before this time", &openapi.Schema{Type: "string", Format: "date-time"}),
+		}, pagination...),
+		Responses: map[string]*openapi.Response{"200": doc.JSON(models.AuditResponse{}, "The matching entries, newest first")},
+	}, auth.PermAuditRead, http.StatusBadRequest))
+
+	doc.Add(http.MethodGet, "/admin/students/deleted", operation(doc, &openapi.Operation{
+		Summary:     "List deleted students",
+		OperationID: "listDeletedStudents",
+		Parameters:  pagination,
+		Responses:   map[string]*openapi.Response{"200": doc.JSON(models.PaginationResponse{}, "A page of deleted students")},
+	}, auth.PermStudentsDelete, http.StatusBadRequest))
+}
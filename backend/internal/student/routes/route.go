@@ -1,14 +1,30 @@
 package routes
 
 import (
+	"backend/internal/auth"
 	"backend/internal/student/controllers"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(router *gin.Engine, studentController *controllers.StudentController) {
-	router.GET("/students", studentController.GetAll)
-	router.GET("/students/:id", studentController.Get)
-	router.DELETE("/students/:id", studentController.Delete)
-	router.POST("/students", studentController.Add)
+// SetupRoutes registers the student routes behind authenticate, each requiring the
+// permission it needs.
+func SetupRoutes(router *gin.Engine, studentController *controllers.StudentController, authenticate gin.HandlerFunc) {
+	students := router.Group("", authenticate)
+	students.GET("/students", auth.Require(auth.PermStudentsRead), studentController.GetAll)
+	students.GET("/students/export", auth.Require(auth.PermStudentsRead), studentController.Export)
+	students.GET("/students/:id", auth.Require(auth.PermStudentsRead), studentController.Get)
+	students.DELETE("/students/:id", auth.Require(auth.PermStudentsDelete), studentController.Delete)
+	students.POST("/students", auth.Require(auth.PermStudentsWrite), studentController.Add)
+	students.POST("/students/import", auth.Require(auth.PermStudentsWrite), studentController.Import)
+	students.PUT("/students/:id", auth.Require(auth.PermStudentsWrite), studentController.Update)
+	students.PATCH("/students/:id", auth.Require(auth.PermStudentsWrite), studentController.Patch)
+	students.POST("/students/:id/restore", auth.Require(auth.PermStudentsDelete), studentController.Restore)
+	students.GET("/students/:id/history", auth.Require(auth.PermAuditRead), studentController.History)
+	students.POST("/students/:id/transitions", auth.Require(auth.PermStudentsWrite), studentController.Transition)
+	students.GET("/students/:id/transitions", auth.Require(auth.PermStudentsRead), studentController.GetTransitions)
+	students.GET("/audit", auth.Require(auth.PermAuditRead), studentController.Audit)
+
+	admin := students.Group("/admin")
+	admin.GET("/students/deleted", auth.Require(auth.PermStudentsDelete), studentController.GetDeleted)
 }
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"backend/internal/auth/authtest"
+	"backend/internal/student/controllers"
+	"backend/internal/student/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func TestSetupRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Every route must be rejected before it reaches the service.
+	controller := controllers.Controller(mocks.NewMockStudentService(ctrl))
+	router := gin.New()
+	SetupRoutes(router, controller, authtest.AuthenticateAs())
+
+	authtest.AssertForbidden(t, router)
+}
@@ -0,0 +1,252 @@
+// Package rpc serves the student service over gRPC. The protobuf definition is
+// in backend/proto; regenerate studentpb with go generate after changing it.
+package rpc
+
+//go:generate buf generate ../../../proto --template ../../../proto/buf.gen.yaml -o ../../..
+
+import (
+	"backend/internal/audit"
+	"backend/internal/auth"
+	"backend/internal/problem"
+	"backend/internal/student/models"
+	"backend/internal/student/rpc/studentpb"
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// StudentService is the part of the student service the gRPC API exposes.
+type StudentService interface {
+	GetAll(ctx context.Context, query models.StudentQuery, page int, pageSize int) (models.PaginationResponse, error)
+	Get(ctx context.Context, id uuid.UUID) (*models.Student, error)
+	Add(ctx context.Context, student *models.Student) error
+	Update(ctx context.Context, id uuid.UUID, student *models.Student) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+var errInvalidID = &problem.ValidationError{Detail: "invalid UUID", Fields: []problem.FieldError{{Field: "id", Code: "uuid", Message: "must be a UUID"}}}
+
+type StudentServer struct {
+	studentpb.UnimplementedStudentServiceServer
+	Service         StudentService
+	DefaultPageSize int
+	MaxPageSize     int
+}
+
+func Server(service StudentService) *StudentServer {
+	return &StudentServer{Service: service}
+}
+
+// requestContext is the context handed to the service, like the REST
+// controller's: the principal becomes the actor of the audit entries, and the
+// client's x-request-id, or a new one, their request ID. The ID is sent back
+// in the response header.
+func requestContext(ctx context.Context) context.Context {
+	ctx = auth.AuditContext(ctx)
+	requestID := ""
+	if values := metadata.ValueFromIncomingContext(ctx, strings.ToLower(audit.RequestIDHeader)); len(values) > 0 {
+		requestID = values[0]
+	}
+	if requestID == "" || len(requestID) > 64 {
+		requestID = uuid.New().String()
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(audit.RequestIDHeader, requestID))
+	return audit.WithRequestID(ctx, requestID)
+}
+
+// statusError converts the errors of the service to gRPC statuses.
+func statusError(err error) error {
+	return problem.GRPCStatus(err).Err()
+}
+
+func (s *StudentServer) GetStudent(ctx context.Context, req *studentpb.GetStudentRequest) (*studentpb.Student, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, statusError(errInvalidID)
+	}
+
+	student, err := s.Service.Get(requestContext(ctx), id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(student), nil
+}
+
+func (s *StudentServer) CreateStudent(ctx context.Context, req *studentpb.CreateStudentRequest) (*studentpb.Student, error) {
+	student := fromMessage(req.GetStudent())
+	if err := s.Service.Add(requestContext(ctx), student); err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(student), nil
+}
+
+func (s *StudentServer) UpdateStudent(ctx context.Context, req *studentpb.UpdateStudentRequest) (*studentpb.Student, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, statusError(errInvalidID)
+	}
+
+	student := fromMessage(req.GetStudent())
+	if err := s.Service.Update(requestContext(ctx), id, student); err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(student), nil
+}
+
+func (s *StudentServer) DeleteStudent(ctx context.Context, req *studentpb.DeleteStudentRequest) (*emptypb.Empty, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, statusError(errInvalidID)
+	}
+
+	if err := s.Service.Delete(requestContext(ctx), id); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListStudents sends the requested page and every page after it. The pages are
+// read one at a time, so a student added or deleted meanwhile can shift the
+// later pages, as it would between REST requests.
+func (s *StudentServer) ListStudents(req *studentpb.ListStudentsRequest, stream studentpb.StudentService_ListStudentsServer) error {
+	page, pageSize, err := s.pagination(req)
+	if err != nil {
+		return statusError(err)
+	}
+
+	values := url.Values{}
+	for key, value := range req.GetFilters() {
+		values.Set(key, value)
+	}
+	values.Set("q", req.GetQ())
+	values.Set("sort", req.GetSort())
+	query, err := models.ParseStudentQuery(values)
+	if err != nil {
+		return statusError(err)
+	}
+
+	ctx := requestContext(stream.Context())
+	for {
+		response, err := s.Service.GetAll(ctx, query, page, pageSize)
+		if err != nil {
+			return statusError(err)
+		}
+		if err := stream.Send(toPageMessage(response)); err != nil {
+			return err
+		}
+		if page >= response.Page.Pages {
+			return nil
+		}
+		page++
+	}
+}
+
+// pagination applies the configured default and maximum page size, like the
+// REST controller. Zero means the default; negative values are invalid.
+func (s *StudentServer) pagination(req *studentpb.ListStudentsRequest) (int, int, error) {
+	page := int(req.GetPage())
+	pageSize := int(req.GetPageSize())
+
+	var fields []problem.FieldError
+	if page < 0 {
+		fields = append(fields, problem.FieldError{Field: "page", Code: "positive", Message: "must be a positive integer"})
+	}
+	if pageSize < 0 {
+		fields = append(fields, problem.FieldError{Field: "page_size", Code: "positive", Message: "must be a positive integer"})
+	}
+	if len(fields) > 0 {
+		return 0, 0, &problem.ValidationError{Detail: models.ErrInvalidPage.Detail, Fields: fields}
+	}
+
+	if page == 0 {
+		page = 1
+	}
+	if pageSize == 0 {
+		pageSize = s.DefaultPageSize
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	if s.MaxPageSize > 0 && pageSize > s.MaxPageSize {
+		pageSize = s.MaxPageSize
+	}
+	return page, pageSize, nil
+}
+
+// toMessage converts a student the service returned, and so has already
+// redacted for the principal.
+func toMessage(student *models.Student) *studentpb.Student {
+	message := &studentpb.Student{
+		Id:             student.ID,
+		StudentNumber:  student.StudentNumber,
+		Name:           student.Name,
+		Surname:        student.Surname,
+		Status:         string(student.Status),
+		EnrollmentDate: student.EnrollmentDate,
+		DateOfBirth:    student.DateOfBirth,
+		Email:          student.Email,
+		Phone:          student.Phone,
+	}
+	for _, address := range student.Addresses {
+		message.Addresses = append(message.Addresses, &studentpb.Address{
+			Kind:       string(address.Kind),
+			Line1:      address.Line1,
+			Line2:      address.Line2,
+			City:       address.City,
+			Region:     address.Region,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+		})
+	}
+	if student.DeletedAt != nil {
+		message.DeletedAt = timestamppb.New(*student.DeletedAt)
+	}
+	return message
+}
+
+// fromMessage only reads the fields clients may set; the ID comes from the
+// request or is generated, and the student number is assigned.
+func fromMessage(message *studentpb.Student) *models.Student {
+	student := &models.Student{
+		Name:           message.GetName(),
+		Surname:        message.GetSurname(),
+		Status:         models.Status(message.GetStatus()),
+		EnrollmentDate: message.GetEnrollmentDate(),
+		DateOfBirth:    message.GetDateOfBirth(),
+		Email:          message.GetEmail(),
+		Phone:          message.GetPhone(),
+	}
+	for _, address := range message.GetAddresses() {
+		student.Addresses = append(student.Addresses, models.Address{
+			Kind:       models.AddressKind(address.GetKind()),
+			Line1:      address.GetLine1(),
+			Line2:      address.GetLine2(),
+			City:       address.GetCity(),
+			Region:     address.GetRegion(),
+			PostalCode: address.GetPostalCode(),
+			Country:    address.GetCountry(),
+		})
+	}
+	return student
+}
+
+func toPageMessage(response models.PaginationResponse) *studentpb.StudentPage {
+	message := &studentpb.StudentPage{
+		Page: &studentpb.Page{
+			PageNumber:    int32(response.Page.Number),
+			PageSize:      int32(response.Page.Size),
+			TotalElements: int64(response.Page.Elements),
+			TotalPages:    int32(response.Page.Pages),
+		},
+	}
+	for i := range response.Students {
+		message.Students = append(message.Students, toMessage(&response.Students[i]))
+	}
+	return message
+}
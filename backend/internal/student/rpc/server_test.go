@@ -0,0 +1,266 @@
+package rpc
+
+import (
+	"backend/internal/audit"
+	"backend/internal/auth"
+	"backend/internal/auth/authtest"
+	"backend/internal/student/mocks"
+	"backend/internal/student/models"
+	"backend/internal/student/repository"
+	"backend/internal/student/rpc/studentpb"
+	"backend/internal/student/services"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const studentID = "7995c72f-7d04-4136-8b5f-000d6d4aae23"
+
+// authenticateAs puts a principal on every call, standing in for the auth
+// interceptors.
+func authenticateAs(principal *auth.Principal) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			return handler(auth.WithPrincipal(ctx, principal), req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, &principalStream{ServerStream: stream, ctx: auth.WithPrincipal(stream.Context(), principal)})
+		}),
+	}
+}
+
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *principalStream) Context() context.Context {
+	return s.ctx
+}
+
+func newTestClient(t *testing.T, server *StudentServer, principal *auth.Principal) studentpb.StudentServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer(authenticateAs(principal)...)
+	studentpb.RegisterStudentServiceServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return studentpb.NewStudentServiceClient(conn)
+}
+
+func TestStudentServer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockStudentService(ctrl)
+	server := Server(service)
+	server.DefaultPageSize = 2
+	server.MaxPageSize = 3
+	client := newTestClient(t, server, &auth.Principal{Username: "registrar", Permissions: []auth.Permission{auth.PermAll}})
+	ctx := context.Background()
+	id := uuid.MustParse(studentID)
+
+	t.Run("GetStudent", func(t *testing.T) {
+		deletedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		service.EXPECT().Get(gomock.Any(), id).DoAndReturn(func(ctx context.Context, _ uuid.UUID) (*models.Student, error) {
+			assert.Equal(t, "registrar", audit.Actor(ctx))
+			assert.Equal(t, "req-1", audit.RequestID(ctx))
+			return &models.Student{ID: studentID, StudentNumber: "2026-000001", Name: "Hasan", Surname: "Huseyin", Status: models.StatusEnrolled,
+				EnrollmentDate: "2026-09-01", DateOfBirth: "2008-05-14", Email: "hasan@example.com", Phone: "+905551112233",
+				Addresses: []models.Address{{Kind: models.AddressHome, Line1: "Ataturk Cd. 1", City: "Izmir", Country: "TR"}}, DeletedAt: &deletedAt}, nil
+		})
+
+		var header metadata.MD
+		student, err := client.GetStudent(metadata.AppendToOutgoingContext(ctx, "x-request-id", "req-1"), &studentpb.GetStudentRequest{Id: studentID}, grpc.Header(&header))
+		require.NoError(t, err)
+		assert.Equal(t, studentID, student.Id)
+		assert.Equal(t, "Hasan", student.Name)
+		assert.Equal(t, "Huseyin", student.Surname)
+		assert.Equal(t, "2026-000001", student.StudentNumber)
+		assert.Equal(t, "enrolled", student.Status)
+		assert.Equal(t, "2026-09-01", student.EnrollmentDate)
+		assert.Equal(t, "2008-05-14", student.DateOfBirth)
+		assert.Equal(t, "hasan@example.com", student.Email)
+		assert.Equal(t, "+905551112233", student.Phone)
+		require.Len(t, student.Addresses, 1)
+		assert.Equal(t, "home", student.Addresses[0].Kind)
+		assert.Equal(t, "Izmir", student.Addresses[0].City)
+		assert.Equal(t, deletedAt, student.DeletedAt.AsTime())
+		assert.Equal(t, []string{"req-1"}, header.Get("x-request-id"))
+	})
+
+	t.Run("GetStudentErrors", func(t *testing.T) {
+		_, err := client.GetStudent(ctx, &studentpb.GetStudentRequest{Id: "not-a-uuid"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Len(t, status.Convert(err).Details(), 1)
+		assert.Equal(t, "id", status.Convert(err).Details()[0].(*errdetails.BadRequest).FieldViolations[0].Field)
+
+		service.EXPECT().Get(gomock.Any(), id).Return(nil, models.ErrStudentNotFound)
+		_, err = client.GetStudent(ctx, &studentpb.GetStudentRequest{Id: studentID})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, "student not found", status.Convert(err).Message())
+
+		service.EXPECT().Get(gomock.Any(), id).Return(nil, errors.New("syntax error near SELECT"))
+		_, err = client.GetStudent(ctx, &studentpb.GetStudentRequest{Id: studentID})
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.NotContains(t, status.Convert(err).Message(), "SELECT", "unexpected errors are not leaked")
+	})
+
+	t.Run("CreateStudent", func(t *testing.T) {
+		service.EXPECT().Add(gomock.Any(), &models.Student{Name: "Hasan", Surname: "Huseyin"}).DoAndReturn(func(_ context.Context, student *models.Student) error {
+			student.ID = studentID
+			return nil
+		})
+		student, err := client.CreateStudent(ctx, &studentpb.CreateStudentRequest{Student: &studentpb.Student{Id: "ignored", Name: "Hasan", Surname: "Huseyin"}})
+		require.NoError(t, err)
+		assert.Equal(t, studentID, student.Id)
+
+		service.EXPECT().Add(gomock.Any(), gomock.Any()).Return(models.ErrStudentExists)
+		_, err = client.CreateStudent(ctx, &studentpb.CreateStudentRequest{Student: &studentpb.Student{Name: "Hasan", Surname: "Huseyin"}})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	t.Run("UpdateStudent", func(t *testing.T) {
+		update := &models.Student{Name: "Ahmet", Surname: "Yilmaz", Email: "ahmet@example.com",
+			Addresses: []models.Address{{Kind: models.AddressMailing, Line1: "PK 12", City: "Ankara", Country: "TR"}}}
+		service.EXPECT().Update(gomock.Any(), id, update).DoAndReturn(func(_ context.Context, _ uuid.UUID, student *models.Student) error {
+			student.ID, student.StudentNumber, student.Status = studentID, "2026-000001", models.StatusEnrolled
+			return nil
+		})
+		student, err := client.UpdateStudent(ctx, &studentpb.UpdateStudentRequest{Id: studentID, Student: &studentpb.Student{
+			Id: "ignored", StudentNumber: "ignored", Name: "Ahmet", Surname: "Yilmaz", Email: "ahmet@example.com",
+			Addresses: []*studentpb.Address{{Kind: "mailing", Line1: "PK 12", City: "Ankara", Country: "TR"}},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, studentID, student.Id)
+		assert.Equal(t, "2026-000001", student.StudentNumber)
+		assert.Equal(t, "Ahmet", student.Name)
+		assert.Equal(t, "ahmet@example.com", student.Email)
+	})
+
+	t.Run("DeleteStudent", func(t *testing.T) {
+		service.EXPECT().Delete(gomock.Any(), id).Return(nil)
+		_, err := client.DeleteStudent(ctx, &studentpb.DeleteStudentRequest{Id: studentID})
+		assert.NoError(t, err)
+
+		service.EXPECT().Delete(gomock.Any(), id).Return(models.ErrStudentNotFound)
+		_, err = client.DeleteStudent(ctx, &studentpb.DeleteStudentRequest{Id: studentID})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	receive := func(t *testing.T, stream studentpb.StudentService_ListStudentsClient) ([]*studentpb.StudentPage, error) {
+		var pages []*studentpb.StudentPage
+		for {
+			page, err := stream.Recv()
+			if err == io.EOF {
+				return pages, nil
+			}
+			if err != nil {
+				return pages, err
+			}
+			pages = append(pages, page)
+		}
+	}
+
+	t.Run("ListStudents", func(t *testing.T) {
+		query := models.StudentQuery{
+			Filters: []models.Filter{{Field: "name", Op: models.FilterPrefix, Value: "H"}},
+			Search:  "hu",
+			Sort:    []models.SortKey{{Field: "surname", Desc: true}, {Field: "id"}},
+		}
+		page := func(number int) models.PaginationResponse {
+			return models.PaginationResponse{
+				Students: []models.Student{{ID: studentID, Name: "Hasan", Surname: "Huseyin"}},
+				Page:     models.Page{Number: number, Size: 3, Elements: 8, Pages: 3},
+			}
+		}
+		gomock.InOrder(
+			service.EXPECT().GetAll(gomock.Any(), query, 2, 3).Return(page(2), nil),
+			service.EXPECT().GetAll(gomock.Any(), query, 3, 3).Return(page(3), nil),
+		)
+
+		stream, err := client.ListStudents(ctx, &studentpb.ListStudentsRequest{
+			Page:     2,
+			PageSize: 50,
+			Q:        "hu",
+			Sort:     "-surname",
+			Filters:  map[string]string{"name[prefix]": "H"},
+		})
+		require.NoError(t, err)
+		pages, err := receive(t, stream)
+		require.NoError(t, err)
+		require.Len(t, pages, 2)
+		assert.Equal(t, int32(2), pages[0].Page.PageNumber)
+		assert.Equal(t, int32(3), pages[1].Page.PageNumber)
+		assert.Equal(t, int64(8), pages[1].Page.TotalElements)
+		assert.Equal(t, "Hasan", pages[1].Students[0].Name)
+	})
+
+	t.Run("ListStudentsDefaults", func(t *testing.T) {
+		service.EXPECT().GetAll(gomock.Any(), models.DefaultStudentQuery(), 1, 2).Return(models.PaginationResponse{Page: models.Page{Number: 1, Size: 2}}, nil)
+
+		stream, err := client.ListStudents(ctx, &studentpb.ListStudentsRequest{})
+		require.NoError(t, err)
+		pages, err := receive(t, stream)
+		require.NoError(t, err)
+		assert.Len(t, pages, 1, "an empty list is one empty page")
+	})
+
+	t.Run("ListStudentsErrors", func(t *testing.T) {
+		stream, err := client.ListStudents(ctx, &studentpb.ListStudentsRequest{Page: -1, PageSize: -1})
+		require.NoError(t, err)
+		_, err = receive(t, stream)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Len(t, status.Convert(err).Details()[0].(*errdetails.BadRequest).FieldViolations, 2)
+
+		stream, err = client.ListStudents(ctx, &studentpb.ListStudentsRequest{Sort: "age"})
+		require.NoError(t, err)
+		_, err = receive(t, stream)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, `invalid query: cannot sort by "age"`, status.Convert(err).Message())
+
+		service.EXPECT().GetAll(gomock.Any(), gomock.Any(), 1, 2).Return(models.PaginationResponse{}, auth.ErrUnauthenticated)
+		stream, err = client.ListStudents(ctx, &studentpb.ListStudentsRequest{})
+		require.NoError(t, err)
+		_, err = receive(t, stream)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
+
+// TestRedaction runs the real service, which hides the personal details from
+// the principals who may not read them, like it does over REST.
+func TestRedaction(t *testing.T) {
+	service := services.Service(repository.NewMemoryRepository())
+	student := &models.Student{Name: "Hasan", Surname: "Huseyin", Email: "hasan@example.com", Phone: "+905551112233"}
+	require.NoError(t, service.Add(authtest.AdminContext, student))
+
+	teacher := &auth.Principal{Username: "teacher", Permissions: []auth.Permission{auth.PermStudentsRead}}
+	client := newTestClient(t, Server(service), teacher)
+	message, err := client.GetStudent(context.Background(), &studentpb.GetStudentRequest{Id: student.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "Hasan", message.Name)
+	assert.Equal(t, "enrolled", message.Status)
+	assert.Empty(t, message.Email)
+	assert.Empty(t, message.Phone)
+}
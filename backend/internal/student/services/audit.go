@@ -0,0 +1,75 @@
+package services
+
+import (
+	"backend/internal/audit"
+	"backend/internal/auth"
+	"backend/internal/student/models"
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// History lists the audit entries of one student, newest first. It keeps working
+// after the student is deleted or purged.
+func (s *StudentService) History(ctx context.Context, id uuid.UUID, page int, pageSize int) (models.AuditResponse, error) {
+	return s.Audit(ctx, audit.Filter{EntityType: "student", EntityID: id.String()}, page, pageSize)
+}
+
+// Audit lists the audit entries matching filter, newest first.
+func (s *StudentService) Audit(ctx context.Context, filter audit.Filter, page int, pageSize int) (models.AuditResponse, error) {
+	if err := auth.Authorize(ctx, auth.PermAuditRead); err != nil {
+		return models.AuditResponse{}, err
+	}
+	if page <= 0 || pageSize <= 0 {
+		return models.AuditResponse{}, models.ErrInvalidPage
+	}
+	entries, err := s.repository.GetAudit(filter, page, pageSize)
+	if err != nil {
+		return models.AuditResponse{}, err
+	}
+
+	total, err := s.repository.TotalAuditCount(filter)
+	if err != nil {
+		return models.AuditResponse{}, err
+	}
+
+	for i := range entries {
+		if err := redactEntry(ctx, &entries[i]); err != nil {
+			return models.AuditResponse{}, err
+		}
+	}
+
+	response := models.AuditResponse{
+		Entries: entries,
+		Page:    paginationResponse(nil, page, pageSize, total).Page,
+	}
+	if response.Entries == nil {
+		response.Entries = []audit.Entry{}
+	}
+	return response, nil
+}
+
+// redactEntry hides the fields of the snapshots of a student entry that the
+// principal may not read, as Get does for the student itself.
+func redactEntry(ctx context.Context, entry *audit.Entry) error {
+	if entry.EntityType != "student" {
+		return nil
+	}
+	for _, snapshot := range []*audit.Snapshot{&entry.Before, &entry.After} {
+		if len(*snapshot) == 0 {
+			continue
+		}
+		var student models.Student
+		if err := json.Unmarshal(*snapshot, &student); err != nil {
+			return err
+		}
+		auth.Redact(ctx, &student)
+		data, err := json.Marshal(&student)
+		if err != nil {
+			return err
+		}
+		*snapshot = data
+	}
+	return nil
+}
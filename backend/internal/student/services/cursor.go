@@ -0,0 +1,76 @@
+package services
+
+import (
+	"backend/internal/student/models"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// cursor is the payload of an opaque page cursor. Sort binds it to the order it
+// was created for, so it cannot be replayed against a different sort.
+type cursor struct {
+	Sort     string   `json:"s"`
+	Values   []string `json:"v"`
+	Backward bool     `json:"b,omitempty"`
+}
+
+func randomSecret() []byte {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic(err)
+	}
+	return secret
+}
+
+// keysetAfter returns the keyset that continues query after student.
+func keysetAfter(query models.StudentQuery, student *models.Student) *models.Keyset {
+	keyset := &models.Keyset{}
+	for _, key := range query.Sort {
+		keyset.Values = append(keyset.Values, student.Field(key.Field))
+	}
+	return keyset
+}
+
+// encodeCursor returns base64url(payload) "." base64url(HMAC-SHA256(payload)).
+func encodeCursor(secret []byte, query models.StudentQuery, student *models.Student, backward bool) string {
+	c := cursor{Sort: query.SortString(), Values: keysetAfter(query, student).Values, Backward: backward}
+	payload, _ := json.Marshal(c)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func decodeCursor(secret []byte, query models.StudentQuery, encoded string) (*models.Keyset, error) {
+	encodedPayload, encodedSignature, found := strings.Cut(encoded, ".")
+	if !found {
+		return nil, models.ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, models.ErrInvalidCursor
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return nil, models.ErrInvalidCursor
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, models.ErrInvalidCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return nil, models.ErrInvalidCursor
+	}
+	if c.Sort != query.SortString() || len(c.Values) != len(query.Sort) {
+		return nil, models.ErrInvalidCursor
+	}
+	return &models.Keyset{Values: c.Values, Backward: c.Backward}, nil
+}
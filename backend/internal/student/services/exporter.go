@@ -0,0 +1,202 @@
+package services
+
+import (
+	"backend/internal/auth"
+	"backend/internal/student/models"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// exportChunkSize is the number of students read from the repository at a time.
+const exportChunkSize = 500
+
+// exportHeader names the columns of the CSV and XLSX exports, which Import
+// reads back. The personal details the principal may not read are left empty.
+var exportHeader = []string{"id", "studentNumber", "name", "surname", "status", "enrollmentDate", "dateOfBirth", "email", "phone"}
+
+// exportRow is the row of student under exportHeader.
+func exportRow(student *models.Student) []string {
+	return []string{student.ID, student.StudentNumber, student.Name, student.Surname, string(student.Status),
+		student.EnrollmentDate, student.DateOfBirth, student.Email, student.Phone}
+}
+
+type exporter interface {
+	Write(student *models.Student) error
+	// Flush pushes buffered rows to the underlying writer between chunks.
+	Flush() error
+	Close() error
+}
+
+// Export streams every student matching query to w. Students are read in chunks
+// with keyset pagination, so memory use does not grow with the table.
+func (s *StudentService) Export(ctx context.Context, query models.StudentQuery, format models.FileFormat, w io.Writer) error {
+	if err := auth.Authorize(ctx, auth.PermStudentsRead); err != nil {
+		return err
+	}
+	if len(query.Sort) == 0 {
+		query.Sort = models.DefaultStudentQuery().Sort
+	}
+	out, err := newExporter(format, w)
+	if err != nil {
+		return err
+	}
+
+	var keyset *models.Keyset
+	for {
+		students, err := s.repository.GetAllByKeyset(query, keyset, exportChunkSize)
+		if err != nil {
+			return err
+		}
+		for i := range students {
+			// Redact a copy, the original is needed for the next keyset.
+			student := students[i]
+			auth.Redact(ctx, &student)
+			if err := out.Write(&student); err != nil {
+				return err
+			}
+		}
+		if len(students) < exportChunkSize {
+			break
+		}
+		if err := out.Flush(); err != nil {
+			return err
+		}
+		keyset = keysetAfter(query, &students[len(students)-1])
+	}
+	return out.Close()
+}
+
+func newExporter(format models.FileFormat, w io.Writer) (exporter, error) {
+	switch format {
+	case models.FormatCSV:
+		out := &csvExporter{writer: csv.NewWriter(w), flusher: w}
+		return out, out.writer.Write(exportHeader)
+	case models.FormatNDJSON:
+		return &ndjsonExporter{encoder: json.NewEncoder(w), flusher: w}, nil
+	case models.FormatXLSX:
+		return newXLSXExporter(w)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// flush flushes w when it is an http.ResponseWriter or similar.
+func flush(w io.Writer) {
+	if flusher, ok := w.(interface{ Flush() }); ok {
+		flusher.Flush()
+	}
+}
+
+type csvExporter struct {
+	writer  *csv.Writer
+	flusher io.Writer
+}
+
+func (e *csvExporter) Write(student *models.Student) error {
+	return e.writer.Write(exportRow(student))
+}
+
+func (e *csvExporter) Flush() error {
+	e.writer.Flush()
+	flush(e.flusher)
+	return e.writer.Error()
+}
+
+func (e *csvExporter) Close() error {
+	return e.Flush()
+}
+
+type ndjsonExporter struct {
+	encoder *json.Encoder
+	flusher io.Writer
+}
+
+func (e *ndjsonExporter) Write(student *models.Student) error {
+	return e.encoder.Encode(student)
+}
+
+func (e *ndjsonExporter) Flush() error {
+	flush(e.flusher)
+	return nil
+}
+
+func (e *ndjsonExporter) Close() error {
+	return e.Flush()
+}
+
+// xlsxExporter uses excelize's stream writer, which spills rows to a temporary
+// file instead of keeping the sheet in memory. The workbook is written on Close.
+// A sheet holds excelize.TotalRows rows, so the students past them go on to a
+// new sheet with a header of its own.
+type xlsxExporter struct {
+	workbook *excelize.File
+	stream   *excelize.StreamWriter
+	w        io.Writer
+	sheets   int
+	row      int
+}
+
+func newXLSXExporter(w io.Writer) (*xlsxExporter, error) {
+	e := &xlsxExporter{workbook: excelize.NewFile(), w: w}
+	return e, e.newSheet()
+}
+
+// newSheet finishes the current sheet and starts the next, named Sheet1,
+// Sheet2 and so on, with the header.
+func (e *xlsxExporter) newSheet() error {
+	e.sheets++
+	name := fmt.Sprintf("Sheet%d", e.sheets)
+	if e.stream != nil {
+		if err := e.stream.Flush(); err != nil {
+			return err
+		}
+		if _, err := e.workbook.NewSheet(name); err != nil {
+			return err
+		}
+	}
+	stream, err := e.workbook.NewStreamWriter(name)
+	if err != nil {
+		return err
+	}
+	e.stream, e.row = stream, 1
+	return e.writeRow(exportHeader)
+}
+
+func (e *xlsxExporter) writeRow(row []string) error {
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+	e.row++
+	values := make([]interface{}, len(row))
+	for i, value := range row {
+		values[i] = value
+	}
+	return e.stream.SetRow(cell, values)
+}
+
+func (e *xlsxExporter) Write(student *models.Student) error {
+	if e.row > excelize.TotalRows {
+		if err := e.newSheet(); err != nil {
+			return err
+		}
+	}
+	return e.writeRow(exportRow(student))
+}
+
+func (e *xlsxExporter) Flush() error {
+	return nil
+}
+
+func (e *xlsxExporter) Close() error {
+	defer e.workbook.Close()
+	if err := e.stream.Flush(); err != nil {
+		return err
+	}
+	return e.workbook.Write(e.w)
+}
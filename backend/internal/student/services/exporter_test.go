@@ -0,0 +1,182 @@
+package services
+
+import (
+	"backend/internal/auth"
+	"backend/internal/auth/authtest"
+	"backend/internal/student/mocks"
+	"backend/internal/student/models"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := Service(repo)
+
+	query := models.DefaultStudentQuery()
+	students := []models.Student{
+		{ID: "1c4f0e9f-5a66-493d-84d4-400e7a7175a1", StudentNumber: "2024-000001", Name: "Ahmet", Surname: "Talha", Status: models.StatusEnrolled,
+			EnrollmentDate: "2024-09-01", DateOfBirth: "2006-05-14", Email: "ahmet@example.com", Phone: "+905551112233"},
+		{ID: "6a6dbce8-ca2a-4473-ae71-b342d7b13545", Name: "matrak", Surname: "efe, jr"},
+	}
+
+	t.Run("Export CSV", func(t *testing.T) {
+		repo.EXPECT().GetAllByKeyset(query, nil, exportChunkSize).Return(students, nil)
+
+		var out bytes.Buffer
+		err := service.Export(authtest.AdminContext, query, models.FormatCSV, &out)
+		assert.NoError(t, err)
+		assert.Equal(t, "id,studentNumber,name,surname,status,enrollmentDate,dateOfBirth,email,phone\n"+
+			"1c4f0e9f-5a66-493d-84d4-400e7a7175a1,2024-000001,Ahmet,Talha,enrolled,2024-09-01,2006-05-14,ahmet@example.com,+905551112233\n"+
+			"6a6dbce8-ca2a-4473-ae71-b342d7b13545,,matrak,\"efe, jr\",,,,,\n", out.String())
+	})
+
+	t.Run("Export NDJSON", func(t *testing.T) {
+		repo.EXPECT().GetAllByKeyset(query, nil, exportChunkSize).Return(students, nil)
+
+		var out bytes.Buffer
+		err := service.Export(authtest.AdminContext, query, models.FormatNDJSON, &out)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"id":"1c4f0e9f-5a66-493d-84d4-400e7a7175a1","studentNumber":"2024-000001","name":"Ahmet","surname":"Talha","status":"enrolled",`+
+			`"enrollmentDate":"2024-09-01","dateOfBirth":"2006-05-14","email":"ahmet@example.com","phone":"+905551112233"}`+"\n"+
+			`{"id":"6a6dbce8-ca2a-4473-ae71-b342d7b13545","name":"matrak","surname":"efe, jr"}`+"\n", out.String())
+	})
+
+	t.Run("Export XLSX", func(t *testing.T) {
+		repo.EXPECT().GetAllByKeyset(query, nil, exportChunkSize).Return(students, nil)
+
+		var out bytes.Buffer
+		err := service.Export(authtest.AdminContext, query, models.FormatXLSX, &out)
+		assert.NoError(t, err)
+
+		workbook, err := excelize.OpenReader(&out)
+		assert.NoError(t, err)
+		rows, err := workbook.GetRows("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{
+			exportHeader,
+			{students[0].ID, "2024-000001", "Ahmet", "Talha", "enrolled", "2024-09-01", "2006-05-14", "ahmet@example.com", "+905551112233"},
+			{students[1].ID, "", "matrak", "efe, jr"},
+		}, rows)
+	})
+
+	t.Run("Export XLSX Sheets", func(t *testing.T) {
+		var out bytes.Buffer
+		e, err := newXLSXExporter(&out)
+		assert.NoError(t, err)
+		// Skip to the last row of the first sheet.
+		e.row = excelize.TotalRows
+		for i := range students {
+			assert.NoError(t, e.Write(&students[i]))
+		}
+		assert.NoError(t, e.Close())
+
+		workbook, err := excelize.OpenReader(&out)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Sheet1", "Sheet2"}, workbook.GetSheetList())
+		last, err := excelize.CoordinatesToCellName(1, excelize.TotalRows)
+		assert.NoError(t, err)
+		id, err := workbook.GetCellValue("Sheet1", last)
+		assert.NoError(t, err)
+		assert.Equal(t, students[0].ID, id, "the first sheet is filled")
+		rows, err := workbook.GetRows("Sheet2")
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{
+			exportHeader,
+			{students[1].ID, "", "matrak", "efe, jr"},
+		}, rows, "the rest goes on a new sheet with a header")
+	})
+
+	t.Run("Export Redacted", func(t *testing.T) {
+		repo.EXPECT().GetAllByKeyset(query, nil, exportChunkSize).Return(students[:1], nil)
+
+		teacher := auth.WithPrincipal(context.Background(), &auth.Principal{Username: "teacher", Permissions: []auth.Permission{auth.PermStudentsRead}})
+		var out bytes.Buffer
+		err := service.Export(teacher, query, models.FormatCSV, &out)
+		assert.NoError(t, err)
+		assert.Equal(t, "id,studentNumber,name,surname,status,enrollmentDate,dateOfBirth,email,phone\n"+
+			"1c4f0e9f-5a66-493d-84d4-400e7a7175a1,2024-000001,Ahmet,Talha,enrolled,2024-09-01,,,\n", out.String())
+	})
+
+	t.Run("Export In Chunks", func(t *testing.T) {
+		var chunk []models.Student
+		for i := 0; i < exportChunkSize; i++ {
+			chunk = append(chunk, models.Student{ID: fmt.Sprintf("%08d-0000-0000-0000-000000000000", i), Name: "a", Surname: "b"})
+		}
+		last := chunk[len(chunk)-1]
+
+		first := repo.EXPECT().GetAllByKeyset(query, nil, exportChunkSize).Return(chunk, nil)
+		repo.EXPECT().GetAllByKeyset(query, &models.Keyset{Values: []string{last.ID}}, exportChunkSize).Return(students[:1], nil).After(first)
+
+		var out bytes.Buffer
+		err := service.Export(authtest.AdminContext, query, models.FormatCSV, &out)
+		assert.NoError(t, err)
+		assert.Equal(t, exportChunkSize+2, strings.Count(out.String(), "\n"))
+	})
+
+	t.Run("Export Fail", func(t *testing.T) {
+		repo.EXPECT().GetAllByKeyset(query, nil, exportChunkSize).Return(nil, errors.New("connection refused"))
+
+		var out bytes.Buffer
+		err := service.Export(authtest.AdminContext, query, models.FormatCSV, &out)
+		assert.EqualError(t, err, "connection refused")
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("Unsupported Format", func(t *testing.T) {
+		err := service.Export(authtest.AdminContext, query, "pdf", &bytes.Buffer{})
+		assert.EqualError(t, err, `unsupported export format "pdf"`)
+	})
+}
+
+func TestExportImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := Service(repo)
+
+	exported := []models.Student{
+		{ID: "1c4f0e9f-5a66-493d-84d4-400e7a7175a1", StudentNumber: "2024-000001", Name: "Ahmet", Surname: "Talha", Status: models.StatusEnrolled,
+			EnrollmentDate: "2024-09-01", DateOfBirth: "2006-05-14", Email: "ahmet@example.com", Phone: "+905551112233"},
+		{ID: "6a6dbce8-ca2a-4473-ae71-b342d7b13545", StudentNumber: "2025-000002", Name: "Matrak", Surname: "Efe", Status: models.StatusEnrolled,
+			EnrollmentDate: "2025-02-10"},
+	}
+
+	for _, format := range []models.FileFormat{models.FormatCSV, models.FormatXLSX} {
+		t.Run(string(format), func(t *testing.T) {
+			repo.EXPECT().GetAllByKeyset(gomock.Any(), nil, exportChunkSize).Return(exported, nil)
+			var file bytes.Buffer
+			assert.NoError(t, service.Export(authtest.AdminContext, models.DefaultStudentQuery(), format, &file))
+
+			var imported []models.Student
+			repo.EXPECT().AddBatch(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, students []models.Student) error {
+				imported = students
+				return nil
+			})
+			report, err := service.Import(authtest.AdminContext, &file, format, false)
+			assert.NoError(t, err)
+			assert.Equal(t, 2, report.Created)
+
+			assert.Len(t, imported, len(exported))
+			for i := range imported {
+				assert.NotEqual(t, exported[i].ID, imported[i].ID, "imported students are new")
+				assert.Empty(t, imported[i].StudentNumber, "the repository numbers imported students")
+				want := exported[i]
+				want.ID, want.StudentNumber = imported[i].ID, ""
+				assert.Equal(t, want, imported[i])
+			}
+		})
+	}
+}
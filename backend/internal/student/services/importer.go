@@ -0,0 +1,237 @@
+package services
+
+import (
+	"backend/internal/auth"
+	"backend/internal/problem"
+	"backend/internal/student/models"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"io"
+	"strings"
+)
+
+// importBatchSize is the number of students written per transaction.
+const importBatchSize = 100
+
+// columnAliases maps normalized header names to the student field they hold.
+// The headers of an export are among them, so an export can be imported again.
+var columnAliases = map[string]string{
+	"name":           "name",
+	"firstname":      "name",
+	"givenname":      "name",
+	"ad":             "name",
+	"surname":        "surname",
+	"lastname":       "surname",
+	"familyname":     "surname",
+	"soyad":          "surname",
+	"soyadı":         "surname",
+	"studentnumber":  "studentNumber",
+	"studentno":      "studentNumber",
+	"öğrencino":      "studentNumber",
+	"status":         "status",
+	"durum":          "status",
+	"enrollmentdate": "enrollmentDate",
+	"enrolled":       "enrollmentDate",
+	"kayıttarihi":    "enrollmentDate",
+	"dateofbirth":    "dateOfBirth",
+	"birthdate":      "dateOfBirth",
+	"dob":            "dateOfBirth",
+	"doğumtarihi":    "dateOfBirth",
+	"email":          "email",
+	"emailaddress":   "email",
+	"eposta":         "email",
+	"phone":          "phone",
+	"phonenumber":    "phone",
+	"telephone":      "phone",
+	"telefon":        "phone",
+}
+
+// Import reads students from a CSV or XLSX file whose first row is a header, validates
+// every row like Add does and inserts the valid ones in batched transactions. The rows
+// of a batch that fails are inserted one by one, so that only the failing rows fail.
+// With dryRun set the rows are only validated.
+func (s *StudentService) Import(ctx context.Context, file io.Reader, format models.FileFormat, dryRun bool) (*models.ImportReport, error) {
+	if err := auth.Authorize(ctx, auth.PermStudentsWrite); err != nil {
+		return nil, err
+	}
+	records, err := readRecords(file, format)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: the file is empty", models.ErrInvalidImport)
+	}
+	columns, err := mapColumns(records[0])
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.ImportReport{DryRun: dryRun, Rows: []models.ImportRow{}}
+	seen := map[string]int{}
+	var batch []models.Student
+	var batchRows []int
+
+	flush := func() {
+		if len(batch) == 0 || dryRun {
+			batch, batchRows = nil, nil
+			return
+		}
+		if err := s.repository.AddBatch(ctx, batch); err != nil {
+			// One row the database refuses, such as one with a taken email, fails
+			// the whole batch, so its rows are saved one by one to keep the others.
+			for j, i := range batchRows {
+				if err := s.repository.Add(ctx, &batch[j]); err != nil {
+					report.Rows[i] = models.ImportRow{Row: report.Rows[i].Row, Status: models.ImportFailed, Reason: saveFailure(err)}
+				}
+			}
+		}
+		batch, batchRows = nil, nil
+	}
+
+	for i, record := range records[1:] {
+		row := models.ImportRow{Row: i + 2}
+		// Like the body of Add, the row may carry a student number, which
+		// prepareNew drops: the repository assigns a new one.
+		student := models.Student{
+			StudentNumber:  cell(record, columns, "studentNumber"),
+			Name:           cell(record, columns, "name"),
+			Surname:        cell(record, columns, "surname"),
+			Status:         models.Status(cell(record, columns, "status")),
+			EnrollmentDate: cell(record, columns, "enrollmentDate"),
+			DateOfBirth:    cell(record, columns, "dateOfBirth"),
+			Email:          cell(record, columns, "email"),
+			Phone:          cell(record, columns, "phone"),
+		}
+		invalid := validateNew(&student)
+		// Namesakes are common, so only a row with the email of an earlier row
+		// is taken for a duplicate of it.
+		key := strings.ToLower(student.Email)
+
+		switch previous, duplicate := seen[key]; {
+		case isBlank(record):
+			row.Status, row.Reason = models.ImportSkipped, "empty row"
+		case invalid != nil:
+			row.Status, row.Reason = models.ImportFailed, invalid.Error()
+		case key != "" && duplicate:
+			row.Status, row.Reason = models.ImportSkipped, fmt.Sprintf("duplicate of row %d", previous)
+		default:
+			seen[key] = row.Row
+			prepareNew(&student)
+			row.Status, row.StudentID = models.ImportCreated, student.ID
+			batch = append(batch, student)
+			batchRows = append(batchRows, len(report.Rows))
+		}
+		report.Rows = append(report.Rows, row)
+
+		if len(batch) == importBatchSize {
+			flush()
+		}
+	}
+	flush()
+
+	for _, row := range report.Rows {
+		switch row.Status {
+		case models.ImportCreated:
+			report.Created++
+		case models.ImportSkipped:
+			report.Skipped++
+		case models.ImportFailed:
+			report.Failed++
+		}
+	}
+	return report, nil
+}
+
+// saveFailure is the reason a row could not be saved, as the report shows it.
+// Conflicts, such as a taken email, and invalid students are described; other
+// errors come from the database and only say that saving failed.
+func saveFailure(err error) string {
+	var conflict *problem.ConflictError
+	if errors.As(err, &conflict) {
+		return "failed to save: " + conflict.Error()
+	}
+	var invalid *problem.ValidationError
+	if errors.As(err, &invalid) {
+		return "failed to save: " + invalid.Error()
+	}
+	if errors.Is(err, problem.ErrUnavailable) {
+		return "failed to save: the database is unavailable"
+	}
+	return "failed to save"
+}
+
+func readRecords(file io.Reader, format models.FileFormat) ([][]string, error) {
+	switch format {
+	case models.FormatCSV:
+		reader := csv.NewReader(file)
+		reader.FieldsPerRecord = -1
+		records, err := reader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidImport, err)
+		}
+		if len(records) > 0 && len(records[0]) > 0 {
+			// Spreadsheet programs often start CSV exports with a byte order mark.
+			records[0][0] = strings.TrimPrefix(records[0][0], "\ufeff")
+		}
+		return records, nil
+	case models.FormatXLSX:
+		workbook, err := excelize.OpenReader(file)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidImport, err)
+		}
+		defer workbook.Close()
+		sheets := workbook.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, fmt.Errorf("%w: the workbook has no sheets", models.ErrInvalidImport)
+		}
+		records, err := workbook.GetRows(sheets[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidImport, err)
+		}
+		return records, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", models.ErrInvalidImport, format)
+	}
+}
+
+// mapColumns returns the index of the column of each field in header. The name
+// and surname columns are required, the others optional.
+func mapColumns(header []string) (map[string]int, error) {
+	columns := map[string]int{}
+	for i, title := range header {
+		normalized := strings.ToLower(strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.TrimSpace(title)))
+		if field, ok := columnAliases[normalized]; ok {
+			if _, dup := columns[field]; !dup {
+				columns[field] = i
+			}
+		}
+	}
+	for _, field := range []string{"name", "surname"} {
+		if _, ok := columns[field]; !ok {
+			return nil, fmt.Errorf("%w: no %s column in the header", models.ErrInvalidImport, field)
+		}
+	}
+	return columns, nil
+}
+
+// cell returns the value of field in record, or "" when the file has no column
+// for it or the row is short.
+func cell(record []string, columns map[string]int, field string) string {
+	index, ok := columns[field]
+	if !ok || index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+func isBlank(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
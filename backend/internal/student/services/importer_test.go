@@ -0,0 +1,187 @@
+package services
+
+import (
+	"backend/internal/auth/authtest"
+	"backend/internal/problem"
+	"backend/internal/student/mocks"
+	"backend/internal/student/models"
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := Service(repo)
+
+	csvFile := "\ufeffFirst Name,Last Name,Email\n" +
+		"hasan,huseyin,hasan@example.com\n" +
+		",,\n" +
+		"ahmet,,ahmet@example.com\n" +
+		"  Hasan , Huseyinoglu ,HASAN@example.com\n" +
+		"matrak,efe\n" +
+		"matrak,efe\n"
+
+	statuses := func(report *models.ImportReport) []models.ImportStatus {
+		var result []models.ImportStatus
+		for _, row := range report.Rows {
+			result = append(result, row.Status)
+		}
+		return result
+	}
+
+	t.Run("Import CSV", func(t *testing.T) {
+		var saved []models.Student
+		repo.EXPECT().AddBatch(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, students []models.Student) error {
+			saved = students
+			return nil
+		}).Times(1)
+
+		report, err := service.Import(authtest.AdminContext, strings.NewReader(csvFile), models.FormatCSV, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []models.ImportStatus{
+			models.ImportCreated,
+			models.ImportSkipped,
+			models.ImportFailed,
+			models.ImportSkipped,
+			models.ImportCreated,
+			models.ImportCreated,
+		}, statuses(report))
+		assert.Equal(t, 3, report.Created)
+		assert.Equal(t, 2, report.Skipped)
+		assert.Equal(t, 1, report.Failed)
+
+		assert.Equal(t, models.ImportRow{Row: 3, Status: models.ImportSkipped, Reason: "empty row"}, report.Rows[1])
+		assert.Equal(t, models.ImportRow{Row: 4, Status: models.ImportFailed, Reason: "surname: is required"}, report.Rows[2])
+		assert.Equal(t, models.ImportRow{Row: 5, Status: models.ImportSkipped, Reason: "duplicate of row 2"}, report.Rows[3])
+
+		assert.Len(t, saved, 3, "namesakes without an email are not duplicates")
+		assert.Equal(t, "Hasan", saved[0].Name, "names are normalized like Add does")
+		assert.Equal(t, report.Rows[0].StudentID, saved[0].ID)
+		assert.Equal(t, report.Rows[4].StudentID, saved[1].ID)
+		assert.Equal(t, report.Rows[5].StudentID, saved[2].ID)
+	})
+
+	t.Run("Dry Run", func(t *testing.T) {
+		repo.EXPECT().AddBatch(gomock.Any(), gomock.Any()).Times(0)
+
+		report, err := service.Import(authtest.AdminContext, strings.NewReader(csvFile), models.FormatCSV, true)
+		assert.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, 3, report.Created)
+		assert.Equal(t, 1, report.Failed)
+	})
+
+	t.Run("Batches", func(t *testing.T) {
+		var buf strings.Builder
+		buf.WriteString("name,surname\n")
+		for i := 0; i < importBatchSize+1; i++ {
+			buf.WriteString("student" + strings.Repeat("y", i/90) + ",number" + strings.Repeat("x", i%90) + "\n")
+		}
+
+		first := repo.EXPECT().AddBatch(gomock.Any(), gomock.Len(importBatchSize)).Return(nil)
+		second := repo.EXPECT().AddBatch(gomock.Any(), gomock.Len(1)).Return(errors.New("connection refused")).After(first)
+		repo.EXPECT().Add(gomock.Any(), gomock.Any()).Return(errors.New("connection refused")).After(second)
+
+		report, err := service.Import(authtest.AdminContext, strings.NewReader(buf.String()), models.FormatCSV, false)
+		assert.NoError(t, err)
+		assert.Equal(t, importBatchSize, report.Created)
+		assert.Equal(t, 1, report.Failed)
+		assert.Equal(t, models.ImportRow{Row: importBatchSize + 2, Status: models.ImportFailed, Reason: "failed to save"}, report.Rows[importBatchSize], "database errors are not shown")
+	})
+
+	t.Run("Failed Batch", func(t *testing.T) {
+		repo.EXPECT().AddBatch(gomock.Any(), gomock.Len(3)).Return(models.ErrEmailTaken)
+		repo.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, student *models.Student) error {
+			if student.Name == "Ahmet" {
+				return models.ErrEmailTaken
+			}
+			return nil
+		}).Times(3)
+
+		report, err := service.Import(authtest.AdminContext, strings.NewReader("name,surname\nhasan,huseyin\nahmet,ceylan\nmatrak,efe\n"), models.FormatCSV, false)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, report.Created, "one failing row does not fail its batch")
+		assert.Equal(t, 1, report.Failed)
+		assert.Equal(t, models.ImportRow{Row: 3, Status: models.ImportFailed, Reason: "failed to save: " + models.ErrEmailTaken.Detail}, report.Rows[1])
+		assert.Equal(t, models.ImportCreated, report.Rows[2].Status)
+	})
+
+	t.Run("Save Failures", func(t *testing.T) {
+		for err, reason := range map[error]string{
+			models.ErrEmailTaken: "failed to save: email is already used by another student",
+			&problem.UnavailableError{Err: errors.New("dial tcp 127.0.0.1:3306: connect: connection refused")}: "failed to save: the database is unavailable",
+			errors.New("Error 1146 (42S02): Table 'students.students' doesn't exist"):                          "failed to save",
+		} {
+			repo.EXPECT().AddBatch(gomock.Any(), gomock.Any()).Return(err)
+			repo.EXPECT().Add(gomock.Any(), gomock.Any()).Return(err)
+
+			report, importErr := service.Import(authtest.AdminContext, strings.NewReader("name,surname\nhasan,huseyin\n"), models.FormatCSV, false)
+			assert.NoError(t, importErr)
+			assert.Equal(t, reason, report.Rows[0].Reason)
+		}
+	})
+
+	t.Run("Profiles", func(t *testing.T) {
+		file := "Name,Surname,Status,Email,Date of Birth,Phone,Enrollment Date\n" +
+			"hasan,huseyin,applicant,hasan@example.com,2006-05-14,+905551112233,2024-09-01\n" +
+			"ahmet,talha,graduated,,,,\n" +
+			"matrak,efe,,not an email,,,\n" +
+			"ali,veli,,,,,\n"
+		repo.EXPECT().AddBatch(gomock.Any(), gomock.Len(2)).DoAndReturn(func(_ context.Context, students []models.Student) error {
+			assert.Equal(t, models.Student{ID: students[0].ID, Name: "Hasan", Surname: "Huseyin", Status: models.StatusApplicant,
+				EnrollmentDate: "2024-09-01", DateOfBirth: "2006-05-14", Email: "hasan@example.com", Phone: "+905551112233"}, students[0])
+			assert.Equal(t, models.StatusEnrolled, students[1].Status, "the defaults of Add apply")
+			assert.NotEmpty(t, students[1].EnrollmentDate)
+			return nil
+		})
+
+		report, err := service.Import(authtest.AdminContext, strings.NewReader(file), models.FormatCSV, false)
+		assert.NoError(t, err)
+		assert.Equal(t, models.ImportRow{Row: 3, Status: models.ImportFailed, Reason: models.ErrInitialStatus.Error()}, report.Rows[1], "students start out like they do in Add")
+		assert.Equal(t, models.ImportFailed, report.Rows[2].Status)
+		assert.Contains(t, report.Rows[2].Reason, "email")
+	})
+
+	t.Run("Import XLSX", func(t *testing.T) {
+		workbook := excelize.NewFile()
+		workbook.SetSheetRow("Sheet1", "A1", &[]interface{}{"Soyad", "Ad"})
+		workbook.SetSheetRow("Sheet1", "A2", &[]interface{}{"huseyin", "hasan"})
+		var file bytes.Buffer
+		assert.NoError(t, workbook.Write(&file))
+
+		repo.EXPECT().AddBatch(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, students []models.Student) error {
+			assert.Equal(t, "Hasan", students[0].Name)
+			assert.Equal(t, "Huseyin", students[0].Surname)
+			return nil
+		})
+
+		report, err := service.Import(authtest.AdminContext, &file, models.FormatXLSX, false)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, report.Created)
+	})
+
+	t.Run("Invalid Files", func(t *testing.T) {
+		_, err := service.Import(authtest.AdminContext, strings.NewReader("email\nhasan@example.com\n"), models.FormatCSV, false)
+		assert.ErrorIs(t, err, models.ErrInvalidImport)
+		assert.EqualError(t, err, "invalid import file: no name column in the header")
+
+		_, err = service.Import(authtest.AdminContext, strings.NewReader(""), models.FormatCSV, false)
+		assert.EqualError(t, err, "invalid import file: the file is empty")
+
+		_, err = service.Import(authtest.AdminContext, strings.NewReader("not a workbook"), models.FormatXLSX, false)
+		assert.ErrorIs(t, err, models.ErrInvalidImport)
+
+		_, err = service.Import(authtest.AdminContext, strings.NewReader(""), "", false)
+		assert.EqualError(t, err, `invalid import file: unsupported format ""`)
+	})
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"backend/internal/student/models"
+	"encoding/json"
+)
+
+// mergePatch applies patch to student following RFC 7386: object members are merged
+// recursively, null removes a member and any other value replaces it.
+func mergePatch(student *models.Student, patch interface{}) (*models.Student, error) {
+	raw, err := json.Marshal(student)
+	if err != nil {
+		return nil, err
+	}
+	var target interface{}
+	if err := json.Unmarshal(raw, &target); err != nil {
+		return nil, err
+	}
+
+	merged, err := json.Marshal(mergeValue(target, patch))
+	if err != nil {
+		return nil, err
+	}
+	var patched models.Student
+	if err := json.Unmarshal(merged, &patched); err != nil {
+		return nil, models.ErrInvalidPatch
+	}
+	return &patched, nil
+}
+
+func mergeValue(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergeValue(targetObject[key], value)
+	}
+	return targetObject
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"backend/internal/audit"
+	"backend/internal/auth"
+	"context"
+	"log"
+	"time"
+)
+
+// Purge permanently removes students that were soft deleted before the given time.
+func (s *StudentService) Purge(ctx context.Context, before time.Time) (int64, error) {
+	if err := auth.Authorize(ctx, auth.PermStudentsDelete); err != nil {
+		return 0, err
+	}
+	return s.repository.Purge(ctx, before)
+}
+
+// RunPurger purges students deleted longer than retention ago every interval until
+// ctx is done. Purges are audited as the system actor.
+func (s *StudentService) RunPurger(ctx context.Context, retention time.Duration, interval time.Duration) {
+	ctx = audit.WithActor(auth.WithPrincipal(ctx, auth.SystemPrincipal()), audit.SystemActor)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := s.Purge(ctx, time.Now().Add(-retention))
+		if err != nil {
+			log.Println("failed to purge deleted students:", err)
+		} else if purged > 0 {
+			log.Printf("purged %d deleted students", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
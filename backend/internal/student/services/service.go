@@ -1,39 +1,82 @@
 package services
 
 import (
+	"backend/internal/audit"
+	"backend/internal/auth"
 	"backend/internal/student/models"
-	"errors"
+	"backend/internal/validation"
+	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Repository interface {
 	Get(id uuid.UUID) (*models.Student, error)
-	Add(student *models.Student) error
-	Delete(id uuid.UUID) error
-	GetAll(page int, pageSize int) ([]models.Student, error)
-	TotalStudentCount() (int64, error)
+	GetByIDs(ids []uuid.UUID) ([]models.Student, error)
+	Add(ctx context.Context, student *models.Student) error
+	AddBatch(ctx context.Context, students []models.Student) error
+	Update(ctx context.Context, student *models.Student) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) error
+	GetDeleted(page int, pageSize int) ([]models.Student, error)
+	TotalDeletedCount() (int64, error)
+	Purge(ctx context.Context, before time.Time) (int64, error)
+	GetAll(query models.StudentQuery, page int, pageSize int) ([]models.Student, error)
+	GetAllByKeyset(query models.StudentQuery, keyset *models.Keyset, limit int) ([]models.Student, error)
+	TotalStudentCount(query models.StudentQuery) (int64, error)
+	GetAudit(filter audit.Filter, page int, pageSize int) ([]audit.Entry, error)
+	TotalAuditCount(filter audit.Filter) (int64, error)
+	Transition(ctx context.Context, transition *models.Transition) error
+	GetTransitions(studentID uuid.UUID) ([]models.Transition, error)
 }
 
 type StudentService struct {
 	repository Repository
+	// CursorSecret signs page cursors. It defaults to a random secret, so cursors
+	// only stay valid across restarts and replicas when it is configured.
+	CursorSecret []byte
+	// guards holds the guards of the transitions to each status.
+	guards map[models.Status][]Guard
 }
 
 func Service(repository Repository) *StudentService {
-	return &StudentService{repository: repository}
+	return &StudentService{repository: repository, CursorSecret: randomSecret(), guards: map[models.Status][]Guard{}}
 }
 
-func (s *StudentService) Get(id uuid.UUID) (*models.Student, error) {
+func (s *StudentService) Get(ctx context.Context, id uuid.UUID) (*models.Student, error) {
+	if err := auth.Authorize(ctx, auth.PermStudentsRead); err != nil {
+		return nil, err
+	}
 	student, err := s.repository.Get(id)
 
 	if err != nil {
 		return nil, err
 	}
+	auth.Redact(ctx, student)
 	return student, nil
 }
 
-func (s *StudentService) Delete(id uuid.UUID) error {
-	err := s.repository.Delete(id)
+// GetByIDs returns the students with the given IDs in one query, in no
+// particular order. IDs of missing or deleted students are left out.
+func (s *StudentService) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Student, error) {
+	if err := auth.Authorize(ctx, auth.PermStudentsRead); err != nil {
+		return nil, err
+	}
+	students, err := s.repository.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	auth.Redact(ctx, students)
+	return students, nil
+}
+
+func (s *StudentService) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := auth.Authorize(ctx, auth.PermStudentsDelete); err != nil {
+		return err
+	}
+	err := s.repository.Delete(ctx, id)
 
 	if err != nil {
 		return err
@@ -41,34 +84,135 @@ func (s *StudentService) Delete(id uuid.UUID) error {
 	return nil
 }
 
-func (s *StudentService) Add(student *models.Student) error {
-	if student.Name == "" || student.Surname == "" {
-		return errors.New("name and surname are required") //bunlari sanirim controller'a almaliyim??
+// Restore brings back a soft deleted student.
+func (s *StudentService) Restore(ctx context.Context, id uuid.UUID) (*models.Student, error) {
+	if err := auth.Authorize(ctx, auth.PermStudentsDelete); err != nil {
+		return nil, err
+	}
+	err := s.repository.Restore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, id)
+}
+
+// GetDeleted lists soft deleted students that have not been purged yet.
+func (s *StudentService) GetDeleted(ctx context.Context, page int, pageSize int) (models.PaginationResponse, error) {
+	if err := auth.Authorize(ctx, auth.PermStudentsDelete); err != nil {
+		return models.PaginationResponse{}, err
+	}
+	if page <= 0 || pageSize <= 0 {
+		return models.PaginationResponse{}, models.ErrInvalidPage
+	}
+	students, err := s.repository.GetDeleted(page, pageSize)
+	if err != nil {
+		return models.PaginationResponse{}, err
 	}
-	uID := uuid.New()
-	student.ID = uID.String()
 
-	err := s.repository.Add(student)
+	totalStudents, err := s.repository.TotalDeletedCount()
+	if err != nil {
+		return models.PaginationResponse{}, err
+	}
+	auth.Redact(ctx, students)
+	return paginationResponse(students, page, pageSize, totalStudents), nil
+}
+
+func (s *StudentService) Add(ctx context.Context, student *models.Student) error {
+	if err := auth.Authorize(ctx, auth.PermStudentsWrite); err != nil {
+		return err
+	}
+	if err := validateNew(student); err != nil {
+		return err
+	}
+	prepareNew(student)
+
+	err := s.repository.Add(ctx, student)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (s *StudentService) GetAll(page int, pageSize int) (models.PaginationResponse, error) {
+// Update replaces every field of the student with the given id but the number
+// and the status, which only Transition changes.
+func (s *StudentService) Update(ctx context.Context, id uuid.UUID, student *models.Student) error {
+	if err := auth.Authorize(ctx, auth.PermStudentsWrite); err != nil {
+		return err
+	}
+	if err := validate(student); err != nil {
+		return err
+	}
+	if student.Status != "" {
+		current, err := s.repository.Get(id)
+		if err != nil {
+			return err
+		}
+		if student.Status != current.Status {
+			return models.ErrStatusReadOnly
+		}
+	}
+	student.ID = id.String()
+
+	err := s.repository.Update(ctx, student)
+	if err != nil {
+		return err
+	}
+	auth.Redact(ctx, student)
+	return nil
+}
+
+// Patch applies a JSON Merge Patch (RFC 7386) document to the student with the given id.
+func (s *StudentService) Patch(ctx context.Context, id uuid.UUID, patch []byte) (*models.Student, error) {
+	if err := auth.Authorize(ctx, auth.PermStudentsWrite); err != nil {
+		return nil, err
+	}
+	var patchDoc interface{}
+	if err := json.Unmarshal(patch, &patchDoc); err != nil {
+		return nil, models.ErrInvalidPatch
+	}
+	if _, ok := patchDoc.(map[string]interface{}); !ok {
+		return nil, models.ErrInvalidPatch
+	}
+
+	student, err := s.repository.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	patched, err := mergePatch(student, patchDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Update(ctx, id, patched)
+	if err != nil {
+		return nil, err
+	}
+	return patched, nil
+}
+
+func (s *StudentService) GetAll(ctx context.Context, query models.StudentQuery, page int, pageSize int) (models.PaginationResponse, error) {
+	if err := auth.Authorize(ctx, auth.PermStudentsRead); err != nil {
+		return models.PaginationResponse{}, err
+	}
 	if page <= 0 || pageSize <= 0 {
-		return models.PaginationResponse{}, errors.New("page and pagesize cannot be lower than 1") //bunlari sanirim controller'a almaliyim??
+		return models.PaginationResponse{}, models.ErrInvalidPage
 	}
-	students, err := s.repository.GetAll(page, pageSize)
+	students, err := s.repository.GetAll(query, page, pageSize)
 	if err != nil {
 		return models.PaginationResponse{}, err
 	}
 
-	totalStudents, err := s.repository.TotalStudentCount()
+	totalStudents, err := s.repository.TotalStudentCount(query)
 	if err != nil {
 		return models.PaginationResponse{}, err
 	}
 
+	auth.Redact(ctx, students)
+	return paginationResponse(students, page, pageSize, totalStudents), nil
+}
+
+func paginationResponse(students []models.Student, page int, pageSize int, totalStudents int64) models.PaginationResponse {
 	totalPages := (totalStudents + int64(pageSize) - 1) / int64(pageSize)
 
 	pageInfo := models.Page{
@@ -78,10 +222,182 @@ func (s *StudentService) GetAll(page int, pageSize int) (models.PaginationRespon
 		Pages:    int(totalPages),
 	}
 
-	response := models.PaginationResponse{
+	return models.PaginationResponse{
 		Students: students,
 		Page:     pageInfo,
 	}
+}
+
+// GetAllByCursor returns the page after (or before) cursor, starting from the first
+// page when cursor is empty. The total is only counted when withTotal is set.
+func (s *StudentService) GetAllByCursor(ctx context.Context, query models.StudentQuery, cursor string, pageSize int, withTotal bool) (models.CursorResponse, error) {
+	if err := auth.Authorize(ctx, auth.PermStudentsRead); err != nil {
+		return models.CursorResponse{}, err
+	}
+	if pageSize <= 0 {
+		return models.CursorResponse{}, models.ErrInvalidPage
+	}
+	if len(query.Sort) == 0 {
+		query.Sort = models.DefaultStudentQuery().Sort
+	}
 
+	var keyset *models.Keyset
+	if cursor != "" {
+		var err error
+		keyset, err = decodeCursor(s.CursorSecret, query, cursor)
+		if err != nil {
+			return models.CursorResponse{}, err
+		}
+	}
+	backward := keyset != nil && keyset.Backward
+
+	// One extra row tells whether there is another page in the direction of travel.
+	students, err := s.repository.GetAllByKeyset(query, keyset, pageSize+1)
+	if err != nil {
+		return models.CursorResponse{}, err
+	}
+	more := len(students) > pageSize
+	if more && backward {
+		students = students[1:]
+	} else if more {
+		students = students[:pageSize]
+	}
+
+	response := models.CursorResponse{Students: students}
+	if len(students) > 0 {
+		first, last := &students[0], &students[len(students)-1]
+		if more || backward {
+			response.Cursors.Next = encodeCursor(s.CursorSecret, query, last, false)
+		}
+		if (more && backward) || (keyset != nil && !backward) {
+			response.Cursors.Prev = encodeCursor(s.CursorSecret, query, first, true)
+		}
+	}
+
+	if withTotal {
+		total, err := s.repository.TotalStudentCount(query)
+		if err != nil {
+			return models.CursorResponse{}, err
+		}
+		response.Total = &total
+	}
+	auth.Redact(ctx, response.Students)
 	return response, nil
 }
+
+// Count returns the number of students matching query.
+func (s *StudentService) Count(ctx context.Context, query models.StudentQuery) (int64, error) {
+	if err := auth.Authorize(ctx, auth.PermStudentsRead); err != nil {
+		return 0, err
+	}
+	return s.repository.TotalStudentCount(query)
+}
+
+// GetConnection returns a page of a Relay connection over the students matching
+// query. Every edge has its own cursor, signed like those of GetAllByCursor, so
+// a client can resume after or before any student of the page.
+func (s *StudentService) GetConnection(ctx context.Context, query models.StudentQuery, args models.ConnectionArgs) (models.StudentConnection, error) {
+	if err := auth.Authorize(ctx, auth.PermStudentsRead); err != nil {
+		return models.StudentConnection{}, err
+	}
+	backward := args.Last > 0
+	if args.First < 0 || args.Last < 0 || (args.First > 0) == backward ||
+		(backward && args.After != "") || (!backward && args.Before != "") {
+		return models.StudentConnection{}, models.ErrInvalidPaging
+	}
+	if len(query.Sort) == 0 {
+		query.Sort = models.DefaultStudentQuery().Sort
+	}
+
+	limit, cursor := args.First, args.After
+	if backward {
+		limit, cursor = args.Last, args.Before
+	}
+	var keyset *models.Keyset
+	if cursor != "" {
+		var err error
+		keyset, err = decodeCursor(s.CursorSecret, query, cursor)
+		if err != nil {
+			return models.StudentConnection{}, err
+		}
+		keyset.Backward = backward
+	}
+
+	// One extra row tells whether there are more students in the direction of
+	// travel. The last students without a cursor are the first ones of the
+	// reversed order.
+	var students []models.Student
+	var err error
+	if backward && keyset == nil {
+		students, err = s.repository.GetAllByKeyset(query.Reversed(), nil, limit+1)
+		for i, j := 0, len(students)-1; i < j; i, j = i+1, j-1 {
+			students[i], students[j] = students[j], students[i]
+		}
+	} else {
+		students, err = s.repository.GetAllByKeyset(query, keyset, limit+1)
+	}
+	if err != nil {
+		return models.StudentConnection{}, err
+	}
+	more := len(students) > limit
+	if more && backward {
+		students = students[1:]
+	} else if more {
+		students = students[:limit]
+	}
+
+	connection := models.StudentConnection{
+		HasNextPage:     (!backward && more) || (backward && keyset != nil),
+		HasPreviousPage: (backward && more) || (!backward && keyset != nil),
+	}
+	for i := range students {
+		connection.Edges = append(connection.Edges, models.StudentEdge{Cursor: encodeCursor(s.CursorSecret, query, &students[i], false)})
+	}
+	auth.Redact(ctx, students)
+	for i := range students {
+		connection.Edges[i].Student = students[i]
+	}
+	return connection, nil
+}
+
+// validate normalizes student and checks it against the rules in its validate
+// tags. Every entry point that stores students goes through it.
+func validate(student *models.Student) error {
+	return validation.Validate(student)
+}
+
+// validateNew validates a student about to be added, which may only start in
+// one of the initial statuses.
+func validateNew(student *models.Student) error {
+	if err := validate(student); err != nil {
+		return err
+	}
+	if student.Status != "" && !containsStatus(models.InitialStatuses, student.Status) {
+		return models.ErrInitialStatus
+	}
+	return nil
+}
+
+func containsStatus(statuses []models.Status, status models.Status) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// prepareNew gives a validated student about to be added its ID and the
+// defaults of the fields left empty. The repository assigns the student number,
+// and a new student is never deleted, whatever the request says.
+func prepareNew(student *models.Student) {
+	student.ID = uuid.New().String()
+	student.StudentNumber = ""
+	student.DeletedAt = nil
+	if student.Status == "" {
+		student.Status = models.StatusEnrolled
+	}
+	if student.EnrollmentDate == "" {
+		student.EnrollmentDate = time.Now().Format(validation.DateLayout)
+	}
+}
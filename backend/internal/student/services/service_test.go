@@ -1,10 +1,16 @@
 package services
 
 import (
+	"backend/internal/audit"
+	"backend/internal/auth"
+	"backend/internal/auth/authtest"
+	"backend/internal/problem"
 	"backend/internal/student/mocks"
 	"backend/internal/student/models"
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -36,7 +42,7 @@ func TestGet(t *testing.T) {
 
 		student := ModelToEntity(expectedStudent)
 		repo.EXPECT().Get(student.ID).Return(expectedStudent, nil)
-		actual, err := service.Get(student.ID)
+		actual, err := service.Get(authtest.AdminContext, student.ID)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedStudent, actual)
 	})
@@ -52,7 +58,7 @@ func TestGet(t *testing.T) {
 
 		expectedError := errors.New("Failed to fetch student")
 		repo.EXPECT().Get(nilStudent.ID).Return(nil, expectedError)
-		actual, err := service.Get(nilStudent.ID)
+		actual, err := service.Get(authtest.AdminContext, nilStudent.ID)
 		assert.Error(t, err)
 		assert.Nil(t, actual)
 		assert.Equal(t, expectedError, err)
@@ -62,15 +68,16 @@ func TestGet(t *testing.T) {
 
 func TestGetAll(t *testing.T) {
 	//can't be nil, has default page, pagesize.
-	//GetAll(page int, pageSize int) --> returns models.PaginationResponse
+	//GetAll(query models.StudentQuery, page int, pageSize int) --> returns models.PaginationResponse
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	repo := mocks.NewMockRepository(ctrl)
 	service := Service(repo)
 
+	query := models.DefaultStudentQuery()
 	totalStudents := int64(3)
-	repo.EXPECT().TotalStudentCount().Return(totalStudents, nil)
+	repo.EXPECT().TotalStudentCount(query).Return(totalStudents, nil)
 
 	t.Run("GetAll Success", func(t *testing.T) {
 		expectedStudents := []models.Student{
@@ -103,24 +110,40 @@ func TestGetAll(t *testing.T) {
 		page := 1
 		pageSize := 10
 
-		repo.EXPECT().GetAll(page, pageSize).Return(expectedStudents, nil)
+		repo.EXPECT().GetAll(query, page, pageSize).Return(expectedStudents, nil)
 
-		actual, err := service.GetAll(page, pageSize)
+		actual, err := service.GetAll(authtest.AdminContext, query, page, pageSize)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedResponse, actual)
 	})
 	t.Run("GetAll Fail", func(t *testing.T) {
 		page := 0
 		pageSize := 0
-		expectedError := errors.New("page and pagesize cannot be lower than 1")
-
-		repo.EXPECT().GetAll(page, pageSize).Return(nil, expectedError).Times(0)
-		response, err := service.GetAll(page, pageSize)
+		repo.EXPECT().GetAll(query, page, pageSize).Times(0)
+		response, err := service.GetAll(authtest.AdminContext, query, page, pageSize)
 		nilResponse := models.PaginationResponse{Students: []models.Student(nil), Page: models.Page{Number: 0, Size: 0, Elements: 0, Pages: 0}}
 
 		assert.Equal(t, response, nilResponse)
-		assert.Error(t, err)
-		assert.Equal(t, err, expectedError)
+		assert.ErrorIs(t, err, models.ErrInvalidPage)
+		assert.ErrorIs(t, err, problem.ErrValidation)
+	})
+	t.Run("GetAll Filtered", func(t *testing.T) {
+		filtered := models.DefaultStudentQuery()
+		filtered.Filters = []models.Filter{{Field: "surname", Op: models.FilterPrefix, Value: "hu"}}
+		expectedStudents := []models.Student{
+			{
+				ID:      "7995c72f-7d04-4136-8b5f-000d6d4aae23",
+				Name:    "hasan",
+				Surname: "huseyin",
+			},
+		}
+
+		repo.EXPECT().GetAll(filtered, 1, 10).Return(expectedStudents, nil)
+		repo.EXPECT().TotalStudentCount(filtered).Return(int64(1), nil)
+
+		actual, err := service.GetAll(authtest.AdminContext, filtered, 1, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, models.Page{Number: 1, Size: 10, Elements: 1, Pages: 1}, actual.Page)
 	})
 }
 
@@ -137,10 +160,60 @@ func TestAdd(t *testing.T) {
 			Surname: "kelesoglan",
 		}
 
-		repo.EXPECT().Add(gomock.Any()).Return(nil).Times(1)
-		err := service.Add(student)
+		repo.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		err := service.Add(authtest.AdminContext, student)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Add Defaults", func(t *testing.T) {
+		student := &models.Student{
+			Name:          "keloglan",
+			Surname:       "kelesoglan",
+			StudentNumber: "2020-000001",
+			Email:         " Kel@Example.com ",
+		}
+
+		repo.EXPECT().Add(gomock.Any(), student).Return(nil).Times(1)
+		err := service.Add(authtest.AdminContext, student)
 
 		assert.NoError(t, err)
+		assert.Equal(t, models.StatusEnrolled, student.Status)
+		assert.Equal(t, time.Now().Format("2006-01-02"), student.EnrollmentDate)
+		assert.Empty(t, student.StudentNumber, "the repository assigns the number")
+		assert.Equal(t, "kel@example.com", student.Email)
+	})
+
+	t.Run("Add Email Taken", func(t *testing.T) {
+		student := &models.Student{Name: "keloglan", Surname: "kelesoglan", Email: "kel@example.com"}
+
+		repo.EXPECT().Add(gomock.Any(), student).Return(models.ErrEmailTaken).Times(1)
+		err := service.Add(authtest.AdminContext, student)
+
+		assert.ErrorIs(t, err, problem.ErrConflict)
+	})
+
+	t.Run("Add Initial Status", func(t *testing.T) {
+		student := &models.Student{Name: "keloglan", Surname: "kelesoglan", Status: models.StatusApplicant}
+
+		repo.EXPECT().Add(gomock.Any(), student).Return(nil).Times(1)
+		assert.NoError(t, service.Add(authtest.AdminContext, student))
+		assert.Equal(t, models.StatusApplicant, student.Status)
+
+		graduate := &models.Student{Name: "keloglan", Surname: "kelesoglan", Status: models.StatusGraduated}
+		repo.EXPECT().Add(gomock.Any(), graduate).Times(0)
+		assert.Equal(t, models.ErrInitialStatus, service.Add(authtest.AdminContext, graduate))
+	})
+
+	t.Run("Add Ignores DeletedAt", func(t *testing.T) {
+		deletedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		student := &models.Student{Name: "keloglan", Surname: "kelesoglan", DeletedAt: &deletedAt}
+
+		repo.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, student *models.Student) error {
+			assert.Nil(t, student.DeletedAt, "a new student is never deleted")
+			return nil
+		}).Times(1)
+		assert.NoError(t, service.Add(authtest.AdminContext, student))
 	})
 
 	t.Run("Add Fail", func(t *testing.T) {
@@ -149,12 +222,12 @@ func TestAdd(t *testing.T) {
 			Surname: "",
 		}
 
-		expectedError := errors.New("name and surname are required")
-		repo.EXPECT().Add(nilStudent).Return(expectedError).Times(0)
-		err := service.Add(nilStudent)
+		repo.EXPECT().Add(gomock.Any(), nilStudent).Times(0)
+		err := service.Add(authtest.AdminContext, nilStudent)
 
-		assert.Error(t, err)
-		assert.Equal(t, err, expectedError)
+		var validation *problem.ValidationError
+		assert.ErrorAs(t, err, &validation)
+		assert.Len(t, validation.Fields, 2, "both missing fields are reported")
 	})
 }
 
@@ -173,8 +246,8 @@ func TestDelete(t *testing.T) {
 		}
 
 		mockStudent := ModelToEntity(student)
-		repo.EXPECT().Delete(mockStudent.ID).Return(nil).Times(1)
-		err := service.Delete(mockStudent.ID)
+		repo.EXPECT().Delete(gomock.Any(), mockStudent.ID).Return(nil).Times(1)
+		err := service.Delete(authtest.AdminContext, mockStudent.ID)
 
 		assert.NoError(t, err)
 	})
@@ -189,11 +262,518 @@ func TestDelete(t *testing.T) {
 		mockStudent := ModelToEntity(student)
 		expectedErrorMessage := "expected delete error"
 		expectedError := errors.New(expectedErrorMessage)
-		repo.EXPECT().Delete(mockStudent.ID).Return(expectedError).Times(1)
-		err := service.Delete(mockStudent.ID)
+		repo.EXPECT().Delete(gomock.Any(), mockStudent.ID).Return(expectedError).Times(1)
+		err := service.Delete(authtest.AdminContext, mockStudent.ID)
 
 		assert.Error(t, err)
 		// t.Log("Error:", err.Error())
 		assert.EqualError(t, err, expectedErrorMessage)
 	})
 }
+
+func TestUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := Service(repo)
+
+	id := uuid.MustParse("7995c72f-7d04-4136-8b5f-000d6d4aae23")
+
+	t.Run("Update Success", func(t *testing.T) {
+		student := &models.Student{
+			Name:    "hasan",
+			Surname: "huseyin",
+		}
+		expectedStudent := &models.Student{
+			ID:      id.String(),
+			Name:    "Hasan",
+			Surname: "Huseyin",
+		}
+
+		repo.EXPECT().Update(gomock.Any(), expectedStudent).Return(nil).Times(1)
+		err := service.Update(authtest.AdminContext, id, student)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedStudent, student)
+	})
+
+	t.Run("Update Validation Fail", func(t *testing.T) {
+		student := &models.Student{
+			Name:    "hasan",
+			Surname: "",
+		}
+
+		repo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+		err := service.Update(authtest.AdminContext, id, student)
+
+		var validation *problem.ValidationError
+		assert.ErrorAs(t, err, &validation)
+		assert.Equal(t, []problem.FieldError{{Field: "surname", Code: "required", Message: "is required"}}, validation.Fields)
+	})
+
+	t.Run("Update Status", func(t *testing.T) {
+		current := &models.Student{ID: id.String(), Name: "Hasan", Surname: "Huseyin", Status: models.StatusEnrolled}
+		student := &models.Student{Name: "Hasan", Surname: "Huseyin", Status: models.StatusEnrolled}
+
+		repo.EXPECT().Get(id).Return(current, nil).Times(1)
+		repo.EXPECT().Update(gomock.Any(), student).Return(nil).Times(1)
+		assert.NoError(t, service.Update(authtest.AdminContext, id, student), "the current status may be sent back")
+
+		student.Status = models.StatusGraduated
+		repo.EXPECT().Get(id).Return(current, nil).Times(1)
+		repo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+		assert.Equal(t, models.ErrStatusReadOnly, service.Update(authtest.AdminContext, id, student))
+	})
+
+	t.Run("Update Not Found", func(t *testing.T) {
+		student := &models.Student{
+			Name:    "hasan",
+			Surname: "huseyin",
+		}
+
+		repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(models.ErrStudentNotFound).Times(1)
+		err := service.Update(authtest.AdminContext, id, student)
+
+		assert.ErrorIs(t, err, models.ErrStudentNotFound)
+	})
+}
+
+func TestPatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := Service(repo)
+
+	id := uuid.MustParse("7995c72f-7d04-4136-8b5f-000d6d4aae23")
+	existing := func() *models.Student {
+		return &models.Student{
+			ID:      id.String(),
+			Name:    "Hasan",
+			Surname: "Huseyin",
+		}
+	}
+
+	t.Run("Patch Success", func(t *testing.T) {
+		expectedStudent := &models.Student{
+			ID:      id.String(),
+			Name:    "Hasan",
+			Surname: "Hüseyin",
+		}
+
+		repo.EXPECT().Get(id).Return(existing(), nil).Times(1)
+		repo.EXPECT().Update(gomock.Any(), expectedStudent).Return(nil).Times(1)
+		actual, err := service.Patch(authtest.AdminContext, id, []byte(`{"surname": "hüseyin"}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedStudent, actual)
+	})
+
+	t.Run("Patch Cannot Change ID", func(t *testing.T) {
+		repo.EXPECT().Get(id).Return(existing(), nil).Times(1)
+		repo.EXPECT().Update(gomock.Any(), existing()).Return(nil).Times(1)
+		actual, err := service.Patch(authtest.AdminContext, id, []byte(`{"id": "6a6dbce8-ca2a-4473-ae71-b342d7b13545"}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, id.String(), actual.ID)
+	})
+
+	t.Run("Patch Null Removes Required Field", func(t *testing.T) {
+		repo.EXPECT().Get(id).Return(existing(), nil).Times(1)
+		repo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+		actual, err := service.Patch(authtest.AdminContext, id, []byte(`{"name": null}`))
+
+		assert.ErrorIs(t, err, problem.ErrValidation)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("Patch Not An Object", func(t *testing.T) {
+		repo.EXPECT().Get(gomock.Any()).Times(0)
+		actual, err := service.Patch(authtest.AdminContext, id, []byte(`["name"]`))
+
+		assert.ErrorIs(t, err, models.ErrInvalidPatch)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("Patch Not Found", func(t *testing.T) {
+		repo.EXPECT().Get(id).Return(nil, models.ErrStudentNotFound).Times(1)
+		actual, err := service.Patch(authtest.AdminContext, id, []byte(`{"name": "ali"}`))
+
+		assert.ErrorIs(t, err, models.ErrStudentNotFound)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestGetAllByCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := Service(repo)
+
+	query := models.DefaultStudentQuery()
+	students := []models.Student{
+		{ID: "1c4f0e9f-5a66-493d-84d4-400e7a7175a1", Name: "ahmet", Surname: "talha"},
+		{ID: "6a6dbce8-ca2a-4473-ae71-b342d7b13545", Name: "matrak", Surname: "efe"},
+		{ID: "7995c72f-7d04-4136-8b5f-000d6d4aae23", Name: "hasan", Surname: "huseyin"},
+	}
+
+	t.Run("First Page", func(t *testing.T) {
+		repo.EXPECT().GetAllByKeyset(query, nil, 3).Return(students, nil)
+
+		response, err := service.GetAllByCursor(authtest.AdminContext, query, "", 2, false)
+		assert.NoError(t, err)
+		assert.Equal(t, students[:2], response.Students)
+		assert.NotEmpty(t, response.Cursors.Next)
+		assert.Empty(t, response.Cursors.Prev)
+		assert.Nil(t, response.Total)
+
+		keyset, err := decodeCursor(service.CursorSecret, query, response.Cursors.Next)
+		assert.NoError(t, err)
+		assert.Equal(t, &models.Keyset{Values: []string{students[1].ID}}, keyset)
+	})
+
+	t.Run("Last Page", func(t *testing.T) {
+		after := encodeCursor(service.CursorSecret, query, &students[1], false)
+		keyset := &models.Keyset{Values: []string{students[1].ID}}
+		repo.EXPECT().GetAllByKeyset(query, keyset, 3).Return(students[2:], nil)
+
+		response, err := service.GetAllByCursor(authtest.AdminContext, query, after, 2, false)
+		assert.NoError(t, err)
+		assert.Equal(t, students[2:], response.Students)
+		assert.Empty(t, response.Cursors.Next)
+		assert.NotEmpty(t, response.Cursors.Prev)
+
+		keyset, err = decodeCursor(service.CursorSecret, query, response.Cursors.Prev)
+		assert.NoError(t, err)
+		assert.Equal(t, &models.Keyset{Values: []string{students[2].ID}, Backward: true}, keyset)
+	})
+
+	t.Run("Backward To First Page", func(t *testing.T) {
+		before := encodeCursor(service.CursorSecret, query, &students[2], true)
+		keyset := &models.Keyset{Values: []string{students[2].ID}, Backward: true}
+		repo.EXPECT().GetAllByKeyset(query, keyset, 3).Return(students[:2], nil)
+
+		response, err := service.GetAllByCursor(authtest.AdminContext, query, before, 2, false)
+		assert.NoError(t, err)
+		assert.Equal(t, students[:2], response.Students)
+		assert.NotEmpty(t, response.Cursors.Next)
+		assert.Empty(t, response.Cursors.Prev)
+	})
+
+	t.Run("With Total", func(t *testing.T) {
+		repo.EXPECT().GetAllByKeyset(query, nil, 11).Return(students, nil)
+		repo.EXPECT().TotalStudentCount(query).Return(int64(3), nil)
+
+		response, err := service.GetAllByCursor(authtest.AdminContext, query, "", 10, true)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), *response.Total)
+		assert.Empty(t, response.Cursors.Next)
+	})
+
+	t.Run("Tampered Cursor", func(t *testing.T) {
+		cursor := encodeCursor([]byte("another secret"), query, &students[0], false)
+
+		_, err := service.GetAllByCursor(authtest.AdminContext, query, cursor, 2, false)
+		assert.ErrorIs(t, err, models.ErrInvalidCursor)
+
+		_, err = service.GetAllByCursor(authtest.AdminContext, query, "garbage", 2, false)
+		assert.ErrorIs(t, err, models.ErrInvalidCursor)
+	})
+
+	t.Run("Cursor For Another Sort", func(t *testing.T) {
+		cursor := encodeCursor(service.CursorSecret, query, &students[0], false)
+		sorted := models.StudentQuery{Sort: []models.SortKey{{Field: "name"}, {Field: "id"}}}
+
+		_, err := service.GetAllByCursor(authtest.AdminContext, sorted, cursor, 2, false)
+		assert.ErrorIs(t, err, models.ErrInvalidCursor)
+	})
+}
+
+func TestGetConnection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := Service(repo)
+
+	query := models.DefaultStudentQuery()
+	students := []models.Student{
+		{ID: "1c4f0e9f-5a66-493d-84d4-400e7a7175a1", Name: "ahmet", Surname: "talha"},
+		{ID: "6a6dbce8-ca2a-4473-ae71-b342d7b13545", Name: "matrak", Surname: "efe"},
+		{ID: "7995c72f-7d04-4136-8b5f-000d6d4aae23", Name: "hasan", Surname: "huseyin"},
+	}
+	edge := func(student models.Student) models.StudentEdge {
+		return models.StudentEdge{Cursor: encodeCursor(service.CursorSecret, query, &student, false), Student: student}
+	}
+
+	t.Run("First", func(t *testing.T) {
+		repo.EXPECT().GetAllByKeyset(query, nil, 3).Return(students, nil)
+
+		connection, err := service.GetConnection(authtest.AdminContext, query, models.ConnectionArgs{First: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, models.StudentConnection{
+			Edges:       []models.StudentEdge{edge(students[0]), edge(students[1])},
+			HasNextPage: true,
+		}, connection)
+	})
+
+	t.Run("First After", func(t *testing.T) {
+		keyset := &models.Keyset{Values: []string{students[1].ID}}
+		repo.EXPECT().GetAllByKeyset(query, keyset, 3).Return(students[2:], nil)
+
+		connection, err := service.GetConnection(authtest.AdminContext, query, models.ConnectionArgs{First: 2, After: edge(students[1]).Cursor})
+		assert.NoError(t, err)
+		assert.Equal(t, models.StudentConnection{
+			Edges:           []models.StudentEdge{edge(students[2])},
+			HasPreviousPage: true,
+		}, connection)
+	})
+
+	t.Run("Last", func(t *testing.T) {
+		reversed := []models.Student{students[2], students[1], students[0]}
+		repo.EXPECT().GetAllByKeyset(query.Reversed(), nil, 3).Return(reversed, nil)
+
+		connection, err := service.GetConnection(authtest.AdminContext, query, models.ConnectionArgs{Last: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, models.StudentConnection{
+			Edges:           []models.StudentEdge{edge(students[1]), edge(students[2])},
+			HasPreviousPage: true,
+		}, connection)
+	})
+
+	t.Run("Last Before", func(t *testing.T) {
+		keyset := &models.Keyset{Values: []string{students[2].ID}, Backward: true}
+		repo.EXPECT().GetAllByKeyset(query, keyset, 3).Return(students[:2], nil)
+
+		connection, err := service.GetConnection(authtest.AdminContext, query, models.ConnectionArgs{Last: 2, Before: edge(students[2]).Cursor})
+		assert.NoError(t, err)
+		assert.Equal(t, models.StudentConnection{
+			Edges:       []models.StudentEdge{edge(students[0]), edge(students[1])},
+			HasNextPage: true,
+		}, connection)
+	})
+
+	t.Run("Invalid Arguments", func(t *testing.T) {
+		cursor := edge(students[0]).Cursor
+		for _, args := range []models.ConnectionArgs{
+			{},
+			{First: -1},
+			{First: 2, Last: 2},
+			{First: 2, Before: cursor},
+			{Last: 2, After: cursor},
+		} {
+			_, err := service.GetConnection(authtest.AdminContext, query, args)
+			assert.ErrorIs(t, err, models.ErrInvalidPaging, "%+v", args)
+		}
+	})
+
+	t.Run("Tampered Cursor", func(t *testing.T) {
+		_, err := service.GetConnection(authtest.AdminContext, query, models.ConnectionArgs{First: 2, After: "garbage"})
+		assert.ErrorIs(t, err, models.ErrInvalidCursor)
+	})
+}
+
+func TestGetByIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := Service(repo)
+
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	students := []models.Student{{ID: ids[0].String(), Name: "ahmet", Surname: "talha"}}
+	repo.EXPECT().GetByIDs(ids).Return(students, nil)
+
+	found, err := service.GetByIDs(authtest.AdminContext, ids)
+	assert.NoError(t, err)
+	assert.Equal(t, students, found)
+}
+
+func TestRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := Service(repo)
+
+	id := uuid.MustParse("7995c72f-7d04-4136-8b5f-000d6d4aae23")
+
+	t.Run("Restore Success", func(t *testing.T) {
+		expectedStudent := &models.Student{ID: id.String(), Name: "hasan", Surname: "huseyin"}
+		repo.EXPECT().Restore(gomock.Any(), id).Return(nil)
+		repo.EXPECT().Get(id).Return(expectedStudent, nil)
+
+		actual, err := service.Restore(authtest.AdminContext, id)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedStudent, actual)
+	})
+
+	t.Run("Restore Fail", func(t *testing.T) {
+		repo.EXPECT().Restore(gomock.Any(), id).Return(models.ErrStudentNotFound)
+
+		actual, err := service.Restore(authtest.AdminContext, id)
+		assert.ErrorIs(t, err, models.ErrStudentNotFound)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestGetDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := Service(repo)
+
+	deletedAt := time.Now()
+	students := []models.Student{{ID: "7995c72f-7d04-4136-8b5f-000d6d4aae23", Name: "hasan", Surname: "huseyin", DeletedAt: &deletedAt}}
+	repo.EXPECT().GetDeleted(1, 1).Return(students, nil)
+	repo.EXPECT().TotalDeletedCount().Return(int64(3), nil)
+
+	response, err := service.GetDeleted(authtest.AdminContext, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, models.PaginationResponse{
+		Students: students,
+		Page:     models.Page{Number: 1, Size: 1, Elements: 3, Pages: 3},
+	}, response)
+
+	_, err = service.GetDeleted(authtest.AdminContext, 0, 1)
+	assert.Error(t, err)
+}
+
+func TestRunPurger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := Service(repo)
+
+	ctx, cancel := context.WithCancel(authtest.AdminContext)
+	start := time.Now()
+	repo.EXPECT().Purge(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time) (int64, error) {
+		assert.WithinDuration(t, start.Add(-24*time.Hour), before, time.Minute)
+		cancel()
+		return 2, nil
+	})
+
+	service.RunPurger(ctx, 24*time.Hour, time.Hour)
+}
+
+func TestHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := Service(repo)
+
+	id := uuid.MustParse("7995c72f-7d04-4136-8b5f-000d6d4aae23")
+	filter := audit.Filter{EntityType: "student", EntityID: id.String()}
+
+	t.Run("History Success", func(t *testing.T) {
+		entries := []audit.Entry{{ID: 2, EntityType: "student", EntityID: id.String(), Action: audit.ActionUpdate}}
+		repo.EXPECT().GetAudit(filter, 2, 1).Return(entries, nil)
+		repo.EXPECT().TotalAuditCount(filter).Return(int64(2), nil)
+
+		response, err := service.History(authtest.AdminContext, id, 2, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, models.AuditResponse{
+			Entries: entries,
+			Page:    models.Page{Number: 2, Size: 1, Elements: 2, Pages: 2},
+		}, response)
+	})
+
+	t.Run("History Empty", func(t *testing.T) {
+		repo.EXPECT().GetAudit(filter, 1, 10).Return(nil, nil)
+		repo.EXPECT().TotalAuditCount(filter).Return(int64(0), nil)
+
+		response, err := service.History(authtest.AdminContext, id, 1, 10)
+		assert.NoError(t, err)
+		assert.NotNil(t, response.Entries)
+	})
+
+	t.Run("History Invalid Page", func(t *testing.T) {
+		_, err := service.History(authtest.AdminContext, id, 0, 10)
+		assert.Error(t, err)
+	})
+
+	t.Run("History Redacted", func(t *testing.T) {
+		entries := []audit.Entry{{ID: 1, EntityType: "student", EntityID: id.String(), Action: audit.ActionUpdate,
+			Before: audit.Snapshot(`{"id":"` + id.String() + `","name":"hasan","surname":"huseyin","email":"hasan@example.com"}`),
+			After:  audit.Snapshot(`{"id":"` + id.String() + `","name":"hasan","surname":"kaya","email":"hasan@example.com"}`)}}
+		repo.EXPECT().GetAudit(filter, 1, 10).Return(entries, nil)
+		repo.EXPECT().TotalAuditCount(filter).Return(int64(1), nil)
+
+		auditor := auth.WithPrincipal(context.Background(), &auth.Principal{Username: "auditor", Permissions: []auth.Permission{auth.PermAuditRead}})
+		response, err := service.History(auditor, id, 1, 10)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"id":"`+id.String()+`","name":"hasan","surname":"huseyin"}`, string(response.Entries[0].Before))
+		assert.JSONEq(t, `{"id":"`+id.String()+`","name":"hasan","surname":"kaya"}`, string(response.Entries[0].After))
+	})
+}
+
+func TestAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := Service(repo)
+
+	teacher := auth.WithPrincipal(context.Background(), &auth.Principal{Username: "teacher", Permissions: []auth.Permission{auth.PermStudentsRead}})
+	id := uuid.MustParse("7995c72f-7d04-4136-8b5f-000d6d4aae23")
+
+	t.Run("Denied", func(t *testing.T) {
+		// The repository mock fails the test if any of these reach it.
+		err := service.Delete(teacher, id)
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+		assert.Equal(t, &auth.ForbiddenError{Permission: auth.PermStudentsDelete}, err)
+
+		assert.ErrorIs(t, service.Add(teacher, &models.Student{Name: "hasan", Surname: "huseyin"}), auth.ErrForbidden)
+		_, err = service.Patch(teacher, id, []byte(`{}`))
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+		_, err = service.History(teacher, id, 1, 10)
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+		_, err = service.GetDeleted(teacher, 1, 10)
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+	})
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		_, err := service.Get(context.Background(), id)
+		assert.ErrorIs(t, err, auth.ErrUnauthenticated)
+	})
+
+	t.Run("Allowed", func(t *testing.T) {
+		student := &models.Student{ID: id.String(), Name: "hasan", Surname: "huseyin"}
+		repo.EXPECT().Get(id).Return(student, nil)
+
+		actual, err := service.Get(teacher, id)
+		assert.NoError(t, err)
+		assert.Equal(t, student, actual)
+	})
+
+	t.Run("Redacted", func(t *testing.T) {
+		deletedAt := time.Now()
+		restorer := auth.WithPrincipal(context.Background(), &auth.Principal{Permissions: []auth.Permission{auth.PermStudentsRead, auth.PermStudentsWrite}})
+		students := []models.Student{{ID: id.String(), Name: "hasan", Surname: "huseyin", DeletedAt: &deletedAt}}
+		repo.EXPECT().GetAll(gomock.Any(), 1, 10).Return(students, nil)
+		repo.EXPECT().TotalStudentCount(gomock.Any()).Return(int64(1), nil)
+
+		response, err := service.GetAll(restorer, models.DefaultStudentQuery(), 1, 10)
+		assert.NoError(t, err)
+		assert.Nil(t, response.Students[0].DeletedAt, "deletedAt needs students:delete")
+	})
+
+	t.Run("Personal Details Redacted", func(t *testing.T) {
+		student := &models.Student{
+			ID: id.String(), StudentNumber: "2026-000001", Name: "hasan", Surname: "huseyin", Status: models.StatusEnrolled,
+			DateOfBirth: "2008-04-23", Email: "hasan@example.com", Phone: "+905551234567",
+			Addresses: []models.Address{{Kind: models.AddressHome, Line1: "Atatürk Cd. 12", City: "İzmir", Country: "Türkiye"}},
+		}
+		repo.EXPECT().Get(id).Return(student, nil)
+
+		actual, err := service.Get(teacher, id)
+		assert.NoError(t, err)
+		assert.Equal(t, &models.Student{ID: id.String(), StudentNumber: "2026-000001", Name: "hasan", Surname: "huseyin", Status: models.StatusEnrolled}, actual,
+			"personal details need students:read:pii")
+	})
+}
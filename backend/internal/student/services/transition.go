@@ -0,0 +1,89 @@
+package services
+
+import (
+	"backend/internal/audit"
+	"backend/internal/auth"
+	"backend/internal/student/models"
+	"backend/internal/validation"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Guard checks the requirements a student must meet to move to a status, such
+// as complete grades before graduating, and returns those that are not met.
+type Guard func(ctx context.Context, student *models.Student) ([]string, error)
+
+// AddGuard makes transitions to status check guard. Guards run in the order
+// they were added, and all of them run, so that every unmet requirement is
+// reported at once.
+func (s *StudentService) AddGuard(status models.Status, guard Guard) {
+	s.guards[status] = append(s.guards[status], guard)
+}
+
+// Transition moves the student with the given id to another status. The
+// lifecycle must allow it and the student must meet the requirements of the
+// guards of the new status; otherwise a *models.TransitionError is returned.
+func (s *StudentService) Transition(ctx context.Context, id uuid.UUID, request *models.TransitionRequest) (*models.Transition, error) {
+	if err := auth.Authorize(ctx, auth.PermStudentsWrite); err != nil {
+		return nil, err
+	}
+	if err := validation.Validate(request); err != nil {
+		return nil, err
+	}
+	for _, status := range models.ReasonRequired {
+		if request.To == status && request.Reason == "" {
+			return nil, models.ErrReasonRequired
+		}
+	}
+
+	student, err := s.repository.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if !models.CanTransition(student.Status, request.To) {
+		return nil, &models.TransitionError{From: student.Status, To: request.To}
+	}
+	var unmet []string
+	for _, guard := range s.guards[request.To] {
+		failed, err := guard(ctx, student)
+		if err != nil {
+			return nil, err
+		}
+		unmet = append(unmet, failed...)
+	}
+	if len(unmet) > 0 {
+		return nil, &models.TransitionError{From: student.Status, To: request.To, Unmet: unmet}
+	}
+
+	transition := &models.Transition{
+		StudentID: id.String(),
+		From:      student.Status,
+		To:        request.To,
+		Reason:    request.Reason,
+		Actor:     audit.Actor(ctx),
+		At:        time.Now().UTC(),
+	}
+	if err := s.repository.Transition(ctx, transition); err != nil {
+		return nil, err
+	}
+	auth.Redact(ctx, transition)
+	return transition, nil
+}
+
+// GetTransitions returns the history of the student's status, oldest first.
+func (s *StudentService) GetTransitions(ctx context.Context, id uuid.UUID) (models.TransitionList, error) {
+	if err := auth.Authorize(ctx, auth.PermStudentsRead); err != nil {
+		return models.TransitionList{}, err
+	}
+	if _, err := s.repository.Get(id); err != nil {
+		return models.TransitionList{}, err
+	}
+	transitions, err := s.repository.GetTransitions(id)
+	if err != nil {
+		return models.TransitionList{}, err
+	}
+	auth.Redact(ctx, transitions)
+	return models.TransitionList{Transitions: transitions}, nil
+}
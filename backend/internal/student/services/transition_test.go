@@ -0,0 +1,158 @@
+package services
+
+import (
+	"backend/internal/audit"
+	"backend/internal/auth"
+	"backend/internal/auth/authtest"
+	"backend/internal/problem"
+	"backend/internal/student/mocks"
+	"backend/internal/student/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := Service(repo)
+	var unmet []string
+	service.AddGuard(models.StatusGraduated, func(ctx context.Context, student *models.Student) ([]string, error) {
+		return unmet, nil
+	})
+
+	id := uuid.MustParse("7995c72f-7d04-4136-8b5f-000d6d4aae23")
+	student := func(status models.Status) *models.Student {
+		return &models.Student{ID: id.String(), Name: "Hasan", Surname: "Huseyin", Status: status}
+	}
+
+	t.Run("Transition Success", func(t *testing.T) {
+		repo.EXPECT().Get(id).Return(student(models.StatusEnrolled), nil).Times(1)
+		repo.EXPECT().Transition(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, transition *models.Transition) error {
+			transition.ID = 1
+			return nil
+		}).Times(1)
+		ctx := audit.WithActor(authtest.AdminContext, "registrar")
+		transition, err := service.Transition(ctx, id, &models.TransitionRequest{To: "Suspended", Reason: " unpaid fees "})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), transition.ID)
+		assert.Equal(t, id.String(), transition.StudentID)
+		assert.Equal(t, models.StatusEnrolled, transition.From)
+		assert.Equal(t, models.StatusSuspended, transition.To)
+		assert.Equal(t, "unpaid fees", transition.Reason)
+		assert.Equal(t, "registrar", transition.Actor)
+		assert.WithinDuration(t, time.Now(), transition.At, time.Minute)
+	})
+
+	t.Run("Transition Illegal", func(t *testing.T) {
+		repo.EXPECT().Get(id).Return(student(models.StatusGraduated), nil).Times(1)
+		repo.EXPECT().Transition(gomock.Any(), gomock.Any()).Times(0)
+		_, err := service.Transition(authtest.AdminContext, id, &models.TransitionRequest{To: models.StatusEnrolled})
+
+		var transitionErr *models.TransitionError
+		require.ErrorAs(t, err, &transitionErr)
+		assert.Equal(t, models.StatusGraduated, transitionErr.From)
+		assert.Empty(t, transitionErr.Unmet)
+		assert.ErrorIs(t, err, problem.ErrConflict)
+	})
+
+	t.Run("Transition Guarded", func(t *testing.T) {
+		unmet = []string{"MATH101 is not completely graded"}
+		defer func() { unmet = nil }()
+		repo.EXPECT().Get(id).Return(student(models.StatusEnrolled), nil).Times(1)
+		repo.EXPECT().Transition(gomock.Any(), gomock.Any()).Times(0)
+		_, err := service.Transition(authtest.AdminContext, id, &models.TransitionRequest{To: models.StatusGraduated})
+
+		var transitionErr *models.TransitionError
+		require.ErrorAs(t, err, &transitionErr)
+		assert.Equal(t, unmet, transitionErr.Unmet)
+	})
+
+	t.Run("Transition Guard Fails", func(t *testing.T) {
+		service := Service(repo)
+		expectedError := errors.New("grades are unavailable")
+		service.AddGuard(models.StatusGraduated, func(ctx context.Context, student *models.Student) ([]string, error) {
+			return nil, expectedError
+		})
+		repo.EXPECT().Get(id).Return(student(models.StatusEnrolled), nil).Times(1)
+		_, err := service.Transition(authtest.AdminContext, id, &models.TransitionRequest{To: models.StatusGraduated})
+
+		assert.Equal(t, expectedError, err)
+	})
+
+	t.Run("Transition Reason Required", func(t *testing.T) {
+		repo.EXPECT().Get(gomock.Any()).Times(0)
+		_, err := service.Transition(authtest.AdminContext, id, &models.TransitionRequest{To: models.StatusWithdrawn, Reason: "  "})
+
+		assert.Equal(t, models.ErrReasonRequired, err)
+	})
+
+	t.Run("Transition Invalid Status", func(t *testing.T) {
+		repo.EXPECT().Get(gomock.Any()).Times(0)
+		_, err := service.Transition(authtest.AdminContext, id, &models.TransitionRequest{To: "expelled"})
+
+		assert.ErrorIs(t, err, problem.ErrValidation)
+	})
+
+	t.Run("Transition Changed Meanwhile", func(t *testing.T) {
+		repo.EXPECT().Get(id).Return(student(models.StatusEnrolled), nil).Times(1)
+		repo.EXPECT().Transition(gomock.Any(), gomock.Any()).Return(models.ErrStatusChanged).Times(1)
+		_, err := service.Transition(authtest.AdminContext, id, &models.TransitionRequest{To: models.StatusGraduated})
+
+		assert.ErrorIs(t, err, models.ErrStatusChanged)
+	})
+
+	t.Run("Transition Denied", func(t *testing.T) {
+		reader := auth.WithPrincipal(context.Background(), &auth.Principal{Username: "teacher", Permissions: []auth.Permission{auth.PermStudentsRead}})
+		repo.EXPECT().Get(gomock.Any()).Times(0)
+		_, err := service.Transition(reader, id, &models.TransitionRequest{To: models.StatusSuspended, Reason: "unpaid fees"})
+
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+	})
+}
+
+func TestGetTransitions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := Service(repo)
+	id := uuid.MustParse("7995c72f-7d04-4136-8b5f-000d6d4aae23")
+	history := []models.Transition{{ID: 1, StudentID: id.String(), From: models.StatusEnrolled, To: models.StatusSuspended, Reason: "unpaid fees"}}
+
+	t.Run("GetTransitions Success", func(t *testing.T) {
+		repo.EXPECT().Get(id).Return(&models.Student{ID: id.String()}, nil).Times(1)
+		repo.EXPECT().GetTransitions(id).Return(history, nil).Times(1)
+		list, err := service.GetTransitions(authtest.AdminContext, id)
+
+		require.NoError(t, err)
+		assert.Equal(t, history, list.Transitions)
+	})
+
+	t.Run("GetTransitions Redacted", func(t *testing.T) {
+		reader := auth.WithPrincipal(context.Background(), &auth.Principal{Username: "teacher", Permissions: []auth.Permission{auth.PermStudentsRead}})
+		repo.EXPECT().Get(id).Return(&models.Student{ID: id.String()}, nil).Times(1)
+		repo.EXPECT().GetTransitions(id).Return(append([]models.Transition{}, history...), nil).Times(1)
+		list, err := service.GetTransitions(reader, id)
+
+		require.NoError(t, err)
+		assert.Empty(t, list.Transitions[0].Reason, "reasons are personal details")
+	})
+
+	t.Run("GetTransitions Not Found", func(t *testing.T) {
+		repo.EXPECT().Get(id).Return(nil, models.ErrStudentNotFound).Times(1)
+		repo.EXPECT().GetTransitions(gomock.Any()).Times(0)
+		_, err := service.GetTransitions(authtest.AdminContext, id)
+
+		assert.ErrorIs(t, err, models.ErrStudentNotFound)
+	})
+}
@@ -0,0 +1,226 @@
+package validation
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+var builtins = map[string]Rule{
+	"trim":     trim,
+	"required": required,
+	"min":      minLength,
+	"max":      maxLength,
+	"name":     personName,
+	"namecase": nameCase,
+	"date":     date,
+	"email":    email,
+	"phone":    phone,
+}
+
+// trim removes leading and trailing whitespace and collapses inner runs of
+// whitespace into a single space.
+func trim(value reflect.Value, _ string) string {
+	if value.Kind() == reflect.String && value.CanSet() {
+		value.SetString(strings.Join(strings.Fields(value.String()), " "))
+	}
+	return ""
+}
+
+func required(value reflect.Value, _ string) string {
+	if value.IsZero() {
+		return "is required"
+	}
+	return ""
+}
+
+// minLength bounds the length of a string, which may still be empty, or the
+// value of a number.
+func minLength(value reflect.Value, param string) string {
+	if limit, cmp, ok := compareNumber(value, param); ok {
+		if cmp < 0 {
+			return fmt.Sprintf("must be at least %d", limit)
+		}
+		return ""
+	}
+	limit, length, ok := lengthOf(value, param)
+	if ok && length > 0 && length < limit {
+		return fmt.Sprintf("must be at least %d characters", limit)
+	}
+	return ""
+}
+
+func maxLength(value reflect.Value, param string) string {
+	if limit, cmp, ok := compareNumber(value, param); ok {
+		if cmp > 0 {
+			return fmt.Sprintf("must be at most %d", limit)
+		}
+		return ""
+	}
+	limit, length, ok := lengthOf(value, param)
+	if ok && length > limit {
+		return fmt.Sprintf("must be at most %d characters", limit)
+	}
+	return ""
+}
+
+// lengthOf counts characters rather than bytes, so limits mean the same for
+// "Ali" and "Çağrı".
+func lengthOf(value reflect.Value, param string) (limit int, length int, ok bool) {
+	limit, err := strconv.Atoi(param)
+	if err != nil || value.Kind() != reflect.String {
+		return 0, 0, false
+	}
+	return limit, utf8.RuneCountInString(value.String()), true
+}
+
+// compareNumber compares an integer or floating-point value to the limit,
+// returning -1, 0 or 1 as the value is below, at or above it.
+func compareNumber(value reflect.Value, param string) (limit int64, cmp int, ok bool) {
+	limit, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return limit, sign(float64(value.Int()) - float64(limit)), true
+	case reflect.Float32, reflect.Float64:
+		return limit, sign(value.Float() - float64(limit)), true
+	}
+	return 0, 0, false
+}
+
+func sign(x float64) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	}
+	return 0
+}
+
+// DateLayout is how dates without a time of day are written.
+const DateLayout = "2006-01-02"
+
+// date accepts a calendar date written as YYYY-MM-DD.
+func date(value reflect.Value, _ string) string {
+	if value.Kind() != reflect.String || value.String() == "" {
+		return ""
+	}
+	if _, err := time.Parse(DateLayout, value.String()); err != nil {
+		return "must be a date, such as 2026-09-28"
+	}
+	return ""
+}
+
+// email accepts a bare address such as ayse@example.com, without a display
+// name, and lower-cases it so that addresses compare equal however they were
+// typed.
+func email(value reflect.Value, _ string) string {
+	if value.Kind() != reflect.String || !value.CanSet() || value.String() == "" {
+		return ""
+	}
+	address, err := mail.ParseAddress(value.String())
+	if err != nil || address.Name != "" || address.Address != value.String() {
+		return "must be an email address, such as ayse@example.com"
+	}
+	value.SetString(strings.ToLower(address.Address))
+	return ""
+}
+
+// phone accepts 7 to 15 digits, optionally after a plus sign, separated by
+// spaces, hyphens, dots or parentheses, and keeps only the plus and the
+// digits: "+90 (555) 123-45-67" becomes "+905551234567".
+func phone(value reflect.Value, _ string) string {
+	if value.Kind() != reflect.String || !value.CanSet() || value.String() == "" {
+		return ""
+	}
+	const message = "must be a phone number, such as +90 555 123 45 67"
+	var b strings.Builder
+	digits := 0
+	for i, r := range value.String() {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+			digits++
+		case r == '+' && i == 0:
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return message
+		}
+	}
+	if digits < 7 || digits > 15 {
+		return message
+	}
+	value.SetString(b.String())
+	return ""
+}
+
+// personName accepts letters of any script, with single spaces, hyphens and
+// apostrophes between them: "Ayşe", "Jean-Luc", "O'Brien", "De La Cruz".
+func personName(value reflect.Value, _ string) string {
+	if value.Kind() != reflect.String || value.String() == "" {
+		return ""
+	}
+	const message = "may only contain letters, with spaces, hyphens or apostrophes between them"
+	previousLetter := false
+	for _, r := range value.String() {
+		switch {
+		case unicode.IsLetter(r):
+			previousLetter = true
+		case unicode.Is(unicode.Mn, r):
+			// A combining mark belongs to the letter before it.
+			if !previousLetter {
+				return message
+			}
+		case isNameSeparator(r):
+			if !previousLetter {
+				return message
+			}
+			previousLetter = false
+		default:
+			return message
+		}
+	}
+	if !previousLetter {
+		return message
+	}
+	return ""
+}
+
+func isNameSeparator(r rune) bool {
+	return r == ' ' || r == '-' || r == '\'' || r == '’'
+}
+
+// nameCase capitalizes names typed entirely in lower or upper case, "AYŞE
+// ÖZTÜRK" becoming "Ayşe Öztürk". Mixed case such as "McDonald" is deliberate
+// and kept. Casing follows Unicode's default mapping, so a capital I becomes i
+// rather than the Turkish ı; type such names in mixed case.
+func nameCase(value reflect.Value, _ string) string {
+	if value.Kind() != reflect.String || !value.CanSet() {
+		return ""
+	}
+	s := value.String()
+	if s != strings.ToLower(s) && s != strings.ToUpper(s) {
+		return ""
+	}
+	var b strings.Builder
+	startOfWord := true
+	for _, r := range s {
+		if startOfWord {
+			b.WriteRune(unicode.ToTitle(r))
+		} else {
+			b.WriteRune(unicode.ToLower(r))
+		}
+		startOfWord = isNameSeparator(r)
+	}
+	value.SetString(b.String())
+	return ""
+}
@@ -0,0 +1,190 @@
+// Package validation checks and normalizes structs according to their validate
+// tags, so the REST API, the importer and any other entry point apply the same
+// rules:
+//
+//	Name string `json:"name" validate:"trim,required,max=100,name,namecase"`
+//
+// Rules run in the order they are listed. Normalizing rules such as trim rewrite
+// the field, so the struct must be passed by pointer. A field stops at its first
+// failing rule, and every failing field is reported at once.
+package validation
+
+import (
+	"backend/internal/problem"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Rule checks the value of a field and may rewrite it to normalize it. param is
+// the text after "=" in the tag. It returns the message of the failure, or ""
+// when the value is valid.
+type Rule func(value reflect.Value, param string) string
+
+type check struct {
+	name  string
+	param string
+	rule  Rule
+}
+
+type field struct {
+	index  int
+	name   string
+	checks []check
+	// nested is set for struct, pointer to struct and slice of struct fields,
+	// which are validated in turn.
+	nested bool
+}
+
+// Validator holds a set of named rules.
+type Validator struct {
+	mu     sync.RWMutex
+	rules  map[string]Rule
+	fields sync.Map // reflect.Type -> []field
+}
+
+// New returns a validator with the built-in rules.
+func New() *Validator {
+	v := &Validator{rules: map[string]Rule{}}
+	for name, rule := range builtins {
+		v.rules[name] = rule
+	}
+	return v
+}
+
+var defaultValidator = New()
+
+// Register adds a rule to the default validator.
+func Register(name string, rule Rule) {
+	defaultValidator.Register(name, rule)
+}
+
+// Validate validates v with the default validator.
+func Validate(v interface{}) error {
+	return defaultValidator.Validate(v)
+}
+
+// Register adds or replaces a rule. Register rules before validating the types
+// that use them.
+func (v *Validator) Register(name string, rule Rule) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.rules[name] = rule
+}
+
+// Validate normalizes and checks the struct v points to. The failures are
+// returned as a *problem.ValidationError listing every invalid field, named
+// after its JSON key.
+func (v *Validator) Validate(target interface{}) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("validation: expected a pointer to a struct, got %T", target)
+	}
+
+	var failures []problem.FieldError
+	if err := v.validateStruct(value.Elem(), "", &failures); err != nil {
+		return err
+	}
+	if len(failures) > 0 {
+		return &problem.ValidationError{Fields: failures}
+	}
+	return nil
+}
+
+func (v *Validator) validateStruct(value reflect.Value, prefix string, failures *[]problem.FieldError) error {
+	fields, err := v.fieldsOf(value.Type())
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		fieldValue := value.Field(f.index)
+		path := prefix + f.name
+
+		failed := false
+		for _, c := range f.checks {
+			if message := c.rule(fieldValue, c.param); message != "" {
+				*failures = append(*failures, problem.FieldError{Field: path, Code: c.name, Message: message})
+				failed = true
+				break
+			}
+		}
+		if failed || !f.nested {
+			continue
+		}
+		if err := v.validateNested(fieldValue, path, failures); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *Validator) validateNested(value reflect.Value, path string, failures *[]problem.FieldError) error {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return nil
+		}
+		return v.validateNested(value.Elem(), path, failures)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := v.validateNested(value.Index(i), fmt.Sprintf("%s[%d]", path, i), failures); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Struct:
+		return v.validateStruct(value, path+".", failures)
+	}
+	return nil
+}
+
+// fieldsOf parses the validate tags of t once and caches the result.
+func (v *Validator) fieldsOf(t reflect.Type) ([]field, error) {
+	if cached, ok := v.fields.Load(t); ok {
+		return cached.([]field), nil
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+		f := field{index: i, name: jsonName(structField), nested: isNested(structField.Type)}
+		if tag := structField.Tag.Get("validate"); tag != "" && tag != "-" {
+			for _, spec := range strings.Split(tag, ",") {
+				name, param, _ := strings.Cut(strings.TrimSpace(spec), "=")
+				rule, ok := v.rules[name]
+				if !ok {
+					return nil, fmt.Errorf("validation: unknown rule %q on %s.%s", name, t.Name(), structField.Name)
+				}
+				f.checks = append(f.checks, check{name: name, param: param, rule: rule})
+			}
+		}
+		if len(f.checks) > 0 || f.nested {
+			fields = append(fields, f)
+		}
+	}
+	v.fields.Store(t, fields)
+	return fields, nil
+}
+
+// jsonName is the name clients know the field by.
+func jsonName(structField reflect.StructField) string {
+	name, _, _ := strings.Cut(structField.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return structField.Name
+	}
+	return name
+}
+
+func isNested(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	// time.Time and the like carry no validate tags of their own.
+	return t.Kind() == reflect.Struct && t.PkgPath() != "time"
+}
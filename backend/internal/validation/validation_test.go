@@ -0,0 +1,178 @@
+package validation
+
+import (
+	"backend/internal/problem"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City string `json:"city" validate:"trim,required"`
+}
+
+type person struct {
+	Name      string    `json:"name" validate:"trim,required,min=2,max=10,name,namecase"`
+	Nickname  string    `json:"nickname,omitempty" validate:"trim,max=5"`
+	Home      *address  `json:"home"`
+	Addresses []address `json:"addresses"`
+	Ignored   string
+}
+
+func fields(t *testing.T, err error) []problem.FieldError {
+	t.Helper()
+	var validation *problem.ValidationError
+	require.ErrorAs(t, err, &validation)
+	return validation.Fields
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("Normalizes", func(t *testing.T) {
+		p := &person{Name: "  ayşe   nur ", Nickname: " ay "}
+		require.NoError(t, Validate(p))
+		assert.Equal(t, "Ayşe Nur", p.Name)
+		assert.Equal(t, "ay", p.Nickname)
+	})
+
+	t.Run("EveryFieldAtOnce", func(t *testing.T) {
+		p := &person{Name: "   ", Nickname: "toolong", Home: &address{}, Addresses: []address{{City: "İzmir"}, {City: " "}}}
+		err := Validate(p)
+		assert.ErrorIs(t, err, problem.ErrValidation)
+		assert.Equal(t, []problem.FieldError{
+			{Field: "name", Code: "required", Message: "is required"},
+			{Field: "nickname", Code: "max", Message: "must be at most 5 characters"},
+			{Field: "home.city", Code: "required", Message: "is required"},
+			{Field: "addresses[1].city", Code: "required", Message: "is required"},
+		}, fields(t, err))
+		assert.EqualError(t, err, "name: is required, nickname: must be at most 5 characters, home.city: is required, addresses[1].city: is required")
+	})
+
+	t.Run("NotAStructPointer", func(t *testing.T) {
+		assert.Error(t, Validate(person{Name: "Ali"}))
+		assert.Error(t, Validate((*person)(nil)))
+	})
+}
+
+func TestRules(t *testing.T) {
+	for _, tc := range []struct {
+		Name       string
+		Normalized string
+		Code       string
+	}{
+		{"ali", "Ali", ""},
+		{"ÇAĞLA", "Çağla", ""},
+		{"McDonald", "McDonald", ""},
+		{"o'brien", "O'Brien", ""},
+		{"jean-luc", "Jean-Luc", ""},
+		{"José", "José", ""},
+		{"Jose\u0301", "Jose\u0301", ""},
+		{"Ali2", "Ali2", "name"},
+		{"Ali--Veli", "Ali--Veli", "name"},
+		{"-Ali", "-Ali", "name"},
+		{"Ali'", "Ali'", "name"},
+		{"A", "A", "min"},
+		{strings.Repeat("ş", 11), strings.Repeat("ş", 11), "max"},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			p := &person{Name: tc.Name}
+			err := Validate(p)
+			if tc.Code == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tc.Normalized, p.Name)
+				return
+			}
+			assert.Equal(t, tc.Code, fields(t, err)[0].Code)
+		})
+	}
+}
+
+func TestNumberBounds(t *testing.T) {
+	type course struct {
+		Credits int `json:"credits" validate:"min=0,max=30"`
+	}
+	for _, tc := range []struct {
+		Credits int
+		Code    string
+		Message string
+	}{
+		{0, "", ""},
+		{30, "", ""},
+		{-1, "min", "must be at least 0"},
+		{31, "max", "must be at most 30"},
+	} {
+		err := Validate(&course{Credits: tc.Credits})
+		if tc.Code == "" {
+			assert.NoError(t, err, tc.Credits)
+			continue
+		}
+		assert.Equal(t, []problem.FieldError{{Field: "credits", Code: tc.Code, Message: tc.Message}}, fields(t, err))
+	}
+
+	type score struct {
+		Points float64 `json:"points" validate:"min=0,max=100"`
+	}
+	assert.NoError(t, Validate(&score{Points: 99.5}))
+	assert.Equal(t, []problem.FieldError{{Field: "points", Code: "min", Message: "must be at least 0"}}, fields(t, Validate(&score{Points: -0.5})))
+	assert.Equal(t, []problem.FieldError{{Field: "points", Code: "max", Message: "must be at most 100"}}, fields(t, Validate(&score{Points: 100.25})))
+}
+
+func TestDate(t *testing.T) {
+	type session struct {
+		Date string `json:"date" validate:"date"`
+	}
+	for _, valid := range []string{"", "2026-09-28", "2028-02-29"} {
+		assert.NoError(t, Validate(&session{Date: valid}), valid)
+	}
+	for _, invalid := range []string{"2026-9-28", "2026-02-30", "28.09.2026", "2026-09-28T10:00:00Z"} {
+		assert.Equal(t, []problem.FieldError{{Field: "date", Code: "date", Message: "must be a date, such as 2026-09-28"}}, fields(t, Validate(&session{Date: invalid})), invalid)
+	}
+}
+
+func TestEmail(t *testing.T) {
+	type contact struct {
+		Email string `json:"email" validate:"email"`
+	}
+	for typed, normalized := range map[string]string{"": "", "ayse@example.com": "ayse@example.com", "Ayse.Ozturk@Example.COM": "ayse.ozturk@example.com"} {
+		c := &contact{Email: typed}
+		assert.NoError(t, Validate(c), typed)
+		assert.Equal(t, normalized, c.Email)
+	}
+	for _, invalid := range []string{"ayse", "ayse@", "Ayşe <ayse@example.com>", "ayse@example.com, ali@example.com"} {
+		assert.Equal(t, []problem.FieldError{{Field: "email", Code: "email", Message: "must be an email address, such as ayse@example.com"}}, fields(t, Validate(&contact{Email: invalid})), invalid)
+	}
+}
+
+func TestPhone(t *testing.T) {
+	type contact struct {
+		Phone string `json:"phone" validate:"phone"`
+	}
+	for typed, normalized := range map[string]string{"": "", "+90 (555) 123-45-67": "+905551234567", "555.123.4567": "5551234567"} {
+		c := &contact{Phone: typed}
+		assert.NoError(t, Validate(c), typed)
+		assert.Equal(t, normalized, c.Phone)
+	}
+	for _, invalid := range []string{"12345", "+90 555 123 45 67 89 01", "555-CALL-NOW", "90+5551234567"} {
+		assert.Equal(t, []problem.FieldError{{Field: "phone", Code: "phone", Message: "must be a phone number, such as +90 555 123 45 67"}}, fields(t, Validate(&contact{Phone: invalid})), invalid)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	v := New()
+	v.Register("turkishid", func(value reflect.Value, _ string) string {
+		if len(value.String()) != 11 {
+			return "must have 11 digits"
+		}
+		return ""
+	})
+
+	type citizen struct {
+		NationalID string `json:"nationalId" validate:"turkishid"`
+	}
+	assert.NoError(t, v.Validate(&citizen{NationalID: "10000000146"}))
+	assert.Equal(t, []problem.FieldError{{Field: "nationalId", Code: "turkishid", Message: "must have 11 digits"}}, fields(t, v.Validate(&citizen{NationalID: "123"})))
+
+	assert.ErrorContains(t, Validate(&citizen{}), `unknown rule "turkishid"`, "rules are registered per validator")
+}